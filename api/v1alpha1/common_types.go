@@ -24,6 +24,9 @@ import (
 const (
 	// PauseAnnotationKey defines the annotation used to pause a chaos
 	PauseAnnotationKey = "experiment.chaos-mesh.org/pause"
+
+	// DryRunAnnotationKey defines the annotation used to plan a chaos without applying it
+	DryRunAnnotationKey = "experiment.chaos-mesh.org/dry-run"
 )
 
 // SelectorSpec defines the some selectors to select objects.
@@ -81,6 +84,37 @@ type SchedulerSpec struct {
 	//
 	// More rule info: https://godoc.org/github.com/robfig/cron
 	Cron string `json:"cron"`
+
+	// TimeZone is the name of the time zone in which the cron rule is evaluated,
+	// e.g. "Asia/Shanghai". The local time zone of the controller is used if it's empty.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy specifies how to treat the round which starts while the last
+	// round is still running.
+	// Supported policy: Forbid / Replace / Allow
+	// Default policy: Forbid
+	// +optional
+	// +kubebuilder:validation:Enum=Forbid;Replace;Allow
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadlineSeconds is the deadline in seconds for starting a round if it
+	// misses the scheduled time for any reason, e.g. the controller is down.
+	// The missed rounds are skipped.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Suspend tells the scheduler to suspend the subsequent rounds, the running
+	// round isn't affected.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// HistoryLimit is the number of the rounds kept in the history.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
 }
 
 // PodMode represents the mode to run pod chaos action.
@@ -120,6 +154,23 @@ type ChaosStatus struct {
 
 	// Experiment records the last experiment state.
 	Experiment ExperimentStatus `json:"experiment"`
+
+	// Probes records the last result of each steady-state probe.
+	// +optional
+	Probes []ProbeStatus `json:"probes,omitempty"`
+
+	// Profile records the current stage of the time-varying fault profile.
+	// +optional
+	Profile *ProfileStatus `json:"profile,omitempty"`
+
+	// Conditions are the conditions of the chaos, e.g. whether the chaos is injected into
+	// all the selected pods.
+	// +optional
+	Conditions []ChaosCondition `json:"conditions,omitempty"`
+
+	// DryRun records the changes planned by the chaos in dry-run mode.
+	// +optional
+	DryRun *DryRunStatus `json:"dryRun,omitempty"`
 }
 
 func (in *ChaosStatus) GetNextStart() time.Time {
@@ -169,6 +220,10 @@ type ScheduleStatus struct {
 	// Next time when this action will be recovered
 	// +optional
 	NextRecover *metav1.Time `json:"nextRecover,omitempty"`
+
+	// History records the last rounds of the chaos, the oldest first.
+	// +optional
+	History []ScheduleRecord `json:"history,omitempty"`
 }
 
 // ExperimentPhase is the current status of chaos experiment.
@@ -180,6 +235,7 @@ const (
 	ExperimentPhasePaused   ExperimentPhase = "Paused"
 	ExperimentPhaseFailed   ExperimentPhase = "Failed"
 	ExperimentPhaseFinished ExperimentPhase = "Finished"
+	ExperimentPhaseAborted  ExperimentPhase = "Aborted"
 )
 
 type ExperimentStatus struct {
@@ -221,12 +277,39 @@ type InnerSchedulerObject interface {
 type InnerObject interface {
 	IsDeleted() bool
 	IsPaused() bool
+	IsDryRun() bool
 	GetChaos() *ChaosInstance
 	StatefulObject
 }
 
 // +kubebuilder:object:generate=false
 
+// SelectSpec defines the selector and mode to select the pods
+type SelectSpec interface {
+	GetSelector() SelectorSpec
+	GetMode() PodMode
+	GetValue() string
+}
+
+// +kubebuilder:object:generate=false
+
+// SelectableObject is the Object which selects the pods to inject by SelectSpec
+type SelectableObject interface {
+	InnerObject
+	GetSelectSpec() SelectSpec
+}
+
+// +kubebuilder:object:generate=false
+
+// ContinuousObject is the Object which keeps injecting into the pods matching its selector
+// while it's running, including the pods created or restarted later
+type ContinuousObject interface {
+	SelectableObject
+	IsContinuous() bool
+}
+
+// +kubebuilder:object:generate=false
+
 // StatefulObject defines a basic Object that can get the status
 type StatefulObject interface {
 	runtime.Object
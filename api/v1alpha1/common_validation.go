@@ -49,6 +49,10 @@ func ValidateScheduler(schedulerObject InnerSchedulerObject, spec *field.Path) f
 
 	scheduler := schedulerObject.GetScheduler()
 
+	if scheduler != nil {
+		allErrs = append(allErrs, ValidateSchedulerSpec(scheduler, schedulerField)...)
+	}
+
 	if duration != nil && scheduler != nil {
 		errs := validateSchedulerParams(duration, durationField, scheduler, schedulerField)
 		if len(errs) != 0 {
@@ -60,6 +64,38 @@ func ValidateScheduler(schedulerObject InnerSchedulerObject, spec *field.Path) f
 	return allErrs
 }
 
+// ValidateSchedulerSpec validates the fields of scheduler except the cron rule
+func ValidateSchedulerSpec(spec *SchedulerSpec, schedulerField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, err := spec.GetLocation(); err != nil {
+		allErrs = append(allErrs, field.Invalid(schedulerField.Child("timeZone"), spec.TimeZone,
+			fmt.Sprintf("load time zone error:%s", err)))
+	}
+
+	switch spec.GetConcurrencyPolicy() {
+	case ForbidConcurrent, ReplaceConcurrent, AllowConcurrent:
+	default:
+		allErrs = append(allErrs, field.NotSupported(schedulerField.Child("concurrencyPolicy"), spec.ConcurrencyPolicy,
+			[]string{string(ForbidConcurrent), string(ReplaceConcurrent), string(AllowConcurrent)}))
+	}
+
+	if spec.StartingDeadlineSeconds != nil && *spec.StartingDeadlineSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(schedulerField.Child("startingDeadlineSeconds"),
+			*spec.StartingDeadlineSeconds, "value must be greater than or equal to 0"))
+	}
+
+	if spec.HistoryLimit != nil && *spec.HistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(schedulerField.Child("historyLimit"),
+			*spec.HistoryLimit, "value must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+// overlapCheckTicks is the number of ticks of the cron rule checked for the overlap with the duration
+const overlapCheckTicks = 100
+
 func validateSchedulerParams(duration *time.Duration, durationField *field.Path, spec *SchedulerSpec, schedulerField *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if duration != nil && spec != nil {
@@ -70,13 +106,30 @@ func validateSchedulerParams(duration *time.Duration, durationField *field.Path,
 			allErrs = append(allErrs, err...)
 		}
 
-		if scheduler != nil {
-			tmpTime := time.Time{}
-			nextTime := scheduler.Next(tmpTime)
-			interval := nextTime.Sub(tmpTime)
-			if *duration >= interval {
-				allErrs = append(allErrs, field.Invalid(cronField, spec.Cron,
-					fmt.Sprintf("the scheduling interval:\"%s\" must be greater than the duration:%s", spec.Cron, *duration)))
+		// The rounds are allowed to overlap with the other policies
+		if scheduler != nil && spec.GetConcurrencyPolicy() == ForbidConcurrent {
+			location, err := spec.GetLocation()
+			if err != nil {
+				// the error of time zone has been reported
+				location = time.Local
+			}
+
+			// The interval of cron rule may be irregular, e.g. "0 0,1 * * *", so the minimum
+			// interval of the following ticks is checked.
+			tmpTime := time.Now().In(location)
+			for i := 0; i < overlapCheckTicks; i++ {
+				nextTime := scheduler.Next(tmpTime)
+				if nextTime.IsZero() {
+					break
+				}
+				interval := nextTime.Sub(tmpTime)
+				if i > 0 && *duration >= interval {
+					allErrs = append(allErrs, field.Invalid(cronField, spec.Cron,
+						fmt.Sprintf("the scheduling interval:\"%s\" must be greater than the duration:%s, "+
+							"or the concurrency policy should be %s or %s", spec.Cron, *duration, ReplaceConcurrent, AllowConcurrent)))
+					break
+				}
+				tmpTime = nextTime
 			}
 		}
 	}
@@ -126,3 +179,88 @@ func ValidatePodMode(value string, mode PodMode, valueField *field.Path) field.E
 	}
 	return allErrs
 }
+
+// ValidateProbes validates the steady-state probes
+func ValidateProbes(probes []ProbeSpec, probesField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := map[string]bool{}
+
+	for i := range probes {
+		probe := &probes[i]
+		probeField := probesField.Index(i)
+
+		if probe.Name == "" {
+			allErrs = append(allErrs, field.Required(probeField.Child("name"), "the name of probe is required"))
+		} else if names[probe.Name] {
+			allErrs = append(allErrs, field.Duplicate(probeField.Child("name"), probe.Name))
+		}
+		names[probe.Name] = true
+
+		allErrs = append(allErrs, validateProbeDuration(probe.Interval, probeField.Child("interval"))...)
+		allErrs = append(allErrs, validateProbeDuration(probe.Timeout, probeField.Child("timeout"))...)
+
+		switch probe.Type {
+		case HTTPProbeType:
+			if probe.HTTP == nil || probe.HTTP.URL == "" {
+				allErrs = append(allErrs, field.Required(probeField.Child("http", "url"),
+					fmt.Sprintf("url is required for %s probe", probe.Type)))
+			}
+		case TCPProbeType:
+			if probe.TCP == nil || probe.TCP.Address == "" {
+				allErrs = append(allErrs, field.Required(probeField.Child("tcp", "address"),
+					fmt.Sprintf("address is required for %s probe", probe.Type)))
+			}
+		case PrometheusProbeType:
+			allErrs = append(allErrs, probe.Prometheus.validate(probeField.Child("prometheus"))...)
+		case ExecProbeType:
+			if probe.Exec == nil || probe.Exec.Pod == "" || len(probe.Exec.Command) == 0 {
+				allErrs = append(allErrs, field.Required(probeField.Child("exec"),
+					fmt.Sprintf("pod and command are required for %s probe", probe.Type)))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(probeField.Child("type"), probe.Type,
+				[]string{string(HTTPProbeType), string(TCPProbeType), string(PrometheusProbeType), string(ExecProbeType)}))
+		}
+	}
+	return allErrs
+}
+
+func validateProbeDuration(duration *string, durationField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if duration == nil {
+		return allErrs
+	}
+	d, err := time.ParseDuration(*duration)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(durationField, *duration,
+			fmt.Sprintf("parse duration field error:%s", err)))
+	} else if d <= 0 {
+		allErrs = append(allErrs, field.Invalid(durationField, *duration, "duration should be greater than 0"))
+	}
+	return allErrs
+}
+
+func (in *PrometheusProbe) validate(prometheusField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in == nil {
+		allErrs = append(allErrs, field.Required(prometheusField, "prometheus is required for prometheus probe"))
+		return allErrs
+	}
+	if in.URL == "" {
+		allErrs = append(allErrs, field.Required(prometheusField.Child("url"), "url is required for prometheus probe"))
+	}
+	if in.Query == "" {
+		allErrs = append(allErrs, field.Required(prometheusField.Child("query"), "query is required for prometheus probe"))
+	}
+	switch in.Operator {
+	case ">", ">=", "<", "<=", "==", "!=":
+	default:
+		allErrs = append(allErrs, field.NotSupported(prometheusField.Child("operator"), in.Operator,
+			[]string{">", ">=", "<", "<=", "==", "!="}))
+	}
+	if _, err := strconv.ParseFloat(in.Threshold, 64); err != nil {
+		allErrs = append(allErrs, field.Invalid(prometheusField.Child("threshold"), in.Threshold,
+			fmt.Sprintf(ValidateValueParseError, err)))
+	}
+	return allErrs
+}
@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Describe("common_webhook", func() {
@@ -27,4 +28,74 @@ var _ = Describe("common_webhook", func() {
 			Expect(selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
 		})
 	})
+	Context("ValidateProbes", func() {
+		It("Validate", func() {
+			type TestCase struct {
+				name   string
+				probes []ProbeSpec
+				expect string
+			}
+			interval := "5s"
+			invalidInterval := "5"
+			tcs := []TestCase{
+				{
+					name: "valid probes",
+					probes: []ProbeSpec{
+						{Name: "http", Type: HTTPProbeType, Interval: &interval, HTTP: &HTTPProbe{URL: "http://app/healthz"}},
+						{Name: "tcp", Type: TCPProbeType, TCP: &TCPProbe{Address: "app:3306"}},
+						{Name: "prometheus", Type: PrometheusProbeType, Prometheus: &PrometheusProbe{
+							URL: "http://prometheus:9090", Query: "error_rate", Operator: "<", Threshold: "0.1"}},
+						{Name: "exec", Type: ExecProbeType, Exec: &ExecProbe{Pod: "app", Command: []string{"true"}}},
+					},
+					expect: "",
+				},
+				{
+					name: "duplicated name",
+					probes: []ProbeSpec{
+						{Name: "tcp", Type: TCPProbeType, TCP: &TCPProbe{Address: "app:3306"}},
+						{Name: "tcp", Type: TCPProbeType, TCP: &TCPProbe{Address: "app:3307"}},
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid interval",
+					probes: []ProbeSpec{
+						{Name: "tcp", Type: TCPProbeType, Interval: &invalidInterval, TCP: &TCPProbe{Address: "app:3306"}},
+					},
+					expect: "error",
+				},
+				{
+					name: "missing http url",
+					probes: []ProbeSpec{
+						{Name: "http", Type: HTTPProbeType},
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid prometheus threshold",
+					probes: []ProbeSpec{
+						{Name: "prometheus", Type: PrometheusProbeType, Prometheus: &PrometheusProbe{
+							URL: "http://prometheus:9090", Query: "error_rate", Operator: "<", Threshold: "low"}},
+					},
+					expect: "error",
+				},
+				{
+					name: "unknown type",
+					probes: []ProbeSpec{
+						{Name: "grpc", Type: "grpc"},
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				errs := ValidateProbes(tc.probes, field.NewPath("spec", "probes"))
+				if tc.expect == "error" {
+					Expect(errs).ToNot(BeEmpty(), tc.name)
+				} else {
+					Expect(errs).To(BeEmpty(), tc.name)
+				}
+			}
+		})
+	})
 })
@@ -0,0 +1,179 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ChaosConditionType is the type of condition of chaos.
+type ChaosConditionType string
+
+const (
+	// ConditionSelected means that there are pods selected by the chaos
+	ConditionSelected ChaosConditionType = "Selected"
+	// ConditionAllInjected means that the chaos is injected into all the selected pods
+	ConditionAllInjected ChaosConditionType = "AllInjected"
+	// ConditionAllRecovered means that all the selected pods are recovered from the chaos
+	ConditionAllRecovered ChaosConditionType = "AllRecovered"
+	// ConditionPaused means that the chaos is paused
+	ConditionPaused ChaosConditionType = "Paused"
+	// ConditionBlocked means that the chaos is blocked by a schedule policy
+	ConditionBlocked ChaosConditionType = "Blocked"
+)
+
+// ChaosCondition describes one aspect of the current state of chaos.
+type ChaosCondition struct {
+	// Type of condition.
+	Type ChaosConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// A brief CamelCase reason for the last transition of the condition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GetCondition returns the condition with the type, or nil if it doesn't exist
+func (in *ChaosStatus) GetCondition(conditionType ChaosConditionType) *ChaosCondition {
+	for i := range in.Conditions {
+		if in.Conditions[i].Type == conditionType {
+			return &in.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates the condition, the LastTransitionTime is only changed
+// when the status of condition is changed
+func (in *ChaosStatus) SetCondition(condition ChaosCondition) {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	existing := in.GetCondition(condition.Type)
+	if existing == nil {
+		in.Conditions = append(in.Conditions, condition)
+		return
+	}
+
+	if existing.Status != condition.Status {
+		existing.LastTransitionTime = condition.LastTransitionTime
+	}
+	existing.Status = condition.Status
+	existing.Reason = condition.Reason
+	existing.Message = condition.Message
+}
+
+// UpdateConditions refreshes the conditions according to the records of pods
+func (in *ChaosStatus) UpdateConditions(paused bool) {
+	records := in.Experiment.PodRecords
+
+	var failed, injected, recovered []string
+	for _, record := range records {
+		key := record.Namespace + "/" + record.Name
+		switch record.Phase {
+		case PodInjectionPhaseInjected:
+			injected = append(injected, key)
+		case PodInjectionPhaseRecovered:
+			recovered = append(recovered, key)
+		case PodInjectionPhaseFailed:
+			failed = append(failed, fmt.Sprintf("%s: %s", key, record.LastError))
+		}
+	}
+
+	in.SetCondition(newCondition(ConditionSelected, len(records) > 0,
+		"PodsSelected", fmt.Sprintf("%d pods are selected", len(records))))
+	in.SetCondition(newCondition(ConditionAllInjected, len(records) > 0 && len(injected) == len(records),
+		"PodsInjected", fmt.Sprintf("%d of %d pods are injected", len(injected), len(records)), failed...))
+	in.SetCondition(newCondition(ConditionAllRecovered, len(records) > 0 && len(recovered) == len(records),
+		"PodsRecovered", fmt.Sprintf("%d of %d pods are recovered", len(recovered), len(records)), failed...))
+	in.SetCondition(newCondition(ConditionPaused, paused, "Paused", ""))
+}
+
+func newCondition(conditionType ChaosConditionType, ok bool, reason string, message string, errs ...string) ChaosCondition {
+	condition := ChaosCondition{
+		Type:    conditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+	if !ok {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = "Not" + reason
+	}
+	if len(errs) > 0 {
+		condition.Message = fmt.Sprintf("%s, failed pods: %s", message, strings.Join(errs, "; "))
+	}
+	return condition
+}
+
+// PodInjectionPhase is the phase of chaos on a pod.
+type PodInjectionPhase string
+
+const (
+	// PodInjectionPhaseInjected means that the chaos is injected into the pod
+	PodInjectionPhaseInjected PodInjectionPhase = "Injected"
+	// PodInjectionPhaseFailed means that it's failed to inject the chaos into the pod,
+	// or to recover the pod from the chaos
+	PodInjectionPhaseFailed PodInjectionPhase = "Failed"
+	// PodInjectionPhaseRecovered means that the pod is recovered from the chaos
+	PodInjectionPhaseRecovered PodInjectionPhase = "Recovered"
+)
+
+// SetInjected updates the record with the result of injecting the chaos into the pod
+func (in *PodStatus) SetInjected(err error) {
+	now := metav1.Now()
+	in.InjectedTime = &now
+	in.RecoveredTime = nil
+	in.setResult(PodInjectionPhaseInjected, err)
+}
+
+// SetRecovered updates the record with the result of recovering the pod
+func (in *PodStatus) SetRecovered(err error) {
+	if err == nil {
+		now := metav1.Now()
+		in.RecoveredTime = &now
+	}
+	in.setResult(PodInjectionPhaseRecovered, err)
+}
+
+func (in *PodStatus) setResult(phase PodInjectionPhase, err error) {
+	if err != nil {
+		in.Phase = PodInjectionPhaseFailed
+		in.LastError = err.Error()
+		return
+	}
+	in.Phase = phase
+	in.LastError = ""
+}
+
+// SetPodRecovered updates the record of the pod with the result of recovering it.
+// It's a no-op if the pod isn't recorded.
+func (in *ExperimentStatus) SetPodRecovered(namespace string, name string, err error) {
+	for i := range in.PodRecords {
+		if in.PodRecords[i].Namespace == namespace && in.PodRecords[i].Name == name {
+			in.PodRecords[i].SetRecovered(err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Condition", func() {
+	Context("SetCondition", func() {
+		It("should only change the transition time when the status is changed", func() {
+			status := &ChaosStatus{}
+			first := metav1.Unix(100, 0)
+			status.SetCondition(ChaosCondition{Type: ConditionPaused, Status: corev1.ConditionFalse, LastTransitionTime: first})
+			status.SetCondition(ChaosCondition{Type: ConditionPaused, Status: corev1.ConditionFalse, Reason: "NotPaused"})
+			Expect(status.Conditions).To(HaveLen(1))
+			Expect(status.Conditions[0].LastTransitionTime).To(Equal(first))
+			Expect(status.Conditions[0].Reason).To(Equal("NotPaused"))
+
+			status.SetCondition(ChaosCondition{Type: ConditionPaused, Status: corev1.ConditionTrue})
+			Expect(status.GetCondition(ConditionPaused).Status).To(Equal(corev1.ConditionTrue))
+			Expect(status.GetCondition(ConditionPaused).LastTransitionTime).ToNot(Equal(first))
+			Expect(status.GetCondition(ConditionSelected)).To(BeNil())
+		})
+	})
+
+	Context("UpdateConditions", func() {
+		It("should update the conditions by the records of pods", func() {
+			status := &ChaosStatus{}
+			status.UpdateConditions(false)
+			Expect(status.GetCondition(ConditionSelected).Status).To(Equal(corev1.ConditionFalse))
+			Expect(status.GetCondition(ConditionAllInjected).Status).To(Equal(corev1.ConditionFalse))
+
+			status.Experiment.PodRecords = []PodStatus{
+				{Namespace: "default", Name: "p0"},
+				{Namespace: "default", Name: "p1"},
+			}
+			status.Experiment.PodRecords[0].SetInjected(nil)
+			status.Experiment.PodRecords[1].SetInjected(errors.New("container not found"))
+			status.UpdateConditions(false)
+			Expect(status.GetCondition(ConditionSelected).Status).To(Equal(corev1.ConditionTrue))
+			Expect(status.GetCondition(ConditionAllInjected).Status).To(Equal(corev1.ConditionFalse))
+			Expect(status.GetCondition(ConditionAllInjected).Message).To(Equal(
+				"1 of 2 pods are injected, failed pods: default/p1: container not found"))
+
+			status.Experiment.SetPodRecovered("default", "p0", nil)
+			status.Experiment.SetPodRecovered("default", "p1", nil)
+			status.UpdateConditions(true)
+			Expect(status.GetCondition(ConditionAllRecovered).Status).To(Equal(corev1.ConditionTrue))
+			Expect(status.GetCondition(ConditionPaused).Status).To(Equal(corev1.ConditionTrue))
+			Expect(status.Experiment.PodRecords[1].LastError).To(BeEmpty())
+			Expect(status.Experiment.PodRecords[1].RecoveredTime).ToNot(BeNil())
+		})
+	})
+})
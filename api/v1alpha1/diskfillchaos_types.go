@@ -0,0 +1,117 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +chaos-mesh:base
+
+// DiskFillChaos is the Schema for the diskfillchaos API
+type DiskFillChaos struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the behavior of a disk fill chaos experiment
+	Spec DiskFillChaosSpec `json:"spec"`
+
+	// +optional
+	// Most recently observed status of the disk fill chaos experiment
+	Status DiskFillChaosStatus `json:"status"`
+}
+
+// DiskFillChaosSpec defines the desired state of DiskFillChaos
+type DiskFillChaosSpec struct {
+	// Mode defines the mode to run chaos action.
+	// Supported mode: one / all / fixed / fixed-percent / random-max-percent
+	// +kubebuilder:validation:Enum=one;all;fixed;fixed-percent;random-max-percent
+	Mode PodMode `json:"mode"`
+
+	// Value is required when the mode is set to `FixedPodMode` / `FixedPercentPodMod` / `RandomMaxPercentPodMod`.
+	// If `FixedPodMode`, provide an integer of pods to do chaos action.
+	// If `FixedPercentPodMod`, provide a number from 0-100 to specify the percent of pods the server can do chaos action.
+	// If `RandomMaxPercentPodMod`,  provide a number from 0-100 to specify the max percent of pods to do chaos action
+	// +optional
+	Value string `json:"value"`
+
+	// Selector is used to select pods that are used to inject chaos action.
+	Selector SelectorSpec `json:"selector"`
+
+	// ContainerName indicates the target container to fill the disk in.
+	// If not set, the first container will be injected
+	// +optional
+	ContainerName *string `json:"containerName,omitempty"`
+
+	// Path defines the directory in the container where the file filling the disk is created.
+	Path string `json:"path"`
+
+	// Size defines the size of the file filling the disk, such as "10Gi" or "512Mi".
+	// It's limited by the free space of the disk.
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// Percent defines the size of the file filling the disk as the percentage of the capacity,
+	// and provides a number from 0-100. It's limited by the free space of the disk.
+	// All the free space is filled if neither Size nor Percent is set.
+	// +optional
+	Percent int `json:"percent,omitempty"`
+
+	// Duration represents the duration of the chaos action
+	// +optional
+	Duration *string `json:"duration,omitempty"`
+
+	// Scheduler defines some schedule rules to control the running time of the chaos experiment about disk.
+	// +optional
+	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+}
+
+// GetSelector is a getter for Selector (for implementing SelectSpec)
+func (in *DiskFillChaosSpec) GetSelector() SelectorSpec {
+	return in.Selector
+}
+
+// GetMode is a getter for Mode (for implementing SelectSpec)
+func (in *DiskFillChaosSpec) GetMode() PodMode {
+	return in.Mode
+}
+
+// GetValue is a getter for Value (for implementing SelectSpec)
+func (in *DiskFillChaosSpec) GetValue() string {
+	return in.Value
+}
+
+// GetSize returns the size of the file filling the disk in bytes, it's zero if Size is not set
+func (in *DiskFillChaosSpec) GetSize() (int64, error) {
+	if len(in.Size) == 0 {
+		return 0, nil
+	}
+	size, err := resource.ParseQuantity(in.Size)
+	if err != nil {
+		return 0, err
+	}
+	return size.Value(), nil
+}
+
+// DiskFillChaosStatus defines the observed state of DiskFillChaos
+type DiskFillChaosStatus struct {
+	ChaosStatus `json:",inline"`
+}
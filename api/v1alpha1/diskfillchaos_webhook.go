@@ -0,0 +1,126 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var diskfillchaoslog = logf.Log.WithName("diskfillchaos-resource")
+
+// SetupWebhookWithManager setup DiskFillChaos's webhook with manager
+func (in *DiskFillChaos) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-chaos-mesh-org-v1alpha1-diskfillchaos,mutating=true,failurePolicy=fail,groups=chaos-mesh.org,resources=diskfillchaos,verbs=create;update,versions=v1alpha1,name=mdiskfillchaos.kb.io
+
+var _ webhook.Defaulter = &DiskFillChaos{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (in *DiskFillChaos) Default() {
+	diskfillchaoslog.Info("default", "name", in.Name)
+
+	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-chaos-mesh-org-v1alpha1-diskfillchaos,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=diskfillchaos,versions=v1alpha1,name=vdiskfillchaos.kb.io
+
+var _ ChaosValidator = &DiskFillChaos{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (in *DiskFillChaos) ValidateCreate() error {
+	diskfillchaoslog.Info("validate create", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (in *DiskFillChaos) ValidateUpdate(old runtime.Object) error {
+	diskfillchaoslog.Info("validate update", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (in *DiskFillChaos) ValidateDelete() error {
+	diskfillchaoslog.Info("validate delete", "name", in.Name)
+
+	// Nothing to do?
+	return nil
+}
+
+// Validate validates chaos object
+func (in *DiskFillChaos) Validate() error {
+	specField := field.NewPath("spec")
+	allErrs := in.ValidateScheduler(specField)
+	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
+	allErrs = append(allErrs, in.Spec.validatePath(specField.Child("path"))...)
+	allErrs = append(allErrs, in.Spec.validateSize(specField)...)
+
+	if len(allErrs) > 0 {
+		return fmt.Errorf(allErrs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// ValidateScheduler validates the scheduler and duration
+func (in *DiskFillChaos) ValidateScheduler(spec *field.Path) field.ErrorList {
+	return ValidateScheduler(in, spec)
+}
+
+// ValidatePodMode validates the value with podmode
+func (in *DiskFillChaos) ValidatePodMode(spec *field.Path) field.ErrorList {
+	return ValidatePodMode(in.Spec.Value, in.Spec.Mode, spec.Child("value"))
+}
+
+func (in *DiskFillChaosSpec) validatePath(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !filepath.IsAbs(in.Path) {
+		allErrs = append(allErrs, field.Invalid(path, in.Path, "path should be an absolute path"))
+	}
+	return allErrs
+}
+
+func (in *DiskFillChaosSpec) validateSize(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(in.Size) > 0 && in.Percent != 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("percent"), in.Percent,
+			"size and percent can't be set at the same time"))
+	}
+
+	size, err := in.GetSize()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("size"), in.Size,
+			fmt.Sprintf("parse size field error:%s", err)))
+	} else if size < 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("size"), in.Size,
+			"size should not be negative"))
+	}
+
+	if in.Percent > 100 || in.Percent < 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("percent"), in.Percent,
+			"percent field should be in 0-100"))
+	}
+	return allErrs
+}
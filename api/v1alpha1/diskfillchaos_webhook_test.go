@@ -0,0 +1,197 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("diskfillchaos_webhook", func() {
+	Context("Defaulter", func() {
+		It("set default namespace selector", func() {
+			diskfillchaos := &DiskFillChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault},
+			}
+			diskfillchaos.Default()
+			Expect(diskfillchaos.Spec.Selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
+		})
+	})
+	Context("ChaosValidator of diskfillchaos", func() {
+		It("Validate", func() {
+
+			type TestCase struct {
+				name    string
+				chaos   DiskFillChaos
+				execute func(chaos *DiskFillChaos) error
+				expect  string
+			}
+			duration := "400s"
+
+			tcs := []TestCase{
+				{
+					name: "simple ValidateCreate",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo1",
+						},
+						Spec: DiskFillChaosSpec{
+							Path: "/var/lib/data",
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "simple ValidateDelete",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo2",
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateDelete()
+					},
+					expect: "",
+				},
+				{
+					name: "validate size",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo3",
+						},
+						Spec: DiskFillChaosSpec{
+							Path:      "/var/lib/data",
+							Size:      "10Gi",
+							Duration:  &duration,
+							Scheduler: &SchedulerSpec{Cron: "@every 10m"},
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate relative path",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo4",
+						},
+						Spec: DiskFillChaosSpec{
+							Path: "data",
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "parse size error",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo5",
+						},
+						Spec: DiskFillChaosSpec{
+							Path: "/var/lib/data",
+							Size: "10GB",
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate negative size",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo6",
+						},
+						Spec: DiskFillChaosSpec{
+							Path: "/var/lib/data",
+							Size: "-1Gi",
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate size and percent conflict",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo7",
+						},
+						Spec: DiskFillChaosSpec{
+							Path:    "/var/lib/data",
+							Size:    "1Gi",
+							Percent: 50,
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate percent",
+					chaos: DiskFillChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo8",
+						},
+						Spec: DiskFillChaosSpec{
+							Path:    "/var/lib/data",
+							Percent: 101,
+						},
+					},
+					execute: func(chaos *DiskFillChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				err := tc.execute(&tc.chaos)
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+
+		It("should get the size in bytes", func() {
+			spec := DiskFillChaosSpec{Size: "1Ki"}
+			Expect(spec.GetSize()).To(Equal(int64(1024)))
+
+			spec.Size = ""
+			Expect(spec.GetSize()).To(Equal(int64(0)))
+		})
+	})
+})
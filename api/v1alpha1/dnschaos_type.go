@@ -35,10 +35,10 @@ const (
 type DNSChaosScope string
 
 const (
-	// OuterScope represents DNS chaos only works on the inner host in Kubernetes cluster
+	// OuterScope represents DNS chaos only works on the outer host of Kubernetes cluster
 	OuterScope DNSChaosScope = "outer"
 
-	// InnerScope represents DNS chaos only works on the outer host of Kubernetes cluster
+	// InnerScope represents DNS chaos only works on the inner host in Kubernetes cluster
 	InnerScope DNSChaosScope = "inner"
 
 	// AllScope represents DNS chaos works on host
@@ -47,6 +47,17 @@ const (
 	// TODO: maybe we can support set the RegExp for the host
 )
 
+// DNSErrorCode is the response code of the DNS requests under error action.
+type DNSErrorCode string
+
+const (
+	// NXDomainErrorCode represents the domain doesn't exist.
+	NXDomainErrorCode DNSErrorCode = "NXDOMAIN"
+
+	// ServFailErrorCode represents the DNS server failed to complete the request.
+	ServFailErrorCode DNSErrorCode = "SERVFAIL"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -95,11 +106,38 @@ type DNSChaosSpec struct {
 	// Scheduler defines some schedule rules to control the running time of the chaos experiment about network.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
 	// Action defines the scope which the DNS chaos works.
 	// Supported action: outer, inner, all
 	// Default action: outer
 	// +kubebuilder:validation:Enum=outer;inner;all
 	Scope DNSChaosScope `json:"scope"`
+
+	// Patterns defines the domains which the DNS chaos works on, e.g. "google.com" or "*.google.com".
+	// A `*` in the pattern matches any characters. The patterns are matched with the fully qualified
+	// domain names, so the names of services should be like "svc.ns.svc.cluster.local".
+	// The DNS chaos works on all the domains in the scope if it's empty.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+
+	// ErrorCode defines the response code of the DNS requests under error action.
+	// Supported code: NXDOMAIN, SERVFAIL
+	// Default code: SERVFAIL
+	// +kubebuilder:validation:Enum=NXDOMAIN;SERVFAIL
+	// +optional
+	ErrorCode DNSErrorCode `json:"errorCode,omitempty"`
+}
+
+// GetErrorCode returns the response code of the DNS requests under error action
+func (in *DNSChaosSpec) GetErrorCode() DNSErrorCode {
+	if in.ErrorCode == "" {
+		return ServFailErrorCode
+	}
+	return in.ErrorCode
 }
 
 // GetSelector is a getter for Selector (for implementing SelectSpec)
@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -25,6 +26,8 @@ import (
 // log is for logging in this package.
 var dnschaoslog = logf.Log.WithName("dnschaos-resource")
 
+var dnsPatternRegexp = regexp.MustCompile(`^[a-zA-Z0-9\-.*]+$`)
+
 // +kubebuilder:webhook:path=/mutate-chaos-mesh-org-v1alpha1-dnschaos,mutating=true,failurePolicy=fail,groups=chaos-mesh.org,resources=dnschaos,verbs=create;update,versions=v1alpha1,name=mdnschaos.kb.io
 
 var _ webhook.Defaulter = &DNSChaos{}
@@ -65,6 +68,8 @@ func (in *DNSChaos) Validate() error {
 	specField := field.NewPath("spec")
 	allErrs := in.ValidateScheduler(specField)
 	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
+	allErrs = append(allErrs, in.ValidatePatterns(specField.Child("patterns"))...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -82,3 +87,17 @@ func (in *DNSChaos) ValidateScheduler(spec *field.Path) field.ErrorList {
 func (in *DNSChaos) ValidatePodMode(spec *field.Path) field.ErrorList {
 	return ValidatePodMode(in.Spec.Value, in.Spec.Mode, spec.Child("value"))
 }
+
+// ValidatePatterns validates the domain patterns
+func (in *DNSChaos) ValidatePatterns(patterns *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, pattern := range in.Spec.Patterns {
+		if !dnsPatternRegexp.MatchString(pattern) {
+			allErrs = append(allErrs, field.Invalid(patterns.Index(i), pattern,
+				"pattern should only contain letters, digits, '-', '.' and '*'"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("dnschaos_webhook", func() {
+	Context("Defaulter", func() {
+		It("set default namespace selector", func() {
+			dnschaos := &DNSChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault},
+			}
+			dnschaos.Default()
+			Expect(dnschaos.Spec.Selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
+		})
+	})
+	Context("ChaosValidator of dnschaos", func() {
+		It("Validate", func() {
+
+			type TestCase struct {
+				name    string
+				chaos   DNSChaos
+				execute func(chaos *DNSChaos) error
+				expect  string
+			}
+			tcs := []TestCase{
+				{
+					name: "simple ValidateCreate",
+					chaos: DNSChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo1",
+						},
+					},
+					execute: func(chaos *DNSChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "valid patterns",
+					chaos: DNSChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo2",
+						},
+						Spec: DNSChaosSpec{
+							Patterns: []string{"google.com", "*.chaos-mesh.org", "web-*.default.svc.cluster.local"},
+						},
+					},
+					execute: func(chaos *DNSChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "invalid patterns",
+					chaos: DNSChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo3",
+						},
+						Spec: DNSChaosSpec{
+							Patterns: []string{"google.com", "chaos mesh/org"},
+						},
+					},
+					execute: func(chaos *DNSChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "empty pattern",
+					chaos: DNSChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo4",
+						},
+						Spec: DNSChaosSpec{
+							Patterns: []string{""},
+						},
+					},
+					execute: func(chaos *DNSChaos) error {
+						return chaos.ValidateUpdate(chaos)
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				err := tc.execute(&tc.chaos)
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,35 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// DryRunStatus records the changes which the chaos would make if it's not in dry-run mode.
+type DryRunStatus struct {
+	// Pods are the changes planned on each selected pod.
+	// +optional
+	Pods []PodPlan `json:"pods,omitempty"`
+}
+
+// PodPlan is the change planned on a pod in dry-run mode.
+type PodPlan struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Kind is the kind of change, e.g. PodNetworkChaos, PodIoChaos or Stressors.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Plan is the rendered change, e.g. the spec of PodNetworkChaos in json.
+	// +optional
+	Plan string `json:"plan,omitempty"`
+}
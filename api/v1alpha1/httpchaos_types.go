@@ -13,7 +13,14 @@
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // +kubebuilder:object:root=true
 // +chaos-mesh:base
@@ -36,16 +43,28 @@ const (
 	HTTPMixedAction                 = "mixed"
 )
 
+// Matcher matches a header of the request. The request is matched when all of
+// the defined conditions hold.
 type Matcher struct {
-	Name           string  `json:"name"`
-	ExactMatch     *string `json:"exact_match,omitempty"`
-	RegexMatch     *string `json:"regex_match,omitempty"`
+	// Name is the name of the header.
+	Name string `json:"name"`
+	// ExactMatch matches the header whose value equals to it.
+	ExactMatch *string `json:"exact_match,omitempty"`
+	// RegexMatch matches the header whose whole value matches the regular expression.
+	RegexMatch *string `json:"regex_match,omitempty"`
+	// SafeRegexMatch is the same as RegexMatch, as the regular expressions are always RE2.
 	SafeRegexMatch *string `json:"safe_regex_match,omitempty"`
-	RangeMatch     *string `json:"range_match,omitempty"`
-	PresentMatch   *string `json:"present_match,omitempty"`
-	PrefixMatch    *string `json:"prefix_match,omitempty"`
-	SuffixMatch    *string `json:"suffix_match,omitempty"`
-	InvertMatch    *string `json:"invert_match,omitempty"`
+	// RangeMatch matches the header whose value is an integer in the range `[start,end)`,
+	// which is represented as `start,end`.
+	RangeMatch *string `json:"range_match,omitempty"`
+	// PresentMatch matches the header whose existence equals to it ("true" or "false").
+	PresentMatch *string `json:"present_match,omitempty"`
+	// PrefixMatch matches the header whose value has the prefix.
+	PrefixMatch *string `json:"prefix_match,omitempty"`
+	// SuffixMatch matches the header whose value has the suffix.
+	SuffixMatch *string `json:"suffix_match,omitempty"`
+	// InvertMatch inverts the result of the match if it's "true".
+	InvertMatch *string `json:"invert_match,omitempty"`
 }
 
 type HTTPChaosSpec struct {
@@ -56,6 +75,11 @@ type HTTPChaosSpec struct {
 	// control the running time of the chaos experiment about pods.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
 	// Action defines the specific pod chaos action.
 	// Supported action: delay | abort | mixed
 	// Default action: delay
@@ -90,6 +114,49 @@ type HTTPChaosSpec struct {
 
 	// Specifies how the header match will be performed to route the request.
 	Headers []Matcher `json:"headers,omitempty"`
+
+	// Port is the port of the HTTP server in the target pods.
+	// The requests sent to this port are redirected to the chaos proxy.
+	Port int32 `json:"port"`
+
+	// Delay represents the delay of the requests, which is required by the `delay` and the `mixed` actions.
+	// +optional
+	Delay *string `json:"delay,omitempty"`
+
+	// AbortCode is the status code responded to the aborted requests. Default code: 500
+	// +optional
+	AbortCode int32 `json:"abortCode,omitempty"`
+}
+
+// GetDelay returns the delay of the requests
+func (in *HTTPChaosSpec) GetDelay() (time.Duration, error) {
+	if in.Delay == nil {
+		return 0, nil
+	}
+	return time.ParseDuration(*in.Delay)
+}
+
+// GetAbortCode returns the status code of the aborted requests
+func (in *HTTPChaosSpec) GetAbortCode() int {
+	if in.AbortCode == 0 {
+		return http.StatusInternalServerError
+	}
+	return int(in.AbortCode)
+}
+
+// GetPercent returns the percentage of the injected requests
+func (in *HTTPChaosSpec) GetPercent() (int, error) {
+	if in.Percent == "" {
+		return 100, nil
+	}
+	percent, err := strconv.Atoi(in.Percent)
+	if err != nil {
+		return 0, err
+	}
+	if percent < 0 || percent > 100 {
+		return 0, fmt.Errorf("percent %d should be in [0, 100]", percent)
+	}
+	return percent, nil
 }
 
 func (in *HTTPChaosSpec) GetHeaders() []Matcher {
@@ -110,4 +177,16 @@ func (in *HTTPChaosSpec) GetSelector() SelectorSpec {
 
 type HTTPChaosStatus struct {
 	ChaosStatus `json:",inline"`
+
+	// Instances records the proxy processes in each pod
+	// +optional
+	Instances map[string]HTTPChaosInstance `json:"instances,omitempty"`
+}
+
+// HTTPChaosInstance is a proxy process started by chaos-daemon
+type HTTPChaosInstance struct {
+	// Pid is the pid of the proxy process
+	Pid int64 `json:"pid"`
+	// StartTime is the create time of the proxy process
+	StartTime int64 `json:"startTime"`
 }
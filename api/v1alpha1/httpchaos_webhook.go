@@ -0,0 +1,118 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var httpchaoslog = logf.Log.WithName("httpchaos-resource")
+
+// +kubebuilder:webhook:path=/mutate-chaos-mesh-org-v1alpha1-httpchaos,mutating=true,failurePolicy=fail,groups=chaos-mesh.org,resources=httpchaos,verbs=create;update,versions=v1alpha1,name=mhttpchaos.kb.io
+
+var _ webhook.Defaulter = &HTTPChaos{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (in *HTTPChaos) Default() {
+	httpchaoslog.Info("default", "name", in.Name)
+
+	in.Spec.Selector.DefaultNamespace(in.GetNamespace())
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-chaos-mesh-org-v1alpha1-httpchaos,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=httpchaos,versions=v1alpha1,name=vhttpchaos.kb.io
+
+var _ ChaosValidator = &HTTPChaos{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (in *HTTPChaos) ValidateCreate() error {
+	httpchaoslog.Info("validate create", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (in *HTTPChaos) ValidateUpdate(old runtime.Object) error {
+	httpchaoslog.Info("validate update", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (in *HTTPChaos) ValidateDelete() error {
+	httpchaoslog.Info("validate delete", "name", in.Name)
+
+	// Nothing to do?
+	return nil
+}
+
+// Validate validates chaos object
+func (in *HTTPChaos) Validate() error {
+	specField := field.NewPath("spec")
+	allErrs := in.ValidateScheduler(specField)
+	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
+	allErrs = append(allErrs, in.Spec.validateHTTP(specField)...)
+
+	if len(allErrs) > 0 {
+		return fmt.Errorf(allErrs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// ValidateScheduler validates the scheduler and duration
+func (in *HTTPChaos) ValidateScheduler(spec *field.Path) field.ErrorList {
+	return ValidateScheduler(in, spec)
+}
+
+// ValidatePodMode validates the value with podmode
+func (in *HTTPChaos) ValidatePodMode(spec *field.Path) field.ErrorList {
+	return ValidatePodMode(in.Spec.Value, in.Spec.Mode, spec.Child("value"))
+}
+
+// validateHTTP validates the port, delay, abort code and percent of the requests
+func (in *HTTPChaosSpec) validateHTTP(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.Port < 1 || in.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("port"), in.Port,
+			"port should be in [1, 65535]"))
+	}
+
+	if in.Delay == nil {
+		if in.Action == HTTPDelayAction || in.Action == HTTPMixedAction {
+			allErrs = append(allErrs, field.Required(spec.Child("delay"),
+				fmt.Sprintf("delay is required by the %s action", in.Action)))
+		}
+	} else if _, err := in.GetDelay(); err != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("delay"), *in.Delay,
+			fmt.Sprintf("parse delay field error:%s", err)))
+	}
+
+	if in.AbortCode != 0 && http.StatusText(int(in.AbortCode)) == "" {
+		allErrs = append(allErrs, field.Invalid(spec.Child("abortCode"), in.AbortCode,
+			"abortCode should be a valid HTTP status code"))
+	}
+
+	if _, err := in.GetPercent(); err != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("percent"), in.Percent,
+			fmt.Sprintf("parse percent field error:%s", err)))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("httpchaos_webhook", func() {
+	Context("Defaulter", func() {
+		It("set default namespace selector", func() {
+			httpchaos := &HTTPChaos{
+				ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault},
+			}
+			httpchaos.Default()
+			Expect(httpchaos.Spec.Selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
+		})
+	})
+	Context("ChaosValidator of httpchaos", func() {
+		It("Validate", func() {
+
+			type TestCase struct {
+				name    string
+				chaos   HTTPChaos
+				execute func(chaos *HTTPChaos) error
+				expect  string
+			}
+			delay := "1s"
+			invalidDelay := "1"
+			tcs := []TestCase{
+				{
+					name: "simple ValidateCreate",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo1",
+						},
+						Spec: HTTPChaosSpec{
+							Action: HTTPDelayAction,
+							Port:   80,
+							Delay:  &delay,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "simple ValidateUpdate",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo2",
+						},
+						Spec: HTTPChaosSpec{
+							Action:    HTTPAbortAction,
+							Port:      8080,
+							AbortCode: 503,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateUpdate(chaos)
+					},
+					expect: "",
+				},
+				{
+					name: "simple ValidateDelete",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo3",
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateDelete()
+					},
+					expect: "",
+				},
+				{
+					name: "invalid port",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo4",
+						},
+						Spec: HTTPChaosSpec{
+							Action: HTTPAbortAction,
+							Port:   65536,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "missing delay",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo5",
+						},
+						Spec: HTTPChaosSpec{
+							Action: HTTPMixedAction,
+							Port:   80,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid delay",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo6",
+						},
+						Spec: HTTPChaosSpec{
+							Action: HTTPDelayAction,
+							Port:   80,
+							Delay:  &invalidDelay,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid abort code",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo7",
+						},
+						Spec: HTTPChaosSpec{
+							Action:    HTTPAbortAction,
+							Port:      80,
+							AbortCode: 999,
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid percent",
+					chaos: HTTPChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo8",
+						},
+						Spec: HTTPChaosSpec{
+							Action:  HTTPAbortAction,
+							Port:    80,
+							Percent: "101",
+						},
+					},
+					execute: func(chaos *HTTPChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				err := tc.execute(&tc.chaos)
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
@@ -50,9 +50,11 @@ type IoChaosSpec struct {
 	Value string `json:"value"`
 
 	// Action defines the specific pod chaos action.
-	// Supported action: latency / fault / attrOverride
-	// +kubebuilder:validation:Enum=latency;fault;attrOverride
-	Action IoChaosType `json:"action"`
+	// Supported action: latency / fault / attrOverride / mistake / throttle
+	// It can be omitted if the actions are defined in Actions.
+	// +kubebuilder:validation:Enum=latency;fault;attrOverride;mistake;throttle
+	// +optional
+	Action IoChaosType `json:"action,omitempty"`
 
 	// Delay defines the value of I/O chaos action delay.
 	// A delay string is a possibly signed sequence of
@@ -71,6 +73,17 @@ type IoChaosSpec struct {
 	// +optional
 	Attr *AttrOverrideSpec `json:"attr,omitempty"`
 
+	// Mistake defines the wrong data which replaces the data of read/write.
+	// It is required when the action is `mistake`.
+	// +optional
+	Mistake *MistakeSpec `json:"mistake,omitempty"`
+
+	// Throttle defines the limits of the bandwidth and IOPS of a block device,
+	// which are set on the cgroup of container.
+	// It is required when the action is `throttle`.
+	// +optional
+	Throttle *IoThrottleSpec `json:"throttle,omitempty"`
+
 	// Path defines the path of files for injecting I/O chaos action.
 	// +optional
 	Path string `json:"path,omitempty"`
@@ -85,6 +98,12 @@ type IoChaosSpec struct {
 	// +optional
 	Percent int `json:"percent,omitempty"`
 
+	// Actions defines a list of I/O chaos actions which are injected together,
+	// each of them has its own path, methods and percent.
+	// They are injected after the action defined by Action.
+	// +optional
+	Actions []IoChaosActionSpec `json:"actions,omitempty"`
+
 	// VolumePath represents the mount path of injected volume
 	VolumePath string `json:"volumePath"`
 
@@ -92,6 +111,11 @@ type IoChaosSpec struct {
 	// control the running time of the chaos experiment about pods.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
 	// Duration represents the duration of the chaos action.
 	// It is required when the action is `PodFailureAction`.
 	// A duration string is a possibly signed sequence of
@@ -102,6 +126,86 @@ type IoChaosSpec struct {
 	Duration *string `json:"duration,omitempty"`
 }
 
+// IoChaosActionSpec defines one of the I/O chaos actions of IoChaos
+type IoChaosActionSpec struct {
+	// Action defines the specific I/O chaos action.
+	// Supported action: latency / fault / attrOverride / mistake / throttle
+	// +kubebuilder:validation:Enum=latency;fault;attrOverride;mistake;throttle
+	Action IoChaosType `json:"action"`
+
+	// Delay defines the value of I/O chaos action delay.
+	// A delay string is a possibly signed sequence of
+	// decimal numbers, each with optional fraction and a unit suffix,
+	// such as "300ms".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// +optional
+	Delay string `json:"delay,omitempty"`
+
+	// Errno defines the error code that returned by I/O action.
+	// +optional
+	Errno uint32 `json:"errno,omitempty"`
+
+	// Attr defines the overrided attribution
+	// +optional
+	Attr *AttrOverrideSpec `json:"attr,omitempty"`
+
+	// Mistake defines the wrong data which replaces the data of read/write.
+	// It is required when the action is `mistake`.
+	// +optional
+	Mistake *MistakeSpec `json:"mistake,omitempty"`
+
+	// Throttle defines the limits of the bandwidth and IOPS of a block device,
+	// which are set on the cgroup of container.
+	// It is required when the action is `throttle`.
+	// +optional
+	Throttle *IoThrottleSpec `json:"throttle,omitempty"`
+
+	// Path defines the path of files for injecting I/O chaos action.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Methods defines the I/O methods for injecting I/O chaos action.
+	// default: all I/O methods.
+	// +optional
+	Methods []IoMethod `json:"methods,omitempty"`
+
+	// Percent defines the percentage of injection errors and provides a number from 0-100.
+	// default: 100.
+	// +optional
+	Percent int `json:"percent,omitempty"`
+}
+
+// GetThrottles returns the limits of all the throttle actions
+func (in *IoChaosSpec) GetThrottles() []IoThrottleSpec {
+	var throttles []IoThrottleSpec
+	for _, action := range in.GetActions() {
+		if action.Action == IoThrottle && action.Throttle != nil {
+			throttles = append(throttles, *action.Throttle)
+		}
+	}
+	return throttles
+}
+
+// GetActions returns all the I/O chaos actions of the spec, the one defined
+// by the inline fields comes first if it is set
+func (in *IoChaosSpec) GetActions() []IoChaosActionSpec {
+	actions := make([]IoChaosActionSpec, 0, len(in.Actions)+1)
+	if in.Action != "" {
+		actions = append(actions, IoChaosActionSpec{
+			Action:   in.Action,
+			Delay:    in.Delay,
+			Errno:    in.Errno,
+			Attr:     in.Attr,
+			Mistake:  in.Mistake,
+			Throttle: in.Throttle,
+			Path:     in.Path,
+			Methods:  in.Methods,
+			Percent:  in.Percent,
+		})
+	}
+	return append(actions, in.Actions...)
+}
+
 func (in *IoChaosSpec) GetSelector() SelectorSpec {
 	return in.Selector
 }
@@ -117,4 +221,15 @@ func (in *IoChaosSpec) GetValue() string {
 // IoChaosStatus defines the observed state of IoChaos
 type IoChaosStatus struct {
 	ChaosStatus `json:",inline"`
+
+	// PreviousThrottles are the limits of the devices on the pods before applying the throttles,
+	// which are restored on recover. The key is the namespace and name of pod.
+	// +optional
+	PreviousThrottles map[string]PodIoThrottles `json:"previousThrottles,omitempty"`
+}
+
+// PodIoThrottles are the limits of the devices on a pod
+type PodIoThrottles struct {
+	// Throttles are the limits of the devices, a rate of 0 means unlimited
+	Throttles []IoThrottleSpec `json:"throttles"`
 }
@@ -78,6 +78,78 @@ var _ = Describe("IoChaos", func() {
 			Expect(iochaos.GetNextStart()).To(Equal(nTime))
 		})
 
+		It("should get all the actions", func() {
+			iochaos := &IoChaos{
+				Spec: IoChaosSpec{
+					Action:  IoFaults,
+					Errno:   5,
+					Path:    "/data/wal/*",
+					Methods: []IoMethod{Write},
+					Percent: 5,
+					Actions: []IoChaosActionSpec{
+						{
+							Action:  IoLatency,
+							Delay:   "50ms",
+							Methods: []IoMethod{Fsync},
+						},
+					},
+				},
+			}
+			Expect(iochaos.Spec.GetActions()).To(Equal([]IoChaosActionSpec{
+				{
+					Action:  IoFaults,
+					Errno:   5,
+					Path:    "/data/wal/*",
+					Methods: []IoMethod{Write},
+					Percent: 5,
+				},
+				{
+					Action:  IoLatency,
+					Delay:   "50ms",
+					Methods: []IoMethod{Fsync},
+				},
+			}))
+
+			iochaos.Spec.Action = ""
+			Expect(iochaos.Spec.GetActions()).To(Equal(iochaos.Spec.Actions))
+		})
+
+		It("should get throttles of all the actions", func() {
+			iochaos := &IoChaos{
+				Spec: IoChaosSpec{
+					Action: IoThrottle,
+					Throttle: &IoThrottleSpec{
+						Device:  "/dev/sda",
+						ReadBps: 1048576,
+					},
+					Actions: []IoChaosActionSpec{
+						{
+							Action: IoLatency,
+							Delay:  "10ms",
+						},
+						{
+							Action: IoThrottle,
+							Throttle: &IoThrottleSpec{
+								Device:    "8:16",
+								WriteIops: 100,
+							},
+						},
+					},
+				},
+			}
+
+			Expect(iochaos.Spec.GetThrottles()).To(Equal([]IoThrottleSpec{
+				{
+					Device:  "/dev/sda",
+					ReadBps: 1048576,
+				},
+				{
+					Device:    "8:16",
+					WriteIops: 100,
+				},
+			}))
+		})
+
 		It("should set recover time successfully", func() {
 			iochaos := &IoChaos{}
 			nTime := time.Now()
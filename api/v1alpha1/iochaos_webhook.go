@@ -15,6 +15,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +29,9 @@ import (
 // log is for logging in this package.
 var iochaoslog = logf.Log.WithName("iochaos-resource")
 
+// deviceNumberRegexp matches the major and minor number of a device, such as 8:0
+var deviceNumberRegexp = regexp.MustCompile(`^\d+:\d+$`)
+
 // SetupWebhookWithManager setup IoChaos's webhook with manager
 func (in *IoChaos) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -74,9 +79,8 @@ func (in *IoChaos) Validate() error {
 	specField := field.NewPath("spec")
 	allErrs := in.ValidateScheduler(specField)
 	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
-	allErrs = append(allErrs, in.Spec.validateDelay(specField.Child("delay"))...)
-	allErrs = append(allErrs, in.Spec.validateErrno(specField.Child("errno"))...)
-	allErrs = append(allErrs, in.Spec.validatePercent(specField.Child("percent"))...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
+	allErrs = append(allErrs, in.Spec.validateActions(specField)...)
 
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
@@ -94,7 +98,45 @@ func (in *IoChaos) ValidatePodMode(spec *field.Path) field.ErrorList {
 	return ValidatePodMode(in.Spec.Value, in.Spec.Mode, spec.Child("value"))
 }
 
-func (in *IoChaosSpec) validateDelay(delay *field.Path) field.ErrorList {
+// validateActions validates the inline action and every action in the list
+func (in *IoChaosSpec) validateActions(spec *field.Path) field.ErrorList {
+	inline := IoChaosActionSpec{
+		Action:   in.Action,
+		Delay:    in.Delay,
+		Errno:    in.Errno,
+		Percent:  in.Percent,
+		Methods:  in.Methods,
+		Mistake:  in.Mistake,
+		Throttle: in.Throttle,
+	}
+	allErrs := inline.validate(spec)
+
+	actionsField := spec.Child("actions")
+	if len(in.GetActions()) == 0 {
+		allErrs = append(allErrs, field.Required(actionsField, "at least one action should be specified"))
+	}
+	for i := range in.Actions {
+		action := &in.Actions[i]
+		actionField := actionsField.Index(i)
+		if action.Action == "" {
+			allErrs = append(allErrs, field.Required(actionField.Child("action"), "the action should be specified"))
+			continue
+		}
+		allErrs = append(allErrs, action.validate(actionField)...)
+	}
+	return allErrs
+}
+
+func (in *IoChaosActionSpec) validate(action *field.Path) field.ErrorList {
+	allErrs := in.validateDelay(action.Child("delay"))
+	allErrs = append(allErrs, in.validateErrno(action.Child("errno"))...)
+	allErrs = append(allErrs, in.validatePercent(action.Child("percent"))...)
+	allErrs = append(allErrs, in.validateMistake(action)...)
+	allErrs = append(allErrs, in.validateThrottle(action.Child("throttle"))...)
+	return allErrs
+}
+
+func (in *IoChaosActionSpec) validateDelay(delay *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if in.Action == IoLatency {
 		_, err := time.ParseDuration(in.Delay)
@@ -106,7 +148,7 @@ func (in *IoChaosSpec) validateDelay(delay *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func (in *IoChaosSpec) validateErrno(errno *field.Path) field.ErrorList {
+func (in *IoChaosActionSpec) validateErrno(errno *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if in.Action == IoFaults {
 		if in.Errno == 0 {
@@ -117,7 +159,7 @@ func (in *IoChaosSpec) validateErrno(errno *field.Path) field.ErrorList {
 	return allErrs
 }
 
-func (in *IoChaosSpec) validatePercent(percentField *field.Path) field.ErrorList {
+func (in *IoChaosActionSpec) validatePercent(percentField *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if in.Percent > 100 || in.Percent < 0 {
 		allErrs = append(allErrs, field.Invalid(percentField, in.Percent,
@@ -126,3 +168,73 @@ func (in *IoChaosSpec) validatePercent(percentField *field.Path) field.ErrorList
 
 	return allErrs
 }
+
+// validateMistake validates the mistake and the methods of the mistake action,
+// which can only be injected into read and write
+func (in *IoChaosActionSpec) validateMistake(action *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in.Action != IoMistake {
+		return allErrs
+	}
+
+	for i, method := range in.Methods {
+		if method != Read && method != Write {
+			allErrs = append(allErrs, field.NotSupported(action.Child("methods").Index(i), method,
+				[]string{string(Read), string(Write)}))
+		}
+	}
+
+	mistakeField := action.Child("mistake")
+	if in.Mistake == nil {
+		allErrs = append(allErrs, field.Required(mistakeField,
+			fmt.Sprintf("the mistake should be specified for action:%s", in.Action)))
+		return allErrs
+	}
+
+	switch in.Mistake.Filling {
+	case ZeroFilling, RandomFilling, FlipFilling:
+	default:
+		allErrs = append(allErrs, field.NotSupported(mistakeField.Child("filling"), in.Mistake.Filling,
+			[]string{string(ZeroFilling), string(RandomFilling), string(FlipFilling)}))
+	}
+	if in.Mistake.MaxOccurrences < 1 {
+		allErrs = append(allErrs, field.Invalid(mistakeField.Child("maxOccurrences"), in.Mistake.MaxOccurrences,
+			"maxOccurrences should be greater than 0"))
+	}
+	if in.Mistake.MaxLength < 1 {
+		allErrs = append(allErrs, field.Invalid(mistakeField.Child("maxLength"), in.Mistake.MaxLength,
+			"maxLength should be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateThrottle validates the device and limits of the throttle action
+func (in *IoChaosActionSpec) validateThrottle(throttleField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in.Action != IoThrottle {
+		return allErrs
+	}
+
+	if in.Throttle == nil {
+		allErrs = append(allErrs, field.Required(throttleField,
+			fmt.Sprintf("the throttle should be specified for action:%s", in.Action)))
+		return allErrs
+	}
+
+	if in.Throttle.Device == "" {
+		allErrs = append(allErrs, field.Required(throttleField.Child("device"),
+			"the device should be specified"))
+	} else if !filepath.IsAbs(in.Throttle.Device) && !deviceNumberRegexp.MatchString(in.Throttle.Device) {
+		allErrs = append(allErrs, field.Invalid(throttleField.Child("device"), in.Throttle.Device,
+			"the device should be an absolute path or in the form of major:minor"))
+	}
+
+	if in.Throttle.ReadBps == 0 && in.Throttle.WriteBps == 0 &&
+		in.Throttle.ReadIops == 0 && in.Throttle.WriteIops == 0 {
+		allErrs = append(allErrs, field.Invalid(throttleField, in.Throttle,
+			"at least one of readBps, writeBps, readIops and writeIops should be greater than 0"))
+	}
+
+	return allErrs
+}
@@ -49,6 +49,10 @@ var _ = Describe("iochaos_webhook", func() {
 							Namespace: metav1.NamespaceDefault,
 							Name:      "foo1",
 						},
+						Spec: IoChaosSpec{
+							Action: IoLatency,
+							Delay:  "10ms",
+						},
 					},
 					execute: func(chaos *IoChaos) error {
 						return chaos.ValidateCreate()
@@ -62,6 +66,10 @@ var _ = Describe("iochaos_webhook", func() {
 							Namespace: metav1.NamespaceDefault,
 							Name:      "foo2",
 						},
+						Spec: IoChaosSpec{
+							Action: IoLatency,
+							Delay:  "10ms",
+						},
 					},
 					execute: func(chaos *IoChaos) error {
 						return chaos.ValidateUpdate(chaos)
@@ -304,6 +312,296 @@ var _ = Describe("iochaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "validate multiple actions",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo17",
+						},
+						Spec: IoChaosSpec{
+							Actions: []IoChaosActionSpec{
+								{
+									Action:  IoFaults,
+									Errno:   5,
+									Path:    "/data/wal/*",
+									Methods: []IoMethod{Write},
+									Percent: 5,
+								},
+								{
+									Action:  IoLatency,
+									Delay:   "50ms",
+									Methods: []IoMethod{Fsync},
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the errno of one of the actions",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo18",
+						},
+						Spec: IoChaosSpec{
+							Action: IoLatency,
+							Delay:  "10ms",
+							Actions: []IoChaosActionSpec{
+								{
+									Action: IoFaults,
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the percent of one of the actions",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo19",
+						},
+						Spec: IoChaosSpec{
+							Actions: []IoChaosActionSpec{
+								{
+									Action:  IoLatency,
+									Delay:   "10ms",
+									Percent: 101,
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the action of one of the actions is required",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo20",
+						},
+						Spec: IoChaosSpec{
+							Actions: []IoChaosActionSpec{
+								{
+									Delay: "10ms",
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate mistake",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo21",
+						},
+						Spec: IoChaosSpec{
+							Action:  IoMistake,
+							Methods: []IoMethod{Read, Write},
+							Mistake: &MistakeSpec{
+								Filling:        RandomFilling,
+								MaxOccurrences: 3,
+								MaxLength:      64,
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate mistake is required",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo22",
+						},
+						Spec: IoChaosSpec{
+							Actions: []IoChaosActionSpec{
+								{
+									Action: IoMistake,
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the methods of mistake",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo23",
+						},
+						Spec: IoChaosSpec{
+							Action:  IoMistake,
+							Methods: []IoMethod{Open},
+							Mistake: &MistakeSpec{
+								Filling:        ZeroFilling,
+								MaxOccurrences: 1,
+								MaxLength:      1,
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the filling and the length of mistake",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo24",
+						},
+						Spec: IoChaosSpec{
+							Action: IoMistake,
+							Mistake: &MistakeSpec{
+								Filling:        "unknown",
+								MaxOccurrences: 1,
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate throttle",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo25",
+						},
+						Spec: IoChaosSpec{
+							Action: IoThrottle,
+							Throttle: &IoThrottleSpec{
+								Device:   "/dev/sda",
+								ReadBps:  1048576,
+								WriteBps: 1048576,
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate throttle with device number",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo26",
+						},
+						Spec: IoChaosSpec{
+							Actions: []IoChaosActionSpec{
+								{
+									Action: IoThrottle,
+									Throttle: &IoThrottleSpec{
+										Device:    "8:0",
+										WriteIops: 100,
+									},
+								},
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate throttle without throttle",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo27",
+						},
+						Spec: IoChaosSpec{
+							Action: IoThrottle,
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate throttle with invalid device",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo28",
+						},
+						Spec: IoChaosSpec{
+							Action: IoThrottle,
+							Throttle: &IoThrottleSpec{
+								Device:  "sda",
+								ReadBps: 1048576,
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate throttle without limits",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo29",
+						},
+						Spec: IoChaosSpec{
+							Action: IoThrottle,
+							Throttle: &IoThrottleSpec{
+								Device: "/dev/sda",
+							},
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the actions are required",
+					chaos: IoChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo30",
+						},
+					},
+					execute: func(chaos *IoChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
 			}
 
 			for _, tc := range tcs {
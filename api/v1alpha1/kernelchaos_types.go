@@ -58,6 +58,11 @@ type KernelChaosSpec struct {
 
 	// Scheduler defines some schedule rules to control the running time of the chaos experiment about time.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
 }
 
 // GetSelector is a getter for Selector (for implementing SelectSpec)
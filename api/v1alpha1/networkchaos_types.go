@@ -68,6 +68,14 @@ const (
 
 	// BandwidthAction represents the chaos action of network bandwidth of pods.
 	BandwidthAction NetworkChaosAction = "bandwidth"
+
+	// ResetAction represents the chaos action of resetting the tcp connections between pods,
+	// the packets are rejected with tcp reset.
+	ResetAction NetworkChaosAction = "reset"
+
+	// RejectAction represents the chaos action of rejecting the packets between pods,
+	// the packets are rejected with an ICMP error.
+	RejectAction NetworkChaosAction = "reject"
 )
 
 // Direction represents traffic direction from source to target,
@@ -118,12 +126,80 @@ func (in *Target) GetValue() string {
 	return in.TargetValue
 }
 
+// PartitionGroup represents a group of pods in the multi-group partition
+type PartitionGroup struct {
+	// Name is the name of group, which is referred in the links
+	Name string `json:"name"`
+
+	// Target defines the pods in the group
+	Target `json:",inline"`
+}
+
+// PartitionLink represents a link cut in one direction between two groups
+type PartitionLink struct {
+	// From is the name of group sending the packets
+	From string `json:"from"`
+
+	// To is the name of group receiving the packets
+	To string `json:"to"`
+}
+
+// TopologySpec defines the zone to isolate in the outage of zone
+type TopologySpec struct {
+	// TopologyKey is the label of nodes to group the pods into zones.
+	// Default: topology.kubernetes.io/zone
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Zone is the value of topology label of the zone to isolate.
+	// A random zone among the selected pods is isolated if it's empty.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// IsolateExternalTargets isolates the zone from the external targets too
+	// +optional
+	IsolateExternalTargets bool `json:"isolateExternalTargets,omitempty"`
+}
+
+// CutLinks returns the links cut between groups, indexed by the position of groups
+func (in *NetworkChaosSpec) CutLinks() [][2]int {
+	index := make(map[string]int, len(in.Groups))
+	for i, group := range in.Groups {
+		index[group.Name] = i
+	}
+
+	links := [][2]int{}
+	if len(in.Links) == 0 {
+		for from := range in.Groups {
+			for to := range in.Groups {
+				if from != to {
+					links = append(links, [2]int{from, to})
+				}
+			}
+		}
+		return links
+	}
+
+	for _, link := range in.Links {
+		from, ok := index[link.From]
+		if !ok {
+			continue
+		}
+		to, ok := index[link.To]
+		if !ok || from == to {
+			continue
+		}
+		links = append(links, [2]int{from, to})
+	}
+	return links
+}
+
 // NetworkChaosSpec defines the desired state of NetworkChaos
 type NetworkChaosSpec struct {
 	// Action defines the specific network chaos action.
-	// Supported action: partition, netem, delay, loss, duplicate, corrupt
+	// Supported action: partition, netem, delay, loss, duplicate, corrupt, bandwidth, reset, reject
 	// Default action: delay
-	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth
+	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth;reset;reject
 	Action NetworkChaosAction `json:"action"`
 
 	// Mode defines the mode to run chaos action.
@@ -147,10 +223,34 @@ type NetworkChaosSpec struct {
 	// Scheduler defines some schedule rules to control the running time of the chaos experiment about network.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
 	// TcParameter represents the traffic control definition
 	TcParameter `json:",inline"`
 
-	// Direction represents the direction, this applies on netem and network partition action
+	// Continuous keeps injecting the chaos into the pods matching the selector while the experiment is
+	// running, including the pods created or restarted later. The pods injected before are kept, and
+	// the newcomers are selected within the budget of mode. The pods of target are selected
+	// again when the chaos is injected into the newcomers.
+	// +optional
+	Continuous bool `json:"continuous,omitempty"`
+
+	// Profile changes the fault over time while the experiment is running, e.g. ramps the loss,
+	// steps the latency or flaps the partition. Ramp and step work on delay, loss, duplicate,
+	// corrupt, bandwidth, reset and reject action, and flap works on all the actions.
+	// +optional
+	Profile *ProfileSpec `json:"profile,omitempty"`
+
+	// Reject represents the detail about reset and reject action
+	// +optional
+	Reject *RejectSpec `json:"reject,omitempty"`
+
+	// Direction represents the direction, this applies on netem and network partition action.
+	// For netem actions, the traffic to the targets is shaped on the egress of the selected pods,
+	// and the traffic from the targets is shaped on the ingress of the selected pods.
 	// +optional
 	// +kubebuilder:validation:Enum=to;from;both;""
 	Direction Direction `json:"direction,omitempty"`
@@ -159,9 +259,64 @@ type NetworkChaosSpec struct {
 	// +optional
 	Target *Target `json:"target,omitempty"`
 
-	// ExternalTargets represents network targets outside k8s
+	// ExternalTargets represents network targets outside k8s, which can be ips, cidrs or domains.
+	// The domains are resolved in the pods periodically, so the addresses follow the changes of dns records.
 	// +optional
 	ExternalTargets []string `json:"externalTargets,omitempty"`
+
+	// Groups splits the pods into several groups for partition, reset and reject action.
+	// The pods in the same group can talk to each other, but the links across groups are cut.
+	// The selector, target and direction are ignored if it's set.
+	// +optional
+	Groups []PartitionGroup `json:"groups,omitempty"`
+
+	// Links defines the links cut between groups, only the traffic from the `from` group to
+	// the `to` group is cut in each link. If it's empty, all the links across groups are cut
+	// in both directions.
+	// +optional
+	Links []PartitionLink `json:"links,omitempty"`
+
+	// Topology simulates the outage of a zone for partition, reset and reject action. The selected
+	// pods are grouped by the topology label of their nodes, and the pods in one zone are isolated
+	// from the pods in the other zones. The target and direction are ignored if it's set.
+	// +optional
+	Topology *TopologySpec `json:"topology,omitempty"`
+
+	// TrafficFilter limits the chaos to the traffic with specified protocol and ports
+	TrafficFilter `json:",inline"`
+
+	// Device represents the network device in the pod to inject chaos.
+	// If it's empty, `eth0` is used for traffic control actions and all devices are blocked for partition.
+	// `*` represents all the network devices except the loopback device.
+	// +optional
+	Device string `json:"device,omitempty"`
+}
+
+// AllNetworkDevices represents all the network devices in the pod except the loopback device
+const AllNetworkDevices = "*"
+
+// TrafficFilter represents the protocol and ports of the traffic which the chaos works on
+type TrafficFilter struct {
+	// Protocol represents the protocol of the traffic, all protocols are affected if it's empty
+	// +optional
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;""
+	Protocol string `json:"protocol,omitempty"`
+
+	// SourcePort represents the source ports of the traffic, which can be a port, a port range
+	// or a list of them separated by comma, e.g. "80", "8000-9000" or "80,443,8000-9000".
+	// The protocol must be tcp or udp if it's set.
+	// +optional
+	SourcePort string `json:"sourcePort,omitempty"`
+
+	// DestinationPort represents the destination ports of the traffic, in the same form as SourcePort.
+	// The protocol must be tcp or udp if it's set.
+	// +optional
+	DestinationPort string `json:"destinationPort,omitempty"`
+}
+
+// IsEmpty returns whether the filter matches all traffic
+func (in *TrafficFilter) IsEmpty() bool {
+	return in.Protocol == "" && in.SourcePort == "" && in.DestinationPort == ""
 }
 
 // GetSelector is a getter for Selector (for implementing SelectSpec)
@@ -179,9 +334,67 @@ func (in *NetworkChaosSpec) GetValue() string {
 	return in.Value
 }
 
+// IsContinuous is a getter for Continuous (for implementing ContinuousObject)
+func (in *NetworkChaos) IsContinuous() bool {
+	return in.Spec.Continuous
+}
+
+// GetProfile is a getter for Profile (for implementing ProfiledObject)
+func (in *NetworkChaos) GetProfile() *ProfileSpec {
+	return in.Spec.Profile
+}
+
+// ProfiledSpec returns the spec in the current stage of the profile, whose main parameter
+// of the action is replaced with the value of the stage
+func (in *NetworkChaos) ProfiledSpec() *NetworkChaosSpec {
+	spec := in.Spec.DeepCopy()
+	if spec.Profile == nil || in.Status.Profile == nil || in.Status.Profile.Value == "" {
+		return spec
+	}
+
+	spec.setProfileValue(in.Status.Profile.Value)
+	return spec
+}
+
+// setProfileValue replaces the main parameter of the action with the value
+func (in *NetworkChaosSpec) setProfileValue(value string) {
+	switch in.Action {
+	case DelayAction:
+		if in.Delay != nil {
+			in.Delay.Latency = value
+		}
+	case LossAction:
+		if in.Loss != nil {
+			in.Loss.Loss = value
+		}
+	case DuplicateAction:
+		if in.Duplicate != nil {
+			in.Duplicate.Duplicate = value
+		}
+	case CorruptAction:
+		if in.Corrupt != nil {
+			in.Corrupt.Corrupt = value
+		}
+	case BandwidthAction:
+		if in.Bandwidth != nil {
+			in.Bandwidth.Rate = value
+		}
+	case ResetAction, RejectAction:
+		if in.Reject == nil {
+			in.Reject = &RejectSpec{}
+		}
+		in.Reject.Probability = value
+	}
+}
+
 // NetworkChaosStatus defines the observed state of NetworkChaos
 type NetworkChaosStatus struct {
 	ChaosStatus `json:",inline"`
+
+	// IsolatedZone is the zone isolated in the outage of zone. The random zone
+	// is kept once chosen, so that the same zone is isolated in every run.
+	// +optional
+	IsolatedZone string `json:"isolatedZone,omitempty"`
 }
 
 // DelaySpec defines detail of a delay action
@@ -190,6 +403,55 @@ type DelaySpec struct {
 	Correlation string       `json:"correlation,omitempty"`
 	Jitter      string       `json:"jitter,omitempty"`
 	Reorder     *ReorderSpec `json:"reorder,omitempty"`
+
+	// Distribution represents the distribution of jitter, which only works with jitter.
+	// The jitter is distributed uniformly if it's empty.
+	// Supported distribution: normal, pareto, paretonormal
+	// +optional
+	// +kubebuilder:validation:Enum=normal;pareto;paretonormal;""
+	Distribution string `json:"distribution,omitempty"`
+
+	// Rate limits the rate of the link, the packets are delayed to fit the rate
+	// +optional
+	Rate *NetemRateSpec `json:"rate,omitempty"`
+
+	// Slot delivers the packets in slots to emulate bursty links, e.g. Wi-Fi or cellular
+	// +optional
+	Slot *SlotSpec `json:"slot,omitempty"`
+}
+
+// NetemRateSpec defines detail of the rate of netem
+type NetemRateSpec struct {
+	// Rate is the speed of the link. Allows bps, kbps, mbps, gbps, tbps unit. bps means bytes per second.
+	Rate string `json:"rate"`
+	// PacketOverhead is the overhead of each packet in bytes, which can be negative
+	// +optional
+	PacketOverhead int32 `json:"packetOverhead,omitempty"`
+	// CellSize is the size of link layer cells in bytes, e.g. 53 for ATM
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CellSize uint32 `json:"cellSize,omitempty"`
+	// CellOverhead is the overhead of each cell in bytes, which can be negative
+	// +optional
+	CellOverhead int32 `json:"cellOverhead,omitempty"`
+}
+
+// SlotSpec defines detail of the slot of netem
+type SlotSpec struct {
+	// MinDelay is the minimum delay between slots, e.g. "800us"
+	MinDelay string `json:"minDelay"`
+	// MaxDelay is the maximum delay between slots, the delays are distributed uniformly
+	// between MinDelay and MaxDelay. MinDelay is used if it's empty.
+	// +optional
+	MaxDelay string `json:"maxDelay,omitempty"`
+	// MaxPackets is the maximum number of packets delivered in a slot
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxPackets uint32 `json:"maxPackets,omitempty"`
+	// MaxBytes is the maximum bytes delivered in a slot
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxBytes uint32 `json:"maxBytes,omitempty"`
 }
 
 // ToNetem implements Netem interface.
@@ -230,9 +492,53 @@ func (in *DelaySpec) ToNetem() (*chaosdaemonpb.Netem, error) {
 		netem.Gap = uint32(in.Reorder.Gap)
 	}
 
+	netem.Distribution = in.Distribution
+
+	if in.Rate != nil {
+		rate, err := convertUnitToBytes(in.Rate.Rate)
+		if err != nil {
+			return nil, err
+		}
+
+		netem.Rate = rate
+		netem.PacketOverhead = in.Rate.PacketOverhead
+		netem.CellSize = in.Rate.CellSize
+		netem.CellOverhead = in.Rate.CellOverhead
+	}
+
+	if in.Slot != nil {
+		minDelay, maxDelay, err := in.Slot.parseDelays()
+		if err != nil {
+			return nil, err
+		}
+
+		netem.SlotMin = uint32(minDelay.Nanoseconds() / 1e3)
+		netem.SlotMax = uint32(maxDelay.Nanoseconds() / 1e3)
+		netem.SlotPackets = in.Slot.MaxPackets
+		netem.SlotBytes = in.Slot.MaxBytes
+	}
+
 	return netem, nil
 }
 
+// parseDelays parses the minimum and maximum delay of slots
+func (in *SlotSpec) parseDelays() (time.Duration, time.Duration, error) {
+	minDelay, err := time.ParseDuration(in.MinDelay)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	maxDelay := minDelay
+	if in.MaxDelay != "" {
+		maxDelay, err = time.ParseDuration(in.MaxDelay)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return minDelay, maxDelay, nil
+}
+
 // LossSpec defines detail of a loss action
 type LossSpec struct {
 	Loss        string `json:"loss"`
@@ -305,6 +611,32 @@ func (in *CorruptSpec) ToNetem() (*chaosdaemonpb.Netem, error) {
 	}, nil
 }
 
+// RejectType represents the ICMP error replied to the rejected packets
+type RejectType string
+
+const (
+	// PortUnreachable replies the ICMP port unreachable error
+	PortUnreachable RejectType = "port-unreachable"
+
+	// HostUnreachable replies the ICMP host unreachable error
+	HostUnreachable RejectType = "host-unreachable"
+)
+
+// RejectSpec defines detail of reset and reject action
+type RejectSpec struct {
+	// Probability represents the percentage of the packets to be rejected, e.g. "25" or "12.5",
+	// all the packets are rejected if it's empty.
+	// +optional
+	Probability string `json:"probability,omitempty"`
+
+	// RejectWith represents the ICMP error replied by reject action.
+	// Supported value: port-unreachable / host-unreachable
+	// Default value: port-unreachable
+	// +optional
+	// +kubebuilder:validation:Enum=port-unreachable;host-unreachable;""
+	RejectWith RejectType `json:"rejectWith,omitempty"`
+}
+
 // BandwidthSpec defines detail of bandwidth limit.
 type BandwidthSpec struct {
 	// Rate is the speed knob. Allows bps, kbps, mbps, gbps, tbps unit. bps means bytes per second.
@@ -99,4 +99,46 @@ var _ = Describe("NetworkChaos", func() {
 			Expect(n).To(Equal(uint64(0)))
 		})
 	})
+
+	Context("DelaySpec", func() {
+		It("should convert distribution, rate and slot to netem", func() {
+			delay := &DelaySpec{
+				Latency:      "10ms",
+				Jitter:       "2ms",
+				Correlation:  "25",
+				Distribution: "normal",
+				Rate: &NetemRateSpec{
+					Rate:           "1kbps",
+					PacketOverhead: -4,
+				},
+				Slot: &SlotSpec{
+					MinDelay:   "800us",
+					MaxDelay:   "1ms",
+					MaxPackets: 32,
+				},
+			}
+			netem, err := delay.ToNetem()
+			Expect(err).Should(Succeed())
+			Expect(netem.Time).To(Equal(uint32(10000)))
+			Expect(netem.Distribution).To(Equal("normal"))
+			Expect(netem.Rate).To(Equal(uint64(1024)))
+			Expect(netem.PacketOverhead).To(Equal(int32(-4)))
+			Expect(netem.SlotMin).To(Equal(uint32(800)))
+			Expect(netem.SlotMax).To(Equal(uint32(1000)))
+			Expect(netem.SlotPackets).To(Equal(uint32(32)))
+		})
+
+		It("should use the minimum delay of slot as the maximum one", func() {
+			delay := &DelaySpec{
+				Latency:     "10ms",
+				Jitter:      "0ms",
+				Correlation: "0",
+				Slot:        &SlotSpec{MinDelay: "1ms"},
+			}
+			netem, err := delay.ToNetem()
+			Expect(err).Should(Succeed())
+			Expect(netem.SlotMin).To(Equal(uint32(1000)))
+			Expect(netem.SlotMax).To(Equal(uint32(1000)))
+		})
+	})
 })
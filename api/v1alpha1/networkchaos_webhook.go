@@ -15,7 +15,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -24,12 +26,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// deviceNameRegexp matches the valid name of network device, which is no longer than 15 characters
+var deviceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]{1,15}$`)
+
 const (
 	// DefaultJitter defines default value for jitter
 	DefaultJitter = "0ms"
 
 	// DefaultCorrelation defines default value for correlation
 	DefaultCorrelation = "0"
+
+	// DefaultTopologyKey defines default label of nodes to group the pods into zones
+	DefaultTopologyKey = "topology.kubernetes.io/zone"
 )
 
 // log is for logging in this package.
@@ -49,6 +57,14 @@ func (in *NetworkChaos) Default() {
 		in.Spec.Target.TargetSelector.DefaultNamespace(in.GetNamespace())
 	}
 
+	for i := range in.Spec.Groups {
+		in.Spec.Groups[i].TargetSelector.DefaultNamespace(in.GetNamespace())
+	}
+
+	if in.Spec.Topology != nil && in.Spec.Topology.TopologyKey == "" {
+		in.Spec.Topology.TopologyKey = DefaultTopologyKey
+	}
+
 	// set default direction
 	if in.Spec.Direction == "" {
 		in.Spec.Direction = To
@@ -98,7 +114,9 @@ func (in *NetworkChaos) Validate() error {
 	specField := field.NewPath("spec")
 	allErrs := in.ValidateScheduler(specField)
 	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
-	allErrs = append(allErrs, in.ValidateExternalTargets(specField)...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
+	allErrs = append(allErrs, in.Spec.TrafficFilter.validateTrafficFilter(specField)...)
+	allErrs = append(allErrs, validateDevice(in.Spec.Device, specField.Child("device"))...)
 
 	if in.Spec.Delay != nil {
 		allErrs = append(allErrs, in.Spec.Delay.validateDelay(specField.Child("delay"))...)
@@ -120,6 +138,17 @@ func (in *NetworkChaos) Validate() error {
 		allErrs = append(allErrs, in.Spec.Target.validateTarget(specField.Child("target"))...)
 	}
 
+	allErrs = append(allErrs, in.Spec.validateReject(specField)...)
+	allErrs = append(allErrs, in.Spec.validateGroups(specField)...)
+
+	if in.Spec.Topology != nil {
+		allErrs = append(allErrs, in.Spec.validateTopology(specField)...)
+	}
+
+	if in.Spec.Profile != nil {
+		allErrs = append(allErrs, in.Spec.validateProfile(specField.Child("profile"))...)
+	}
+
 	if len(allErrs) > 0 {
 		return fmt.Errorf(allErrs.ToAggregate().Error())
 	}
@@ -136,21 +165,85 @@ func (in *NetworkChaos) ValidatePodMode(spec *field.Path) field.ErrorList {
 	return ValidatePodMode(in.Spec.Value, in.Spec.Mode, spec.Child("value"))
 }
 
-// ValidateExternalTargets validates externalTargets must be with `to` direction
-func (in *NetworkChaos) ValidateExternalTargets(target *field.Path) field.ErrorList {
+// validateDevice validates the name of network device
+func validateDevice(device string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if device != "" && device != AllNetworkDevices && !deviceNameRegexp.MatchString(device) {
+		allErrs = append(allErrs,
+			field.Invalid(path, device, "invalid network device name"))
+	}
+	return allErrs
+}
+
+// validateTrafficFilter validates the protocol and ports
+func (in *TrafficFilter) validateTrafficFilter(spec *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if in.Spec.ExternalTargets != nil && in.Spec.Direction == From && in.Spec.Action != PartitionAction {
+	switch in.Protocol {
+	case "", "tcp", "udp", "icmp":
+	default:
 		allErrs = append(allErrs,
-			field.Invalid(target.Child("direction"), in.Spec.Direction,
-				fmt.Sprintf("external targets cannot be used with `from` direction in netem action yet")))
+			field.Invalid(spec.Child("protocol"), in.Protocol, "protocol should be one of tcp, udp and icmp"))
 	}
 
-	// TODO: validate externalTargets are in ip or domain form
+	for _, port := range []struct {
+		name  string
+		ports string
+	}{
+		{"sourcePort", in.SourcePort},
+		{"destinationPort", in.DestinationPort},
+	} {
+		name, ports := port.name, port.ports
+		if ports == "" {
+			continue
+		}
+
+		if in.Protocol != "tcp" && in.Protocol != "udp" {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child(name), ports, "ports can only be used with tcp or udp protocol"))
+		}
+
+		if err := validatePorts(ports); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child(name), ports, fmt.Sprintf("parse ports error:%s", err)))
+		}
+	}
 
 	return allErrs
 }
 
+// validatePorts validates the ports in the form of "80,443,8000-9000",
+// at most 15 ports can be specified and a port range counts as two ports
+func validatePorts(ports string) error {
+	count := 0
+	for _, part := range strings.Split(ports, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid port range %s", part)
+		}
+
+		values := make([]uint64, 0, len(bounds))
+		for _, bound := range bounds {
+			value, err := strconv.ParseUint(bound, 10, 16)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+
+		if len(values) == 2 && values[0] > values[1] {
+			return fmt.Errorf("invalid port range %s", part)
+		}
+		count += len(values)
+	}
+
+	if count > 15 {
+		return fmt.Errorf("too many ports")
+	}
+
+	return nil
+}
+
 // validateDelay validates the delay
 func (in *DelaySpec) validateDelay(delay *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -177,6 +270,56 @@ func (in *DelaySpec) validateDelay(delay *field.Path) field.ErrorList {
 	if in.Reorder != nil {
 		allErrs = append(allErrs, in.Reorder.validateReorder(delay.Child("reorder"))...)
 	}
+
+	switch in.Distribution {
+	case "":
+	case "normal", "pareto", "paretonormal":
+		if jitter, err := time.ParseDuration(in.Jitter); err != nil || jitter == 0 {
+			allErrs = append(allErrs,
+				field.Invalid(delay.Child("distribution"), in.Distribution, "distribution only works with jitter"))
+		}
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(delay.Child("distribution"), in.Distribution,
+				"distribution should be one of normal, pareto and paretonormal"))
+	}
+
+	if in.Rate != nil {
+		rate, err := convertUnitToBytes(in.Rate.Rate)
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(delay.Child("rate", "rate"), in.Rate.Rate,
+					fmt.Sprintf("parse rate field error:%s", err)))
+		} else if rate == 0 {
+			allErrs = append(allErrs,
+				field.Invalid(delay.Child("rate", "rate"), in.Rate.Rate, "rate should be greater than 0"))
+		}
+	}
+
+	if in.Slot != nil {
+		allErrs = append(allErrs, in.Slot.validateSlot(delay.Child("slot"))...)
+	}
+	return allErrs
+}
+
+// validateSlot validates the slot
+func (in *SlotSpec) validateSlot(slot *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	minDelay, maxDelay, err := in.parseDelays()
+	if err != nil {
+		allErrs = append(allErrs,
+			field.Invalid(slot, in, fmt.Sprintf("parse delay field error:%s", err)))
+		return allErrs
+	}
+
+	if minDelay <= 0 {
+		allErrs = append(allErrs,
+			field.Invalid(slot.Child("minDelay"), in.MinDelay, "minDelay should be greater than 0"))
+	}
+	if maxDelay < minDelay {
+		allErrs = append(allErrs,
+			field.Invalid(slot.Child("maxDelay"), in.MaxDelay, "maxDelay should not be less than minDelay"))
+	}
 	return allErrs
 }
 
@@ -271,6 +414,136 @@ func (in *BandwidthSpec) validateBandwidth(bandwidth *field.Path) field.ErrorLis
 	return allErrs
 }
 
+// validateReject validates the reset and reject action
+func (in *NetworkChaosSpec) validateReject(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.Action == ResetAction && in.Protocol != "" && in.Protocol != "tcp" {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("protocol"), in.Protocol, "reset action only works on tcp protocol"))
+	}
+
+	if in.Reject == nil {
+		return allErrs
+	}
+	reject := spec.Child("reject")
+
+	if in.Action != ResetAction && in.Action != RejectAction {
+		allErrs = append(allErrs,
+			field.Invalid(reject, in.Reject, "reject can only be used with reset or reject action"))
+	}
+
+	if in.Reject.Probability != "" {
+		probability, err := strconv.ParseFloat(in.Reject.Probability, 32)
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(reject.Child("probability"), in.Reject.Probability,
+					fmt.Sprintf("parse probability field error:%s", err)))
+		} else if probability <= 0 || probability > 100 {
+			allErrs = append(allErrs,
+				field.Invalid(reject.Child("probability"), in.Reject.Probability,
+					"probability should be greater than 0 and no more than 100"))
+		}
+	}
+
+	switch in.Reject.RejectWith {
+	case "":
+	case PortUnreachable, HostUnreachable:
+		if in.Action == ResetAction {
+			allErrs = append(allErrs,
+				field.Invalid(reject.Child("rejectWith"), in.Reject.RejectWith, "reset action always replies with tcp reset"))
+		}
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(reject.Child("rejectWith"), in.Reject.RejectWith,
+				"rejectWith should be one of port-unreachable and host-unreachable"))
+	}
+
+	return allErrs
+}
+
+// validateProfile validates the profile and the spec in each stage of it
+func (in *NetworkChaosSpec) validateProfile(profile *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if _, _, err := in.Profile.GetIntervals(); err != nil {
+		allErrs = append(allErrs,
+			field.Invalid(profile, in.Profile, fmt.Sprintf("parse interval error:%s", err)))
+	}
+
+	var values []string
+	switch in.Profile.Type {
+	case FlapProfileType:
+		return allErrs
+	case RampProfileType:
+		if in.Profile.Steps <= 0 {
+			allErrs = append(allErrs,
+				field.Invalid(profile.Child("steps"), in.Profile.Steps, "steps should be greater than 0"))
+		}
+		if _, err := interpolate(in.Profile.From, in.Profile.To, 0); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(profile, in.Profile, err.Error()))
+		}
+		values = []string{in.Profile.From, in.Profile.To}
+	case StepProfileType:
+		if len(in.Profile.Values) == 0 {
+			allErrs = append(allErrs,
+				field.Invalid(profile.Child("values"), in.Profile.Values, "values should not be empty"))
+		}
+		values = in.Profile.Values
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(profile.Child("type"), in.Profile.Type, "type should be one of ramp, step and flap"))
+		return allErrs
+	}
+
+	// the values take place of the main parameter of the action, which must be present
+	spec := field.NewPath("spec")
+	for _, value := range values {
+		stage := in.DeepCopy()
+		stage.setProfileValue(value)
+
+		switch in.Action {
+		case DelayAction:
+			if stage.Delay == nil {
+				return append(allErrs, field.Required(spec.Child("delay"), "delay is required by profile"))
+			}
+			allErrs = append(allErrs, stage.Delay.validateDelay(profile)...)
+		case LossAction:
+			if stage.Loss == nil {
+				return append(allErrs, field.Required(spec.Child("loss"), "loss is required by profile"))
+			}
+			allErrs = append(allErrs, stage.Loss.validateLoss(profile)...)
+		case DuplicateAction:
+			if stage.Duplicate == nil {
+				return append(allErrs, field.Required(spec.Child("duplicate"), "duplicate is required by profile"))
+			}
+			allErrs = append(allErrs, stage.Duplicate.validateDuplicate(profile)...)
+		case CorruptAction:
+			if stage.Corrupt == nil {
+				return append(allErrs, field.Required(spec.Child("corrupt"), "corrupt is required by profile"))
+			}
+			allErrs = append(allErrs, stage.Corrupt.validateCorrupt(profile)...)
+		case BandwidthAction:
+			if stage.Bandwidth == nil {
+				return append(allErrs, field.Required(spec.Child("bandwidth"), "bandwidth is required by profile"))
+			}
+			allErrs = append(allErrs, stage.Bandwidth.validateBandwidth(profile)...)
+		case ResetAction, RejectAction:
+			if probability, err := strconv.ParseFloat(value, 32); err != nil || probability < 0 || probability > 100 {
+				allErrs = append(allErrs,
+					field.Invalid(profile, value, "probability should be between 0 and 100"))
+			}
+		default:
+			return append(allErrs,
+				field.Invalid(profile.Child("type"), in.Profile.Type,
+					fmt.Sprintf("%s profile can't be used with %s action", in.Profile.Type, in.Action)))
+		}
+	}
+
+	return allErrs
+}
+
 // validateTarget validates the target
 func (in *Target) validateTarget(target *field.Path) field.ErrorList {
 	modes := []PodMode{OnePodMode, AllPodMode, FixedPodMode, FixedPercentPodMode, RandomMaxPercentPodMode}
@@ -284,3 +557,104 @@ func (in *Target) validateTarget(target *field.Path) field.ErrorList {
 	return field.ErrorList{field.Invalid(target.Child("mode"), in.TargetMode,
 		fmt.Sprintf("mode %s not supported", in.TargetMode))}
 }
+
+// validateGroups validates the groups and links of multi-group partition
+func (in *NetworkChaosSpec) validateGroups(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	groupsField := spec.Child("groups")
+
+	if len(in.Groups) == 0 {
+		if len(in.Links) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(spec.Child("links"), in.Links, "links can only be used with groups"))
+		}
+		return allErrs
+	}
+
+	switch in.Action {
+	case PartitionAction, ResetAction, RejectAction:
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(groupsField, in.Groups, "groups can only be used with partition, reset and reject action"))
+	}
+
+	if len(in.Groups) < 2 {
+		allErrs = append(allErrs,
+			field.Invalid(groupsField, in.Groups, "at least two groups are required"))
+	}
+	if in.Target != nil {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("target"), in.Target, "target can't be used with groups"))
+	}
+	if len(in.ExternalTargets) > 0 {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("externalTargets"), in.ExternalTargets, "externalTargets can't be used with groups"))
+	}
+	if in.Continuous {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("continuous"), in.Continuous, "continuous can't be used with groups"))
+	}
+
+	names := make(map[string]bool, len(in.Groups))
+	for i := range in.Groups {
+		group := &in.Groups[i]
+		groupField := groupsField.Index(i)
+		if group.Name == "" {
+			allErrs = append(allErrs,
+				field.Required(groupField.Child("name"), "the name of group is required"))
+		} else if names[group.Name] {
+			allErrs = append(allErrs,
+				field.Duplicate(groupField.Child("name"), group.Name))
+		}
+		names[group.Name] = true
+
+		allErrs = append(allErrs, group.Target.validateTarget(groupField)...)
+	}
+
+	for i, link := range in.Links {
+		linkField := spec.Child("links").Index(i)
+		if !names[link.From] {
+			allErrs = append(allErrs,
+				field.NotFound(linkField.Child("from"), link.From))
+		}
+		if !names[link.To] {
+			allErrs = append(allErrs,
+				field.NotFound(linkField.Child("to"), link.To))
+		}
+		if link.From == link.To {
+			allErrs = append(allErrs,
+				field.Invalid(linkField, link, "the link should be between two different groups"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateTopology validates the outage of zone
+func (in *NetworkChaosSpec) validateTopology(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	topologyField := spec.Child("topology")
+
+	switch in.Action {
+	case PartitionAction, ResetAction, RejectAction:
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(topologyField, in.Topology, "topology can only be used with partition, reset and reject action"))
+	}
+
+	if in.Target != nil {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("target"), in.Target, "target can't be used with topology"))
+	}
+	if len(in.Groups) > 0 {
+		allErrs = append(allErrs,
+			field.Invalid(spec.Child("groups"), in.Groups, "groups can't be used with topology"))
+	}
+	if in.Topology.IsolateExternalTargets && len(in.ExternalTargets) == 0 {
+		allErrs = append(allErrs,
+			field.Invalid(topologyField.Child("isolateExternalTargets"), in.Topology.IsolateExternalTargets,
+				"externalTargets are required to isolate the zone from them"))
+	}
+
+	return allErrs
+}
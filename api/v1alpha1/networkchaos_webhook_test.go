@@ -277,7 +277,7 @@ var _ = Describe("networkchaos_webhook", func() {
 					expect: "error",
 				},
 				{
-					name: "validate direction and externalTargets",
+					name: "validate from direction with externalTargets",
 					chaos: NetworkChaos{
 						ObjectMeta: metav1.ObjectMeta{
 							Namespace: metav1.NamespaceDefault,
@@ -291,6 +291,641 @@ var _ = Describe("networkchaos_webhook", func() {
 					execute: func(chaos *NetworkChaos) error {
 						return chaos.ValidateCreate()
 					},
+					expect: "",
+				},
+				{
+					name: "validate protocol and ports",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo13",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{Protocol: "tcp", SourcePort: "80", DestinationPort: "443,8000-9000"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate ports without protocol",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo14",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{DestinationPort: "53"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate ports with icmp",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo15",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{Protocol: "icmp", DestinationPort: "53"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate invalid port range",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo16",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{Protocol: "udp", SourcePort: "9000-8000"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate invalid port",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo17",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{Protocol: "udp", DestinationPort: "65536"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate device",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo19",
+						},
+						Spec: NetworkChaosSpec{
+							Device: "net1",
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate all devices",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo20",
+						},
+						Spec: NetworkChaosSpec{
+							Device: "*",
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate invalid device",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo21",
+						},
+						Spec: NetworkChaosSpec{
+							Device: "a-very-long-device-name",
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate unknown protocol",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo18",
+						},
+						Spec: NetworkChaosSpec{
+							TrafficFilter: TrafficFilter{Protocol: "sctp"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate reset action",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo22",
+						},
+						Spec: NetworkChaosSpec{
+							Action: ResetAction,
+							Reject: &RejectSpec{Probability: "12.5"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate reset action with udp",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo23",
+						},
+						Spec: NetworkChaosSpec{
+							Action:        ResetAction,
+							TrafficFilter: TrafficFilter{Protocol: "udp"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate reset action with rejectWith",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo24",
+						},
+						Spec: NetworkChaosSpec{
+							Action: ResetAction,
+							Reject: &RejectSpec{RejectWith: HostUnreachable},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate reject action",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo25",
+						},
+						Spec: NetworkChaosSpec{
+							Action: RejectAction,
+							Reject: &RejectSpec{Probability: "100", RejectWith: HostUnreachable},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate invalid probability",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo26",
+						},
+						Spec: NetworkChaosSpec{
+							Action: RejectAction,
+							Reject: &RejectSpec{Probability: "0"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate reject with other action",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo27",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Reject: &RejectSpec{Probability: "50"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate delay with distribution, rate and slot",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo28",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:      "10ms",
+									Jitter:       "2ms",
+									Correlation:  "25",
+									Distribution: "pareto",
+									Rate:         &NetemRateSpec{Rate: "1mbps", PacketOverhead: -4},
+									Slot:         &SlotSpec{MinDelay: "800us", MaxDelay: "1ms"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate distribution without jitter",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo29",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:      "10ms",
+									Jitter:       "0ms",
+									Correlation:  "25",
+									Distribution: "normal",
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate distribution with empty jitter",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo45",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:      "10ms",
+									Correlation:  "25",
+									Distribution: "normal",
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate uniform distribution",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo46",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:      "10ms",
+									Jitter:       "5ms",
+									Correlation:  "25",
+									Distribution: "uniform",
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate invalid rate",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo30",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:     "10ms",
+									Jitter:      "0ms",
+									Correlation: "25",
+									Rate:        &NetemRateSpec{Rate: "0bps"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate invalid slot",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo31",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Latency:     "10ms",
+									Jitter:      "0ms",
+									Correlation: "25",
+									Slot:        &SlotSpec{MinDelay: "1ms", MaxDelay: "800us"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate ramp profile",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo32",
+						},
+						Spec: NetworkChaosSpec{
+							Action: LossAction,
+							TcParameter: TcParameter{
+								Loss: &LossSpec{Loss: "0", Correlation: "0"},
+							},
+							Profile: &ProfileSpec{Type: RampProfileType, Interval: "1m", From: "0", To: "30", Steps: 3},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate ramp profile with partition",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo33",
+						},
+						Spec: NetworkChaosSpec{
+							Action:  PartitionAction,
+							Profile: &ProfileSpec{Type: RampProfileType, Interval: "1m", From: "0", To: "30", Steps: 3},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate flap profile with partition",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo34",
+						},
+						Spec: NetworkChaosSpec{
+							Action:  PartitionAction,
+							Profile: &ProfileSpec{Type: FlapProfileType, Interval: "30s", OffInterval: "10s"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate step profile with invalid value",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo35",
+						},
+						Spec: NetworkChaosSpec{
+							Action: DelayAction,
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{Latency: "10ms", Jitter: "0ms", Correlation: "0"},
+							},
+							Profile: &ProfileSpec{Type: StepProfileType, Interval: "1m", Values: []string{"50ms", "1S"}},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate profile with invalid interval",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo36",
+						},
+						Spec: NetworkChaosSpec{
+							Action:  PartitionAction,
+							Profile: &ProfileSpec{Type: FlapProfileType, Interval: "1"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate partition groups",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo37",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Groups: []PartitionGroup{
+								{Name: "a", Target: Target{TargetMode: OnePodMode}},
+								{Name: "b", Target: Target{TargetMode: AllPodMode}},
+							},
+							Links: []PartitionLink{{From: "a", To: "b"}},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate partition groups with unknown link",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo38",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Groups: []PartitionGroup{
+								{Name: "a", Target: Target{TargetMode: OnePodMode}},
+								{Name: "b", Target: Target{TargetMode: AllPodMode}},
+							},
+							Links: []PartitionLink{{From: "a", To: "c"}},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate partition groups with duplicated name",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo39",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Groups: []PartitionGroup{
+								{Name: "a", Target: Target{TargetMode: OnePodMode}},
+								{Name: "a", Target: Target{TargetMode: AllPodMode}},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate groups with delay action",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo40",
+						},
+						Spec: NetworkChaosSpec{
+							Action: DelayAction,
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{Latency: "10ms", Jitter: "0ms", Correlation: "0"},
+							},
+							Groups: []PartitionGroup{
+								{Name: "a", Target: Target{TargetMode: OnePodMode}},
+								{Name: "b", Target: Target{TargetMode: AllPodMode}},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate zone outage",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo41",
+						},
+						Spec: NetworkChaosSpec{
+							Action:          PartitionAction,
+							ExternalTargets: []string{"www.google.com"},
+							Topology:        &TopologySpec{Zone: "az1", IsolateExternalTargets: true},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate zone outage without external targets",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo42",
+						},
+						Spec: NetworkChaosSpec{
+							Action:   PartitionAction,
+							Topology: &TopologySpec{IsolateExternalTargets: true},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate zone outage with target",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo43",
+						},
+						Spec: NetworkChaosSpec{
+							Action:   PartitionAction,
+							Target:   &Target{TargetMode: AllPodMode},
+							Topology: &TopologySpec{},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate continuous with groups",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo44",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Groups: []PartitionGroup{
+								{Name: "a", Target: Target{TargetMode: OnePodMode}},
+								{Name: "b", Target: Target{TargetMode: AllPodMode}},
+							},
+							Continuous: true,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
 					expect: "error",
 				},
 			}
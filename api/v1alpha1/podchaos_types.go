@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // +kubebuilder:object:root=true
@@ -55,6 +56,11 @@ type PodChaosSpec struct {
 	// control the running time of the chaos experiment about pods.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
 
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
 	// Action defines the specific pod chaos action.
 	// Supported action: pod-kill / pod-failure / container-kill
 	// Default action: pod-kill
@@ -123,4 +129,30 @@ type PodStatus struct {
 	// e.g. "delete this pod" or "pause this pod duration 5m"
 	// +optional
 	Message string `json:"message"`
+
+	// UID is the uid of pod when the chaos is injected, it's recorded by the continuous chaos
+	// to find the recreated pods
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
+	// ContainerIDs are the ids of containers when the chaos is injected, they're recorded by the
+	// continuous chaos to find the restarted containers
+	// +optional
+	ContainerIDs []string `json:"containerIDs,omitempty"`
+
+	// Phase is the phase of chaos on the pod.
+	// +optional
+	Phase PodInjectionPhase `json:"phase,omitempty"`
+
+	// InjectedTime is the last time when the chaos is injected into the pod
+	// +optional
+	InjectedTime *metav1.Time `json:"injectedTime,omitempty"`
+
+	// RecoveredTime is the time when the pod is recovered from the chaos
+	// +optional
+	RecoveredTime *metav1.Time `json:"recoveredTime,omitempty"`
+
+	// LastError is the error of the last injection or recovery on the pod
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
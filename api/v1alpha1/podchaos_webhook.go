@@ -65,6 +65,7 @@ func (in *PodChaos) Validate() error {
 	specField := field.NewPath("spec")
 	allErrs := in.ValidateScheduler(specField)
 	allErrs = append(allErrs, in.ValidatePodMode(specField)...)
+	allErrs = append(allErrs, ValidateProbes(in.Spec.Probes, specField.Child("probes"))...)
 	allErrs = append(allErrs, in.Spec.validateContainerName(specField.Child("containerName"))...)
 
 	if len(allErrs) > 0 {
@@ -89,6 +90,7 @@ func (in *PodChaos) ValidateScheduler(spec *field.Path) field.ErrorList {
 		} else {
 			_, err := ParseCron(in.Spec.Scheduler.Cron, schedulerField.Child("cron"))
 			allErrs = append(allErrs, err...)
+			allErrs = append(allErrs, ValidateSchedulerSpec(in.Spec.Scheduler, schedulerField)...)
 		}
 		break
 	case ContainerKillAction:
@@ -98,6 +100,7 @@ func (in *PodChaos) ValidateScheduler(spec *field.Path) field.ErrorList {
 		} else {
 			_, err := ParseCron(in.Spec.Scheduler.Cron, schedulerField.Child("cron"))
 			allErrs = append(allErrs, err...)
+			allErrs = append(allErrs, ValidateSchedulerSpec(in.Spec.Scheduler, schedulerField)...)
 		}
 		break
 	default:
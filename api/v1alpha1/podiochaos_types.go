@@ -54,6 +54,10 @@ type IoChaosAction struct {
 	// +optional
 	*AttrOverrideSpec `json:",inline"`
 
+	// Mistake represents the mistake to inject into the read/write buffers
+	// +optional
+	*MistakeSpec `json:"mistake,omitempty"`
+
 	// Source represents the source of current rules
 	Source string `json:"source,omitempty"`
 }
@@ -70,6 +74,13 @@ const (
 
 	// IoAttrOverride represents replacing attribution for io operation
 	IoAttrOverride IoChaosType = "attrOverride"
+
+	// IoMistake represents injecting incorrect read or write for io operation
+	IoMistake IoChaosType = "mistake"
+
+	// IoThrottle represents limiting the bandwidth and IOPS of a block device, it's
+	// applied on the cgroup of container rather than the io operations
+	IoThrottle IoChaosType = "throttle"
 )
 
 // Filter represents a filter of IoChaos action, which will define the
@@ -120,6 +131,60 @@ type AttrOverrideSpec struct {
 	Rdev *uint32 `json:"rdev,omitempty"`
 }
 
+// MistakeSpec represents the wrong data which replaces the data in the read/write buffers
+type MistakeSpec struct {
+	// Filling determines how the wrong data is generated.
+	// Supported filling: zero / random / flip
+	// +kubebuilder:validation:Enum=zero;random;flip
+	Filling FillingType `json:"filling"`
+
+	// MaxOccurrences is the max number of the wrong data segments in one buffer,
+	// there will be [1, MaxOccurrences] segments.
+	// +kubebuilder:validation:Minimum=1
+	MaxOccurrences int64 `json:"maxOccurrences"`
+
+	// MaxLength is the max length of each wrong data segment in bytes.
+	// +kubebuilder:validation:Minimum=1
+	MaxLength int64 `json:"maxLength"`
+}
+
+// FillingType represents how the wrong data of a mistake is generated
+type FillingType string
+
+const (
+	// ZeroFilling fills the segments with zero
+	ZeroFilling FillingType = "zero"
+
+	// RandomFilling fills the segments with random bytes
+	RandomFilling FillingType = "random"
+
+	// FlipFilling flips all the bits of the segments
+	FlipFilling FillingType = "flip"
+)
+
+// IoThrottleSpec represents the limits of the bandwidth and IOPS of a block device
+type IoThrottleSpec struct {
+	// Device is the block device on the node, such as "/dev/sda",
+	// or its major and minor number, such as "8:0".
+	Device string `json:"device"`
+
+	// ReadBps is the limit of read bytes per second
+	// +optional
+	ReadBps uint64 `json:"readBps,omitempty"`
+
+	// WriteBps is the limit of written bytes per second
+	// +optional
+	WriteBps uint64 `json:"writeBps,omitempty"`
+
+	// ReadIops is the limit of read operations per second
+	// +optional
+	ReadIops uint64 `json:"readIops,omitempty"`
+
+	// WriteIops is the limit of write operations per second
+	// +optional
+	WriteIops uint64 `json:"writeIops,omitempty"`
+}
+
 // Timespec represents a time
 type Timespec struct {
 	Sec  int64 `json:"sec"`
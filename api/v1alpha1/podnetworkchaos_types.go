@@ -58,9 +58,15 @@ type RawIPSet struct {
 	// The name of ipset
 	Name string `json:"name"`
 
-	// The contents of ipset
+	// The contents of ipset, which can be mixed with IPv4 and IPv6 cidrs.
+	// The IPv6 cidrs will be stored in a separate ipset with `family inet6`.
 	Cidrs []string `json:"cidrs"`
 
+	// The domains whose addresses are added into ipset, which are resolved by chaos-daemon
+	// in the pod periodically, so that the ipset follows the changes of dns records.
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+
 	// The name and namespace of the source network chaos
 	RawRuleSource `json:",inline"`
 }
@@ -87,9 +93,51 @@ type RawIptables struct {
 	// The block direction of this iptables rule
 	Direction ChainDirection `json:"direction"`
 
+	// The protocol and ports of the traffic to block
+	TrafficFilter `json:",inline"`
+
+	// The network device to block, all devices are blocked if it's empty
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// The target of the rules, the packets are dropped if it's empty
+	// +optional
+	// +kubebuilder:validation:Enum=DROP;REJECT;""
+	Target IptablesTarget `json:"target,omitempty"`
+
+	// The reply to the packets rejected by REJECT target, e.g. tcp-reset or icmp-port-unreachable
+	// +optional
+	RejectWith string `json:"rejectWith,omitempty"`
+
+	// The percentage of the packets which the rules work on, all the packets are matched if it's empty
+	// +optional
+	Probability string `json:"probability,omitempty"`
+
 	RawRuleSource `json:",inline"`
 }
 
+// IptablesTarget represents the target of iptables rules
+type IptablesTarget string
+
+const (
+	// DropTarget drops the packets silently
+	DropTarget IptablesTarget = "DROP"
+
+	// RejectTarget drops the packets and replies with an error
+	RejectTarget IptablesTarget = "REJECT"
+)
+
+const (
+	// TCPResetReply replies the rejected tcp packets with a reset
+	TCPResetReply = "tcp-reset"
+
+	// ICMPPortUnreachableReply replies the rejected packets with ICMP port unreachable error
+	ICMPPortUnreachableReply = "icmp-port-unreachable"
+
+	// ICMPHostUnreachableReply replies the rejected packets with ICMP host unreachable error
+	ICMPHostUnreachableReply = "icmp-host-unreachable"
+)
+
 // TcType the type of traffic control
 type TcType string
 
@@ -112,6 +160,18 @@ type RawTrafficControl struct {
 	// +optional
 	IPSet string `json:"ipset,omitempty"`
 
+	// The protocol and ports of the traffic to control
+	TrafficFilter `json:",inline"`
+
+	// Ingress represents whether the traffic control works on the ingress traffic,
+	// which is redirected to an ifb device in the pod
+	// +optional
+	Ingress bool `json:"ingress,omitempty"`
+
+	// The network device to control, `eth0` is used if it's empty
+	// +optional
+	Device string `json:"device,omitempty"`
+
 	// The name and namespace of the source network chaos
 	Source string `json:"source"`
 }
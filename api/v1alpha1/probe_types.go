@@ -0,0 +1,197 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultProbeInterval is the interval between two probes if it's not set
+	DefaultProbeInterval = 10 * time.Second
+	// DefaultProbeTimeout is the timeout of a probe if it's not set
+	DefaultProbeTimeout = 3 * time.Second
+)
+
+// ProbeType represents the type of a steady-state probe.
+type ProbeType string
+
+const (
+	// HTTPProbeType sends a HTTP GET request and checks the status code.
+	HTTPProbeType ProbeType = "http"
+	// TCPProbeType checks whether a TCP connection can be established.
+	TCPProbeType ProbeType = "tcp"
+	// PrometheusProbeType runs an instant query and compares the result with a threshold.
+	PrometheusProbeType ProbeType = "prometheus"
+	// ExecProbeType runs a command in a container and checks the exit code.
+	ExecProbeType ProbeType = "exec"
+)
+
+// ProbeSpec defines a steady-state probe, which is evaluated periodically while
+// the experiment is running. Once the probe fails for FailureThreshold times in a
+// row, the experiment is recovered and aborted.
+type ProbeSpec struct {
+	// Name is the unique name of the probe in the chaos.
+	Name string `json:"name"`
+
+	// Type defines the type of the probe.
+	// Supported type: http / tcp / prometheus / exec
+	// +kubebuilder:validation:Enum=http;tcp;prometheus;exec
+	Type ProbeType `json:"type"`
+
+	// Interval is the duration between two probes. Default interval: 10s
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// Timeout is the timeout of each probe. Default timeout: 3s
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+
+	// FailureThreshold is the number of consecutive failures before aborting
+	// the experiment. Default threshold: 1
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+
+	// HTTP is required when the type is http.
+	// +optional
+	HTTP *HTTPProbe `json:"http,omitempty"`
+
+	// TCP is required when the type is tcp.
+	// +optional
+	TCP *TCPProbe `json:"tcp,omitempty"`
+
+	// Prometheus is required when the type is prometheus.
+	// +optional
+	Prometheus *PrometheusProbe `json:"prometheus,omitempty"`
+
+	// Exec is required when the type is exec.
+	// +optional
+	Exec *ExecProbe `json:"exec,omitempty"`
+}
+
+// GetInterval returns the interval of the probe
+func (in *ProbeSpec) GetInterval() time.Duration {
+	return parseDurationOrDefault(in.Interval, DefaultProbeInterval)
+}
+
+// GetTimeout returns the timeout of the probe
+func (in *ProbeSpec) GetTimeout() time.Duration {
+	return parseDurationOrDefault(in.Timeout, DefaultProbeTimeout)
+}
+
+// GetFailureThreshold returns the failure threshold of the probe
+func (in *ProbeSpec) GetFailureThreshold() int {
+	if in.FailureThreshold <= 0 {
+		return 1
+	}
+	return in.FailureThreshold
+}
+
+func parseDurationOrDefault(duration *string, defaultDuration time.Duration) time.Duration {
+	if duration == nil {
+		return defaultDuration
+	}
+	d, err := time.ParseDuration(*duration)
+	if err != nil || d <= 0 {
+		return defaultDuration
+	}
+	return d
+}
+
+// HTTPProbe sends a HTTP GET request to the URL
+type HTTPProbe struct {
+	// URL is the address to send the request to.
+	URL string `json:"url"`
+
+	// ExpectedStatus is the expected status code of the response. Default status: 200
+	// +optional
+	ExpectedStatus int `json:"expectedStatus,omitempty"`
+}
+
+// TCPProbe tries to establish a TCP connection to the address
+type TCPProbe struct {
+	// Address is the host:port to connect to.
+	Address string `json:"address"`
+}
+
+// PrometheusProbe runs an instant query against a Prometheus server.
+// The steady state holds when `<result> <operator> <threshold>` is true for every sample.
+type PrometheusProbe struct {
+	// URL is the address of the Prometheus server, e.g. http://prometheus:9090
+	URL string `json:"url"`
+
+	// Query is the PromQL instant query.
+	Query string `json:"query"`
+
+	// Operator is used to compare the result with the threshold.
+	// Supported operator: > / >= / < / <= / == / !=
+	// +kubebuilder:validation:Enum=">";">=";"<";"<=";"==";"!="
+	Operator string `json:"operator"`
+
+	// Threshold is a float number compared with the result of the query.
+	Threshold string `json:"threshold"`
+}
+
+// ExecProbe runs a command in a container, the steady state holds when the command exits with zero.
+// The pod must be in the namespace of the chaos, and the controller manager isn't granted to create
+// pods/exec by default, so it should be granted by a RoleBinding in that namespace.
+type ExecProbe struct {
+	// Pod is the name of the pod in the namespace of the chaos.
+	Pod string `json:"pod"`
+
+	// Container is the name of the container. Default container is the first container of the pod.
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Command is the command to run.
+	Command []string `json:"command"`
+}
+
+// ProbeStatus represents the last result of a probe
+type ProbeStatus struct {
+	// Name is the name of the probe.
+	Name string `json:"name"`
+
+	// LastProbeTime is the last time when the probe ran.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// ConsecutiveFailures is the number of failures in a row.
+	// +optional
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+
+	// Message is the error of the last failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetProbeStatus returns the status of the probe with the given name, or nil if it has never run.
+func (in *ChaosStatus) GetProbeStatus(name string) *ProbeStatus {
+	for i := range in.Probes {
+		if in.Probes[i].Name == name {
+			return &in.Probes[i]
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:object:generate=false
+
+// ProbedObject is the Object which could be checked by steady-state probes
+type ProbedObject interface {
+	GetProbes() []ProbeSpec
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ProfileType represents the type of a time-varying fault profile.
+type ProfileType string
+
+const (
+	// RampProfileType changes the value of the fault linearly from From to To in Steps steps.
+	RampProfileType ProfileType = "ramp"
+	// StepProfileType changes the value of the fault to each of Values in order.
+	StepProfileType ProfileType = "step"
+	// FlapProfileType injects and recovers the fault alternately.
+	FlapProfileType ProfileType = "flap"
+)
+
+// ProfileSpec defines how the fault changes over time while the experiment is running.
+// The value of each stage takes the place of the main parameter of the action, e.g. the
+// latency of delay, the percentage of loss or the rate of bandwidth. The last stage of
+// ramp and step lasts until the experiment is recovered.
+type ProfileSpec struct {
+	// Type defines the type of the profile.
+	// Supported type: ramp / step / flap
+	// +kubebuilder:validation:Enum=ramp;step;flap
+	Type ProfileType `json:"type"`
+
+	// Interval is the duration of each stage, e.g. "1m".
+	// For flap, it's the duration of the fault in each period.
+	Interval string `json:"interval"`
+
+	// OffInterval is the duration without the fault in each period of flap.
+	// Interval is used if it's empty.
+	// +optional
+	OffInterval string `json:"offInterval,omitempty"`
+
+	// From is the value of the first stage of ramp.
+	// +optional
+	From string `json:"from,omitempty"`
+
+	// To is the value of the last stage of ramp.
+	// +optional
+	To string `json:"to,omitempty"`
+
+	// Steps is the number of changes from From to To of ramp.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Steps int `json:"steps,omitempty"`
+
+	// Values are the values of the stages of step.
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// ProfileStatus records the current stage of the profile.
+type ProfileStatus struct {
+	// Stage is the index of the current stage since the experiment started.
+	Stage int `json:"stage"`
+
+	// Active represents whether the fault is injected in the current stage,
+	// which is false in the off period of flap.
+	Active bool `json:"active"`
+
+	// Value is the value of the fault in the current stage, it's empty for flap.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// GetIntervals returns the durations of the stages, the second one is the duration
+// without the fault of flap.
+func (in *ProfileSpec) GetIntervals() (time.Duration, time.Duration, error) {
+	interval, err := time.ParseDuration(in.Interval)
+	if err != nil {
+		return 0, 0, err
+	}
+	if interval <= 0 {
+		return 0, 0, fmt.Errorf("interval should be greater than 0")
+	}
+
+	offInterval := interval
+	if in.OffInterval != "" {
+		offInterval, err = time.ParseDuration(in.OffInterval)
+		if err != nil {
+			return 0, 0, err
+		}
+		if offInterval <= 0 {
+			return 0, 0, fmt.Errorf("offInterval should be greater than 0")
+		}
+	}
+
+	return interval, offInterval, nil
+}
+
+// StageAt returns the stage at the elapsed time since the experiment started, and the
+// duration until the next stage, which is 0 if the stage won't change any more.
+func (in *ProfileSpec) StageAt(elapsed time.Duration) (ProfileStatus, time.Duration, error) {
+	interval, offInterval, err := in.GetIntervals()
+	if err != nil {
+		return ProfileStatus{}, 0, err
+	}
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	switch in.Type {
+	case RampProfileType:
+		if in.Steps <= 0 {
+			return ProfileStatus{}, 0, fmt.Errorf("steps should be greater than 0")
+		}
+
+		stage, next := stageOf(elapsed, interval, in.Steps)
+		value, err := interpolate(in.From, in.To, float64(stage)/float64(in.Steps))
+		if err != nil {
+			return ProfileStatus{}, 0, err
+		}
+		return ProfileStatus{Stage: stage, Active: true, Value: value}, next, nil
+	case StepProfileType:
+		if len(in.Values) == 0 {
+			return ProfileStatus{}, 0, fmt.Errorf("values should not be empty")
+		}
+
+		stage, next := stageOf(elapsed, interval, len(in.Values)-1)
+		return ProfileStatus{Stage: stage, Active: true, Value: in.Values[stage]}, next, nil
+	case FlapProfileType:
+		period := interval + offInterval
+		offset := elapsed % period
+		stage := int(elapsed/period) * 2
+		if offset < interval {
+			return ProfileStatus{Stage: stage, Active: true}, interval - offset, nil
+		}
+		return ProfileStatus{Stage: stage + 1, Active: false}, period - offset, nil
+	}
+
+	return ProfileStatus{}, 0, fmt.Errorf("unknown profile type %s", in.Type)
+}
+
+// stageOf returns the index of stage which stops at the last one, and the duration until the next stage
+func stageOf(elapsed time.Duration, interval time.Duration, last int) (int, time.Duration) {
+	stage := int(elapsed / interval)
+	if stage >= last {
+		return last, 0
+	}
+	return stage, interval - elapsed%interval
+}
+
+// interpolate returns the value between from and to by the ratio, the values can be
+// durations, numbers or rates with unit, e.g. "10ms", "30" or "1mbps"
+func interpolate(from string, to string, ratio float64) (string, error) {
+	if fromDuration, err := time.ParseDuration(from); err == nil {
+		if toDuration, err := time.ParseDuration(to); err == nil {
+			value := time.Duration(float64(fromDuration) + float64(toDuration-fromDuration)*ratio)
+			return value.Round(time.Microsecond).String(), nil
+		}
+	}
+
+	if fromNumber, err := strconv.ParseFloat(from, 64); err == nil {
+		if toNumber, err := strconv.ParseFloat(to, 64); err == nil {
+			value := fromNumber + (toNumber-fromNumber)*ratio
+			return strconv.FormatFloat(value, 'f', -1, 32), nil
+		}
+	}
+
+	if fromRate, err := convertUnitToBytes(from); err == nil {
+		if toRate, err := convertUnitToBytes(to); err == nil {
+			value := float64(fromRate) + (float64(toRate)-float64(fromRate))*ratio
+			return fmt.Sprintf("%dbps", uint64(value)), nil
+		}
+	}
+
+	return "", fmt.Errorf("can't interpolate between %s and %s", from, to)
+}
+
+// +kubebuilder:object:generate=false
+
+// ProfiledObject is the Object whose fault changes over time
+type ProfiledObject interface {
+	GetProfile() *ProfileSpec
+}
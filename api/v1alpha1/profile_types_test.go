@@ -0,0 +1,96 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+	Context("StageAt", func() {
+		It("should ramp durations, numbers and rates", func() {
+			profile := &ProfileSpec{Type: RampProfileType, Interval: "1m", From: "10ms", To: "100ms", Steps: 3}
+			stage, next, err := profile.StageAt(90 * time.Second)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 1, Active: true, Value: "40ms"}))
+			Expect(next).To(Equal(30 * time.Second))
+
+			profile = &ProfileSpec{Type: RampProfileType, Interval: "1m", From: "0", To: "30", Steps: 4}
+			stage, _, err = profile.StageAt(2 * time.Minute)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage.Value).To(Equal("15"))
+
+			profile = &ProfileSpec{Type: RampProfileType, Interval: "1m", From: "1kbps", To: "3kbps", Steps: 2}
+			stage, next, err = profile.StageAt(time.Hour)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 2, Active: true, Value: "3072bps"}))
+			Expect(next).To(BeZero())
+		})
+
+		It("should step through the values", func() {
+			profile := &ProfileSpec{Type: StepProfileType, Interval: "1m", Values: []string{"10ms", "50ms"}}
+			stage, next, err := profile.StageAt(0)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 0, Active: true, Value: "10ms"}))
+			Expect(next).To(Equal(time.Minute))
+
+			stage, next, err = profile.StageAt(5 * time.Minute)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 1, Active: true, Value: "50ms"}))
+			Expect(next).To(BeZero())
+		})
+
+		It("should flap the fault", func() {
+			profile := &ProfileSpec{Type: FlapProfileType, Interval: "20s", OffInterval: "10s"}
+			stage, next, err := profile.StageAt(25 * time.Second)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 1, Active: false}))
+			Expect(next).To(Equal(5 * time.Second))
+
+			stage, next, err = profile.StageAt(65 * time.Second)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(stage).To(Equal(ProfileStatus{Stage: 4, Active: true}))
+			Expect(next).To(Equal(15 * time.Second))
+		})
+
+		It("should return error with invalid profile", func() {
+			_, _, err := (&ProfileSpec{Type: FlapProfileType, Interval: "0s"}).StageAt(0)
+			Expect(err).Should(HaveOccurred())
+
+			_, _, err = (&ProfileSpec{Type: RampProfileType, Interval: "1m", From: "10ms", To: "30", Steps: 1}).StageAt(0)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("ProfiledSpec", func() {
+		It("should replace the main parameter of action", func() {
+			chaos := &NetworkChaos{
+				Spec: NetworkChaosSpec{
+					Action:      DelayAction,
+					TcParameter: TcParameter{Delay: &DelaySpec{Latency: "10ms"}},
+					Profile:     &ProfileSpec{Type: StepProfileType, Interval: "1m", Values: []string{"10ms", "50ms"}},
+				},
+			}
+			chaos.Status.Profile = &ProfileStatus{Stage: 1, Active: true, Value: "50ms"}
+			Expect(chaos.ProfiledSpec().Delay.Latency).To(Equal("50ms"))
+			Expect(chaos.Spec.Delay.Latency).To(Equal("10ms"))
+
+			chaos.Spec.Action = ResetAction
+			Expect(chaos.ProfiledSpec().Reject).To(Equal(&RejectSpec{Probability: "50ms"}))
+		})
+	})
+})
@@ -0,0 +1,145 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultScheduleHistoryLimit is the default number of the rounds kept in the history
+const DefaultScheduleHistoryLimit = 10
+
+// ConcurrencyPolicy describes how the round which starts while the last round
+// is still running will be handled.
+type ConcurrencyPolicy string
+
+const (
+	// ForbidConcurrent skips the rounds which start while the last round is running
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent recovers the running round and starts the new one
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+	// AllowConcurrent lets the rounds overlap, the chaos keeps injected until
+	// the last round is finished
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+)
+
+// GetConcurrencyPolicy returns the concurrency policy, Forbid is returned if it's empty
+func (in *SchedulerSpec) GetConcurrencyPolicy() ConcurrencyPolicy {
+	if in.ConcurrencyPolicy == "" {
+		return ForbidConcurrent
+	}
+	return in.ConcurrencyPolicy
+}
+
+// GetLocation returns the location of the time zone, the local time zone is returned
+// if it's empty
+func (in *SchedulerSpec) GetLocation() (*time.Location, error) {
+	if in.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(in.TimeZone)
+}
+
+// GetStartingDeadline returns the deadline for starting a round, or zero if there is no deadline
+func (in *SchedulerSpec) GetStartingDeadline() time.Duration {
+	if in.StartingDeadlineSeconds == nil {
+		return 0
+	}
+	return time.Duration(*in.StartingDeadlineSeconds) * time.Second
+}
+
+// GetHistoryLimit returns the number of the rounds kept in the history
+func (in *SchedulerSpec) GetHistoryLimit() int {
+	if in.HistoryLimit == nil {
+		return DefaultScheduleHistoryLimit
+	}
+	return int(*in.HistoryLimit)
+}
+
+// ScheduleResult is the result of a round of chaos.
+type ScheduleResult string
+
+const (
+	// ScheduleResultRunning means that the round is running
+	ScheduleResultRunning ScheduleResult = "Running"
+	// ScheduleResultSucceeded means that the round is finished and recovered
+	ScheduleResultSucceeded ScheduleResult = "Succeeded"
+	// ScheduleResultFailed means that it's failed to start the round
+	ScheduleResultFailed ScheduleResult = "Failed"
+	// ScheduleResultAborted means that the round is aborted by the steady-state probes
+	ScheduleResultAborted ScheduleResult = "Aborted"
+	// ScheduleResultReplaced means that the round is recovered in advance to start the next round
+	ScheduleResultReplaced ScheduleResult = "Replaced"
+	// ScheduleResultSkipped means that the round is skipped, e.g. it misses the starting deadline
+	ScheduleResultSkipped ScheduleResult = "Skipped"
+	// ScheduleResultBlocked means that the round is recovered in advance by a schedule policy
+	ScheduleResultBlocked ScheduleResult = "Blocked"
+)
+
+// ScheduleRecord records a round of chaos.
+type ScheduleRecord struct {
+	// ScheduledTime is the time when the round is scheduled to start.
+	ScheduledTime metav1.Time `json:"scheduledTime"`
+
+	// StartTime is the time when the chaos is injected.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time when the round is finished.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Result is the result of the round.
+	Result ScheduleResult `json:"result"`
+
+	// Message is the reason of the result, e.g. the error of injecting chaos.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SetRecord adds the record of a round to the history, the record of the same
+// scheduled time is replaced, e.g. a round is retried after it's failed to start.
+// Only the last limit records are kept.
+func (in *ScheduleStatus) SetRecord(record ScheduleRecord, limit int) {
+	if len(in.History) > 0 && in.History[len(in.History)-1].ScheduledTime.Equal(&record.ScheduledTime) {
+		in.History[len(in.History)-1] = record
+	} else {
+		in.History = append(in.History, record)
+	}
+
+	if limit < 0 {
+		limit = 0
+	}
+	if len(in.History) > limit {
+		in.History = append([]ScheduleRecord(nil), in.History[len(in.History)-limit:]...)
+	}
+	if len(in.History) == 0 {
+		in.History = nil
+	}
+}
+
+// FinishRecords finishes all the running rounds with the result
+func (in *ScheduleStatus) FinishRecords(result ScheduleResult, message string) {
+	now := metav1.Now()
+	for i := range in.History {
+		if in.History[i].Result != ScheduleResultRunning {
+			continue
+		}
+		in.History[i].EndTime = &now
+		in.History[i].Result = result
+		in.History[i].Message = message
+	}
+}
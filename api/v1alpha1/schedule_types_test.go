@@ -0,0 +1,98 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Schedule", func() {
+	Context("SetRecord", func() {
+		It("should replace the record of the same round and keep the last records", func() {
+			status := &ScheduleStatus{}
+			status.SetRecord(ScheduleRecord{ScheduledTime: metav1.Unix(100, 0), Result: ScheduleResultFailed}, 2)
+			status.SetRecord(ScheduleRecord{ScheduledTime: metav1.Unix(100, 0), Result: ScheduleResultRunning}, 2)
+			Expect(status.History).To(HaveLen(1))
+			Expect(status.History[0].Result).To(Equal(ScheduleResultRunning))
+
+			status.SetRecord(ScheduleRecord{ScheduledTime: metav1.Unix(200, 0), Result: ScheduleResultRunning}, 2)
+			status.SetRecord(ScheduleRecord{ScheduledTime: metav1.Unix(300, 0), Result: ScheduleResultSkipped}, 2)
+			Expect(status.History).To(HaveLen(2))
+			Expect(status.History[0].ScheduledTime).To(Equal(metav1.Unix(200, 0)))
+			Expect(status.History[1].ScheduledTime).To(Equal(metav1.Unix(300, 0)))
+
+			status.SetRecord(ScheduleRecord{ScheduledTime: metav1.Unix(400, 0), Result: ScheduleResultRunning}, 0)
+			Expect(status.History).To(BeNil())
+		})
+	})
+
+	Context("FinishRecords", func() {
+		It("should only finish the running rounds", func() {
+			status := &ScheduleStatus{History: []ScheduleRecord{
+				{ScheduledTime: metav1.Unix(100, 0), Result: ScheduleResultSkipped},
+				{ScheduledTime: metav1.Unix(200, 0), Result: ScheduleResultRunning},
+				{ScheduledTime: metav1.Unix(300, 0), Result: ScheduleResultRunning},
+			}}
+			status.FinishRecords(ScheduleResultAborted, "probe failed")
+			Expect(status.History[0].Result).To(Equal(ScheduleResultSkipped))
+			Expect(status.History[0].EndTime).To(BeNil())
+			for _, record := range status.History[1:] {
+				Expect(record.Result).To(Equal(ScheduleResultAborted))
+				Expect(record.Message).To(Equal("probe failed"))
+				Expect(record.EndTime).ToNot(BeNil())
+			}
+		})
+	})
+
+	Context("Validate", func() {
+		It("should validate the scheduler", func() {
+			type TestCase struct {
+				name     string
+				spec     SchedulerSpec
+				duration string
+				expect   string
+			}
+			negative := int64(-1)
+			tcs := []TestCase{
+				{name: "valid scheduler", spec: SchedulerSpec{Cron: "@every 1h", TimeZone: "Asia/Shanghai"}, duration: "30m"},
+				{name: "unknown time zone", spec: SchedulerSpec{Cron: "@every 1h", TimeZone: "Mars/Olympus"}, duration: "30m", expect: "error"},
+				{name: "unknown policy", spec: SchedulerSpec{Cron: "@every 1h", ConcurrencyPolicy: "Queue"}, duration: "30m", expect: "error"},
+				{name: "negative deadline", spec: SchedulerSpec{Cron: "@every 1h", StartingDeadlineSeconds: &negative}, duration: "30m", expect: "error"},
+				{name: "overlapped rounds", spec: SchedulerSpec{Cron: "@every 1h"}, duration: "2h", expect: "error"},
+				{name: "overlapped rounds of irregular rule", spec: SchedulerSpec{Cron: "0 0,1 * * *"}, duration: "2h", expect: "error"},
+				{name: "overlapped rounds replaced", spec: SchedulerSpec{Cron: "@every 1h", ConcurrencyPolicy: ReplaceConcurrent}, duration: "2h"},
+				{name: "overlapped rounds allowed", spec: SchedulerSpec{Cron: "0 0,1 * * *", ConcurrencyPolicy: AllowConcurrent}, duration: "2h"},
+			}
+
+			for _, tc := range tcs {
+				duration, err := time.ParseDuration(tc.duration)
+				Expect(err).ToNot(HaveOccurred())
+
+				schedulerField := field.NewPath("spec", "scheduler")
+				errs := ValidateSchedulerSpec(&tc.spec, schedulerField)
+				errs = append(errs, validateSchedulerParams(&duration, field.NewPath("spec", "duration"), &tc.spec, schedulerField)...)
+				if tc.expect == "error" {
+					Expect(errs).ToNot(BeEmpty(), tc.name)
+				} else {
+					Expect(errs).To(BeEmpty(), tc.name)
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KindChaosSchedulePolicy is the kind for chaos schedule policy
+const KindChaosSchedulePolicy = "ChaosSchedulePolicy"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// ChaosSchedulePolicy is the Schema for the chaosschedulepolicies API, which
+// restricts when the selected chaos is allowed to run
+type ChaosSchedulePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the windows and blackouts of the policy
+	Spec ChaosSchedulePolicySpec `json:"spec"`
+}
+
+// ChaosSchedulePolicySpec defines when the selected chaos is allowed to run.
+// The chaos is injected only inside one of the windows and outside all the blackouts,
+// it's deferred otherwise, and the running chaos is recovered when a blackout starts.
+type ChaosSchedulePolicySpec struct {
+	// Selector selects the chaos which the policy applies to.
+	// The policy applies to all the chaos if it's empty.
+	// +optional
+	Selector PolicySelectorSpec `json:"selector,omitempty"`
+
+	// TimeZone is the name of the time zone in which the cron rules are evaluated,
+	// e.g. "Asia/Shanghai". The local time zone of the controller is used if it's empty.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows are the periods in which the chaos is allowed to run.
+	// The chaos is allowed at any time if it's empty.
+	// +optional
+	Windows []TimeWindow `json:"windows,omitempty"`
+
+	// Blackouts are the periods in which the chaos is forbidden, e.g. the release freezes.
+	// They take precedence over the windows.
+	// +optional
+	Blackouts []TimeWindow `json:"blackouts,omitempty"`
+}
+
+// PolicySelectorSpec selects the chaos by its namespace and labels
+type PolicySelectorSpec struct {
+	// Namespaces is a set of namespaces of the chaos.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// LabelSelectors is a map of label:value of the chaos.
+	// +optional
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+}
+
+// TimeWindow is a recurring period defined by a cron rule and a duration,
+// or an explicit range between start and end
+type TimeWindow struct {
+	// Name is the name of the window, which is recorded in the status of blocked chaos.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Cron defines the start of each period, e.g. "0 9 * * 1-5".
+	// +optional
+	Cron string `json:"cron,omitempty"`
+
+	// Duration is the length of each period started by cron, e.g. "8h".
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// Start is the start of the explicit range.
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End is the end of the explicit range.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// GetLocation returns the location of the time zone, the local time zone is returned
+// if it's empty
+func (in *ChaosSchedulePolicySpec) GetLocation() (*time.Location, error) {
+	if in.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(in.TimeZone)
+}
+
+// +kubebuilder:object:root=true
+
+// ChaosSchedulePolicyList contains a list of ChaosSchedulePolicy
+type ChaosSchedulePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosSchedulePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChaosSchedulePolicy{}, &ChaosSchedulePolicyList{})
+}
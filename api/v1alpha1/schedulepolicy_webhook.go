@@ -0,0 +1,117 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var schedulepolicylog = logf.Log.WithName("chaosschedulepolicy-resource")
+
+// SetupWebhookWithManager setup ChaosSchedulePolicy's webhook with manager
+func (in *ChaosSchedulePolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-chaos-mesh-org-v1alpha1-chaosschedulepolicy,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=chaosschedulepolicies,versions=v1alpha1,name=vchaosschedulepolicy.kb.io
+
+var _ webhook.Validator = &ChaosSchedulePolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (in *ChaosSchedulePolicy) ValidateCreate() error {
+	schedulepolicylog.Info("validate create", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (in *ChaosSchedulePolicy) ValidateUpdate(old runtime.Object) error {
+	schedulepolicylog.Info("validate update", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (in *ChaosSchedulePolicy) ValidateDelete() error {
+	schedulepolicylog.Info("validate delete", "name", in.Name)
+
+	// Nothing to do?
+	return nil
+}
+
+// Validate validates chaos schedule policy object
+func (in *ChaosSchedulePolicy) Validate() error {
+	specField := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if _, err := in.Spec.GetLocation(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specField.Child("timeZone"), in.Spec.TimeZone,
+			fmt.Sprintf("load time zone error:%s", err)))
+	}
+
+	for i := range in.Spec.Windows {
+		allErrs = append(allErrs, in.Spec.Windows[i].validate(specField.Child("windows").Index(i))...)
+	}
+	for i := range in.Spec.Blackouts {
+		allErrs = append(allErrs, in.Spec.Blackouts[i].validate(specField.Child("blackouts").Index(i))...)
+	}
+
+	if len(allErrs) > 0 {
+		return fmt.Errorf(allErrs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// validate validates that the window is either a cron rule with duration or an explicit range
+func (in *TimeWindow) validate(windowField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.Cron != "" {
+		if in.Start != nil || in.End != nil {
+			allErrs = append(allErrs, field.Invalid(windowField, in.Name,
+				"cron and the explicit range should not be defined at the same time"))
+		}
+
+		_, errs := ParseCron(in.Cron, windowField.Child("cron"))
+		allErrs = append(allErrs, errs...)
+
+		durationField := windowField.Child("duration")
+		duration, err := time.ParseDuration(in.Duration)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(durationField, in.Duration,
+				fmt.Sprintf("parse duration field error:%s", err)))
+		} else if duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(durationField, in.Duration,
+				"duration must be greater than 0"))
+		}
+		return allErrs
+	}
+
+	if in.Start == nil || in.End == nil {
+		allErrs = append(allErrs, field.Invalid(windowField, in.Name,
+			"either cron with duration or both start and end should be defined"))
+	} else if !in.Start.Before(in.End) {
+		allErrs = append(allErrs, field.Invalid(windowField.Child("end"), in.End,
+			"end must be later than start"))
+	}
+	return allErrs
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("chaosschedulepolicy_webhook", func() {
+	Context("webhook.Validator of chaosschedulepolicy", func() {
+		It("Validate", func() {
+			type TestCase struct {
+				name   string
+				spec   ChaosSchedulePolicySpec
+				expect string
+			}
+			now := time.Now()
+			start := metav1.NewTime(now)
+			end := metav1.NewTime(now.Add(time.Hour))
+			tcs := []TestCase{
+				{
+					name: "valid policy",
+					spec: ChaosSchedulePolicySpec{
+						TimeZone:  "Asia/Shanghai",
+						Windows:   []TimeWindow{{Name: "weekdays", Cron: "0 9 * * 1-5", Duration: "8h"}},
+						Blackouts: []TimeWindow{{Name: "release", Start: &start, End: &end}},
+					},
+				},
+				{
+					name:   "unknown time zone",
+					spec:   ChaosSchedulePolicySpec{TimeZone: "Mars/Olympus"},
+					expect: "error",
+				},
+				{
+					name:   "invalid cron",
+					spec:   ChaosSchedulePolicySpec{Windows: []TimeWindow{{Cron: "xx", Duration: "8h"}}},
+					expect: "error",
+				},
+				{
+					name:   "missing duration",
+					spec:   ChaosSchedulePolicySpec{Windows: []TimeWindow{{Cron: "0 9 * * *"}}},
+					expect: "error",
+				},
+				{
+					name:   "both cron and range",
+					spec:   ChaosSchedulePolicySpec{Windows: []TimeWindow{{Cron: "0 9 * * *", Duration: "8h", Start: &start, End: &end}}},
+					expect: "error",
+				},
+				{
+					name:   "missing end",
+					spec:   ChaosSchedulePolicySpec{Blackouts: []TimeWindow{{Start: &start}}},
+					expect: "error",
+				},
+				{
+					name:   "end before start",
+					spec:   ChaosSchedulePolicySpec{Blackouts: []TimeWindow{{Start: &end, End: &start}}},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				policy := &ChaosSchedulePolicy{Spec: tc.spec}
+				err := policy.ValidateCreate()
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).ToNot(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
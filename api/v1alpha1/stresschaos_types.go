@@ -79,6 +79,11 @@ type StressChaosSpec struct {
 	// Scheduler defines some schedule rules to control the running time of the chaos experiment about time.
 	// +optional
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
 }
 
 // GetSelector is a getter for Selector (for implementing SelectSpec)
@@ -74,6 +74,7 @@ func (in *StressChaos) Validate() error {
 	errs := in.Spec.Validate(root)
 	errs = append(errs, in.ValidatePodMode(root)...)
 	errs = append(errs, in.ValidateScheduler(root.Child("spec"))...)
+	errs = append(errs, ValidateProbes(in.Spec.Probes, root.Child("spec", "probes"))...)
 	if len(errs) > 0 {
 		return fmt.Errorf(errs.ToAggregate().Error())
 	}
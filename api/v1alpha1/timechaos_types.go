@@ -71,6 +71,17 @@ type TimeChaosSpec struct {
 
 	// Scheduler defines some schedule rules to control the running time of the chaos experiment about time.
 	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+
+	// Probes defines the steady-state probes which are evaluated periodically while the experiment is running.
+	// The experiment is recovered and aborted once any of them fails.
+	// +optional
+	Probes []ProbeSpec `json:"probes,omitempty"`
+
+	// Continuous keeps injecting the chaos into the pods matching the selector while the experiment is
+	// running, including the pods created or restarted later. The pods injected before are kept, and
+	// the newcomers are selected within the budget of mode.
+	// +optional
+	Continuous bool `json:"continuous,omitempty"`
 }
 
 // SetDefaultValue will set default value for empty fields
@@ -100,6 +111,11 @@ func (in *TimeChaosSpec) GetValue() string {
 	return in.Value
 }
 
+// IsContinuous is a getter for Continuous (for implementing ContinuousObject)
+func (in *TimeChaos) IsContinuous() bool {
+	return in.Spec.Continuous
+}
+
 // TimeChaosStatus defines the observed state of TimeChaos
 type TimeChaosStatus struct {
 	ChaosStatus `json:",inline"`
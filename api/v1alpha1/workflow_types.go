@@ -0,0 +1,193 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// KindWorkflow is the kind for workflow
+const KindWorkflow = "Workflow"
+
+// +kubebuilder:object:root=true
+
+// Workflow is the Schema for the workflows API
+type Workflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the behavior of a workflow
+	Spec WorkflowSpec `json:"spec"`
+
+	// +optional
+	// Most recently observed status of the workflow
+	Status WorkflowStatus `json:"status"`
+}
+
+// WorkflowSpec defines the steps of a workflow
+type WorkflowSpec struct {
+	// Entry is the name of the template which the workflow starts from.
+	Entry string `json:"entry"`
+
+	// Templates defines all the steps which could be used in the workflow.
+	Templates []Template `json:"templates"`
+}
+
+// TemplateType represents the type of a workflow step.
+type TemplateType string
+
+const (
+	// TemplateTypeSerial runs its children one by one.
+	TemplateTypeSerial TemplateType = "Serial"
+	// TemplateTypeParallel runs all of its children at the same time.
+	TemplateTypeParallel TemplateType = "Parallel"
+	// TemplateTypeSuspend waits for the duration and does nothing.
+	TemplateTypeSuspend TemplateType = "Suspend"
+	// TemplateTypeChaos creates a chaos object and keeps it for the duration.
+	TemplateTypeChaos TemplateType = "Chaos"
+)
+
+// Template is a step of the workflow
+type Template struct {
+	// Name is the unique name of the template in the workflow.
+	Name string `json:"name"`
+
+	// Type defines the type of this template.
+	// Supported type: Serial / Parallel / Suspend / Chaos
+	// +kubebuilder:validation:Enum=Serial;Parallel;Suspend;Chaos
+	Type TemplateType `json:"type"`
+
+	// Duration represents how long the step lasts. It is required by the `Suspend` and the `Chaos` templates.
+	// A duration string is a possibly signed sequence of
+	// decimal numbers, each with optional fraction and a unit suffix,
+	// such as "300ms", "-1.5h" or "2h45m".
+	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	// +optional
+	Duration *string `json:"duration,omitempty"`
+
+	// Children is the names of the templates run by the `Serial` and the `Parallel` templates.
+	// +optional
+	Children []string `json:"children,omitempty"`
+
+	// EmbedChaos describes the chaos created by the `Chaos` template.
+	// +optional
+	EmbedChaos *EmbedChaos `json:"chaos,omitempty"`
+}
+
+// GetDuration would return the duration of the template
+func (in *Template) GetDuration() (*time.Duration, error) {
+	if in.Duration == nil {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(*in.Duration)
+	if err != nil {
+		return nil, err
+	}
+	return &duration, nil
+}
+
+// EmbedChaos defines a chaos object created by a workflow
+type EmbedChaos struct {
+	// Kind is the kind of the chaos, which should be one of the registered chaos kinds, e.g. PodChaos.
+	Kind string `json:"kind"`
+
+	// Spec is the spec of the chaos, which is decoded according to the Kind.
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// WorkflowPhase is the current status of a workflow or one of its steps.
+type WorkflowPhase string
+
+const (
+	WorkflowPhasePending   WorkflowPhase = "Pending"
+	WorkflowPhaseRunning   WorkflowPhase = "Running"
+	WorkflowPhaseSucceeded WorkflowPhase = "Succeeded"
+	WorkflowPhaseFailed    WorkflowPhase = "Failed"
+)
+
+// WorkflowStatus represents the current status of a workflow
+type WorkflowStatus struct {
+	// +optional
+	Phase WorkflowPhase `json:"phase,omitempty"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// Nodes records the status of each started step.
+	// +optional
+	Nodes []WorkflowNodeStatus `json:"nodes,omitempty"`
+}
+
+// GetNode returns the status of the template with the given name, or nil if it has not been started.
+func (in *WorkflowStatus) GetNode(name string) *WorkflowNodeStatus {
+	for i := range in.Nodes {
+		if in.Nodes[i].Name == name {
+			return &in.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// WorkflowNodeStatus represents the status of a started step
+type WorkflowNodeStatus struct {
+	// Name is the name of the template.
+	Name string `json:"name"`
+	// Type is the type of the template.
+	Type TemplateType `json:"type"`
+	// Phase is the current phase of this step.
+	Phase WorkflowPhase `json:"phase"`
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// ChaosRef is the name of the chaos object created by a `Chaos` step.
+	// +optional
+	ChaosRef string `json:"chaosRef,omitempty"`
+	// ChaosPhase records the last observed experiment phase of the chaos object.
+	// +optional
+	ChaosPhase ExperimentPhase `json:"chaosPhase,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// GetTemplate returns the template with the given name, or nil if it doesn't exist
+func (in *Workflow) GetTemplate(name string) *Template {
+	for i := range in.Spec.Templates {
+		if in.Spec.Templates[i].Name == name {
+			return &in.Spec.Templates[i]
+		}
+	}
+	return nil
+}
+
+// IsDeleted returns whether this resource has been deleted
+func (in *Workflow) IsDeleted() bool {
+	return !in.DeletionTimestamp.IsZero()
+}
+
+// +kubebuilder:object:root=true
+
+// WorkflowList contains a list of Workflow
+type WorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Workflow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Workflow{}, &WorkflowList{})
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var workflowlog = logf.Log.WithName("workflow-resource")
+
+// SetupWebhookWithManager setup Workflow's webhook with manager
+func (in *Workflow) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(in).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-chaos-mesh-org-v1alpha1-workflow,mutating=true,failurePolicy=fail,groups=chaos-mesh.org,resources=workflows,verbs=create;update,versions=v1alpha1,name=mworkflow.kb.io
+
+var _ webhook.Defaulter = &Workflow{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (in *Workflow) Default() {
+	workflowlog.Info("default", "name", in.Name)
+
+	// Do nothing here
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-chaos-mesh-org-v1alpha1-workflow,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=workflows,versions=v1alpha1,name=vworkflow.kb.io
+
+var _ webhook.Validator = &Workflow{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (in *Workflow) ValidateCreate() error {
+	workflowlog.Info("validate create", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (in *Workflow) ValidateUpdate(old runtime.Object) error {
+	workflowlog.Info("validate update", "name", in.Name)
+	return in.Validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (in *Workflow) ValidateDelete() error {
+	workflowlog.Info("validate delete", "name", in.Name)
+
+	// Nothing to do?
+	return nil
+}
+
+// Validate validates workflow object
+func (in *Workflow) Validate() error {
+	specField := field.NewPath("spec")
+	allErrs := in.Spec.validateTemplates(specField.Child("templates"))
+
+	if in.GetTemplate(in.Spec.Entry) == nil {
+		allErrs = append(allErrs, field.Invalid(specField.Child("entry"), in.Spec.Entry,
+			"entry should be the name of one of the templates"))
+	} else {
+		allErrs = append(allErrs, in.validateReferences(specField.Child("entry"))...)
+	}
+
+	if len(allErrs) > 0 {
+		return fmt.Errorf(allErrs.ToAggregate().Error())
+	}
+	return nil
+}
+
+// validateTemplates validates every template on its own
+func (in *WorkflowSpec) validateTemplates(templates *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := make(map[string]struct{})
+
+	for i := range in.Templates {
+		template := &in.Templates[i]
+		templateField := templates.Index(i)
+
+		if _, ok := names[template.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(templateField.Child("name"), template.Name))
+		}
+		names[template.Name] = struct{}{}
+		// the name of template is a part of the name of the chaos created by it
+		for _, msg := range validation.IsDNS1123Label(template.Name) {
+			allErrs = append(allErrs, field.Invalid(templateField.Child("name"), template.Name, msg))
+		}
+
+		duration, err := template.GetDuration()
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(templateField.Child("duration"), template.Duration,
+				fmt.Sprintf("parse duration field error:%s", err)))
+		}
+
+		switch template.Type {
+		case TemplateTypeSerial, TemplateTypeParallel:
+			if len(template.Children) == 0 {
+				allErrs = append(allErrs, field.Invalid(templateField.Child("children"), template.Children,
+					fmt.Sprintf("children should not be empty with type:%s", template.Type)))
+			}
+		case TemplateTypeSuspend:
+			if err == nil && duration == nil {
+				allErrs = append(allErrs, field.Invalid(templateField.Child("duration"), template.Duration,
+					fmt.Sprintf("duration is required with type:%s", template.Type)))
+			}
+		case TemplateTypeChaos:
+			if err == nil && duration == nil {
+				allErrs = append(allErrs, field.Invalid(templateField.Child("duration"), template.Duration,
+					fmt.Sprintf("duration is required with type:%s", template.Type)))
+			}
+			if template.EmbedChaos == nil {
+				allErrs = append(allErrs, field.Invalid(templateField.Child("chaos"), template.EmbedChaos,
+					fmt.Sprintf("chaos is required with type:%s", template.Type)))
+			} else {
+				allErrs = append(allErrs, template.EmbedChaos.validate(templateField.Child("chaos"))...)
+			}
+		default:
+			allErrs = append(allErrs, field.Invalid(templateField.Child("type"), template.Type,
+				"unknown template type"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateReferences validates that all the templates reachable from the entry exist,
+// and every template is referenced at most once, so the steps form a tree.
+func (in *Workflow) validateReferences(entry *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	visited := map[string]struct{}{in.Spec.Entry: {}}
+
+	queue := []string{in.Spec.Entry}
+	for len(queue) > 0 {
+		template := in.GetTemplate(queue[0])
+		queue = queue[1:]
+
+		for _, child := range template.Children {
+			if in.GetTemplate(child) == nil {
+				allErrs = append(allErrs, field.NotFound(entry, child))
+				continue
+			}
+			if _, ok := visited[child]; ok {
+				allErrs = append(allErrs, field.Invalid(entry, child,
+					fmt.Sprintf("template %s is referenced more than once or forms a cycle", child)))
+				continue
+			}
+			visited[child] = struct{}{}
+			queue = append(queue, child)
+		}
+	}
+
+	return allErrs
+}
+
+// validate validates the embedded chaos with the validator of its kind
+func (in *EmbedChaos) validate(chaosField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	chaos, err := in.NewChaos()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(chaosField, in.Kind, err.Error()))
+		return allErrs
+	}
+
+	if defaulter, ok := chaos.(webhook.Defaulter); ok {
+		defaulter.Default()
+	}
+	if validator, ok := chaos.(ChaosValidator); ok {
+		if err := validator.Validate(); err != nil {
+			allErrs = append(allErrs, field.Invalid(chaosField.Child("spec"), nil, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// NewChaos decodes the embedded spec into a new chaos object of the embedded kind
+func (in *EmbedChaos) NewChaos() (InnerSchedulerObject, error) {
+	kind, ok := AllKinds()[in.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown chaos kind %s", in.Kind)
+	}
+
+	chaos, ok := kind.Chaos.DeepCopyObject().(InnerSchedulerObject)
+	if !ok {
+		return nil, fmt.Errorf("chaos kind %s is not supported in workflow", in.Kind)
+	}
+
+	spec := in.Spec.Raw
+	if len(spec) == 0 {
+		spec = []byte("{}")
+	}
+	raw, err := json.Marshal(map[string]json.RawMessage{"spec": spec})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, chaos); err != nil {
+		return nil, fmt.Errorf("decode spec of %s error:%s", in.Kind, err)
+	}
+
+	return chaos, nil
+}
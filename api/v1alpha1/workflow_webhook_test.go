@@ -0,0 +1,155 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("workflow_webhook", func() {
+	Context("Validator of workflow", func() {
+		It("Validate", func() {
+
+			type TestCase struct {
+				name   string
+				spec   WorkflowSpec
+				expect string
+			}
+			duration := "10s"
+			podKill := &EmbedChaos{
+				Kind: KindPodChaos,
+				Spec: runtime.RawExtension{Raw: []byte(`{"action":"pod-kill","mode":"one","scheduler":{"cron":"@every 5s"}}`)},
+			}
+			tcs := []TestCase{
+				{
+					name: "serial steps",
+					spec: WorkflowSpec{
+						Entry: "entry",
+						Templates: []Template{
+							{Name: "entry", Type: TemplateTypeSerial, Children: []string{"kill", "wait"}},
+							{Name: "kill", Type: TemplateTypeChaos, Duration: &duration, EmbedChaos: podKill},
+							{Name: "wait", Type: TemplateTypeSuspend, Duration: &duration},
+						},
+					},
+					expect: "",
+				},
+				{
+					name: "entry not found",
+					spec: WorkflowSpec{
+						Entry: "entry",
+						Templates: []Template{
+							{Name: "wait", Type: TemplateTypeSuspend, Duration: &duration},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "child not found",
+					spec: WorkflowSpec{
+						Entry: "entry",
+						Templates: []Template{
+							{Name: "entry", Type: TemplateTypeParallel, Children: []string{"wait"}},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "cycle",
+					spec: WorkflowSpec{
+						Entry: "entry",
+						Templates: []Template{
+							{Name: "entry", Type: TemplateTypeSerial, Children: []string{"loop"}},
+							{Name: "loop", Type: TemplateTypeSerial, Children: []string{"entry"}},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "duplicated template",
+					spec: WorkflowSpec{
+						Entry: "entry",
+						Templates: []Template{
+							{Name: "entry", Type: TemplateTypeSerial, Children: []string{"wait"}},
+							{Name: "wait", Type: TemplateTypeSuspend, Duration: &duration},
+							{Name: "wait", Type: TemplateTypeSuspend, Duration: &duration},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid template name",
+					spec: WorkflowSpec{
+						Entry: "Wait_1",
+						Templates: []Template{
+							{Name: "Wait_1", Type: TemplateTypeSuspend, Duration: &duration},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "suspend without duration",
+					spec: WorkflowSpec{
+						Entry: "wait",
+						Templates: []Template{
+							{Name: "wait", Type: TemplateTypeSuspend},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "unknown chaos kind",
+					spec: WorkflowSpec{
+						Entry: "chaos",
+						Templates: []Template{
+							{Name: "chaos", Type: TemplateTypeChaos, Duration: &duration, EmbedChaos: &EmbedChaos{Kind: "FooChaos"}},
+						},
+					},
+					expect: "error",
+				},
+				{
+					name: "invalid chaos spec",
+					spec: WorkflowSpec{
+						Entry: "chaos",
+						Templates: []Template{
+							{Name: "chaos", Type: TemplateTypeChaos, Duration: &duration, EmbedChaos: &EmbedChaos{
+								Kind: KindPodChaos,
+								Spec: runtime.RawExtension{Raw: []byte(`{"action":"pod-kill","mode":"fixed","value":"0","scheduler":{"cron":"@every 1m"}}`)},
+							}},
+						},
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				workflow := &Workflow{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: metav1.NamespaceDefault,
+						Name:      "foo",
+					},
+					Spec: tc.spec,
+				}
+				err := workflow.ValidateCreate()
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
@@ -21,6 +21,141 @@ import (
 )
 
 
+const KindDiskFillChaos = "DiskFillChaos"
+
+// IsDeleted returns whether this resource has been deleted
+func (in *DiskFillChaos) IsDeleted() bool {
+	return !in.DeletionTimestamp.IsZero()
+}
+
+// IsPaused returns whether this resource has been paused
+func (in *DiskFillChaos) IsPaused() bool {
+	if in.Annotations == nil || in.Annotations[PauseAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *DiskFillChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *DiskFillChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
+// GetDuration would return the duration for chaos
+func (in *DiskFillChaos) GetDuration() (*time.Duration, error) {
+	if in.Spec.Duration == nil {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(*in.Spec.Duration)
+	if err != nil {
+		return nil, err
+	}
+	return &duration, nil
+}
+
+func (in *DiskFillChaos) GetNextStart() time.Time {
+	if in.Status.Scheduler.NextStart == nil {
+		return time.Time{}
+	}
+	return in.Status.Scheduler.NextStart.Time
+}
+
+func (in *DiskFillChaos) SetNextStart(t time.Time) {
+	if t.IsZero() {
+		in.Status.Scheduler.NextStart = nil
+		return
+	}
+
+	if in.Status.Scheduler.NextStart == nil {
+		in.Status.Scheduler.NextStart = &metav1.Time{}
+	}
+	in.Status.Scheduler.NextStart.Time = t
+}
+
+func (in *DiskFillChaos) GetNextRecover() time.Time {
+	if in.Status.Scheduler.NextRecover == nil {
+		return time.Time{}
+	}
+	return in.Status.Scheduler.NextRecover.Time
+}
+
+func (in *DiskFillChaos) SetNextRecover(t time.Time) {
+	if t.IsZero() {
+		in.Status.Scheduler.NextRecover = nil
+		return
+	}
+
+	if in.Status.Scheduler.NextRecover == nil {
+		in.Status.Scheduler.NextRecover = &metav1.Time{}
+	}
+	in.Status.Scheduler.NextRecover.Time = t
+}
+
+// GetScheduler would return the scheduler for chaos
+func (in *DiskFillChaos) GetScheduler() *SchedulerSpec {
+	return in.Spec.Scheduler
+}
+
+// GetProbes would return the steady-state probes for chaos
+func (in *DiskFillChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
+// GetChaos would return the a record for chaos
+func (in *DiskFillChaos) GetChaos() *ChaosInstance {
+	instance := &ChaosInstance{
+		Name:      in.Name,
+		Namespace: in.Namespace,
+		Kind:      KindDiskFillChaos,
+		StartTime: in.CreationTimestamp.Time,
+		Action:    "",
+		UID:       string(in.UID),
+	}
+
+	action := reflect.ValueOf(in).Elem().FieldByName("Spec").FieldByName("Action")
+	if !action.IsZero() {
+		instance.Action = action.String()
+	}
+	if in.Spec.Duration != nil {
+		instance.Duration = *in.Spec.Duration
+	}
+	if in.DeletionTimestamp != nil {
+		instance.EndTime = in.DeletionTimestamp.Time
+	}
+	return instance
+}
+
+// GetStatus returns the status
+func (in *DiskFillChaos) GetStatus() *ChaosStatus {
+	return &in.Status.ChaosStatus
+}
+
+// +kubebuilder:object:root=true
+
+// DiskFillChaosList contains a list of DiskFillChaos
+type DiskFillChaosList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DiskFillChaos `json:"items"`
+}
+
+// ListChaos returns a list of chaos
+func (in *DiskFillChaosList) ListChaos() []*ChaosInstance {
+	res := make([]*ChaosInstance, 0, len(in.Items))
+	for _, item := range in.Items {
+		res = append(res, item.GetChaos())
+	}
+	return res
+}
+
 const KindDNSChaos = "DNSChaos"
 
 // IsDeleted returns whether this resource has been deleted
@@ -36,6 +171,19 @@ func (in *DNSChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *DNSChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *DNSChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *DNSChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -91,6 +239,11 @@ func (in *DNSChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *DNSChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *DNSChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -153,6 +306,19 @@ func (in *HTTPChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *HTTPChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *HTTPChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *HTTPChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -208,6 +374,11 @@ func (in *HTTPChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *HTTPChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *HTTPChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -270,6 +441,19 @@ func (in *IoChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *IoChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *IoChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *IoChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -325,6 +509,11 @@ func (in *IoChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *IoChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *IoChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -387,6 +576,19 @@ func (in *KernelChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *KernelChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *KernelChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *KernelChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -442,6 +644,11 @@ func (in *KernelChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *KernelChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *KernelChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -504,6 +711,19 @@ func (in *NetworkChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *NetworkChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *NetworkChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *NetworkChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -559,6 +779,11 @@ func (in *NetworkChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *NetworkChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *NetworkChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -621,6 +846,19 @@ func (in *PodChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *PodChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *PodChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *PodChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -676,6 +914,11 @@ func (in *PodChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *PodChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *PodChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -738,6 +981,19 @@ func (in *StressChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *StressChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *StressChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *StressChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -793,6 +1049,11 @@ func (in *StressChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *StressChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *StressChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -855,6 +1116,19 @@ func (in *TimeChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *TimeChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *TimeChaos) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *TimeChaos) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -910,6 +1184,11 @@ func (in *TimeChaos) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *TimeChaos) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *TimeChaos) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
@@ -959,6 +1238,12 @@ func (in *TimeChaosList) ListChaos() []*ChaosInstance {
 
 func init() {
 
+	SchemeBuilder.Register(&DiskFillChaos{}, &DiskFillChaosList{})
+	all.register(KindDiskFillChaos, &ChaosKind{
+		Chaos:     &DiskFillChaos{},
+		ChaosList: &DiskFillChaosList{},
+	})
+
 	SchemeBuilder.Register(&DNSChaos{}, &DNSChaosList{})
 	all.register(KindDNSChaos, &ChaosKind{
 		Chaos:     &DNSChaos{},
@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 // Copyright Chaos Mesh Authors.
@@ -147,11 +148,139 @@ func (in *CPUStressor) DeepCopy() *CPUStressor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosCondition) DeepCopyInto(out *ChaosCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosCondition.
+func (in *ChaosCondition) DeepCopy() *ChaosCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosSchedulePolicy) DeepCopyInto(out *ChaosSchedulePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosSchedulePolicy.
+func (in *ChaosSchedulePolicy) DeepCopy() *ChaosSchedulePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosSchedulePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosSchedulePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosSchedulePolicyList) DeepCopyInto(out *ChaosSchedulePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChaosSchedulePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosSchedulePolicyList.
+func (in *ChaosSchedulePolicyList) DeepCopy() *ChaosSchedulePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosSchedulePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosSchedulePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosSchedulePolicySpec) DeepCopyInto(out *ChaosSchedulePolicySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]TimeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosSchedulePolicySpec.
+func (in *ChaosSchedulePolicySpec) DeepCopy() *ChaosSchedulePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosSchedulePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChaosStatus) DeepCopyInto(out *ChaosStatus) {
 	*out = *in
 	in.Scheduler.DeepCopyInto(&out.Scheduler)
 	in.Experiment.DeepCopyInto(&out.Experiment)
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ChaosCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosStatus.
@@ -250,7 +379,19 @@ func (in *DNSChaosSpec) DeepCopyInto(out *DNSChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
@@ -288,6 +429,16 @@ func (in *DelaySpec) DeepCopyInto(out *DelaySpec) {
 		*out = new(ReorderSpec)
 		**out = **in
 	}
+	if in.Rate != nil {
+		in, out := &in.Rate, &out.Rate
+		*out = new(NetemRateSpec)
+		**out = **in
+	}
+	if in.Slot != nil {
+		in, out := &in.Slot, &out.Slot
+		*out = new(SlotSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelaySpec.
@@ -300,6 +451,139 @@ func (in *DelaySpec) DeepCopy() *DelaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFillChaos) DeepCopyInto(out *DiskFillChaos) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFillChaos.
+func (in *DiskFillChaos) DeepCopy() *DiskFillChaos {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFillChaos)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiskFillChaos) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFillChaosList) DeepCopyInto(out *DiskFillChaosList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DiskFillChaos, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFillChaosList.
+func (in *DiskFillChaosList) DeepCopy() *DiskFillChaosList {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFillChaosList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiskFillChaosList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFillChaosSpec) DeepCopyInto(out *DiskFillChaosSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.ContainerName != nil {
+		in, out := &in.ContainerName, &out.ContainerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SchedulerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFillChaosSpec.
+func (in *DiskFillChaosSpec) DeepCopy() *DiskFillChaosSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFillChaosSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskFillChaosStatus) DeepCopyInto(out *DiskFillChaosStatus) {
+	*out = *in
+	in.ChaosStatus.DeepCopyInto(&out.ChaosStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskFillChaosStatus.
+func (in *DiskFillChaosStatus) DeepCopy() *DiskFillChaosStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskFillChaosStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodPlan, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DuplicateSpec) DeepCopyInto(out *DuplicateSpec) {
 	*out = *in
@@ -315,6 +599,42 @@ func (in *DuplicateSpec) DeepCopy() *DuplicateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbedChaos) DeepCopyInto(out *EmbedChaos) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbedChaos.
+func (in *EmbedChaos) DeepCopy() *EmbedChaos {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbedChaos)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecProbe) DeepCopyInto(out *ExecProbe) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecProbe.
+func (in *ExecProbe) DeepCopy() *ExecProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExperimentStatus) DeepCopyInto(out *ExperimentStatus) {
 	*out = *in
@@ -329,7 +649,9 @@ func (in *ExperimentStatus) DeepCopyInto(out *ExperimentStatus) {
 	if in.PodRecords != nil {
 		in, out := &in.PodRecords, &out.PodRecords
 		*out = make([]PodStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -430,6 +752,21 @@ func (in *HTTPChaos) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPChaosInstance) DeepCopyInto(out *HTTPChaosInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPChaosInstance.
+func (in *HTTPChaosInstance) DeepCopy() *HTTPChaosInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPChaosInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPChaosList) DeepCopyInto(out *HTTPChaosList) {
 	*out = *in
@@ -469,7 +806,14 @@ func (in *HTTPChaosSpec) DeepCopyInto(out *HTTPChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
@@ -483,6 +827,11 @@ func (in *HTTPChaosSpec) DeepCopyInto(out *HTTPChaosSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPChaosSpec.
@@ -499,6 +848,13 @@ func (in *HTTPChaosSpec) DeepCopy() *HTTPChaosSpec {
 func (in *HTTPChaosStatus) DeepCopyInto(out *HTTPChaosStatus) {
 	*out = *in
 	in.ChaosStatus.DeepCopyInto(&out.ChaosStatus)
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make(map[string]HTTPChaosInstance, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPChaosStatus.
@@ -511,6 +867,21 @@ func (in *HTTPChaosStatus) DeepCopy() *HTTPChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProbe) DeepCopyInto(out *HTTPProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProbe.
+func (in *HTTPProbe) DeepCopy() *HTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IoChaos) DeepCopyInto(out *IoChaos) {
 	*out = *in
@@ -552,14 +923,54 @@ func (in *IoChaosAction) DeepCopyInto(out *IoChaosAction) {
 		*out = new(AttrOverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MistakeSpec != nil {
+		in, out := &in.MistakeSpec, &out.MistakeSpec
+		*out = new(MistakeSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IoChaosAction.
+func (in *IoChaosAction) DeepCopy() *IoChaosAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IoChaosAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IoChaosActionSpec) DeepCopyInto(out *IoChaosActionSpec) {
+	*out = *in
+	if in.Attr != nil {
+		in, out := &in.Attr, &out.Attr
+		*out = new(AttrOverrideSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mistake != nil {
+		in, out := &in.Mistake, &out.Mistake
+		*out = new(MistakeSpec)
+		**out = **in
+	}
+	if in.Throttle != nil {
+		in, out := &in.Throttle, &out.Throttle
+		*out = new(IoThrottleSpec)
+		**out = **in
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]IoMethod, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IoChaosAction.
-func (in *IoChaosAction) DeepCopy() *IoChaosAction {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IoChaosActionSpec.
+func (in *IoChaosActionSpec) DeepCopy() *IoChaosActionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IoChaosAction)
+	out := new(IoChaosActionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -605,15 +1016,39 @@ func (in *IoChaosSpec) DeepCopyInto(out *IoChaosSpec) {
 		*out = new(AttrOverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mistake != nil {
+		in, out := &in.Mistake, &out.Mistake
+		*out = new(MistakeSpec)
+		**out = **in
+	}
+	if in.Throttle != nil {
+		in, out := &in.Throttle, &out.Throttle
+		*out = new(IoThrottleSpec)
+		**out = **in
+	}
 	if in.Methods != nil {
 		in, out := &in.Methods, &out.Methods
 		*out = make([]IoMethod, len(*in))
 		copy(*out, *in)
 	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]IoChaosActionSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
@@ -636,6 +1071,13 @@ func (in *IoChaosSpec) DeepCopy() *IoChaosSpec {
 func (in *IoChaosStatus) DeepCopyInto(out *IoChaosStatus) {
 	*out = *in
 	in.ChaosStatus.DeepCopyInto(&out.ChaosStatus)
+	if in.PreviousThrottles != nil {
+		in, out := &in.PreviousThrottles, &out.PreviousThrottles
+		*out = make(map[string]PodIoThrottles, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IoChaosStatus.
@@ -663,6 +1105,21 @@ func (in *IoFault) DeepCopy() *IoFault {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IoThrottleSpec) DeepCopyInto(out *IoThrottleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IoThrottleSpec.
+func (in *IoThrottleSpec) DeepCopy() *IoThrottleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IoThrottleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KernelChaos) DeepCopyInto(out *KernelChaos) {
 	*out = *in
@@ -735,7 +1192,14 @@ func (in *KernelChaosSpec) DeepCopyInto(out *KernelChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -856,6 +1320,36 @@ func (in *MemoryStressor) DeepCopy() *MemoryStressor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MistakeSpec) DeepCopyInto(out *MistakeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MistakeSpec.
+func (in *MistakeSpec) DeepCopy() *MistakeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MistakeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetemRateSpec) DeepCopyInto(out *NetemRateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetemRateSpec.
+func (in *NetemRateSpec) DeepCopy() *NetemRateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetemRateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkChaos) DeepCopyInto(out *NetworkChaos) {
 	*out = *in
@@ -927,9 +1421,26 @@ func (in *NetworkChaosSpec) DeepCopyInto(out *NetworkChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.TcParameter.DeepCopyInto(&out.TcParameter)
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reject != nil {
+		in, out := &in.Reject, &out.Reject
+		*out = new(RejectSpec)
+		**out = **in
+	}
 	if in.Target != nil {
 		in, out := &in.Target, &out.Target
 		*out = new(Target)
@@ -940,6 +1451,24 @@ func (in *NetworkChaosSpec) DeepCopyInto(out *NetworkChaosSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]PartitionGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]PartitionLink, len(*in))
+		copy(*out, *in)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		**out = **in
+	}
+	out.TrafficFilter = in.TrafficFilter
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkChaosSpec.
@@ -968,6 +1497,37 @@ func (in *NetworkChaosStatus) DeepCopy() *NetworkChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionGroup) DeepCopyInto(out *PartitionGroup) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionGroup.
+func (in *PartitionGroup) DeepCopy() *PartitionGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionLink) DeepCopyInto(out *PartitionLink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionLink.
+func (in *PartitionLink) DeepCopy() *PartitionLink {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionLink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodChaos) DeepCopyInto(out *PodChaos) {
 	*out = *in
@@ -1034,7 +1594,14 @@ func (in *PodChaosSpec) DeepCopyInto(out *PodChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Duration != nil {
 		in, out := &in.Duration, &out.Duration
@@ -1149,6 +1716,26 @@ func (in *PodIoChaosSpec) DeepCopy() *PodIoChaosSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIoThrottles) DeepCopyInto(out *PodIoThrottles) {
+	*out = *in
+	if in.Throttles != nil {
+		in, out := &in.Throttles, &out.Throttles
+		*out = make([]IoThrottleSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIoThrottles.
+func (in *PodIoThrottles) DeepCopy() *PodIoThrottles {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIoThrottles)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNetworkChaos) DeepCopyInto(out *PodNetworkChaos) {
 	*out = *in
@@ -1234,43 +1821,212 @@ func (in *PodNetworkChaosSpec) DeepCopyInto(out *PodNetworkChaosSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosSpec.
-func (in *PodNetworkChaosSpec) DeepCopy() *PodNetworkChaosSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosSpec.
+func (in *PodNetworkChaosSpec) DeepCopy() *PodNetworkChaosSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkChaosSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodNetworkChaosStatus) DeepCopyInto(out *PodNetworkChaosStatus) {
+	*out = *in
+	in.ChaosStatus.DeepCopyInto(&out.ChaosStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosStatus.
+func (in *PodNetworkChaosStatus) DeepCopy() *PodNetworkChaosStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodNetworkChaosStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPlan) DeepCopyInto(out *PodPlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPlan.
+func (in *PodPlan) DeepCopy() *PodPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStatus) DeepCopyInto(out *PodStatus) {
+	*out = *in
+	if in.ContainerIDs != nil {
+		in, out := &in.ContainerIDs, &out.ContainerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InjectedTime != nil {
+		in, out := &in.InjectedTime, &out.InjectedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecoveredTime != nil {
+		in, out := &in.RecoveredTime, &out.RecoveredTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStatus.
+func (in *PodStatus) DeepCopy() *PodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySelectorSpec) DeepCopyInto(out *PolicySelectorSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelectors != nil {
+		in, out := &in.LabelSelectors, &out.LabelSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySelectorSpec.
+func (in *PolicySelectorSpec) DeepCopy() *PolicySelectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySelectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPProbe)
+		**out = **in
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(TCPProbe)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusProbe)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecProbe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeStatus) DeepCopyInto(out *ProbeStatus) {
+	*out = *in
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeStatus.
+func (in *ProbeStatus) DeepCopy() *ProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSpec) DeepCopyInto(out *ProfileSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSpec.
+func (in *ProfileSpec) DeepCopy() *ProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodNetworkChaosSpec)
+	out := new(ProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodNetworkChaosStatus) DeepCopyInto(out *PodNetworkChaosStatus) {
+func (in *ProfileStatus) DeepCopyInto(out *ProfileStatus) {
 	*out = *in
-	in.ChaosStatus.DeepCopyInto(&out.ChaosStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosStatus.
-func (in *PodNetworkChaosStatus) DeepCopy() *PodNetworkChaosStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileStatus.
+func (in *ProfileStatus) DeepCopy() *ProfileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodNetworkChaosStatus)
+	out := new(ProfileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodStatus) DeepCopyInto(out *PodStatus) {
+func (in *PrometheusProbe) DeepCopyInto(out *PrometheusProbe) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStatus.
-func (in *PodStatus) DeepCopy() *PodStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusProbe.
+func (in *PrometheusProbe) DeepCopy() *PrometheusProbe {
 	if in == nil {
 		return nil
 	}
-	out := new(PodStatus)
+	out := new(PrometheusProbe)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1283,6 +2039,11 @@ func (in *RawIPSet) DeepCopyInto(out *RawIPSet) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.RawRuleSource = in.RawRuleSource
 }
 
@@ -1304,6 +2065,7 @@ func (in *RawIptables) DeepCopyInto(out *RawIptables) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.TrafficFilter = in.TrafficFilter
 	out.RawRuleSource = in.RawRuleSource
 }
 
@@ -1336,6 +2098,7 @@ func (in *RawRuleSource) DeepCopy() *RawRuleSource {
 func (in *RawTrafficControl) DeepCopyInto(out *RawTrafficControl) {
 	*out = *in
 	in.TcParameter.DeepCopyInto(&out.TcParameter)
+	out.TrafficFilter = in.TrafficFilter
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawTrafficControl.
@@ -1348,6 +2111,21 @@ func (in *RawTrafficControl) DeepCopy() *RawTrafficControl {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RejectSpec) DeepCopyInto(out *RejectSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RejectSpec.
+func (in *RejectSpec) DeepCopy() *RejectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RejectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReorderSpec) DeepCopyInto(out *ReorderSpec) {
 	*out = *in
@@ -1363,6 +2141,30 @@ func (in *ReorderSpec) DeepCopy() *ReorderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleRecord) DeepCopyInto(out *ScheduleRecord) {
+	*out = *in
+	in.ScheduledTime.DeepCopyInto(&out.ScheduledTime)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleRecord.
+func (in *ScheduleRecord) DeepCopy() *ScheduleRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduleStatus) DeepCopyInto(out *ScheduleStatus) {
 	*out = *in
@@ -1374,6 +2176,13 @@ func (in *ScheduleStatus) DeepCopyInto(out *ScheduleStatus) {
 		in, out := &in.NextRecover, &out.NextRecover
 		*out = (*in).DeepCopy()
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ScheduleRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleStatus.
@@ -1389,6 +2198,16 @@ func (in *ScheduleStatus) DeepCopy() *ScheduleStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
 	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSpec.
@@ -1474,6 +2293,21 @@ func (in *SelectorSpec) DeepCopy() *SelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlotSpec) DeepCopyInto(out *SlotSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlotSpec.
+func (in *SlotSpec) DeepCopy() *SlotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StressChaos) DeepCopyInto(out *StressChaos) {
 	*out = *in
@@ -1555,7 +2389,14 @@ func (in *StressChaosSpec) DeepCopyInto(out *StressChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1651,6 +2492,21 @@ func (in *Stressors) DeepCopy() *Stressors {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPProbe) DeepCopyInto(out *TCPProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPProbe.
+func (in *TCPProbe) DeepCopy() *TCPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Target) DeepCopyInto(out *Target) {
 	*out = *in
@@ -1707,6 +2563,36 @@ func (in *TcParameter) DeepCopy() *TcParameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Template) DeepCopyInto(out *Template) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(string)
+		**out = **in
+	}
+	if in.Children != nil {
+		in, out := &in.Children, &out.Children
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmbedChaos != nil {
+		in, out := &in.EmbedChaos, &out.EmbedChaos
+		*out = new(EmbedChaos)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Template.
+func (in *Template) DeepCopy() *Template {
+	if in == nil {
+		return nil
+	}
+	out := new(Template)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TimeChaos) DeepCopyInto(out *TimeChaos) {
 	*out = *in
@@ -1788,7 +2674,14 @@ func (in *TimeChaosSpec) DeepCopyInto(out *TimeChaosSpec) {
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
 		*out = new(SchedulerSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1818,6 +2711,29 @@ func (in *TimeChaosStatus) DeepCopy() *TimeChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Timespec) DeepCopyInto(out *Timespec) {
 	*out = *in
@@ -1832,3 +2748,167 @@ func (in *Timespec) DeepCopy() *Timespec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
+func (in *TopologySpec) DeepCopy() *TopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficFilter) DeepCopyInto(out *TrafficFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficFilter.
+func (in *TrafficFilter) DeepCopy() *TrafficFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workflow) DeepCopyInto(out *Workflow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workflow.
+func (in *Workflow) DeepCopy() *Workflow {
+	if in == nil {
+		return nil
+	}
+	out := new(Workflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workflow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowList) DeepCopyInto(out *WorkflowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Workflow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowList.
+func (in *WorkflowList) DeepCopy() *WorkflowList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowNodeStatus) DeepCopyInto(out *WorkflowNodeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowNodeStatus.
+func (in *WorkflowNodeStatus) DeepCopy() *WorkflowNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]Template, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowSpec.
+func (in *WorkflowSpec) DeepCopy() *WorkflowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]WorkflowNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowStatus.
+func (in *WorkflowStatus) DeepCopy() *WorkflowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -34,6 +34,19 @@ func (in *{{.Type}}) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource only plans the changes without applying them
+func (in *{{.Type}}) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
+// GetSelectSpec returns the spec selecting pods
+func (in *{{.Type}}) GetSelectSpec() SelectSpec {
+	return &in.Spec
+}
+
 // GetDuration would return the duration for chaos
 func (in *{{.Type}}) GetDuration() (*time.Duration, error) {
 	if in.Spec.Duration == nil {
@@ -89,6 +102,11 @@ func (in *{{.Type}}) GetScheduler() *SchedulerSpec {
 	return in.Spec.Scheduler
 }
 
+// GetProbes would return the steady-state probes for chaos
+func (in *{{.Type}}) GetProbes() []ProbeSpec {
+	return in.Spec.Probes
+}
+
 // GetChaos would return the a record for chaos
 func (in *{{.Type}}) GetChaos() *ChaosInstance {
 	instance := &ChaosInstance{
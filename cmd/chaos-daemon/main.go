@@ -39,6 +39,7 @@ func init() {
 	flag.IntVar(&conf.HTTPPort, "http-port", 31766, "the port which http server listens on")
 	flag.StringVar(&conf.Runtime, "runtime", "docker", "current container runtime")
 	flag.BoolVar(&conf.Profiling, "pprof", false, "enable pprof")
+	flag.BoolVar(&conf.Netlink, "netlink", true, "program tc qdiscs, tc filters and ipsets through netlink, or execute tc and ipset commands if it's false")
 
 	flag.Parse()
 }
@@ -0,0 +1,105 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"math/rand"
+	"net"
+	"os"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdns"
+	"github.com/chaos-mesh/chaos-mesh/pkg/version"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	addr          string
+	grpcAddr      string
+	upstream      string
+	clusterDomain string
+	resync        bool
+	namespace     string
+	printVersion  bool
+)
+
+var log = ctrl.Log.WithName("chaos-dns-server")
+
+func initFlag() {
+	flag.StringVar(&addr, "addr", ":53", "The address to serve DNS requests on")
+	flag.StringVar(&grpcAddr, "grpc-addr", ":9288", "The address to serve grpc requests on")
+	flag.StringVar(&upstream, "upstream", "", "The upstream DNS server, the first nameserver in /etc/resolv.conf is used if it's empty")
+	flag.StringVar(&clusterDomain, "cluster-domain", "cluster.local", "The domain of Kubernetes cluster")
+	flag.BoolVar(&resync, "resync", true, "Restore the rules of the running DNSChaos from the Kubernetes API server on start")
+	flag.StringVar(&namespace, "namespace", "", "The namespace of DNSChaos to resync, all namespaces are resynced if it's empty")
+	flag.BoolVar(&printVersion, "version", false, "print version information and exit")
+
+	rand.Seed(time.Now().UnixNano())
+	flag.Parse()
+}
+
+func main() {
+	initFlag()
+
+	ctrl.SetLogger(zap.Logger(true))
+
+	version.PrintVersionInfo("Chaos-dns-server")
+	if printVersion {
+		os.Exit(0)
+	}
+
+	if upstream == "" {
+		config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			log.Error(err, "failed to read resolv.conf")
+			os.Exit(1)
+		}
+		if len(config.Servers) == 0 {
+			log.Info("no nameserver in resolv.conf")
+			os.Exit(1)
+		}
+		upstream = net.JoinHostPort(config.Servers[0], config.Port)
+	}
+
+	server := chaosdns.NewServer(upstream, clusterDomain)
+	if resync {
+		scheme := runtime.NewScheme()
+		_ = v1alpha1.AddToScheme(scheme)
+
+		c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		if err != nil {
+			log.Error(err, "failed to create kubernetes client")
+			os.Exit(1)
+		}
+		if err := server.Resync(context.Background(), c, client.InNamespace(namespace)); err != nil {
+			log.Error(err, "failed to resync the rules of dns chaos")
+			os.Exit(1)
+		}
+	}
+
+	log.Info("Starting chaos dns server", "upstream", upstream, "clusterDomain", clusterDomain)
+	if err := server.ListenAndServe(addr, grpcAddr); err != nil {
+		log.Error(err, "failed to serve")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/httpproxy"
+	"github.com/chaos-mesh/chaos-mesh/pkg/version"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	listen       string
+	target       string
+	printVersion bool
+)
+
+var log = ctrl.Log.WithName("chaos-http-proxy")
+
+func initFlag() {
+	flag.StringVar(&listen, "listen", "0.0.0.0:0", "The address to listen on")
+	flag.StringVar(&target, "target", "127.0.0.1:80", "The address of the HTTP server to forward requests to")
+	flag.BoolVar(&printVersion, "version", false, "print version information and exit")
+
+	rand.Seed(time.Now().UnixNano())
+	flag.Parse()
+}
+
+// The proxy reads the rule in JSON from stdin, and prints the port it listens on
+// as the first line of stdout, so that chaos-daemon could redirect the traffic to it.
+func main() {
+	initFlag()
+
+	ctrl.SetLogger(zap.LoggerTo(os.Stderr, true))
+
+	if printVersion {
+		version.PrintVersionInfo("Chaos-http-proxy")
+		os.Exit(0)
+	}
+
+	var rule httpproxy.Rule
+	if err := json.NewDecoder(os.Stdin).Decode(&rule); err != nil {
+		log.Error(err, "failed to decode rule")
+		os.Exit(1)
+	}
+
+	proxy, err := httpproxy.New(rule, target)
+	if err != nil {
+		log.Error(err, "failed to create proxy")
+		os.Exit(1)
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		log.Error(err, "failed to listen", "address", listen)
+		os.Exit(1)
+	}
+	fmt.Println(listener.Addr().(*net.TCPAddr).Port)
+
+	server := &http.Server{Handler: proxy}
+	stopCh := ctrl.SetupSignalHandler()
+	go func() {
+		<-stopCh
+		log.Info("Got signal to exit")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Error(err, "failed to shutdown proxy")
+		}
+	}()
+
+	log.Info("Starting http proxy", "address", listener.Addr().String(), "target", target, "rule", rule)
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		log.Error(err, "failed to serve")
+		os.Exit(1)
+	}
+}
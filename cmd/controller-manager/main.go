@@ -27,12 +27,15 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/metrics"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podiochaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos"
+	"github.com/chaos-mesh/chaos-mesh/controllers/workflow"
+	"github.com/chaos-mesh/chaos-mesh/pkg/probe"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 	"github.com/chaos-mesh/chaos-mesh/pkg/version"
 	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config"
 	"github.com/chaos-mesh/chaos-mesh/pkg/webhook/config/watcher"
 
+	_ "github.com/chaos-mesh/chaos-mesh/controllers/diskfillchaos"
 	_ "github.com/chaos-mesh/chaos-mesh/controllers/dnschaos"
 	_ "github.com/chaos-mesh/chaos-mesh/controllers/httpchaos"
 	_ "github.com/chaos-mesh/chaos-mesh/controllers/iochaos"
@@ -114,6 +117,9 @@ func main() {
 		os.Exit(1)
 	}
 
+	// set the config used by exec probes
+	probe.RestConfig = mgr.GetConfig()
+
 	err = router.SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "fail to setup with manager")
@@ -143,6 +149,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = workflow.NewReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Workflow")
+		os.Exit(1)
+	}
+	if err = (&chaosmeshv1alpha1.Workflow{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Workflow")
+		os.Exit(1)
+	}
+
+	if err = (&chaosmeshv1alpha1.ChaosSchedulePolicy{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ChaosSchedulePolicy")
+		os.Exit(1)
+	}
+
 	// Init metrics collector
 	metricsCollector := metrics.NewChaosCollector(mgr.GetCache(), controllermetrics.Registry)
 
@@ -23,9 +23,13 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/pkg/config"
+	"github.com/chaos-mesh/chaos-mesh/pkg/probe"
+	"github.com/chaos-mesh/chaos-mesh/pkg/profile"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	endpoint "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/schedulepolicy"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 
@@ -126,6 +130,15 @@ func NewReconciler(e endpoint.Endpoint, ctx ctx.Context) *Reconciler {
 	}
 }
 
+// Recover recovers the chaos by the endpoint, and forgets the probes of chaos
+func (r *Reconciler) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if err := r.Endpoint.Recover(ctx, req, chaos); err != nil {
+		return err
+	}
+	probe.Forget(chaos)
+	return nil
+}
+
 // Reconcile the common chaos
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	var err error
@@ -141,6 +154,22 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	status := chaos.GetStatus()
 
+	var block *schedulepolicy.Block
+	var blockRequeueAfter time.Duration
+	if !chaos.IsDeleted() && !chaos.IsPaused() {
+		block, blockRequeueAfter, err = schedulepolicy.Check(ctx, r.Client, chaos, time.Now())
+		if err != nil {
+			r.Log.Error(err, "failed to check the schedule policies")
+			updateFailedMessage(ctx, r, chaos, err.Error())
+			return ctrl.Result{Requeue: true}, err
+		}
+		if schedulepolicy.SetBlocked(status, block) && block == nil &&
+			status.Experiment.Phase == v1alpha1.ExperimentPhaseWaiting {
+			// The chaos isn't blocked any more
+			status.Experiment.Reason = emptyString
+		}
+	}
+
 	if chaos.IsDeleted() {
 		// This chaos was deleted
 		r.Log.Info("Removing self")
@@ -170,13 +199,105 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 		status.Experiment.Phase = v1alpha1.ExperimentPhasePaused
 		status.FailedMessage = emptyString
-	} else if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning {
-		r.Log.Info("The common chaos is already running", "name", req.Name, "namespace", req.Namespace)
+	} else if status.Experiment.Phase == v1alpha1.ExperimentPhaseAborted {
+		r.Log.Info("The common chaos has been aborted", "name", req.Name, "namespace", req.Namespace)
 		return ctrl.Result{}, nil
+	} else if block != nil {
+		r.Log.Info("Blocked by the schedule policy", "policy", block.Policy, "reason", block.Reason)
+
+		// The failed chaos may be injected into part of the pods, so it's also recovered
+		if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning ||
+			status.Experiment.Phase == v1alpha1.ExperimentPhaseFailed {
+			if err = r.Recover(ctx, req, chaos); err != nil {
+				r.Log.Error(err, "failed to recover chaos")
+				updateFailedMessage(ctx, r, chaos, err.Error())
+				return ctrl.Result{Requeue: true}, err
+			}
+			r.Event(chaos, v1.EventTypeWarning, schedulepolicy.EventChaosBlocked, block.Message)
+
+			now := time.Now()
+			status.Experiment.EndTime = &metav1.Time{
+				Time: now,
+			}
+			if status.Experiment.StartTime != nil {
+				status.Experiment.Duration = now.Sub(status.Experiment.StartTime.Time).String()
+			}
+		}
+		status.Experiment.Phase = v1alpha1.ExperimentPhaseWaiting
+		status.Experiment.Reason = block.Message
+		status.FailedMessage = emptyString
+	} else if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning {
+		breached, requeueAfter := probe.Check(ctx, chaos, time.Now())
+		if breached == nil {
+			changed, profileRequeueAfter, err := profile.Step(ctx, r.Endpoint, req, chaos, time.Now())
+			if err != nil {
+				r.Log.Error(err, "failed to change the stage of profile")
+				updateFailedMessage(ctx, r, chaos, err.Error())
+				return ctrl.Result{Requeue: true}, err
+			}
+			if profileRequeueAfter > 0 && (requeueAfter == 0 || profileRequeueAfter < requeueAfter) {
+				requeueAfter = profileRequeueAfter
+			}
+
+			if syncer, ok := r.Endpoint.(endpoint.Syncer); ok {
+				synced, syncRequeueAfter, err := syncer.Sync(ctx, req, chaos)
+				if err != nil {
+					r.Log.Error(err, "failed to sync the continuous chaos")
+					updateFailedMessage(ctx, r, chaos, err.Error())
+					return ctrl.Result{Requeue: true}, err
+				}
+				changed = changed || synced
+				if syncRequeueAfter > 0 && (requeueAfter == 0 || syncRequeueAfter < requeueAfter) {
+					requeueAfter = syncRequeueAfter
+				}
+			}
+
+			if requeueAfter == 0 && !changed {
+				r.Log.Info("The common chaos is already running", "name", req.Name, "namespace", req.Namespace)
+				return ctrl.Result{RequeueAfter: blockRequeueAfter}, nil
+			}
+
+			if err := r.Update(ctx, chaos); err != nil {
+				r.Log.Error(err, "unable to update chaos status")
+				return ctrl.Result{}, err
+			}
+			// Requeue when a window of the schedule policies changes
+			if blockRequeueAfter > 0 && (requeueAfter == 0 || blockRequeueAfter < requeueAfter) {
+				requeueAfter = blockRequeueAfter
+			}
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		r.Log.Info("Aborting", "probe", breached.Name, "message", breached.Message)
+		if err = r.Recover(ctx, req, chaos); err != nil {
+			r.Log.Error(err, "failed to abort chaos")
+			updateFailedMessage(ctx, r, chaos, err.Error())
+			return ctrl.Result{Requeue: true}, err
+		}
+
+		reason := fmt.Sprintf("probe %s failed: %s", breached.Name, breached.Message)
+		r.Event(chaos, v1.EventTypeWarning, probe.EventChaosAborted, reason)
+
+		now := time.Now()
+		status.Experiment.EndTime = &metav1.Time{
+			Time: now,
+		}
+		if status.Experiment.StartTime != nil {
+			status.Experiment.Duration = now.Sub(status.Experiment.StartTime.Time).String()
+		}
+		status.Experiment.Phase = v1alpha1.ExperimentPhaseAborted
+		status.Experiment.Reason = reason
+		status.FailedMessage = emptyString
 	} else {
 		// Start chaos action
 		r.Log.Info("Performing Action")
 
+		if err = profile.Start(chaos); err != nil {
+			r.Log.Error(err, "failed to start the profile")
+			updateFailedMessage(ctx, r, chaos, err.Error())
+			return ctrl.Result{}, err
+		}
+
 		if err = r.Apply(ctx, req, chaos); err != nil {
 			r.Log.Error(err, "failed to apply chaos action")
 			updateFailedMessage(ctx, r, chaos, err.Error())
@@ -197,7 +318,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Time: time.Now(),
 		}
 		status.Experiment.Phase = v1alpha1.ExperimentPhaseRunning
+		status.Experiment.Reason = emptyString
 		status.FailedMessage = emptyString
+		status.Probes = nil
 	}
 
 	if err := r.Update(ctx, chaos); err != nil {
@@ -205,7 +328,21 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue to start the steady-state probes, the profile and the tracking of pods once the chaos is running
+	if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning {
+		if probed, ok := chaos.(v1alpha1.ProbedObject); ok && len(probed.GetProbes()) > 0 {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		if profiled, ok := chaos.(v1alpha1.ProfiledObject); ok && profiled.GetProfile() != nil {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		if continuous, ok := chaos.(v1alpha1.ContinuousObject); ok && continuous.IsContinuous() {
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	// Requeue when a window of the schedule policies changes
+	return ctrl.Result{RequeueAfter: blockRequeueAfter}, nil
 }
 
 func updateFailedMessage(
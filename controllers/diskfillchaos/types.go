@@ -0,0 +1,216 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskfillchaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/router"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
+)
+
+const diskFillChaosMsg = "fill disk on path %s"
+
+// endpoint is diskfillchaos reconciler
+type endpoint struct {
+	ctx.Context
+}
+
+// Apply applies disk-fill-chaos
+func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	diskfillchaos, ok := chaos.(*v1alpha1.DiskFillChaos)
+	if !ok {
+		err := errors.New("chaos is not diskfillchaos")
+		r.Log.Error(err, "chaos is not DiskFillChaos", "chaos", chaos)
+		return err
+	}
+
+	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &diskfillchaos.Spec)
+	if err != nil {
+		r.Log.Error(err, "failed to select and filter pods")
+		return err
+	}
+
+	errs := make([]error, len(pods))
+	err = utils.ApplyAllPods(diskfillchaos, pods, errs, func(pod *v1.Pod) error {
+		return r.applyPod(ctx, pod, diskfillchaos)
+	})
+
+	diskfillchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
+	for i, pod := range pods {
+		ps := v1alpha1.PodStatus{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			HostIP:    pod.Status.HostIP,
+			PodIP:     pod.Status.PodIP,
+			Message:   fmt.Sprintf(diskFillChaosMsg, diskfillchaos.Spec.Path),
+		}
+		if target, err := utils.TargetContainer(&pod, diskfillchaos.Spec.ContainerName); err == nil {
+			ps.ContainerIDs = []string{target}
+		}
+		ps.SetInjected(errs[i])
+
+		diskfillchaos.Status.Experiment.PodRecords = append(diskfillchaos.Status.Experiment.PodRecords, ps)
+	}
+	if err != nil {
+		r.Log.Error(err, "failed to apply chaos on all pods")
+		return err
+	}
+	r.Event(diskfillchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
+	return nil
+}
+
+// Plan renders the requests to fill the disk of the selected pods
+func (r *endpoint) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	diskfillchaos, ok := chaos.(*v1alpha1.DiskFillChaos)
+	if !ok {
+		err := errors.New("chaos is not diskfillchaos")
+		r.Log.Error(err, "chaos is not DiskFillChaos", "chaos", chaos)
+		return nil, err
+	}
+
+	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &diskfillchaos.Spec)
+	if err != nil {
+		r.Log.Error(err, "failed to select and filter pods")
+		return nil, err
+	}
+
+	plans := make([]v1alpha1.PodPlan, 0, len(pods))
+	for _, pod := range pods {
+		request, err := diskFillRequest(&pod, diskfillchaos)
+		if err != nil {
+			return nil, err
+		}
+
+		plan, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Kind:      "DiskFillRequest",
+			Plan:      string(plan),
+		})
+	}
+
+	return plans, nil
+}
+
+// Recover means the reconciler recovers the chaos action
+func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	diskfillchaos, ok := chaos.(*v1alpha1.DiskFillChaos)
+	if !ok {
+		err := errors.New("chaos is not DiskFillChaos")
+		r.Log.Error(err, "chaos is not DiskFillChaos", "chaos", chaos)
+		return err
+	}
+
+	if err := utils.CleanFinalizersAndRecover(ctx, r.Client, r.Log, diskfillchaos, func(pod *v1.Pod) error {
+		return r.recoverPod(ctx, pod, diskfillchaos)
+	}); err != nil {
+		return err
+	}
+	r.Event(diskfillchaos, v1.EventTypeNormal, utils.EventChaosRecovered, "")
+
+	return nil
+}
+
+func (r *endpoint) recoverPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.DiskFillChaos) error {
+	r.Log.Info("Try to recover pod", "namespace", pod.Namespace, "name", pod.Name)
+
+	request, err := diskFillRequest(pod, chaos)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	_, err = daemonClient.RecoverDiskFill(ctx, request)
+	return err
+}
+
+// Object would return the instance of chaos
+func (r *endpoint) Object() v1alpha1.InnerObject {
+	return &v1alpha1.DiskFillChaos{}
+}
+
+func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.DiskFillChaos) error {
+	r.Log.Info("Try to apply disk fill chaos", "namespace",
+		pod.Namespace, "name", pod.Name)
+
+	request, err := diskFillRequest(pod, chaos)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	_, err = daemonClient.ApplyDiskFill(ctx, request)
+	return err
+}
+
+// diskFillRequest builds the request to fill the disk of pod, the file is identified
+// by the uid of chaos so that it can be removed after chaos-daemon restarts
+func diskFillRequest(pod *v1.Pod, chaos *v1alpha1.DiskFillChaos) (*pb.DiskFillRequest, error) {
+	target, err := utils.TargetContainer(pod, chaos.Spec.ContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := chaos.Spec.GetSize()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DiskFillRequest{
+		ContainerId: target,
+		Path:        chaos.Spec.Path,
+		Name:        string(chaos.UID),
+		Size:        uint64(size),
+		Percent:     uint32(chaos.Spec.Percent),
+	}, nil
+}
+
+func init() {
+	router.Register("diskfillchaos", &v1alpha1.DiskFillChaos{}, func(obj runtime.Object) bool {
+		return true
+	}, func(ctx ctx.Context) end.Endpoint {
+		return &endpoint{
+			Context: ctx,
+		}
+	})
+}
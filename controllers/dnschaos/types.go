@@ -16,6 +16,7 @@ package dnschaos
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"golang.org/x/sync/errgroup"
@@ -26,9 +27,13 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdns"
+	dnspb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
@@ -66,24 +71,37 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		return err
 	}
 
-	// TODO: get chaos dns server's address, and send request to this server to set chaos rules.
+	dnsServerIP, err := r.getDNSServerIP(ctx)
+	if err != nil {
+		r.Log.Error(err, "failed to get the address of dns server")
+		return err
+	}
 
-	if err = r.applyAllPods(ctx, pods, dnschaos, ""); err != nil {
-		r.Log.Error(err, "failed to apply chaos on all pods")
+	if err = r.setDNSChaos(ctx, dnschaos, pods); err != nil {
+		r.Log.Error(err, "failed to set chaos rules on dns server")
 		return err
 	}
 
+	errs := make([]error, len(pods))
+	err = r.applyAllPods(ctx, pods, dnschaos, dnsServerIP, errs)
+
 	dnschaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
 			Namespace: pod.Namespace,
 			Name:      pod.Name,
 			HostIP:    pod.Status.HostIP,
 			PodIP:     pod.Status.PodIP,
 		}
+		ps.ContainerIDs = utils.ContainerIDs(&pod)
+		ps.SetInjected(errs[i])
 
 		dnschaos.Status.Experiment.PodRecords = append(dnschaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		r.Log.Error(err, "failed to apply chaos on all pods")
+		return err
+	}
 	r.Event(dnschaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
@@ -100,6 +118,12 @@ func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1
 	if err := r.cleanFinalizersAndRecover(ctx, dnschaos); err != nil {
 		return err
 	}
+
+	// the pods don't send requests to the dns server any more, so it's safe to cancel the rules
+	if err := r.cancelDNSChaos(ctx, dnschaos); err != nil {
+		r.Log.Error(err, "failed to cancel chaos rules on dns server")
+		return err
+	}
 	r.Event(dnschaos, v1.EventTypeNormal, utils.EventChaosRecovered, "")
 
 	return nil
@@ -128,11 +152,13 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 			}
 
 			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			chaos.Status.Experiment.SetPodRecovered(ns, name, nil)
 			chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
 			continue
 		}
 
 		err = r.recoverPod(ctx, &pod, chaos)
+		chaos.Status.Experiment.SetPodRecovered(ns, name, err)
 		if err != nil {
 			result = multierror.Append(result, err)
 			continue
@@ -153,7 +179,113 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 func (r *endpoint) recoverPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.DNSChaos) error {
 	r.Log.Info("Try to recover pod", "namespace", pod.Namespace, "name", pod.Name)
 
-	// TODO: send request to daemon to do recover
+	return r.setDNSServer(ctx, pod, "", false)
+}
+
+// getDNSServerIP returns the cluster IP of chaos dns server's service
+func (r *endpoint) getDNSServerIP(ctx context.Context) (string, error) {
+	var service v1.Service
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: common.ControllerCfg.Namespace,
+		Name:      DNSServerName,
+	}, &service); err != nil {
+		return "", err
+	}
+
+	if len(service.Spec.ClusterIP) == 0 || service.Spec.ClusterIP == v1.ClusterIPNone {
+		return "", fmt.Errorf("service %s/%s doesn't have a cluster ip", service.Namespace, service.Name)
+	}
+	return service.Spec.ClusterIP, nil
+}
+
+// forEachDNSServer calls f with the client of every running chaos dns server
+func (r *endpoint) forEachDNSServer(ctx context.Context, f func(client dnspb.ChaosDNSClient) error) error {
+	var pods v1.PodList
+	if err := r.Client.List(ctx, &pods,
+		client.InNamespace(common.ControllerCfg.Namespace),
+		client.MatchingLabels(DNSServerSelectorLabels)); err != nil {
+		return err
+	}
+
+	g := errgroup.Group{}
+	count := 0
+	for index := range pods.Items {
+		pod := &pods.Items[index]
+		if pod.Status.Phase != v1.PodRunning || len(pod.Status.PodIP) == 0 {
+			continue
+		}
+		count++
+
+		g.Go(func() error {
+			conn, err := utils.CreateGrpcConnectionWithAddress(fmt.Sprintf("%s:%d", pod.Status.PodIP, common.ControllerCfg.DNSServerGRPCPort))
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			return f(dnspb.NewChaosDNSClient(conn))
+		})
+	}
+	if count == 0 {
+		return fmt.Errorf("no running dns server in namespace %s", common.ControllerCfg.Namespace)
+	}
+
+	return g.Wait()
+}
+
+func (r *endpoint) setDNSChaos(ctx context.Context, chaos *v1alpha1.DNSChaos, pods []v1.Pod) error {
+	podIPs := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podIPs = append(podIPs, pod.Status.PodIP)
+	}
+
+	req, err := chaosdns.NewSetDNSChaosRequest(chaos, podIPs)
+	if err != nil {
+		return err
+	}
+
+	return r.forEachDNSServer(ctx, func(client dnspb.ChaosDNSClient) error {
+		_, err := client.SetDNSChaos(ctx, req)
+		return err
+	})
+}
+
+func (r *endpoint) cancelDNSChaos(ctx context.Context, chaos *v1alpha1.DNSChaos) error {
+	name, err := cache.MetaNamespaceKeyFunc(chaos)
+	if err != nil {
+		return err
+	}
+
+	return r.forEachDNSServer(ctx, func(client dnspb.ChaosDNSClient) error {
+		_, err := client.CancelDNSChaos(ctx, &dnspb.CancelDNSChaosRequest{
+			Name: name,
+		})
+		return err
+	})
+}
+
+// setDNSServer points the resolvers of all containers in the pod to the dns server, or restores them
+func (r *endpoint) setDNSServer(ctx context.Context, pod *v1.Pod, dnsServerIP string, enable bool) error {
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return fmt.Errorf("%s/%s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if _, err := daemonClient.SetDNSServer(ctx, &pb.SetDNSServerRequest{
+			ContainerId: container.ContainerID,
+			DnsServer:   dnsServerIP,
+			Enable:      enable,
+		}); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -163,9 +295,11 @@ func (r *endpoint) Object() v1alpha1.InnerObject {
 	return &v1alpha1.DNSChaos{}
 }
 
-func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.DNSChaos, dnsServerIP string) error {
+// applyAllPods applies the chaos on the pods, the error of each pod is set in errs
+func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.DNSChaos, dnsServerIP string, errs []error) error {
 	g := errgroup.Group{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 
 		key, err := cache.MetaNamespaceKeyFunc(pod)
@@ -176,7 +310,8 @@ func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alp
 		chaos.Finalizers = utils.InsertFinalizer(chaos.Finalizers, key)
 
 		g.Go(func() error {
-			return r.applyPod(ctx, pod, chaos, dnsServerIP)
+			errs[index] = r.applyPod(ctx, pod, chaos, dnsServerIP)
+			return errs[index]
 		})
 	}
 	err := g.Wait()
@@ -191,9 +326,7 @@ func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.DN
 	r.Log.Info("Try to apply dns chaos", "namespace",
 		pod.Namespace, "name", pod.Name)
 
-	// TODO: send request to daemon to apply dns chaos
-
-	return nil
+	return r.setDNSServer(ctx, pod, dnsServerIP, true)
 }
 
 func init() {
@@ -15,21 +15,32 @@ package httpchaos
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 
+	"github.com/hashicorp/go-multierror"
 	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/httpproxy"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 )
 
+const httpChaosMsg = "inject http chaos by proxy on port %d"
+
 type endpoint struct {
 	ctx.Context
 }
@@ -42,15 +53,46 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		return err
 	}
 
+	rule, err := buildRule(&httpFaultChaos.Spec)
+	if err != nil {
+		r.Log.Error(err, "failed to build the rule of proxy")
+		return err
+	}
+
 	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &httpFaultChaos.Spec)
 	if err != nil {
 		r.Log.Error(err, "failed to select and filter pods")
 		return err
 	}
-	if err = r.applyAllPods(ctx, pods, httpFaultChaos); err != nil {
+
+	if httpFaultChaos.Status.Instances == nil {
+		httpFaultChaos.Status.Instances = make(map[string]v1alpha1.HTTPChaosInstance, len(pods))
+	}
+	errs := make([]error, len(pods))
+	err = r.applyAllPods(ctx, pods, httpFaultChaos, rule, errs)
+
+	httpFaultChaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
+	for i, pod := range pods {
+		ps := v1alpha1.PodStatus{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			HostIP:    pod.Status.HostIP,
+			PodIP:     pod.Status.PodIP,
+			Action:    string(httpFaultChaos.Spec.Action),
+			Message:   fmt.Sprintf(httpChaosMsg, httpFaultChaos.Spec.Port),
+		}
+		if len(pod.Status.ContainerStatuses) > 0 {
+			ps.ContainerIDs = []string{pod.Status.ContainerStatuses[0].ContainerID}
+		}
+		ps.SetInjected(errs[i])
+
+		httpFaultChaos.Status.Experiment.PodRecords = append(httpFaultChaos.Status.Experiment.PodRecords, ps)
+	}
+	if err != nil {
 		r.Log.Error(err, "failed to apply chaos on all pods")
 		return err
 	}
+	r.Event(httpFaultChaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
 
@@ -61,17 +103,110 @@ func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1
 		r.Log.Error(err, "chaos is not HttpChaos", "chaos", chaos)
 		return err
 	}
+
+	if err := r.cleanFinalizersAndRecover(ctx, httpFaultChaos); err != nil {
+		return err
+	}
 	r.Event(httpFaultChaos, v1.EventTypeNormal, utils.EventChaosRecovered, "")
 	return nil
 }
 
+func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha1.HTTPChaos) error {
+	var result error
+
+	for _, key := range chaos.Finalizers {
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		var pod v1.Pod
+		err = r.Client.Get(ctx, types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		}, &pod)
+
+		if err != nil {
+			if !k8serror.IsNotFound(err) {
+				result = multierror.Append(result, err)
+				continue
+			}
+
+			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			chaos.Status.Experiment.SetPodRecovered(ns, name, nil)
+			delete(chaos.Status.Instances, key)
+			chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
+			continue
+		}
+
+		err = r.recoverPod(ctx, &pod, chaos)
+		chaos.Status.Experiment.SetPodRecovered(ns, name, err)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
+	}
+
+	if chaos.Annotations[common.AnnotationCleanFinalizer] == common.AnnotationCleanFinalizerForced {
+		r.Log.Info("Force cleanup all finalizers", "chaos", chaos)
+		chaos.Finalizers = chaos.Finalizers[:0]
+		return nil
+	}
+
+	return result
+}
+
+func (r *endpoint) recoverPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.HTTPChaos) error {
+	r.Log.Info("Try to recover pod", "namespace", pod.Namespace, "name", pod.Name)
+
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		return err
+	}
+	instance, ok := chaos.Status.Instances[key]
+	if !ok {
+		r.Log.Info("Pod seems already recovered", "pod", pod.UID)
+		return nil
+	}
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return fmt.Errorf("%s/%s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	// an empty rule stops the proxy
+	if _, err = daemonClient.ApplyHttpChaos(ctx, &pb.ApplyHttpChaosRequest{
+		Port:        uint32(chaos.Spec.Port),
+		ContainerId: pod.Status.ContainerStatuses[0].ContainerID,
+		Instance:    instance.Pid,
+		StartTime:   instance.StartTime,
+	}); err != nil {
+		return err
+	}
+	delete(chaos.Status.Instances, key)
+	return nil
+}
+
 func (r *endpoint) Object() v1alpha1.InnerObject {
 	return &v1alpha1.HTTPChaos{}
 }
 
-func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.HTTPChaos) error {
+// applyAllPods applies the chaos on the pods, the error of each pod is set in errs
+func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.HTTPChaos, rule string, errs []error) error {
 	g := errgroup.Group{}
+
+	instancesLock := &sync.RWMutex{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 
 		key, err := cache.MetaNamespaceKeyFunc(pod)
@@ -81,19 +216,78 @@ func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alp
 		chaos.Finalizers = utils.InsertFinalizer(chaos.Finalizers, key)
 
 		g.Go(func() error {
-			return r.applyPod(ctx, pod, chaos)
+			errs[index] = r.applyPod(ctx, pod, chaos, rule, instancesLock)
+			return errs[index]
 		})
 	}
 
 	return g.Wait()
 }
 
-func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.HTTPChaos) error {
-	//TODO: The way to connect with sidecar need be discussed & It will work after the sidecar add to the repo.
+func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.HTTPChaos, rule string, instancesLock *sync.RWMutex) error {
 	r.Log.Info("Try to inject Http chaos on pod", "namespace", pod.Namespace, "name", pod.Name)
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return fmt.Errorf("%s/%s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	instancesLock.RLock()
+	instance := chaos.Status.Instances[key]
+	instancesLock.RUnlock()
+
+	// the old proxy (if any) is replaced by the new one
+	res, err := daemonClient.ApplyHttpChaos(ctx, &pb.ApplyHttpChaosRequest{
+		Rule:        rule,
+		Port:        uint32(chaos.Spec.Port),
+		ContainerId: pod.Status.ContainerStatuses[0].ContainerID,
+		Instance:    instance.Pid,
+		StartTime:   instance.StartTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	instancesLock.Lock()
+	chaos.Status.Instances[key] = v1alpha1.HTTPChaosInstance{
+		Pid:       res.Instance,
+		StartTime: res.StartTime,
+	}
+	instancesLock.Unlock()
 	return nil
 }
 
+// buildRule builds the rule of proxy in JSON from the spec
+func buildRule(spec *v1alpha1.HTTPChaosSpec) (string, error) {
+	delay, err := spec.GetDelay()
+	if err != nil {
+		return "", err
+	}
+	percent, err := spec.GetPercent()
+	if err != nil {
+		return "", err
+	}
+
+	rule, err := json.Marshal(&httpproxy.Rule{
+		Action:    spec.Action,
+		Delay:     delay,
+		AbortCode: spec.GetAbortCode(),
+		Percent:   percent,
+		Headers:   spec.GetHeaders(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(rule), nil
+}
+
 func init() {
 	router.Register("httpchaos", &v1alpha1.HTTPChaos{}, func(obj runtime.Object) bool {
 		return true
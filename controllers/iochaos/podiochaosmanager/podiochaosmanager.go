@@ -15,6 +15,9 @@ package podiochaosmanager
 
 import (
 	"context"
+	"encoding/json"
+	"sort"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
@@ -35,6 +38,10 @@ type PodIoManager struct {
 	client.Client
 
 	Modifications map[types.NamespacedName]*PodIoTransaction
+
+	// Errors records the errors of the last commit on each pod
+	Errors     map[types.NamespacedName]error
+	errorsLock sync.Mutex
 }
 
 // New creates a new PodIoMap
@@ -44,6 +51,7 @@ func New(source string, logger logr.Logger, client client.Client) *PodIoManager
 		Log:           logger,
 		Client:        client,
 		Modifications: make(map[types.NamespacedName]*PodIoTransaction),
+		Errors:        make(map[types.NamespacedName]error),
 	}
 }
 
@@ -110,6 +118,10 @@ func (m *PodIoManager) Commit(ctx context.Context) error {
 
 				return m.Client.Update(ctx, chaos)
 			})
+			m.errorsLock.Lock()
+			m.Errors[key] = updateError
+			m.errorsLock.Unlock()
+
 			if updateError != nil {
 				m.Log.Error(updateError, "error while updating")
 				return updateError
@@ -121,3 +133,43 @@ func (m *PodIoManager) Commit(ctx context.Context) error {
 
 	return g.Wait()
 }
+
+// Plan renders the PodIoChaos of each pod with the modifications, without updating them
+// in the cluster
+func (m *PodIoManager) Plan(ctx context.Context) ([]v1alpha1.PodPlan, error) {
+	keys := make([]types.NamespacedName, 0, len(m.Modifications))
+	for key := range m.Modifications {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	plans := make([]v1alpha1.PodPlan, 0, len(keys))
+	for _, key := range keys {
+		chaos := &v1alpha1.PodIoChaos{}
+		err := m.Client.Get(ctx, key, chaos)
+		if err != nil && !k8sError.IsNotFound(err) {
+			m.Log.Error(err, "error while getting podiochaos")
+			return nil, err
+		}
+
+		if err := m.Modifications[key].Apply(chaos); err != nil {
+			m.Log.Error(err, "error while applying transactions", "transaction", m.Modifications[key])
+			return nil, err
+		}
+
+		plan, err := json.Marshal(chaos.Spec)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Kind:      "PodIoChaos",
+			Plan:      string(plan),
+		})
+	}
+
+	return plans, nil
+}
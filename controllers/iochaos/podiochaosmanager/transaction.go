@@ -97,6 +97,38 @@ func (t *PodIoTransaction) Append(item interface{}) error {
 	}
 }
 
+// AppendActions translates the actions of IoChaos into the actions of podiochaos
+// and appends them in order
+func (t *PodIoTransaction) AppendActions(source string, actions []v1alpha1.IoChaosActionSpec) {
+	for _, action := range actions {
+		// the throttle is set on the cgroup of container rather than injected by toda
+		if action.Action == v1alpha1.IoThrottle {
+			continue
+		}
+
+		t.Steps = append(t.Steps, &Append{
+			Item: v1alpha1.IoChaosAction{
+				Type: action.Action,
+				Filter: v1alpha1.Filter{
+					Path:    action.Path,
+					Percent: action.Percent,
+					Methods: action.Methods,
+				},
+				Faults: []v1alpha1.IoFault{
+					{
+						Errno:  action.Errno,
+						Weight: 1,
+					},
+				},
+				Latency:          action.Delay,
+				AttrOverrideSpec: action.Attr,
+				MistakeSpec:      action.Mistake,
+				Source:           source,
+			},
+		})
+	}
+}
+
 // SetVolumePath sets the volumePath field of podiochaos
 func (t *PodIoTransaction) SetVolumePath(path string) error {
 	t.Steps = append(t.Steps, &SetVolumePath{
@@ -15,10 +15,14 @@ package iochaos
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	v1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
@@ -27,6 +31,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/iochaos/podiochaosmanager"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
@@ -53,14 +58,108 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 	source := iochaos.Namespace + "/" + iochaos.Name
 	m := podiochaosmanager.New(source, r.Log, r.Client)
 
-	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &iochaos.Spec)
+	r.Log.Info("applying iochaos", "iochaos", iochaos)
+
+	pods, err := r.prepare(ctx, m, iochaos)
 	if err != nil {
-		r.Log.Error(err, "failed to select and filter pods")
 		return err
 	}
 
-	r.Log.Info("applying iochaos", "iochaos", iochaos)
+	r.Log.Info("commiting updates of podiochaos")
+	err = m.Commit(ctx)
+
+	action := actionNames(iochaos.Spec.GetActions())
+	throttles := iochaos.Spec.GetThrottles()
+	iochaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
+	for _, pod := range pods {
+		podErr := m.Errors[types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}]
+		if podErr == nil && len(throttles) > 0 {
+			podErr = r.applyIoThrottle(ctx, &pod, iochaos, throttles)
+			if podErr != nil {
+				err = multierror.Append(err, podErr)
+			}
+		}
+
+		ps := v1alpha1.PodStatus{
+			Namespace:    pod.Namespace,
+			Name:         pod.Name,
+			HostIP:       pod.Status.HostIP,
+			PodIP:        pod.Status.PodIP,
+			Action:       action,
+			ContainerIDs: utils.ContainerIDs(&pod),
+		}
+		ps.SetInjected(podErr)
+
+		iochaos.Status.Experiment.PodRecords = append(iochaos.Status.Experiment.PodRecords, ps)
+	}
+	if err != nil {
+		r.Log.Error(err, "fail to commit")
+		return err
+	}
+	r.Event(iochaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
+
+	return nil
+}
+
+// Plan implements the endpoint.Planner.Plan
+func (r *endpoint) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	iochaos, ok := chaos.(*v1alpha1.IoChaos)
+	if !ok {
+		err := errors.New("chaos is not IOChaos")
+		r.Log.Error(err, "chaos is not IOChaos", "chaos", chaos)
+		return nil, err
+	}
+
+	source := iochaos.Namespace + "/" + iochaos.Name
+	m := podiochaosmanager.New(source, r.Log, r.Client)
+
+	pods, err := r.prepare(ctx, m, iochaos)
+	if err != nil {
+		return nil, err
+	}
 
+	plans, err := m.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	throttles := iochaos.Spec.GetThrottles()
+	if len(throttles) == 0 {
+		return plans, nil
+	}
+	for _, pod := range pods {
+		request, err := ioThrottleRequest(&pod, throttles)
+		if err != nil {
+			return nil, err
+		}
+
+		plan, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Kind:      "IoThrottleRequest",
+			Plan:      string(plan),
+		})
+	}
+
+	return plans, nil
+}
+
+// prepare selects the pods and sets the io faults on them in the manager
+func (r *endpoint) prepare(ctx context.Context, m *podiochaosmanager.PodIoManager, iochaos *v1alpha1.IoChaos) ([]v1.Pod, error) {
+	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &iochaos.Spec)
+	if err != nil {
+		r.Log.Error(err, "failed to select and filter pods")
+		return nil, err
+	}
+
+	actions := iochaos.Spec.GetActions()
 	for _, pod := range pods {
 		t := m.WithInit(types.NamespacedName{
 			Name:      pod.Name,
@@ -69,52 +168,25 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 
 		// TODO: support chaos on multiple volume
 		t.SetVolumePath(iochaos.Spec.VolumePath)
-		t.Append(v1alpha1.IoChaosAction{
-			Type: iochaos.Spec.Action,
-			Filter: v1alpha1.Filter{
-				Path:    iochaos.Spec.Path,
-				Percent: iochaos.Spec.Percent,
-				Methods: iochaos.Spec.Methods,
-			},
-			Faults: []v1alpha1.IoFault{
-				{
-					Errno:  iochaos.Spec.Errno,
-					Weight: 1,
-				},
-			},
-			Latency:          iochaos.Spec.Delay,
-			AttrOverrideSpec: iochaos.Spec.Attr,
-			Source:           m.Source,
-		})
+		t.AppendActions(m.Source, actions)
 
 		key, err := cache.MetaNamespaceKeyFunc(&pod)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		iochaos.Finalizers = utils.InsertFinalizer(iochaos.Finalizers, key)
 	}
-	r.Log.Info("commiting updates of podiochaos")
-	err = m.Commit(ctx)
-	if err != nil {
-		r.Log.Error(err, "fail to commit")
-		return err
-	}
 
-	iochaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
-		ps := v1alpha1.PodStatus{
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			HostIP:    pod.Status.HostIP,
-			PodIP:     pod.Status.PodIP,
-			Action:    string(iochaos.Spec.Action),
-		}
+	return pods, nil
+}
 
-		iochaos.Status.Experiment.PodRecords = append(iochaos.Status.Experiment.PodRecords, ps)
+// actionNames returns the names of all the actions which are recorded in the status
+func actionNames(actions []v1alpha1.IoChaosActionSpec) string {
+	names := make([]string, 0, len(actions))
+	for _, action := range actions {
+		names = append(names, string(action.Action))
 	}
-	r.Event(iochaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
-
-	return nil
+	return strings.Join(names, ",")
 }
 
 // Recover implements the reconciler.InnerReconciler.Recover
@@ -156,6 +228,17 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 		if err != nil {
 			r.Log.Error(err, "fail to commit")
 		}
+		if previous, ok := chaos.Status.PreviousThrottles[key]; ok && err == nil {
+			err = r.recoverIoThrottle(ctx, ns, name, previous.Throttles)
+			if err != nil {
+				r.Log.Error(err, "fail to recover io throttle", "namespace", ns, "name", name)
+				chaos.Status.Experiment.SetPodRecovered(ns, name, err)
+				result = multierror.Append(result, err)
+				continue
+			}
+			delete(chaos.Status.PreviousThrottles, key)
+		}
+		chaos.Status.Experiment.SetPodRecovered(ns, name, err)
 
 		chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
 	}
@@ -170,6 +253,106 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 	return result
 }
 
+// applyIoThrottle limits the io of the devices on the cgroup of pod by chaos-daemon, and records
+// the limits before applying in the status of chaos, which are kept if the pod has been applied
+func (r *endpoint) applyIoThrottle(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.IoChaos, throttles []v1alpha1.IoThrottleSpec) error {
+	r.Log.Info("Try to apply io throttle", "namespace", pod.Namespace, "name", pod.Name)
+
+	request, err := ioThrottleRequest(pod, throttles)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	res, err := daemonClient.ApplyIoThrottle(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		return err
+	}
+	if chaos.Status.PreviousThrottles == nil {
+		chaos.Status.PreviousThrottles = make(map[string]v1alpha1.PodIoThrottles)
+	}
+	if _, ok := chaos.Status.PreviousThrottles[key]; !ok {
+		previous := make([]v1alpha1.IoThrottleSpec, 0, len(res.Throttles))
+		for _, throttle := range res.Throttles {
+			previous = append(previous, v1alpha1.IoThrottleSpec{
+				Device:    throttle.Device,
+				ReadBps:   throttle.ReadBps,
+				WriteBps:  throttle.WriteBps,
+				ReadIops:  throttle.ReadIops,
+				WriteIops: throttle.WriteIops,
+			})
+		}
+		chaos.Status.PreviousThrottles[key] = v1alpha1.PodIoThrottles{Throttles: previous}
+	}
+	return nil
+}
+
+// recoverIoThrottle restores the limits of the devices on the cgroup of pod to
+// the previous ones, it's skipped if the pod doesn't exist any more
+func (r *endpoint) recoverIoThrottle(ctx context.Context, ns string, name string, previous []v1alpha1.IoThrottleSpec) error {
+	var pod v1.Pod
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: ns,
+		Name:      name,
+	}, &pod)
+	if err != nil {
+		if k8serror.IsNotFound(err) {
+			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			return nil
+		}
+		return err
+	}
+
+	request, err := ioThrottleRequest(&pod, previous)
+	if err != nil {
+		return err
+	}
+
+	daemonClient, err := utils.NewChaosDaemonClient(ctx, r.Client,
+		&pod, common.ControllerCfg.ChaosDaemonPort)
+	if err != nil {
+		return err
+	}
+	defer daemonClient.Close()
+
+	_, err = daemonClient.RecoverIoThrottle(ctx, request)
+	return err
+}
+
+// ioThrottleRequest builds the request of the throttles on the first container of pod,
+// which is the same container the other io faults are injected into
+func ioThrottleRequest(pod *v1.Pod, throttles []v1alpha1.IoThrottleSpec) (*pb.IoThrottleRequest, error) {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return nil, fmt.Errorf("%s %s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	request := &pb.IoThrottleRequest{
+		ContainerId: pod.Status.ContainerStatuses[0].ContainerID,
+		Throttles:   make([]*pb.IoThrottle, 0, len(throttles)),
+	}
+	for _, throttle := range throttles {
+		request.Throttles = append(request.Throttles, &pb.IoThrottle{
+			Device:    throttle.Device,
+			ReadBps:   throttle.ReadBps,
+			WriteBps:  throttle.WriteBps,
+			ReadIops:  throttle.ReadIops,
+			WriteIops: throttle.WriteIops,
+		})
+	}
+	return request, nil
+}
+
 func init() {
 	router.Register("iochaos", &v1alpha1.IoChaos{}, func(obj runtime.Object) bool {
 		return true
@@ -61,13 +61,11 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		return err
 	}
 
-	if err = r.applyAllPods(ctx, pods, kernelChaos); err != nil {
-		r.Log.Error(err, "failed to apply chaos on all pods")
-		return err
-	}
+	errs := make([]error, len(pods))
+	err = r.applyAllPods(ctx, pods, kernelChaos, errs)
 
 	kernelChaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
 			Namespace: pod.Namespace,
 			Name:      pod.Name,
@@ -75,9 +73,17 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 			PodIP:     pod.Status.PodIP,
 			Message:   fmt.Sprintf(kernelChaosMsg, kernelChaos.Spec.FailKernRequest),
 		}
+		if len(pod.Status.ContainerStatuses) > 0 {
+			ps.ContainerIDs = []string{pod.Status.ContainerStatuses[0].ContainerID}
+		}
+		ps.SetInjected(errs[i])
 
 		kernelChaos.Status.Experiment.PodRecords = append(kernelChaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		r.Log.Error(err, "failed to apply chaos on all pods")
+		return err
+	}
 	r.Event(kernelChaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 
 	return nil
@@ -123,11 +129,13 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 			}
 
 			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			chaos.Status.Experiment.SetPodRecovered(ns, name, nil)
 			chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
 			continue
 		}
 
 		err = r.recoverPod(ctx, &pod, chaos)
+		chaos.Status.Experiment.SetPodRecovered(ns, name, err)
 		if err != nil {
 			result = multierror.Append(result, err)
 			continue
@@ -200,9 +208,11 @@ func (r *endpoint) Object() v1alpha1.InnerObject {
 	return &v1alpha1.KernelChaos{}
 }
 
-func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.KernelChaos) error {
+// applyAllPods applies the chaos on the pods, the error of each pod is set in errs
+func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.KernelChaos, errs []error) error {
 	g := errgroup.Group{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 
 		key, err := cache.MetaNamespaceKeyFunc(pod)
@@ -212,7 +222,8 @@ func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alp
 		chaos.Finalizers = utils.InsertFinalizer(chaos.Finalizers, key)
 
 		g.Go(func() error {
-			return r.applyPod(ctx, pod, chaos)
+			errs[index] = r.applyPod(ctx, pod, chaos)
+			return errs[index]
 		})
 	}
 
@@ -17,6 +17,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/go-multierror"
 	v1 "k8s.io/api/core/v1"
@@ -30,11 +33,11 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/networkchaos/podnetworkmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/iptable"
-	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/tracker"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 )
 
@@ -43,6 +46,11 @@ const (
 
 	sourceIPSetPostFix = "src"
 	targetIPSetPostFix = "tgt"
+	// externalIPSetPostFix is the postfix of ipset of external targets in the outage of zone
+	externalIPSetPostFix = "ext"
+
+	// groupIPSetPostFixPrefix is followed by the index of group in the postfix of ipset name
+	groupIPSetPostFixPrefix = "g"
 )
 
 type endpoint struct {
@@ -69,30 +77,98 @@ func (e *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 	source := networkchaos.Namespace + "/" + networkchaos.Name
 	m := podnetworkmanager.New(source, e.Log, e.Client, e.Reader)
 
-	sources, err := utils.SelectAndFilterPods(ctx, e.Client, e.Reader, &networkchaos.Spec)
+	allPods, err := e.prepare(ctx, source, m, networkchaos)
+	if err != nil {
+		return err
+	}
+
+	err = m.Commit(ctx)
+
+	networkchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(allPods))
+	for _, pod := range allPods {
+		ps := v1alpha1.PodStatus{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			HostIP:    pod.Status.HostIP,
+			PodIP:     pod.Status.PodIP,
+			Action:    string(networkchaos.Spec.Action),
+		}
 
+		if networkchaos.Spec.Duration != nil {
+			ps.Message = fmt.Sprintf(networkPartitionActionMsg, *networkchaos.Spec.Duration)
+		}
+		if len(pod.Status.ContainerStatuses) > 0 {
+			ps.ContainerIDs = []string{pod.Status.ContainerStatuses[0].ContainerID}
+		}
+		ps.SetInjected(m.Errors[types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}])
+
+		networkchaos.Status.Experiment.PodRecords = append(networkchaos.Status.Experiment.PodRecords, ps)
+	}
 	if err != nil {
-		e.Log.Error(err, "failed to select and filter pods")
+		// if pod is not found or not running, don't print error log and wait next time.
+		if err != podnetworkmanager.ErrPodNotFound && err != podnetworkmanager.ErrPodNotRunning {
+			e.Log.Error(err, "fail to commit")
+		}
 		return err
 	}
 
+	e.Event(networkchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
+	return nil
+}
+
+// Plan implements the endpoint.Planner.Plan
+func (e *endpoint) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	networkchaos, ok := chaos.(*v1alpha1.NetworkChaos)
+	if !ok {
+		err := errors.New("chaos is not NetworkChaos")
+		e.Log.Error(err, "chaos is not NetworkChaos", "chaos", chaos)
+		return nil, err
+	}
+
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+	m := podnetworkmanager.New(source, e.Log, e.Client, e.Reader)
+
+	if _, err := e.prepare(ctx, source, m, networkchaos); err != nil {
+		return nil, err
+	}
+
+	return m.Plan(ctx)
+}
+
+// prepare sets the ipsets and chains of the partition in the manager, it returns all the selected pods
+func (e *endpoint) prepare(ctx context.Context, source string, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) ([]v1.Pod, error) {
+	if networkchaos.Spec.Topology != nil {
+		return e.prepareZoneOutage(ctx, source, m, networkchaos)
+	} else if len(networkchaos.Spec.Groups) > 0 {
+		return e.prepareGroups(ctx, source, m, networkchaos)
+	}
+	return e.prepareSourcesAndTargets(ctx, source, m, networkchaos)
+}
+
+// prepareSourcesAndTargets prepares the ipsets and chains to cut the links between the selected pods and targets
+func (e *endpoint) prepareSourcesAndTargets(ctx context.Context, source string, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) ([]v1.Pod, error) {
+	sources, err := tracker.SelectPods(ctx, e.Client, e.Reader, networkchaos)
+
+	if err != nil {
+		e.Log.Error(err, "failed to select and filter pods")
+		return nil, err
+	}
+
 	var targets []v1.Pod
 
 	if networkchaos.Spec.Target != nil {
 		targets, err = utils.SelectAndFilterPods(ctx, e.Client, e.Reader, networkchaos.Spec.Target)
 		if err != nil {
 			e.Log.Error(err, "failed to select and filter pods")
-			return err
+			return nil, err
 		}
 	}
 
 	sourceSet := ipset.BuildIPSet(sources, []string{}, networkchaos, sourceIPSetPostFix, source)
-	externalCidrs, err := netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
-	if err != nil {
-		e.Log.Error(err, "failed to resolve external targets")
-		return err
-	}
-	targetSet := ipset.BuildIPSet(targets, externalCidrs, networkchaos, targetIPSetPostFix, source)
+	targetSet := ipset.BuildIPSet(targets, networkchaos.Spec.ExternalTargets, networkchaos, targetIPSetPostFix, source)
 
 	allPods := append(sources, targets...)
 
@@ -114,18 +190,22 @@ func (e *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 	targetsChains := []v1alpha1.RawIptables{}
 	if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
 		sourcesChains = append(sourcesChains, v1alpha1.RawIptables{
-			Name:      iptable.GenerateName(pb.Chain_OUTPUT, networkchaos),
-			Direction: v1alpha1.Output,
-			IPSets:    []string{targetSet.Name},
+			Name:          iptable.GenerateName(pb.Chain_OUTPUT, networkchaos),
+			Direction:     v1alpha1.Output,
+			IPSets:        []string{targetSet.Name},
+			TrafficFilter: networkchaos.Spec.TrafficFilter,
+			Device:        networkchaos.Spec.Device,
 			RawRuleSource: v1alpha1.RawRuleSource{
 				Source: source,
 			},
 		})
 
 		targetsChains = append(targetsChains, v1alpha1.RawIptables{
-			Name:      iptable.GenerateName(pb.Chain_INPUT, networkchaos),
-			Direction: v1alpha1.Input,
-			IPSets:    []string{sourceSet.Name},
+			Name:          iptable.GenerateName(pb.Chain_INPUT, networkchaos),
+			Direction:     v1alpha1.Input,
+			IPSets:        []string{sourceSet.Name},
+			TrafficFilter: networkchaos.Spec.TrafficFilter,
+			Device:        networkchaos.Spec.Device,
 			RawRuleSource: v1alpha1.RawRuleSource{
 				Source: source,
 			},
@@ -134,18 +214,22 @@ func (e *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 
 	if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
 		sourcesChains = append(sourcesChains, v1alpha1.RawIptables{
-			Name:      iptable.GenerateName(pb.Chain_INPUT, networkchaos),
-			Direction: v1alpha1.Input,
-			IPSets:    []string{targetSet.Name},
+			Name:          iptable.GenerateName(pb.Chain_INPUT, networkchaos),
+			Direction:     v1alpha1.Input,
+			IPSets:        []string{targetSet.Name},
+			TrafficFilter: networkchaos.Spec.TrafficFilter,
+			Device:        networkchaos.Spec.Device,
 			RawRuleSource: v1alpha1.RawRuleSource{
 				Source: source,
 			},
 		})
 
 		targetsChains = append(targetsChains, v1alpha1.RawIptables{
-			Name:      iptable.GenerateName(pb.Chain_OUTPUT, networkchaos),
-			Direction: v1alpha1.Output,
-			IPSets:    []string{sourceSet.Name},
+			Name:          iptable.GenerateName(pb.Chain_OUTPUT, networkchaos),
+			Direction:     v1alpha1.Output,
+			IPSets:        []string{sourceSet.Name},
+			TrafficFilter: networkchaos.Spec.TrafficFilter,
+			Device:        networkchaos.Spec.Device,
 			RawRuleSource: v1alpha1.RawRuleSource{
 				Source: source,
 			},
@@ -155,46 +239,219 @@ func (e *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 
 	err = e.SetChains(ctx, sources, sourcesChains, m, networkchaos)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = e.SetChains(ctx, targets, targetsChains, m, networkchaos)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = m.Commit(ctx)
+	return allPods, nil
+
+}
+
+// prepareGroups prepares the ipsets and chains to cut the links between groups
+func (e *endpoint) prepareGroups(ctx context.Context, source string, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) ([]v1.Pod, error) {
+	groups := make([][]v1.Pod, len(networkchaos.Spec.Groups))
+	sets := make([]v1alpha1.RawIPSet, len(networkchaos.Spec.Groups))
+	allPods := []v1.Pod{}
+
+	// a pod selected by several groups only belongs to the first one
+	selected := make(map[types.NamespacedName]bool)
+	for i := range networkchaos.Spec.Groups {
+		pods, err := utils.SelectAndFilterPods(ctx, e.Client, e.Reader, &networkchaos.Spec.Groups[i].Target)
+		if err != nil {
+			e.Log.Error(err, "failed to select and filter pods", "group", networkchaos.Spec.Groups[i].Name)
+			return nil, err
+		}
+
+		for _, pod := range pods {
+			key := types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}
+			if selected[key] {
+				continue
+			}
+			selected[key] = true
+			groups[i] = append(groups[i], pod)
+		}
+		allPods = append(allPods, groups[i]...)
+
+		sets[i] = ipset.BuildIPSet(groups[i], []string{}, networkchaos, groupIPSetPostFix(i), source)
+	}
+
+	return allPods, e.setGroups(ctx, source, m, networkchaos, groups, sets, networkchaos.Spec.CutLinks())
+}
+
+// prepareZoneOutage prepares the ipsets and chains to isolate the pods in a zone from the other zones
+func (e *endpoint) prepareZoneOutage(ctx context.Context, source string, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) ([]v1.Pod, error) {
+	topology := networkchaos.Spec.Topology
+
+	pods, err := tracker.SelectPods(ctx, e.Client, e.Reader, networkchaos)
 	if err != nil {
-		// if pod is not found or not running, don't print error log and wait next time.
-		if err != podnetworkmanager.ErrPodNotFound && err != podnetworkmanager.ErrPodNotRunning {
-			e.Log.Error(err, "fail to commit")
+		e.Log.Error(err, "failed to select and filter pods")
+		return nil, err
+	}
+
+	zones, err := utils.GroupPodsByNodeLabel(ctx, e.Client, pods, topology.TopologyKey)
+	if err != nil {
+		e.Log.Error(err, "failed to group pods by nodes", "topologyKey", topology.TopologyKey)
+		return nil, err
+	}
+
+	zone, err := chooseZone(networkchaos, zones)
+	if err != nil {
+		return nil, err
+	}
+	networkchaos.Status.IsolatedZone = zone
+	e.Log.Info("isolate zone", "zone", zone, "topologyKey", topology.TopologyKey)
+
+	others := []v1.Pod{}
+	for _, name := range zoneNames(zones) {
+		if name != zone {
+			others = append(others, zones[name]...)
 		}
-		return err
 	}
 
-	networkchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(allPods))
-	for _, pod := range allPods {
-		ps := v1alpha1.PodStatus{
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			HostIP:    pod.Status.HostIP,
-			PodIP:     pod.Status.PodIP,
-			Action:    string(networkchaos.Spec.Action),
+	groups := [][]v1.Pod{zones[zone], others}
+	sets := []v1alpha1.RawIPSet{
+		ipset.BuildIPSet(groups[0], []string{}, networkchaos, sourceIPSetPostFix, source),
+		ipset.BuildIPSet(groups[1], []string{}, networkchaos, targetIPSetPostFix, source),
+	}
+	links := [][2]int{{0, 1}, {1, 0}}
+
+	if topology.IsolateExternalTargets {
+		groups = append(groups, nil)
+		sets = append(sets, ipset.BuildIPSet(nil, networkchaos.Spec.ExternalTargets, networkchaos, externalIPSetPostFix, source))
+		links = append(links, [2]int{0, 2}, [2]int{2, 0})
+	}
+
+	return append(groups[0], groups[1]...), e.setGroups(ctx, source, m, networkchaos, groups, sets, links)
+}
+
+// chooseZone returns the zone to isolate, a random zone is chosen if it's not specified
+func chooseZone(networkchaos *v1alpha1.NetworkChaos, zones map[string][]v1.Pod) (string, error) {
+	if zone := networkchaos.Spec.Topology.Zone; zone != "" {
+		if len(zones[zone]) == 0 {
+			return "", fmt.Errorf("no pod is selected in zone %s", zone)
 		}
+		return zone, nil
+	}
 
-		if networkchaos.Spec.Duration != nil {
-			ps.Message = fmt.Sprintf(networkPartitionActionMsg, *networkchaos.Spec.Duration)
+	// keep the zone chosen before
+	if zone := networkchaos.Status.IsolatedZone; zone != "" && len(zones[zone]) > 0 {
+		return zone, nil
+	}
+
+	// the pods not in any zone can't be isolated as a zone
+	names := []string{}
+	for _, name := range zoneNames(zones) {
+		if name != "" {
+			names = append(names, name)
 		}
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("no pod is selected in any zone of %s", networkchaos.Spec.Topology.TopologyKey)
+	}
 
-		networkchaos.Status.Experiment.PodRecords = append(networkchaos.Status.Experiment.PodRecords, ps)
+	return names[rand.Intn(len(names))], nil
+}
+
+// zoneNames returns the sorted names of zones
+func zoneNames(zones map[string][]v1.Pod) []string {
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setGroups sets the ipsets and chains in the pods of groups, the links are indexed by the position of groups
+func (e *endpoint) setGroups(ctx context.Context, source string, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos,
+	groups [][]v1.Pod, sets []v1alpha1.RawIPSet, links [][2]int) error {
+	allPods := []v1.Pod{}
+	for _, pods := range groups {
+		allPods = append(allPods, pods...)
+	}
+
+	for index := range allPods {
+		pod := allPods[index]
+
+		t := m.WithInit(types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		})
+		for _, set := range sets {
+			t.Append(set)
+		}
+	}
+
+	chains := buildGroupChains(networkchaos, sets, links, source)
+	e.Log.Info("chains prepared", "chains", chains)
+
+	for i := range groups {
+		err := e.SetChains(ctx, groups[i], chains[i], m, networkchaos)
+		if err != nil {
+			return err
+		}
 	}
 
-	e.Event(networkchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
 
+// groupIPSetPostFix returns the postfix of ipset name for the group
+func groupIPSetPostFix(index int) string {
+	return groupIPSetPostFixPrefix + strconv.Itoa(index)
+}
+
+// buildGroupChains builds the chains of every group, the packets to the groups cut from
+// a group are dropped on its output, and the packets from them are dropped on its input
+func buildGroupChains(networkchaos *v1alpha1.NetworkChaos, sets []v1alpha1.RawIPSet, links [][2]int, source string) [][]v1alpha1.RawIptables {
+	outputs := make([][]string, len(sets))
+	inputs := make([][]string, len(sets))
+	for _, link := range links {
+		from, to := link[0], link[1]
+		outputs[from] = append(outputs[from], sets[to].Name)
+		inputs[to] = append(inputs[to], sets[from].Name)
+	}
+
+	chains := make([][]v1alpha1.RawIptables, len(sets))
+	for i := range sets {
+		chains[i] = []v1alpha1.RawIptables{}
+		if len(outputs[i]) > 0 {
+			chains[i] = append(chains[i], v1alpha1.RawIptables{
+				Name:          iptable.GenerateName(pb.Chain_OUTPUT, networkchaos),
+				Direction:     v1alpha1.Output,
+				IPSets:        outputs[i],
+				TrafficFilter: networkchaos.Spec.TrafficFilter,
+				Device:        networkchaos.Spec.Device,
+				RawRuleSource: v1alpha1.RawRuleSource{
+					Source: source,
+				},
+			})
+		}
+		if len(inputs[i]) > 0 {
+			chains[i] = append(chains[i], v1alpha1.RawIptables{
+				Name:          iptable.GenerateName(pb.Chain_INPUT, networkchaos),
+				Direction:     v1alpha1.Input,
+				IPSets:        inputs[i],
+				TrafficFilter: networkchaos.Spec.TrafficFilter,
+				Device:        networkchaos.Spec.Device,
+				RawRuleSource: v1alpha1.RawRuleSource{
+					Source: source,
+				},
+			})
+		}
+	}
+
+	return chains
+}
+
 // SetChains sets iptables chains for pods
 func (e *endpoint) SetChains(ctx context.Context, pods []v1.Pod, chains []v1alpha1.RawIptables, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) error {
+	// the probability of reset and reject follows the current stage of profile
+	spec := networkchaos.ProfiledSpec()
+
 	for index := range pods {
 		pod := &pods[index]
 
@@ -208,6 +465,11 @@ func (e *endpoint) SetChains(ctx context.Context, pods []v1.Pod, chains []v1alph
 			Namespace: pod.Namespace,
 		})
 		for _, chain := range chains {
+			chain = withRejectTarget(chain, spec)
+			// nothing is rejected in the stage whose probability is 0
+			if probability, err := strconv.ParseFloat(chain.Probability, 32); err == nil && probability <= 0 {
+				continue
+			}
 			t.Append(chain)
 		}
 
@@ -217,6 +479,31 @@ func (e *endpoint) SetChains(ctx context.Context, pods []v1.Pod, chains []v1alph
 	return nil
 }
 
+// withRejectTarget makes the chain reject the packets rather than drop them for reset and reject action
+func withRejectTarget(chain v1alpha1.RawIptables, spec *v1alpha1.NetworkChaosSpec) v1alpha1.RawIptables {
+	switch spec.Action {
+	case v1alpha1.ResetAction:
+		chain.Target = v1alpha1.RejectTarget
+		chain.RejectWith = v1alpha1.TCPResetReply
+		// tcp reset can only be replied to the tcp packets
+		chain.Protocol = "tcp"
+	case v1alpha1.RejectAction:
+		chain.Target = v1alpha1.RejectTarget
+		chain.RejectWith = v1alpha1.ICMPPortUnreachableReply
+		if spec.Reject != nil && spec.Reject.RejectWith == v1alpha1.HostUnreachable {
+			chain.RejectWith = v1alpha1.ICMPHostUnreachableReply
+		}
+	default:
+		return chain
+	}
+
+	if spec.Reject != nil {
+		chain.Probability = spec.Reject.Probability
+	}
+
+	return chain
+}
+
 // Recover recovers the chaos
 func (e *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
 	networkchaos, ok := chaos.(*v1alpha1.NetworkChaos)
@@ -266,6 +553,16 @@ func (e *endpoint) cleanFinalizersAndRecover(ctx context.Context, networkchaos *
 			e.Log.Error(err, "fail to commit")
 		}
 
+		recoverErr := m.Errors[types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		}]
+		if recoverErr == podnetworkmanager.ErrPodNotFound {
+			// the pod is deleted, so there is nothing to recover
+			recoverErr = nil
+		}
+		networkchaos.Status.Experiment.SetPodRecovered(ns, name, recoverErr)
+
 		networkchaos.Finalizers = utils.RemoveFromFinalizer(networkchaos.Finalizers, key)
 	}
 	e.Log.Info("After recovering", "finalizers", networkchaos.Finalizers)
@@ -286,7 +583,9 @@ func init() {
 			return false
 		}
 
-		return chaos.Spec.Action == v1alpha1.PartitionAction
+		return chaos.Spec.Action == v1alpha1.PartitionAction ||
+			chaos.Spec.Action == v1alpha1.ResetAction ||
+			chaos.Spec.Action == v1alpha1.RejectAction
 	}, func(ctx ctx.Context) end.Endpoint {
 		return &endpoint{
 			Context: ctx,
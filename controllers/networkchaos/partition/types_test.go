@@ -0,0 +1,154 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestWithRejectTarget(t *testing.T) {
+	g := NewWithT(t)
+
+	chain := v1alpha1.RawIptables{
+		Name:      "chain",
+		Direction: v1alpha1.Output,
+		IPSets:    []string{"ipset"},
+	}
+
+	t.Run("partition", func(t *testing.T) {
+		result := withRejectTarget(chain, &v1alpha1.NetworkChaosSpec{Action: v1alpha1.PartitionAction})
+		g.Expect(result).To(Equal(chain))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		result := withRejectTarget(chain, &v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.ResetAction,
+			Reject: &v1alpha1.RejectSpec{Probability: "50"},
+		})
+		g.Expect(result.Target).To(Equal(v1alpha1.RejectTarget))
+		g.Expect(result.RejectWith).To(Equal(v1alpha1.TCPResetReply))
+		g.Expect(result.Protocol).To(Equal("tcp"))
+		g.Expect(result.Probability).To(Equal("50"))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		result := withRejectTarget(chain, &v1alpha1.NetworkChaosSpec{Action: v1alpha1.RejectAction})
+		g.Expect(result.Target).To(Equal(v1alpha1.RejectTarget))
+		g.Expect(result.RejectWith).To(Equal(v1alpha1.ICMPPortUnreachableReply))
+		g.Expect(result.Probability).To(BeEmpty())
+
+		result = withRejectTarget(chain, &v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.RejectAction,
+			Reject: &v1alpha1.RejectSpec{RejectWith: v1alpha1.HostUnreachable},
+		})
+		g.Expect(result.RejectWith).To(Equal(v1alpha1.ICMPHostUnreachableReply))
+	})
+}
+
+func TestBuildGroupChains(t *testing.T) {
+	g := NewWithT(t)
+
+	networkchaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.PartitionAction,
+			Groups: []v1alpha1.PartitionGroup{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		},
+	}
+	networkchaos.Name = "split-brain"
+	sets := []v1alpha1.RawIPSet{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+
+	ipsetsOf := func(chains []v1alpha1.RawIptables) map[v1alpha1.ChainDirection][]string {
+		result := map[v1alpha1.ChainDirection][]string{}
+		for _, chain := range chains {
+			result[chain.Direction] = chain.IPSets
+		}
+		return result
+	}
+
+	t.Run("all links", func(t *testing.T) {
+		chains := buildGroupChains(networkchaos, sets, networkchaos.Spec.CutLinks(), "default/split-brain")
+		g.Expect(chains).To(HaveLen(3))
+		g.Expect(ipsetsOf(chains[0])).To(Equal(map[v1alpha1.ChainDirection][]string{
+			v1alpha1.Output: {"b", "c"},
+			v1alpha1.Input:  {"b", "c"},
+		}))
+		g.Expect(ipsetsOf(chains[2])).To(Equal(map[v1alpha1.ChainDirection][]string{
+			v1alpha1.Output: {"a", "b"},
+			v1alpha1.Input:  {"a", "b"},
+		}))
+	})
+
+	t.Run("asymmetric links", func(t *testing.T) {
+		asymmetric := networkchaos.DeepCopy()
+		asymmetric.Spec.Links = []v1alpha1.PartitionLink{{From: "a", To: "b"}, {From: "c", To: "b"}}
+
+		chains := buildGroupChains(asymmetric, sets, asymmetric.Spec.CutLinks(), "default/split-brain")
+		g.Expect(ipsetsOf(chains[0])).To(Equal(map[v1alpha1.ChainDirection][]string{
+			v1alpha1.Output: {"b"},
+		}))
+		g.Expect(ipsetsOf(chains[1])).To(Equal(map[v1alpha1.ChainDirection][]string{
+			v1alpha1.Input: {"a", "c"},
+		}))
+		g.Expect(ipsetsOf(chains[2])).To(Equal(map[v1alpha1.ChainDirection][]string{
+			v1alpha1.Output: {"b"},
+		}))
+	})
+}
+
+func TestChooseZone(t *testing.T) {
+	g := NewWithT(t)
+
+	zones := map[string][]v1.Pod{
+		"az1": {{}},
+		"az2": {{}, {}},
+		"":    {{}},
+	}
+	networkchaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action:   v1alpha1.PartitionAction,
+			Topology: &v1alpha1.TopologySpec{TopologyKey: "zone"},
+		},
+	}
+
+	t.Run("specified zone", func(t *testing.T) {
+		chaos := networkchaos.DeepCopy()
+		chaos.Spec.Topology.Zone = "az2"
+		g.Expect(chooseZone(chaos, zones)).To(Equal("az2"))
+
+		chaos.Spec.Topology.Zone = "az3"
+		_, err := chooseZone(chaos, zones)
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("random zone", func(t *testing.T) {
+		chaos := networkchaos.DeepCopy()
+		zone, err := chooseZone(chaos, zones)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(zone).To(BeElementOf("az1", "az2"))
+
+		// the chosen zone is kept
+		chaos.Status.IsolatedZone = "az1"
+		for i := 0; i < 10; i++ {
+			g.Expect(chooseZone(chaos, zones)).To(Equal("az1"))
+		}
+
+		_, err = chooseZone(chaos, map[string][]v1.Pod{"": {{}}})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
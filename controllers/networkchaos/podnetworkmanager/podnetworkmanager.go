@@ -15,7 +15,10 @@ package podnetworkmanager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"sort"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"golang.org/x/sync/errgroup"
@@ -46,6 +49,10 @@ type PodNetworkManager struct {
 	client.Reader
 
 	Modifications map[types.NamespacedName]*PodNetworkTransaction
+
+	// Errors records the errors of the last commit on each pod
+	Errors     map[types.NamespacedName]error
+	errorsLock sync.Mutex
 }
 
 // New creates a new PodNetworkMap
@@ -56,6 +63,7 @@ func New(source string, logger logr.Logger, client client.Client, reader client.
 		Client:        client,
 		Reader:        reader,
 		Modifications: make(map[types.NamespacedName]*PodNetworkTransaction),
+		Errors:        make(map[types.NamespacedName]error),
 	}
 }
 
@@ -135,6 +143,10 @@ func (m *PodNetworkManager) Commit(ctx context.Context) error {
 
 				return m.Client.Update(ctx, chaos)
 			})
+			m.errorsLock.Lock()
+			m.Errors[key] = updateError
+			m.errorsLock.Unlock()
+
 			if updateError != nil {
 				if updateError != ErrPodNotFound && updateError != ErrPodNotRunning {
 					m.Log.Error(updateError, "error while updating")
@@ -150,3 +162,43 @@ func (m *PodNetworkManager) Commit(ctx context.Context) error {
 
 	return g.Wait()
 }
+
+// Plan renders the PodNetworkChaos of each pod with the modifications, without updating them
+// in the cluster
+func (m *PodNetworkManager) Plan(ctx context.Context) ([]v1alpha1.PodPlan, error) {
+	keys := make([]types.NamespacedName, 0, len(m.Modifications))
+	for key := range m.Modifications {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	plans := make([]v1alpha1.PodPlan, 0, len(keys))
+	for _, key := range keys {
+		chaos := &v1alpha1.PodNetworkChaos{}
+		err := m.Client.Get(ctx, key, chaos)
+		if err != nil && !k8sError.IsNotFound(err) {
+			m.Log.Error(err, "error while getting podnetworkchaos")
+			return nil, err
+		}
+
+		if err := m.Modifications[key].Apply(chaos); err != nil {
+			m.Log.Error(err, "error while applying transactions", "transaction", m.Modifications[key])
+			return nil, err
+		}
+
+		plan, err := json.Marshal(chaos.Spec)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+			Kind:      "PodNetworkChaos",
+			Plan:      string(plan),
+		})
+	}
+
+	return plans, nil
+}
@@ -29,10 +29,10 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/networkchaos/podnetworkmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
-	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/tracker"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 )
 
@@ -63,64 +63,12 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 	source := networkchaos.Namespace + "/" + networkchaos.Name
 	m := podnetworkmanager.New(source, r.Log, r.Client, r.Reader)
 
-	sources, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &networkchaos.Spec)
+	pods, err := r.prepare(ctx, m, networkchaos)
 	if err != nil {
-		r.Log.Error(err, "failed to select and filter pods")
-		return err
-	}
-
-	var targets []v1.Pod
-
-	// We should only apply filter when we specify targets
-	if networkchaos.Spec.Target != nil {
-		targets, err = utils.SelectAndFilterPods(ctx, r.Client, r.Reader, networkchaos.Spec.Target)
-		if err != nil {
-			r.Log.Error(err, "failed to select and filter pods")
-			return err
-		}
-	}
-
-	pods := append(sources, targets...)
-
-	externalCidrs, err := netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
-	if err != nil {
-		r.Log.Error(err, "failed to resolve external targets")
-		return err
-	}
-
-	switch networkchaos.Spec.Direction {
-	case v1alpha1.To:
-		err = r.applyTc(ctx, sources, targets, externalCidrs, m, networkchaos)
-		if err != nil {
-			r.Log.Error(err, "failed to apply traffic control", "sources", sources, "targets", targets)
-			return err
-		}
-	case v1alpha1.From:
-		err = r.applyTc(ctx, targets, sources, []string{}, m, networkchaos)
-		if err != nil {
-			r.Log.Error(err, "failed to apply traffic control", "sources", targets, "targets", sources)
-			return err
-		}
-	case v1alpha1.Both:
-		err = r.applyTc(ctx, pods, pods, externalCidrs, m, networkchaos)
-		if err != nil {
-			r.Log.Error(err, "failed to apply traffic control", "sources", pods, "targets", pods)
-			return err
-		}
-	default:
-		err = fmt.Errorf("unknown direction %s", networkchaos.Spec.Direction)
-		r.Log.Error(err, "unknown direction", "direction", networkchaos.Spec.Direction)
 		return err
 	}
 
 	err = m.Commit(ctx)
-	if err != nil {
-		// if pod is not found or not running, don't print error log and wait next time.
-		if err != podnetworkmanager.ErrPodNotFound && err != podnetworkmanager.ErrPodNotRunning {
-			r.Log.Error(err, "fail to commit")
-		}
-		return err
-	}
 
 	networkchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
 	for _, pod := range pods {
@@ -135,13 +83,82 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		if networkchaos.Spec.Duration != nil {
 			ps.Message = fmt.Sprintf(networkTcActionMsg, *networkchaos.Spec.Duration)
 		}
+		if len(pod.Status.ContainerStatuses) > 0 {
+			ps.ContainerIDs = []string{pod.Status.ContainerStatuses[0].ContainerID}
+		}
+		ps.SetInjected(m.Errors[types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}])
 
 		networkchaos.Status.Experiment.PodRecords = append(networkchaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		// if pod is not found or not running, don't print error log and wait next time.
+		if err != podnetworkmanager.ErrPodNotFound && err != podnetworkmanager.ErrPodNotRunning {
+			r.Log.Error(err, "fail to commit")
+		}
+		return err
+	}
 	r.Event(networkchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
 
+// Plan implements the endpoint.Planner.Plan
+func (r *endpoint) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	networkchaos, ok := chaos.(*v1alpha1.NetworkChaos)
+	if !ok {
+		err := errors.New("chaos is not NetworkChaos")
+		r.Log.Error(err, "chaos is not NetworkChaos", "chaos", chaos)
+		return nil, err
+	}
+
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+	m := podnetworkmanager.New(source, r.Log, r.Client, r.Reader)
+
+	if _, err := r.prepare(ctx, m, networkchaos); err != nil {
+		return nil, err
+	}
+
+	return m.Plan(ctx)
+}
+
+// prepare selects the pods and sets the traffic control on them in the manager,
+// it returns all the selected pods
+func (r *endpoint) prepare(ctx context.Context, m *podnetworkmanager.PodNetworkManager, networkchaos *v1alpha1.NetworkChaos) ([]v1.Pod, error) {
+	sources, err := tracker.SelectPods(ctx, r.Client, r.Reader, networkchaos)
+	if err != nil {
+		r.Log.Error(err, "failed to select and filter pods")
+		return nil, err
+	}
+
+	var targets []v1.Pod
+
+	// We should only apply filter when we specify targets
+	if networkchaos.Spec.Target != nil {
+		targets, err = utils.SelectAndFilterPods(ctx, r.Client, r.Reader, networkchaos.Spec.Target)
+		if err != nil {
+			r.Log.Error(err, "failed to select and filter pods")
+			return nil, err
+		}
+	}
+
+	switch networkchaos.Spec.Direction {
+	case v1alpha1.To, v1alpha1.From, v1alpha1.Both:
+		err = r.applyTc(ctx, sources, targets, networkchaos.Spec.ExternalTargets, m, networkchaos)
+		if err != nil {
+			r.Log.Error(err, "failed to apply traffic control", "sources", sources, "targets", targets)
+			return nil, err
+		}
+	default:
+		err = fmt.Errorf("unknown direction %s", networkchaos.Spec.Direction)
+		r.Log.Error(err, "unknown direction", "direction", networkchaos.Spec.Direction)
+		return nil, err
+	}
+
+	return append(sources, targets...), nil
+}
+
 // Recover implements the reconciler.InnerReconciler.Recover
 func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
 	networkchaos, ok := chaos.(*v1alpha1.NetworkChaos)
@@ -182,6 +199,16 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, networkchaos *
 			r.Log.Error(err, "fail to commit")
 		}
 
+		recoverErr := m.Errors[types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		}]
+		if recoverErr == podnetworkmanager.ErrPodNotFound {
+			// the pod is deleted, so there is nothing to recover
+			recoverErr = nil
+		}
+		networkchaos.Status.Experiment.SetPodRecovered(ns, name, recoverErr)
+
 		networkchaos.Finalizers = utils.RemoveFromFinalizer(networkchaos.Finalizers, key)
 	}
 	r.Log.Info("After recovering", "finalizers", networkchaos.Finalizers)
@@ -207,6 +234,9 @@ func (r *endpoint) applyTc(ctx context.Context, sources, targets []v1.Pod, exter
 		networkchaos.Finalizers = utils.InsertFinalizer(networkchaos.Finalizers, key)
 	}
 
+	// the parameters of traffic control follow the current stage of profile
+	spec := networkchaos.ProfiledSpec()
+
 	tcType := v1alpha1.Bandwidth
 	switch networkchaos.Spec.Action {
 	case v1alpha1.NetemAction, v1alpha1.DelayAction, v1alpha1.DuplicateAction, v1alpha1.CorruptAction, v1alpha1.LossAction:
@@ -217,7 +247,17 @@ func (r *endpoint) applyTc(ctx context.Context, sources, targets []v1.Pod, exter
 		return fmt.Errorf("unknown action %s", networkchaos.Spec.Action)
 	}
 
-	// if we don't specify targets, then sources pods apply traffic control on all egress traffic
+	// the traffic to targets is shaped on the egress of sources, and the traffic from targets
+	// is shaped on the ingress of sources
+	directions := []bool{}
+	if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
+		directions = append(directions, false)
+	}
+	if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
+		directions = append(directions, true)
+	}
+
+	// if we don't specify targets, then sources pods apply traffic control on all traffic
 	if len(targets)+len(externalTargets) == 0 {
 		r.Log.Info("apply traffic control", "sources", sources)
 		for index := range sources {
@@ -227,11 +267,16 @@ func (r *endpoint) applyTc(ctx context.Context, sources, targets []v1.Pod, exter
 				Name:      pod.Name,
 				Namespace: pod.Namespace,
 			})
-			t.Append(v1alpha1.RawTrafficControl{
-				Type:        tcType,
-				TcParameter: networkchaos.Spec.TcParameter,
-				Source:      m.Source,
-			})
+			for _, ingress := range directions {
+				t.Append(v1alpha1.RawTrafficControl{
+					Type:          tcType,
+					TcParameter:   spec.TcParameter,
+					TrafficFilter: networkchaos.Spec.TrafficFilter,
+					Ingress:       ingress,
+					Device:        networkchaos.Spec.Device,
+					Source:        m.Source,
+				})
+			}
 		}
 		return nil
 	}
@@ -248,12 +293,17 @@ func (r *endpoint) applyTc(ctx context.Context, sources, targets []v1.Pod, exter
 			Namespace: pod.Namespace,
 		})
 		t.Append(dstIpset)
-		t.Append(v1alpha1.RawTrafficControl{
-			Type:        tcType,
-			TcParameter: networkchaos.Spec.TcParameter,
-			Source:      m.Source,
-			IPSet:       dstIpset.Name,
-		})
+		for _, ingress := range directions {
+			t.Append(v1alpha1.RawTrafficControl{
+				Type:          tcType,
+				TcParameter:   spec.TcParameter,
+				TrafficFilter: networkchaos.Spec.TrafficFilter,
+				Ingress:       ingress,
+				Device:        networkchaos.Spec.Device,
+				Source:        m.Source,
+				IPSet:         dstIpset.Name,
+			})
+		}
 	}
 
 	return nil
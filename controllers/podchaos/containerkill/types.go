@@ -62,8 +62,10 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, obj v1alpha1.Inn
 		return err
 	}
 
+	errs := make([]error, len(pods))
 	g := errgroup.Group{}
 	for podIndex := range pods {
+		podIndex := podIndex
 		pod := &pods[podIndex]
 		haveContainer := false
 
@@ -74,12 +76,13 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, obj v1alpha1.Inn
 			if containerName == podchaos.Spec.ContainerName {
 				haveContainer = true
 				g.Go(func() error {
-					err = r.KillContainer(ctx, pod, containerID)
+					err := r.KillContainer(ctx, pod, containerID)
 					if err != nil {
 						r.Log.Error(err, fmt.Sprintf(
 							"failed to kill container: %s, pod: %s, namespace: %s",
 							containerName, pod.Name, pod.Namespace))
 					}
+					errs[podIndex] = err
 					return err
 				})
 			}
@@ -87,32 +90,47 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, obj v1alpha1.Inn
 
 		if haveContainer == false {
 			r.Log.Error(nil, fmt.Sprintf("the pod %s doesn't have container %s", pod.Name, podchaos.Spec.ContainerName))
+			errs[podIndex] = fmt.Errorf("the pod %s doesn't have container %s", pod.Name, podchaos.Spec.ContainerName)
 		}
 	}
 
-	if err := g.Wait(); err != nil {
-		return err
-	}
+	err = g.Wait()
 
 	podchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			HostIP:    pod.Status.HostIP,
-			PodIP:     pod.Status.PodIP,
-			Action:    string(podchaos.Spec.Action),
-			Message:   fmt.Sprintf(containerKillActionMsg, podchaos.Spec.ContainerName),
+			Namespace:    pod.Namespace,
+			Name:         pod.Name,
+			HostIP:       pod.Status.HostIP,
+			PodIP:        pod.Status.PodIP,
+			Action:       string(podchaos.Spec.Action),
+			Message:      fmt.Sprintf(containerKillActionMsg, podchaos.Spec.ContainerName),
+			ContainerIDs: utils.ContainerIDs(&pod, podchaos.Spec.ContainerName),
 		}
+		ps.SetInjected(errs[i])
 
 		podchaos.Status.Experiment.PodRecords = append(podchaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		return err
+	}
 	r.Event(obj, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
 
 // Recover implements the reconciler.InnerReconciler.Recover
 func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, obj v1alpha1.InnerObject) error {
+	podchaos, ok := obj.(*v1alpha1.PodChaos)
+	if !ok {
+		err := errors.New("chaos is not PodChaos")
+		r.Log.Error(err, "chaos is not PodChaos", "chaos", obj)
+		return err
+	}
+
+	// the killed containers are restarted by kubelet, so there is nothing to recover
+	for i := range podchaos.Status.Experiment.PodRecords {
+		podchaos.Status.Experiment.PodRecords[i].SetRecovered(nil)
+	}
 	return nil
 }
 
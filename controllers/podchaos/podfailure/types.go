@@ -68,13 +68,11 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		r.Log.Error(err, "failed to select and filter pods")
 		return err
 	}
-	err = r.failAllPods(ctx, pods, podchaos)
-	if err != nil {
-		return err
-	}
+	errs := make([]error, len(pods))
+	err = r.failAllPods(ctx, pods, podchaos, errs)
 
 	podchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
 			Namespace: pod.Namespace,
 			Name:      pod.Name,
@@ -85,8 +83,13 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		if podchaos.Spec.Duration != nil {
 			ps.Message = fmt.Sprintf(podFailureActionMsg, *podchaos.Spec.Duration)
 		}
+		ps.ContainerIDs = utils.ContainerIDs(&pod)
+		ps.SetInjected(errs[i])
 		podchaos.Status.Experiment.PodRecords = append(podchaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		return err
+	}
 	r.Event(podchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
@@ -132,11 +135,13 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, podchaos *v1al
 			}
 
 			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			podchaos.Status.Experiment.SetPodRecovered(ns, name, nil)
 			podchaos.Finalizers = utils.RemoveFromFinalizer(podchaos.Finalizers, key)
 			continue
 		}
 
 		err = r.recoverPod(ctx, &pod, podchaos)
+		podchaos.Status.Experiment.SetPodRecovered(ns, name, err)
 		if err != nil {
 			result = multierror.Append(result, err)
 			continue
@@ -154,9 +159,11 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, podchaos *v1al
 	return result
 }
 
-func (r *endpoint) failAllPods(ctx context.Context, pods []v1.Pod, podchaos *v1alpha1.PodChaos) error {
+// failAllPods injects pod-failure into the pods, the error of each pod is set in errs
+func (r *endpoint) failAllPods(ctx context.Context, pods []v1.Pod, podchaos *v1alpha1.PodChaos, errs []error) error {
 	g := errgroup.Group{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 
 		key, err := cache.MetaNamespaceKeyFunc(pod)
@@ -166,7 +173,8 @@ func (r *endpoint) failAllPods(ctx context.Context, pods []v1.Pod, podchaos *v1a
 		podchaos.Finalizers = utils.InsertFinalizer(podchaos.Finalizers, key)
 
 		g.Go(func() error {
-			return r.failPod(ctx, pod, podchaos)
+			errs[index] = r.failPod(ctx, pod, podchaos)
+			return errs[index]
 		})
 	}
 
@@ -216,19 +224,6 @@ func (r *endpoint) failPod(ctx context.Context, pod *v1.Pod, podchaos *v1alpha1.
 		return err
 	}
 
-	ps := v1alpha1.PodStatus{
-		Namespace: pod.Namespace,
-		Name:      pod.Name,
-		HostIP:    pod.Status.HostIP,
-		PodIP:     pod.Status.PodIP,
-		Action:    string(podchaos.Spec.Action),
-	}
-	if podchaos.Spec.Duration != nil {
-		ps.Message = fmt.Sprintf(podFailureActionMsg, *podchaos.Spec.Duration)
-	}
-
-	podchaos.Status.Experiment.PodRecords = append(podchaos.Status.Experiment.PodRecords, ps)
-
 	return nil
 }
 
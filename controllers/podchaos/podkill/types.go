@@ -52,8 +52,10 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 		return err
 	}
 
+	errs := make([]error, len(pods))
 	g := errgroup.Group{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 		g.Go(func() error {
 			r.Log.Info("Deleting", "namespace", pod.Namespace, "name", pod.Name)
@@ -62,28 +64,33 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 				GracePeriodSeconds: &podchaos.Spec.GracePeriod, // PeriodSeconds has to be set specifically
 			}); err != nil {
 				r.Log.Error(err, "unable to delete pod")
+				errs[index] = err
 				return err
 			}
 			return nil
 		})
 	}
 
-	if err := g.Wait(); err != nil {
-		return err
-	}
+	err = g.Wait()
+
 	podchaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-			HostIP:    pod.Status.HostIP,
-			PodIP:     pod.Status.PodIP,
-			Action:    string(podchaos.Spec.Action),
-			Message:   podKillActionMsg,
+			Namespace:    pod.Namespace,
+			Name:         pod.Name,
+			HostIP:       pod.Status.HostIP,
+			PodIP:        pod.Status.PodIP,
+			Action:       string(podchaos.Spec.Action),
+			Message:      podKillActionMsg,
+			ContainerIDs: utils.ContainerIDs(&pod),
 		}
+		ps.SetInjected(errs[i])
 
 		podchaos.Status.Experiment.PodRecords = append(podchaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		return err
+	}
 
 	r.Event(podchaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
@@ -91,6 +98,17 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 
 // Recover implements the reconciler.InnerReconciler.Recover
 func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, obj v1alpha1.InnerObject) error {
+	podchaos, ok := obj.(*v1alpha1.PodChaos)
+	if !ok {
+		err := errors.New("chaos is not PodChaos")
+		r.Log.Error(err, "chaos is not PodChaos", "chaos", obj)
+		return err
+	}
+
+	// the killed pods are recreated by their controllers, so there is nothing to recover
+	for i := range podchaos.Status.Experiment.PodRecords {
+		podchaos.Status.Experiment.PodRecords[i].SetRecovered(nil)
+	}
 	return nil
 }
 
@@ -31,26 +31,44 @@ const (
 	ipsetLen = 27
 )
 
-// BuildIPSet builds an ipset with provided pod ip list
-func BuildIPSet(pods []v1.Pod, externalCidrs []string, networkchaos *v1alpha1.NetworkChaos, namePostFix string, source string) v1alpha1.RawIPSet {
+// BuildIPSet builds an ipset with provided pod ip list and external targets,
+// the domains in external targets are resolved by chaos-daemon in the pods
+func BuildIPSet(pods []v1.Pod, externalTargets []string, networkchaos *v1alpha1.NetworkChaos, namePostFix string, source string) v1alpha1.RawIPSet {
 	name := GenerateIPSetName(networkchaos, namePostFix)
-	cidrs := externalCidrs
+	cidrs, domains := netutils.SplitTargets(externalTargets)
 
 	for _, pod := range pods {
-		if len(pod.Status.PodIP) > 0 {
-			cidrs = append(cidrs, netutils.IPToCidr(pod.Status.PodIP))
+		for _, ip := range podIPs(&pod) {
+			cidrs = append(cidrs, netutils.IPToCidr(ip))
 		}
 	}
 
 	return v1alpha1.RawIPSet{
-		Name:  name,
-		Cidrs: cidrs,
+		Name:    name,
+		Cidrs:   cidrs,
+		Domains: domains,
 		RawRuleSource: v1alpha1.RawRuleSource{
 			Source: source,
 		},
 	}
 }
 
+// podIPs returns all the IPs of pod, a dual-stack pod has both IPv4 and IPv6 addresses
+func podIPs(pod *v1.Pod) []string {
+	ips := []string{}
+	if len(pod.Status.PodIP) > 0 {
+		ips = append(ips, pod.Status.PodIP)
+	}
+
+	for _, podIP := range pod.Status.PodIPs {
+		if len(podIP.IP) > 0 && podIP.IP != pod.Status.PodIP {
+			ips = append(ips, podIP.IP)
+		}
+	}
+
+	return ips
+}
+
 // GenerateIPSetName generates name for ipset
 func GenerateIPSetName(networkchaos *v1alpha1.NetworkChaos, namePostFix string) string {
 	return netutils.CompressName(networkchaos.Name, 27, namePostFix)
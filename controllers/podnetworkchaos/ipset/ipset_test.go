@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmv1alpha1 "github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -52,3 +53,42 @@ func Test_generateIPSetName(t *testing.T) {
 		g.Expect(len(name)).Should(Equal(27))
 	})
 }
+
+func Test_buildIPSet(t *testing.T) {
+	g := NewWithT(t)
+
+	networkChaos := &cmv1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+
+	pods := []v1.Pod{
+		{
+			Status: v1.PodStatus{
+				PodIP: "172.16.0.1",
+			},
+		},
+		{
+			Status: v1.PodStatus{
+				PodIP: "172.16.0.2",
+				PodIPs: []v1.PodIP{
+					{IP: "172.16.0.2"},
+					{IP: "fd00::2"},
+				},
+			},
+		},
+		{
+			Status: v1.PodStatus{
+				PodIP: "fd00::3",
+			},
+		},
+		{},
+	}
+
+	ipset := BuildIPSet(pods, []string{"fd00:1::/64", "www.google.com"}, networkChaos, "tgt", "default/test")
+
+	g.Expect(ipset.Name).Should(Equal("test_tgt"))
+	g.Expect(ipset.Cidrs).Should(Equal([]string{"fd00:1::/64", "172.16.0.1/32", "172.16.0.2/32", "fd00::2/128", "fd00::3/128"}))
+	g.Expect(ipset.Domains).Should(Equal([]string{"www.google.com"}))
+}
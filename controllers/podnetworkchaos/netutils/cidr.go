@@ -15,55 +15,47 @@ package netutils
 
 import (
 	"net"
-	"strings"
 )
 
-// IPToCidr converts from an ip to a full mask cidr
+// IPToCidr converts from an ip to a full mask cidr, which is /32 for IPv4 and /128 for IPv6
 func IPToCidr(ip string) string {
-	// TODO: support IPv6
+	if parsed := net.ParseIP(ip); parsed != nil {
+		ip = parsed.String()
+	}
+
+	if IsIPv6(ip) {
+		return ip + "/128"
+	}
 	return ip + "/32"
 }
 
-// ResolveCidrs converts multiple cidrs/ips/domains into cidr
-func ResolveCidrs(names []string) ([]string, error) {
-	cidrs := []string{}
-	for _, target := range names {
-		// TODO: resolve ip on every pods but not in controller, in case the dns server of these pods differ
-		cidr, err := ResolveCidr(target)
+// IsIPv6 returns whether the ip or cidr is an IPv6 address
+func IsIPv6(ipOrCidr string) bool {
+	ip := net.ParseIP(ipOrCidr)
+	if ip == nil {
+		var err error
+		ip, _, err = net.ParseCIDR(ipOrCidr)
 		if err != nil {
-			return nil, err
+			return false
 		}
-
-		cidrs = append(cidrs, cidr...)
 	}
 
-	return cidrs, nil
+	return ip.To4() == nil
 }
 
-// ResolveCidr converts cidr/ip/domain into cidr
-func ResolveCidr(name string) ([]string, error) {
-	_, ipnet, err := net.ParseCIDR(name)
-	if err == nil {
-		return []string{ipnet.String()}, nil
-	}
-
-	if net.ParseIP(name) != nil {
-		return []string{IPToCidr(name)}, nil
-	}
-
-	addrs, err := net.LookupIP(name)
-	if err != nil {
-		return nil, err
-	}
-
-	cidrs := []string{}
-	for _, addr := range addrs {
-		addr := addr.String()
-
-		// TODO: support IPv6
-		if strings.Contains(addr, ".") {
-			cidrs = append(cidrs, IPToCidr(addr))
+// SplitTargets splits the targets into cidrs and domains, the ips are converted into cidrs.
+// The domains are resolved by chaos-daemon in the pods, in case the dns servers of these pods differ
+func SplitTargets(targets []string) ([]string, []string) {
+	cidrs, domains := []string{}, []string{}
+	for _, target := range targets {
+		if _, ipnet, err := net.ParseCIDR(target); err == nil {
+			cidrs = append(cidrs, ipnet.String())
+		} else if net.ParseIP(target) != nil {
+			cidrs = append(cidrs, IPToCidr(target))
+		} else {
+			domains = append(domains, target)
 		}
 	}
-	return cidrs, nil
+
+	return cidrs, domains
 }
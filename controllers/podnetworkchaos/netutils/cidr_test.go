@@ -0,0 +1,46 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netutils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_ipToCidr(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IPToCidr("172.16.0.1")).Should(Equal("172.16.0.1/32"))
+	g.Expect(IPToCidr("fd00::1")).Should(Equal("fd00::1/128"))
+	g.Expect(IPToCidr("::ffff:172.16.0.1")).Should(Equal("172.16.0.1/32"))
+}
+
+func Test_isIPv6(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IsIPv6("172.16.0.1")).Should(BeFalse())
+	g.Expect(IsIPv6("172.16.0.0/16")).Should(BeFalse())
+	g.Expect(IsIPv6("fd00::1")).Should(BeTrue())
+	g.Expect(IsIPv6("fd00::/64")).Should(BeTrue())
+	g.Expect(IsIPv6("www.google.com")).Should(BeFalse())
+}
+
+func Test_splitTargets(t *testing.T) {
+	g := NewWithT(t)
+
+	cidrs, domains := SplitTargets([]string{"172.16.0.1", "172.16.1.0/24", "fd00::1", "fd00:1::/64", "localhost", "www.google.com"})
+	g.Expect(cidrs).Should(Equal([]string{"172.16.0.1/32", "172.16.1.0/24", "fd00::1/128", "fd00:1::/64"}))
+	g.Expect(domains).Should(Equal([]string{"localhost", "www.google.com"}))
+}
@@ -16,6 +16,7 @@ package podnetworkchaos
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -86,8 +87,9 @@ func (h *Handler) SetIPSets(ctx context.Context, pod *corev1.Pod, chaos *v1alpha
 	ipsets := []*pb.IPSet{}
 	for _, ipset := range chaos.Spec.IPSets {
 		ipsets = append(ipsets, &pb.IPSet{
-			Name:  ipset.Name,
-			Cidrs: ipset.Cidrs,
+			Name:    ipset.Name,
+			Cidrs:   ipset.Cidrs,
+			Domains: ipset.Domains,
 		})
 	}
 	return ipset.FlushIPSets(ctx, h.Client, pod, ipsets)
@@ -107,11 +109,33 @@ func (h *Handler) SetIptables(ctx context.Context, pod *corev1.Pod, chaos *v1alp
 			h.Log.Error(err, "unknown direction")
 			return err
 		}
+
+		target := chain.Target
+		if target == "" {
+			target = v1alpha1.DropTarget
+		}
+
+		var probability float64
+		if chain.Probability != "" {
+			var err error
+			probability, err = strconv.ParseFloat(chain.Probability, 32)
+			if err != nil {
+				h.Log.Error(err, "invalid probability", "probability", chain.Probability)
+				return err
+			}
+		}
+
 		chains = append(chains, &pb.Chain{
-			Name:      chain.Name,
-			Ipsets:    chain.IPSets,
-			Direction: direction,
-			Target:    "DROP",
+			Name:            chain.Name,
+			Ipsets:          chain.IPSets,
+			Direction:       direction,
+			Target:          string(target),
+			Protocol:        chain.Protocol,
+			SourcePort:      chain.SourcePort,
+			DestinationPort: chain.DestinationPort,
+			Device:          chain.Device,
+			RejectWith:      chain.RejectWith,
+			Probability:     float32(probability),
 		})
 	}
 	return iptable.SetIptablesChains(ctx, h.Client, pod, chains)
@@ -127,9 +151,14 @@ func (h *Handler) SetTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.P
 				return err
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_BANDWIDTH,
-				Tbf:   tbf,
-				Ipset: tc.IPSet,
+				Type:            pb.Tc_BANDWIDTH,
+				Tbf:             tbf,
+				Ipset:           tc.IPSet,
+				Protocol:        tc.Protocol,
+				SourcePort:      tc.SourcePort,
+				DestinationPort: tc.DestinationPort,
+				Ingress:         tc.Ingress,
+				Device:          tc.Device,
 			})
 		} else if tc.Type == v1alpha1.Netem {
 			netem, err := mergeNetem(tc.TcParameter)
@@ -137,9 +166,14 @@ func (h *Handler) SetTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.P
 				return err
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_NETEM,
-				Netem: netem,
-				Ipset: tc.IPSet,
+				Type:            pb.Tc_NETEM,
+				Netem:           netem,
+				Ipset:           tc.IPSet,
+				Protocol:        tc.Protocol,
+				SourcePort:      tc.SourcePort,
+				DestinationPort: tc.DestinationPort,
+				Ingress:         tc.Ingress,
+				Device:          tc.Device,
 			})
 		} else {
 			return fmt.Errorf("unknown tc type")
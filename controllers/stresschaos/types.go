@@ -15,20 +15,15 @@ package stresschaos
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/hashicorp/go-multierror"
-	"golang.org/x/sync/errgroup"
 	v1 "k8s.io/api/core/v1"
-	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -63,13 +58,14 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 	}
 
 	stresschaos.Status.Instances = make(map[string]v1alpha1.StressInstance, len(pods))
-	if err = r.applyAllPods(ctx, pods, stresschaos); err != nil {
-		r.Log.Error(err, "failed to apply chaos on all pods")
-		return err
-	}
+	errs := make([]error, len(pods))
+	instancesLock := &sync.RWMutex{}
+	err = utils.ApplyAllPods(stresschaos, pods, errs, func(pod *v1.Pod) error {
+		return r.applyPod(ctx, pod, stresschaos, instancesLock)
+	})
 
 	stresschaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
 			Namespace: pod.Namespace,
 			Name:      pod.Name,
@@ -77,73 +73,84 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 			PodIP:     pod.Status.PodIP,
 			Message:   stressChaosMsg,
 		}
+		if target, err := utils.TargetContainer(&pod, stresschaos.Spec.ContainerName); err == nil {
+			ps.ContainerIDs = []string{target}
+		}
+		ps.SetInjected(errs[i])
 
 		stresschaos.Status.Experiment.PodRecords = append(stresschaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		r.Log.Error(err, "failed to apply chaos on all pods")
+		return err
+	}
 	r.Event(stresschaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
 
-// Recover means the reconciler recovers the chaos action
-func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+// Plan renders the requests to execute stressors on the selected pods
+func (r *endpoint) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
 	stresschaos, ok := chaos.(*v1alpha1.StressChaos)
 	if !ok {
-		err := errors.New("chaos is not StressChaos")
+		err := errors.New("chaos is not stresschaos")
 		r.Log.Error(err, "chaos is not StressChaos", "chaos", chaos)
-		return err
+		return nil, err
 	}
 
-	if err := r.cleanFinalizersAndRecover(ctx, stresschaos); err != nil {
-		return err
+	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &stresschaos.Spec)
+	if err != nil {
+		r.Log.Error(err, "failed to select and generate pods")
+		return nil, err
 	}
-	r.Event(stresschaos, v1.EventTypeNormal, utils.EventChaosRecovered, "")
 
-	return nil
-}
-
-func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha1.StressChaos) error {
-	var result error
+	stressors, err := stressorsOf(stresschaos)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, key := range chaos.Finalizers {
-		ns, name, err := cache.SplitMetaNamespaceKey(key)
+	plans := make([]v1alpha1.PodPlan, 0, len(pods))
+	for _, pod := range pods {
+		target, err := utils.TargetContainer(&pod, stresschaos.Spec.ContainerName)
 		if err != nil {
-			result = multierror.Append(result, err)
-			continue
+			return nil, err
 		}
 
-		var pod v1.Pod
-		err = r.Client.Get(ctx, types.NamespacedName{
-			Namespace: ns,
-			Name:      name,
-		}, &pod)
-
+		plan, err := json.Marshal(&pb.ExecStressRequest{
+			Scope:     pb.ExecStressRequest_CONTAINER,
+			Target:    target,
+			Stressors: stressors,
+		})
 		if err != nil {
-			if !k8serror.IsNotFound(err) {
-				result = multierror.Append(result, err)
-				continue
-			}
-
-			r.Log.Info("Pod not found", "namespace", ns, "name", name)
-			chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
-			continue
+			return nil, err
 		}
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Kind:      "ExecStressRequest",
+			Plan:      string(plan),
+		})
+	}
 
-		err = r.recoverPod(ctx, &pod, chaos)
-		if err != nil {
-			result = multierror.Append(result, err)
-			continue
-		}
+	return plans, nil
+}
 
-		chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
+// Recover means the reconciler recovers the chaos action
+func (r *endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	stresschaos, ok := chaos.(*v1alpha1.StressChaos)
+	if !ok {
+		err := errors.New("chaos is not StressChaos")
+		r.Log.Error(err, "chaos is not StressChaos", "chaos", chaos)
+		return err
 	}
 
-	if chaos.Annotations[common.AnnotationCleanFinalizer] == common.AnnotationCleanFinalizerForced {
-		r.Log.Info("Force cleanup all finalizers", "chaos", chaos)
-		chaos.Finalizers = chaos.Finalizers[:0]
-		return nil
+	if err := utils.CleanFinalizersAndRecover(ctx, r.Client, r.Log, stresschaos, func(pod *v1.Pod) error {
+		return r.recoverPod(ctx, pod, stresschaos)
+	}); err != nil {
+		return err
 	}
+	r.Event(stresschaos, v1.EventTypeNormal, utils.EventChaosRecovered, "")
 
-	return result
+	return nil
 }
 
 func (r *endpoint) recoverPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.StressChaos) error {
@@ -177,26 +184,6 @@ func (r *endpoint) Object() v1alpha1.InnerObject {
 	return &v1alpha1.StressChaos{}
 }
 
-func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.StressChaos) error {
-	g := errgroup.Group{}
-
-	instancesLock := &sync.RWMutex{}
-	for index := range pods {
-		pod := &pods[index]
-
-		key, err := cache.MetaNamespaceKeyFunc(pod)
-		if err != nil {
-			return err
-		}
-		chaos.Finalizers = utils.InsertFinalizer(chaos.Finalizers, key)
-
-		g.Go(func() error {
-			return r.applyPod(ctx, pod, chaos, instancesLock)
-		})
-	}
-	return g.Wait()
-}
-
 func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.StressChaos, instancesLock *sync.RWMutex) error {
 	r.Log.Info("Try to apply stress chaos", "namespace",
 		pod.Namespace, "name", pod.Name)
@@ -216,29 +203,14 @@ func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.St
 		return nil
 	}
 
-	if len(pod.Status.ContainerStatuses) == 0 {
-		return fmt.Errorf("%s %s can't get the state of container", pod.Namespace, pod.Name)
-	}
-	target := pod.Status.ContainerStatuses[0].ContainerID
-	if chaos.Spec.ContainerName != nil &&
-		len(strings.TrimSpace(*chaos.Spec.ContainerName)) != 0 {
-		target = ""
-		for _, container := range pod.Status.ContainerStatuses {
-			if container.Name == *chaos.Spec.ContainerName {
-				target = container.ContainerID
-			}
-		}
-		if len(target) == 0 {
-			return fmt.Errorf("cannot find container with name %s", *chaos.Spec.ContainerName)
-		}
+	target, err := utils.TargetContainer(pod, chaos.Spec.ContainerName)
+	if err != nil {
+		return err
 	}
 
-	stressors := chaos.Spec.StressngStressors
-	if len(stressors) == 0 {
-		stressors, err = chaos.Spec.Stressors.Normalize()
-		if err != nil {
-			return err
-		}
+	stressors, err := stressorsOf(chaos)
+	if err != nil {
+		return err
 	}
 	res, err := daemonClient.ExecStressors(ctx, &pb.ExecStressRequest{
 		Scope:     pb.ExecStressRequest_CONTAINER,
@@ -260,6 +232,14 @@ func (r *endpoint) applyPod(ctx context.Context, pod *v1.Pod, chaos *v1alpha1.St
 	return nil
 }
 
+// stressorsOf returns the arguments of stress-ng
+func stressorsOf(chaos *v1alpha1.StressChaos) (string, error) {
+	if len(chaos.Spec.StressngStressors) > 0 {
+		return chaos.Spec.StressngStressors, nil
+	}
+	return chaos.Spec.Stressors.Normalize()
+}
+
 func init() {
 	router.Register("stresschaos", &v1alpha1.StressChaos{}, func(obj runtime.Object) bool {
 		return true
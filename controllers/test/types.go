@@ -82,6 +82,30 @@ func (c *MockChaosDaemonClient) ApplyIoChaos(ctx context.Context, in *chaosdaemo
 	return nil, mockError("ApplyIoChaos")
 }
 
+func (c *MockChaosDaemonClient) ApplyHttpChaos(ctx context.Context, in *chaosdaemon.ApplyHttpChaosRequest, opts ...grpc.CallOption) (*chaosdaemon.ApplyHttpChaosResponse, error) {
+	return nil, mockError("ApplyHttpChaos")
+}
+
+func (c *MockChaosDaemonClient) SetDNSServer(ctx context.Context, in *chaosdaemon.SetDNSServerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("SetDNSServer")
+}
+
+func (c *MockChaosDaemonClient) ApplyDiskFill(ctx context.Context, in *chaosdaemon.DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("ApplyDiskFill")
+}
+
+func (c *MockChaosDaemonClient) RecoverDiskFill(ctx context.Context, in *chaosdaemon.DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("RecoverDiskFill")
+}
+
+func (c *MockChaosDaemonClient) ApplyIoThrottle(ctx context.Context, in *chaosdaemon.IoThrottleRequest, opts ...grpc.CallOption) (*chaosdaemon.IoThrottleResponse, error) {
+	return nil, mockError("ApplyIoThrottle")
+}
+
+func (c *MockChaosDaemonClient) RecoverIoThrottle(ctx context.Context, in *chaosdaemon.IoThrottleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("RecoverIoThrottle")
+}
+
 func (c *MockChaosDaemonClient) SetTcs(ctx context.Context, in *chaosdaemon.TcsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return nil, mockError("SetTcs")
 }
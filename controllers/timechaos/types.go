@@ -34,6 +34,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/pkg/router"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/tracker"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 )
 
@@ -55,20 +56,18 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 
 	timechaos.SetDefaultValue()
 
-	pods, err := utils.SelectAndFilterPods(ctx, r.Client, r.Reader, &timechaos.Spec)
+	pods, err := tracker.SelectPods(ctx, r.Client, r.Reader, timechaos)
 
 	if err != nil {
 		r.Log.Error(err, "failed to select and filter pods")
 		return err
 	}
 
-	if err = r.applyAllPods(ctx, pods, timechaos); err != nil {
-		r.Log.Error(err, "failed to apply chaos on all pods")
-		return err
-	}
+	errs := make([]error, len(pods))
+	err = r.applyAllPods(ctx, pods, timechaos, errs)
 
 	timechaos.Status.Experiment.PodRecords = make([]v1alpha1.PodStatus, 0, len(pods))
-	for _, pod := range pods {
+	for i, pod := range pods {
 		ps := v1alpha1.PodStatus{
 			Namespace: pod.Namespace,
 			Name:      pod.Name,
@@ -76,9 +75,15 @@ func (r *endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.I
 			PodIP:     pod.Status.PodIP,
 			Message:   fmt.Sprintf(timeChaosMsg, timechaos.Spec.TimeOffset),
 		}
+		ps.ContainerIDs = utils.ContainerIDs(&pod, timechaos.Spec.ContainerNames...)
+		ps.SetInjected(errs[i])
 
 		timechaos.Status.Experiment.PodRecords = append(timechaos.Status.Experiment.PodRecords, ps)
 	}
+	if err != nil {
+		r.Log.Error(err, "failed to apply chaos on all pods")
+		return err
+	}
 	r.Event(timechaos, v1.EventTypeNormal, utils.EventChaosInjected, "")
 	return nil
 }
@@ -123,11 +128,13 @@ func (r *endpoint) cleanFinalizersAndRecover(ctx context.Context, chaos *v1alpha
 			}
 
 			r.Log.Info("Pod not found", "namespace", ns, "name", name)
+			chaos.Status.Experiment.SetPodRecovered(ns, name, nil)
 			chaos.Finalizers = utils.RemoveFromFinalizer(chaos.Finalizers, key)
 			continue
 		}
 
 		err = r.recoverPod(ctx, &pod, chaos)
+		chaos.Status.Experiment.SetPodRecovered(ns, name, err)
 		if err != nil {
 			result = multierror.Append(result, err)
 			continue
@@ -199,9 +206,11 @@ func (r *endpoint) Object() v1alpha1.InnerObject {
 	return &v1alpha1.TimeChaos{}
 }
 
-func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.TimeChaos) error {
+// applyAllPods applies the chaos on the pods, the error of each pod is set in errs
+func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alpha1.TimeChaos, errs []error) error {
 	g := errgroup.Group{}
 	for index := range pods {
+		index := index
 		pod := &pods[index]
 
 		key, err := cache.MetaNamespaceKeyFunc(pod)
@@ -211,7 +220,8 @@ func (r *endpoint) applyAllPods(ctx context.Context, pods []v1.Pod, chaos *v1alp
 		chaos.Finalizers = utils.InsertFinalizer(chaos.Finalizers, key)
 
 		g.Go(func() error {
-			return r.applyPod(ctx, pod, chaos)
+			errs[index] = r.applyPod(ctx, pod, chaos)
+			return errs[index]
 		})
 	}
 
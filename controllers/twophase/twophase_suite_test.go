@@ -16,6 +16,7 @@ package twophase
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -27,7 +28,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -51,6 +54,7 @@ var _ = BeforeSuite(func(done Done) {
 	logf.SetLogger(zap.LoggerTo(GinkgoWriter, true))
 
 	Expect(addFakeToScheme(scheme.Scheme)).To(Succeed())
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 
 	close(done)
 }, 60)
@@ -101,6 +105,12 @@ type fakeTwoPhaseChaos struct {
 	// Next time when this action will be recovered
 	// +optional
 	NextRecover *metav1.Time `json:"nextRecover,omitempty"`
+
+	// Probes defines the steady-state probes
+	Probes []v1alpha1.ProbeSpec `json:"probes,omitempty"`
+
+	// Profile defines the time-varying fault profile
+	Profile *v1alpha1.ProfileSpec `json:"profile,omitempty"`
 }
 
 func (in *fakeTwoPhaseChaos) GetStatus() *v1alpha1.ChaosStatus {
@@ -117,6 +127,11 @@ func (in *fakeTwoPhaseChaos) IsPaused() bool {
 	return false
 }
 
+// IsDryRun returns whether this resource is in dry-run mode
+func (in *fakeTwoPhaseChaos) IsDryRun() bool {
+	return false
+}
+
 func (r fakeEndpoint) Object() v1alpha1.InnerObject {
 	return &fakeTwoPhaseChaos{}
 }
@@ -174,6 +189,14 @@ func (in *fakeTwoPhaseChaos) GetScheduler() *v1alpha1.SchedulerSpec {
 	return in.Scheduler
 }
 
+func (in *fakeTwoPhaseChaos) GetProbes() []v1alpha1.ProbeSpec {
+	return in.Probes
+}
+
+func (in *fakeTwoPhaseChaos) GetProfile() *v1alpha1.ProfileSpec {
+	return in.Profile
+}
+
 func (in *fakeTwoPhaseChaos) GetChaos() *v1alpha1.ChaosInstance {
 	return nil
 }
@@ -208,6 +231,18 @@ func (in *fakeTwoPhaseChaos) DeepCopyInto(out *fakeTwoPhaseChaos) {
 		*out = new(metav1.Time)
 		**out = **in
 	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]v1alpha1.ProbeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(v1alpha1.ProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 func (in *fakeTwoPhaseChaos) DeepCopy() *fakeTwoPhaseChaos {
@@ -474,5 +509,407 @@ var _ = Describe("TwoPhase", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("ApplyError"))
 		})
+
+		It("TwoPhase Abort", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			address := listener.Addr().String()
+			duration := "30m"
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 2h"},
+				Duration:   &duration,
+				Probes: []v1alpha1.ProbeSpec{{
+					Name: "tcp",
+					Type: v1alpha1.TCPProbeType,
+					TCP:  &v1alpha1.TCPProbe{Address: address},
+				}},
+			}
+
+			chaos.SetNextRecover(futureTime)
+			chaos.SetNextStart(pastTime)
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client:        c,
+					EventRecorder: &record.FakeRecorder{},
+					Log:           ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the steady state holds
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseRunning))
+			Expect(status.Probes).To(HaveLen(1))
+
+			// the probe is due and fails
+			Expect(listener.Close()).To(Succeed())
+			status.Probes[0].LastProbeTime = &metav1.Time{Time: pastTime}
+			Expect(c.Update(context.TODO(), _chaos)).To(Succeed())
+
+			// the probes run in background, so the chaos is aborted by a later reconciliation
+			Eventually(func() v1alpha1.ExperimentPhase {
+				_, err = r.Reconcile(req)
+				Expect(err).ToNot(HaveOccurred())
+				err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+				Expect(err).ToNot(HaveOccurred())
+				return _chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Experiment.Phase
+			}, 10*time.Second, 100*time.Millisecond).Should(Equal(v1alpha1.ExperimentPhaseAborted))
+			status = _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Reason).To(ContainSubstring("probe tcp failed"))
+
+			// an aborted chaos won't be started again
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(_chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseAborted))
+		})
+
+		It("TwoPhase Profile", func() {
+			duration := "3h"
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 4h"},
+				Duration:   &duration,
+				Profile: &v1alpha1.ProfileSpec{
+					Type:     v1alpha1.FlapProfileType,
+					Interval: "1h",
+				},
+			}
+
+			chaos.SetNextRecover(futureTime)
+			chaos.SetNextStart(pastTime)
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client:        c,
+					EventRecorder: &record.FakeRecorder{},
+					Log:           ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseRunning))
+			Expect(status.Profile).To(Equal(&v1alpha1.ProfileStatus{Stage: 0, Active: true}))
+
+			// the fault is off in the second stage of flap
+			startTime := time.Now().Add(-70 * time.Minute)
+			status.Experiment.StartTime = &metav1.Time{Time: startTime}
+			_chaos.(*fakeTwoPhaseChaos).SetNextStart(startTime.Add(4 * time.Hour))
+			Expect(c.Update(context.TODO(), _chaos)).To(Succeed())
+
+			result, err := r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 50*time.Minute, time.Minute))
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status = _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseRunning))
+			Expect(status.Profile).To(Equal(&v1alpha1.ProfileStatus{Stage: 1, Active: false}))
+
+			// the stage is kept if it fails to apply
+			startTime = time.Now().Add(-130 * time.Minute)
+			status.Experiment.StartTime = &metav1.Time{Time: startTime}
+			_chaos.(*fakeTwoPhaseChaos).SetNextStart(startTime.Add(4 * time.Hour))
+			Expect(c.Update(context.TODO(), _chaos)).To(Succeed())
+
+			defer mock.With("MockApplyError", errors.New("ApplyError"))()
+			_, err = r.Reconcile(req)
+			Expect(err).To(HaveOccurred())
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status = _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Profile.Stage).To(Equal(1))
+		})
+
+		It("TwoPhase History", func() {
+			duration := "1h"
+			historyLimit := int32(1)
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 2h", HistoryLimit: &historyLimit},
+				Duration:   &duration,
+			}
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client: c,
+					Log:    ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			// the failed round is retried with the same record
+			func() {
+				defer mock.With("MockApplyError", errors.New("ApplyError"))()
+				_, err = r.Reconcile(req)
+				Expect(err).To(HaveOccurred())
+				_, err = r.Reconcile(req)
+				Expect(err).To(HaveOccurred())
+			}()
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			history := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Scheduler.History
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Result).To(Equal(v1alpha1.ScheduleResultFailed))
+			Expect(history[0].Message).To(ContainSubstring("ApplyError"))
+
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			history = _chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Scheduler.History
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Result).To(Equal(v1alpha1.ScheduleResultRunning))
+			Expect(history[0].StartTime).ToNot(BeNil())
+
+			_chaos.(*fakeTwoPhaseChaos).SetNextRecover(pastTime)
+			Expect(c.Update(context.TODO(), _chaos)).To(Succeed())
+
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			history = _chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Scheduler.History
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].Result).To(Equal(v1alpha1.ScheduleResultSucceeded))
+			Expect(history[0].EndTime).ToNot(BeNil())
+		})
+
+		It("TwoPhase Suspend", func() {
+			duration := "1h"
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 2h", Suspend: true},
+				Duration:   &duration,
+			}
+			chaos.SetNextStart(pastTime)
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client: c,
+					Log:    ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			result, err := r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).ToNot(Equal(v1alpha1.ExperimentPhaseRunning))
+			Expect(status.Scheduler.History).To(BeEmpty())
+		})
+
+		It("TwoPhase StartingDeadline", func() {
+			duration := "1h"
+			deadline := int64(60)
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 2h", StartingDeadlineSeconds: &deadline},
+				Duration:   &duration,
+			}
+			chaos.SetNextStart(pastTime)
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client: c,
+					Log:    ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).ToNot(Equal(v1alpha1.ExperimentPhaseRunning))
+			Expect(status.Scheduler.History).To(HaveLen(1))
+			Expect(status.Scheduler.History[0].Result).To(Equal(v1alpha1.ScheduleResultSkipped))
+			// the next round is still on the schedule
+			nextStart := _chaos.(v1alpha1.InnerSchedulerObject).GetNextStart()
+			Expect(nextStart.After(time.Now())).To(BeTrue())
+			Expect(nextStart.Sub(pastTime.Truncate(time.Second)) % (2 * time.Hour)).To(BeZero())
+		})
+
+		It("TwoPhase ConcurrencyPolicy", func() {
+			duration := "3h"
+
+			newRunningChaos := func(policy v1alpha1.ConcurrencyPolicy) (*Reconciler, client.Client) {
+				chaos := fakeTwoPhaseChaos{
+					TypeMeta:   typeMeta,
+					ObjectMeta: objectMeta,
+					Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 1h", ConcurrencyPolicy: policy},
+					Duration:   &duration,
+				}
+				startTime := time.Now().Add(-70 * time.Minute)
+				chaos.Status.Experiment.Phase = v1alpha1.ExperimentPhaseRunning
+				chaos.Status.Experiment.StartTime = &metav1.Time{Time: startTime}
+				chaos.Status.Scheduler.History = []v1alpha1.ScheduleRecord{{
+					ScheduledTime: metav1.Time{Time: startTime},
+					StartTime:     &metav1.Time{Time: startTime},
+					Result:        v1alpha1.ScheduleResultRunning,
+				}}
+				chaos.SetNextStart(startTime.Add(time.Hour))
+				chaos.SetNextRecover(startTime.Add(3 * time.Hour))
+
+				c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos)
+				return &Reconciler{
+					Endpoint: fakeEndpoint{},
+					Context: ctx.Context{
+						Client: c,
+						Log:    ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+					},
+				}, c
+			}
+
+			getChaos := func(c client.Client) *fakeTwoPhaseChaos {
+				chaos := &fakeTwoPhaseChaos{}
+				Expect(c.Get(context.TODO(), req.NamespacedName, chaos)).To(Succeed())
+				return chaos
+			}
+
+			// the overlapped round is skipped
+			r, c := newRunningChaos(v1alpha1.ForbidConcurrent)
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			chaos := getChaos(c)
+			Expect(chaos.Status.Scheduler.History).To(HaveLen(2))
+			Expect(chaos.Status.Scheduler.History[0].Result).To(Equal(v1alpha1.ScheduleResultRunning))
+			Expect(chaos.Status.Scheduler.History[1].Result).To(Equal(v1alpha1.ScheduleResultSkipped))
+			Expect(chaos.GetNextStart().Before(chaos.GetNextRecover())).To(BeFalse())
+
+			// the running round is recovered and the new round is started
+			r, c = newRunningChaos(v1alpha1.ReplaceConcurrent)
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			chaos = getChaos(c)
+			Expect(chaos.Status.Scheduler.History).To(HaveLen(2))
+			Expect(chaos.Status.Scheduler.History[0].Result).To(Equal(v1alpha1.ScheduleResultReplaced))
+			Expect(chaos.Status.Scheduler.History[1].Result).To(Equal(v1alpha1.ScheduleResultRunning))
+			Expect(chaos.GetNextRecover()).To(BeTemporally("~", time.Now().Add(3*time.Hour), time.Minute))
+
+			// the rounds overlap
+			r, c = newRunningChaos(v1alpha1.AllowConcurrent)
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			chaos = getChaos(c)
+			Expect(chaos.Status.Scheduler.History).To(HaveLen(2))
+			Expect(chaos.Status.Scheduler.History[0].Result).To(Equal(v1alpha1.ScheduleResultRunning))
+			Expect(chaos.Status.Scheduler.History[1].Result).To(Equal(v1alpha1.ScheduleResultRunning))
+			Expect(chaos.GetNextStart()).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			Expect(chaos.GetNextRecover()).To(BeTemporally("~", time.Now().Add(3*time.Hour), time.Minute))
+		})
+
+		It("TwoPhase SchedulePolicy", func() {
+			duration := "1h"
+
+			chaos := fakeTwoPhaseChaos{
+				TypeMeta:   typeMeta,
+				ObjectMeta: objectMeta,
+				Scheduler:  &v1alpha1.SchedulerSpec{Cron: "@every 2h"},
+				Duration:   &duration,
+			}
+			chaos.SetNextStart(pastTime)
+
+			start := metav1.NewTime(time.Now().Add(time.Hour))
+			end := metav1.NewTime(time.Now().Add(2 * time.Hour))
+			policy := v1alpha1.ChaosSchedulePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "freeze"},
+				Spec: v1alpha1.ChaosSchedulePolicySpec{
+					Blackouts: []v1alpha1.TimeWindow{{Name: "release", Start: &start, End: &end}},
+				},
+			}
+
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, &chaos, &policy)
+
+			r := Reconciler{
+				Endpoint: fakeEndpoint{},
+				Context: ctx.Context{
+					Client:        c,
+					EventRecorder: &record.FakeRecorder{},
+					Log:           ctrl.Log.WithName("controllers").WithName("TwoPhase"),
+				},
+			}
+
+			// the chaos runs before the blackout
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			_chaos := r.Object()
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(_chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseRunning))
+
+			result, err := r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+			// the running chaos is recovered when the blackout starts
+			start.Time = time.Now().Add(-time.Minute)
+			Expect(c.Update(context.TODO(), &policy)).To(Succeed())
+
+			result, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 2*time.Hour, time.Minute))
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			status := _chaos.(v1alpha1.InnerSchedulerObject).GetStatus()
+			Expect(status.Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseWaiting))
+			Expect(status.Experiment.Reason).To(ContainSubstring("freeze"))
+			Expect(status.GetCondition(v1alpha1.ConditionBlocked).Reason).To(Equal("InBlackout"))
+			Expect(status.Scheduler.History).To(HaveLen(1))
+			Expect(status.Scheduler.History[0].Result).To(Equal(v1alpha1.ScheduleResultBlocked))
+
+			// the next round is deferred
+			_chaos.(*fakeTwoPhaseChaos).SetNextStart(pastTime)
+			Expect(c.Update(context.TODO(), _chaos)).To(Succeed())
+			_, err = r.Reconcile(req)
+			Expect(err).ToNot(HaveOccurred())
+			err = r.Client.Get(context.TODO(), req.NamespacedName, _chaos)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(_chaos.(v1alpha1.InnerSchedulerObject).GetStatus().Experiment.Phase).To(Equal(v1alpha1.ExperimentPhaseWaiting))
+		})
 	})
 })
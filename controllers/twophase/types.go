@@ -21,10 +21,14 @@ import (
 	"k8s.io/client-go/util/retry"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/probe"
+	"github.com/chaos-mesh/chaos-mesh/pkg/profile"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	"github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/schedulepolicy"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -45,6 +49,15 @@ func NewReconciler(e endpoint.Endpoint, ctx ctx.Context) *Reconciler {
 	}
 }
 
+// Recover recovers the chaos by the endpoint, and forgets the probes of chaos
+func (r *Reconciler) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if err := r.Endpoint.Recover(ctx, req, chaos); err != nil {
+		return err
+	}
+	probe.Forget(chaos)
+	return nil
+}
+
 // Reconcile is twophase reconcile implement
 func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	var err error
@@ -80,6 +93,19 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	status := chaos.GetStatus()
 
+	var block *schedulepolicy.Block
+	var blockChanged bool
+	var blockRequeueAfter time.Duration
+	if !chaos.IsDeleted() && !chaos.IsPaused() {
+		block, blockRequeueAfter, err = schedulepolicy.Check(ctx, r.Client, chaos, now)
+		if err != nil {
+			r.Log.Error(err, "failed to check the schedule policies")
+			updateFailedMessage(ctx, r, chaos, err.Error())
+			return ctrl.Result{Requeue: true}, err
+		}
+		blockChanged = schedulepolicy.SetBlocked(status, block)
+	}
+
 	if chaos.IsDeleted() {
 		// This chaos was deleted
 		r.Log.Info("Removing self")
@@ -113,6 +139,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 		status.Experiment.Phase = v1alpha1.ExperimentPhasePaused
 		status.FailedMessage = emptyString
+	} else if status.Experiment.Phase == v1alpha1.ExperimentPhaseAborted {
+		// An aborted chaos won't be started again until it's paused and resumed
+		r.Log.Info("The two phase chaos has been aborted", "name", req.Name, "namespace", req.Namespace)
+		return ctrl.Result{}, nil
 	} else if !chaos.GetNextRecover().IsZero() && chaos.GetNextRecover().Before(now) {
 		// Start recover
 		r.Log.Info("Recovering")
@@ -132,7 +162,39 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Time: time.Now(),
 		}
 		status.Experiment.Phase = v1alpha1.ExperimentPhaseWaiting
+		status.Scheduler.FinishRecords(v1alpha1.ScheduleResultSucceeded, "")
+		status.FailedMessage = emptyString
+	} else if block != nil {
+		r.Log.Info("Blocked by the schedule policy", "policy", block.Policy, "reason", block.Reason)
+
+		// The failed chaos may be injected into part of the pods, so it's also recovered
+		if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning ||
+			status.Experiment.Phase == v1alpha1.ExperimentPhaseFailed {
+			if err = r.Recover(ctx, req, chaos); err != nil {
+				r.Log.Error(err, "failed to recover chaos")
+				updateFailedMessage(ctx, r, chaos, err.Error())
+				return ctrl.Result{Requeue: true}, err
+			}
+			r.Event(chaos, v1.EventTypeWarning, schedulepolicy.EventChaosBlocked, block.Message)
+
+			now := time.Now()
+			status.Experiment.EndTime = &metav1.Time{Time: now}
+			if status.Experiment.StartTime != nil {
+				status.Experiment.Duration = now.Sub(status.Experiment.StartTime.Time).String()
+			}
+			status.Experiment.Phase = v1alpha1.ExperimentPhaseWaiting
+			status.Scheduler.FinishRecords(v1alpha1.ScheduleResultBlocked, block.Message)
+			chaos.SetNextRecover(time.Time{})
+		}
+		status.Experiment.Reason = block.Message
 		status.FailedMessage = emptyString
+
+		if err := r.Update(ctx, chaos); err != nil {
+			r.Log.Error(err, "unable to update chaos status")
+			return ctrl.Result{}, err
+		}
+		r.Log.Info("Requeue request", "after", blockRequeueAfter)
+		return ctrl.Result{RequeueAfter: blockRequeueAfter}, nil
 	} else if (status.Experiment.Phase == v1alpha1.ExperimentPhaseFailed ||
 		status.Experiment.Phase == v1alpha1.ExperimentPhasePaused) &&
 		!chaos.GetNextRecover().IsZero() && chaos.GetNextRecover().After(now) {
@@ -150,47 +212,161 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 		status.FailedMessage = emptyString
 	} else if chaos.GetNextStart().Before(now) {
-		r.Log.Info("Starting")
-
-		tempStart, err := utils.NextTime(*chaos.GetScheduler(), now)
-		if err != nil {
-			r.Log.Error(err, "failed to calculate the start time")
-			updateFailedMessage(ctx, r, chaos, err.Error())
-			return ctrl.Result{}, err
+		if scheduler.Suspend {
+			// The running round is still recovered on time, but no more round is started
+			r.Log.Info("The scheduler is suspended")
+			if !chaos.GetNextRecover().IsZero() {
+				return ctrl.Result{RequeueAfter: chaos.GetNextRecover().Sub(now)}, nil
+			}
+			return ctrl.Result{}, nil
 		}
 
-		tempRecover := now.Add(*duration)
-		if tempStart.Before(tempRecover) {
-			err := fmt.Errorf("nextRecover shouldn't be later than nextStart")
-			r.Log.Error(err, "Then recover can never be reached.", "scheduler", *chaos.GetScheduler(), "duration", *duration)
-			updateFailedMessage(ctx, r, chaos, err.Error())
-			return ctrl.Result{}, err
+		scheduledTime := chaos.GetNextStart()
+		if scheduledTime.IsZero() {
+			// This is the first round
+			scheduledTime = now
+			chaos.SetNextStart(now)
 		}
 
-		if err = applyAction(ctx, r, req, *duration, chaos); err != nil {
-			updateFailedMessage(ctx, r, chaos, err.Error())
-			return ctrl.Result{Requeue: true}, err
-		}
+		// The recover time has been checked above, so the round is still running if it's set
+		running := status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning && !chaos.GetNextRecover().IsZero()
+		policy := scheduler.GetConcurrencyPolicy()
 
-		nextStart, err := utils.NextTime(*chaos.GetScheduler(), status.Experiment.StartTime.Time)
-		if err != nil {
-			r.Log.Error(err, "failed to get the next start time")
-			return ctrl.Result{}, err
-		}
-		nextRecover := status.Experiment.StartTime.Time.Add(*duration)
+		if deadline := scheduler.GetStartingDeadline(); deadline > 0 && now.Sub(scheduledTime) > deadline {
+			r.Log.Info("Skipping the round which misses the starting deadline", "scheduledTime", scheduledTime)
+			if err = skipRound(chaos, scheduledTime, now, "missed the starting deadline"); err != nil {
+				r.Log.Error(err, "failed to get the next start time")
+				updateFailedMessage(ctx, r, chaos, err.Error())
+				return ctrl.Result{}, err
+			}
+		} else if running && policy == v1alpha1.ForbidConcurrent {
+			r.Log.Info("Skipping the round overlapped with the running round", "scheduledTime", scheduledTime)
+			if err = skipRound(chaos, scheduledTime, now, "the last round is still running"); err != nil {
+				r.Log.Error(err, "failed to get the next start time")
+				updateFailedMessage(ctx, r, chaos, err.Error())
+				return ctrl.Result{}, err
+			}
+		} else {
+			if running && policy == v1alpha1.ReplaceConcurrent {
+				r.Log.Info("Replacing the running round")
+				if err = r.Recover(ctx, req, chaos); err != nil {
+					r.Log.Error(err, "failed to recover chaos")
+					updateFailedMessage(ctx, r, chaos, err.Error())
+					return ctrl.Result{Requeue: true}, err
+				}
+				status.Scheduler.FinishRecords(v1alpha1.ScheduleResultReplaced, "")
+				chaos.SetNextRecover(time.Time{})
+			}
 
-		chaos.SetNextStart(*nextStart)
-		chaos.SetNextRecover(nextRecover)
-		status.FailedMessage = emptyString
+			if running && policy == v1alpha1.AllowConcurrent {
+				// The chaos is still injected, so the running round is just extended
+				r.Log.Info("Starting a round overlapped with the running round")
+				status.Experiment.StartTime = &metav1.Time{Time: now}
+				status.Experiment.Duration = duration.String()
+			} else {
+				r.Log.Info("Starting")
+				if err = applyAction(ctx, r, req, *duration, chaos); err != nil {
+					status.Scheduler.SetRecord(v1alpha1.ScheduleRecord{
+						ScheduledTime: metav1.NewTime(scheduledTime),
+						Result:        v1alpha1.ScheduleResultFailed,
+						Message:       err.Error(),
+					}, scheduler.GetHistoryLimit())
+					updateFailedMessage(ctx, r, chaos, err.Error())
+					return ctrl.Result{Requeue: true}, err
+				}
+			}
+
+			status.Scheduler.SetRecord(v1alpha1.ScheduleRecord{
+				ScheduledTime: metav1.NewTime(scheduledTime),
+				StartTime:     status.Experiment.StartTime.DeepCopy(),
+				Result:        v1alpha1.ScheduleResultRunning,
+			}, scheduler.GetHistoryLimit())
+
+			nextStart, err := scheduleNextStart(*scheduler, status.Experiment.StartTime.Time, *duration)
+			if err != nil {
+				r.Log.Error(err, "failed to get the next start time")
+				return ctrl.Result{}, err
+			}
+			nextRecover := status.Experiment.StartTime.Time.Add(*duration)
+
+			chaos.SetNextStart(*nextStart)
+			chaos.SetNextRecover(nextRecover)
+			status.FailedMessage = emptyString
+		}
 	} else {
 		r.Log.Info("Waiting")
 
-		nextStart, err := utils.NextTime(*chaos.GetScheduler(), status.Experiment.StartTime.Time)
+		if blockChanged && status.Experiment.Phase == v1alpha1.ExperimentPhaseWaiting {
+			// The chaos isn't blocked any more
+			status.Experiment.Reason = emptyString
+		}
+
+		var requeueAfter time.Duration
+		if status.Experiment.Phase == v1alpha1.ExperimentPhaseRunning {
+			var breached *v1alpha1.ProbeStatus
+			breached, requeueAfter = probe.Check(ctx, chaos, now)
+			if breached != nil {
+				if err = abort(ctx, r, req, chaos, breached); err != nil {
+					updateFailedMessage(ctx, r, chaos, err.Error())
+					return ctrl.Result{Requeue: true}, err
+				}
+			}
+
+			var changed bool
+			if breached == nil {
+				var profileRequeueAfter time.Duration
+				changed, profileRequeueAfter, err = profile.Step(ctx, r.Endpoint, req, chaos, now)
+				if err != nil {
+					r.Log.Error(err, "failed to change the stage of profile")
+					updateFailedMessage(ctx, r, chaos, err.Error())
+					return ctrl.Result{Requeue: true}, err
+				}
+				if profileRequeueAfter > 0 && (requeueAfter == 0 || profileRequeueAfter < requeueAfter) {
+					requeueAfter = profileRequeueAfter
+				}
+
+				if syncer, ok := r.Endpoint.(endpoint.Syncer); ok {
+					var synced bool
+					var syncRequeueAfter time.Duration
+					synced, syncRequeueAfter, err = syncer.Sync(ctx, req, chaos)
+					if err != nil {
+						r.Log.Error(err, "failed to sync the continuous chaos")
+						updateFailedMessage(ctx, r, chaos, err.Error())
+						return ctrl.Result{Requeue: true}, err
+					}
+					changed = changed || synced
+					if syncRequeueAfter > 0 && (requeueAfter == 0 || syncRequeueAfter < requeueAfter) {
+						requeueAfter = syncRequeueAfter
+					}
+				}
+			}
+
+			if breached != nil || changed || blockChanged || requeueAfter > 0 {
+				if err := r.Update(ctx, chaos); err != nil {
+					r.Log.Error(err, "unable to update chaos status")
+					return ctrl.Result{}, err
+				}
+			}
+			if breached != nil {
+				return ctrl.Result{}, nil
+			}
+		} else if blockChanged {
+			if err := r.Update(ctx, chaos); err != nil {
+				r.Log.Error(err, "unable to update chaos status")
+				return ctrl.Result{}, err
+			}
+		}
+
+		nextTime := chaos.GetNextStart()
+		nextStart, err := scheduleNextStart(*chaos.GetScheduler(), status.Experiment.StartTime.Time, *duration)
+		if err == nil && nextStart.Before(nextTime) {
+			// The rounds between them may be skipped
+			nextStart, err = utils.NextTimeNotBefore(*chaos.GetScheduler(), *nextStart, nextTime)
+		}
 		if err != nil {
 			r.Log.Error(err, "failed to get next start time")
 			return ctrl.Result{}, err
 		}
-		nextTime := chaos.GetNextStart()
 
 		// if nextStart is not equal to nextTime, the scheduler may have been modified.
 		// So set nextStart to time.Now.
@@ -199,6 +375,13 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 				nextTime = chaos.GetNextRecover()
 			}
 			duration := nextTime.Sub(now)
+			if requeueAfter > 0 && requeueAfter < duration {
+				duration = requeueAfter
+			}
+			// Requeue when a window of the schedule policies changes
+			if blockRequeueAfter > 0 && blockRequeueAfter < duration {
+				duration = blockRequeueAfter
+			}
 			r.Log.Info("Requeue request", "after", duration)
 
 			return ctrl.Result{RequeueAfter: duration}, nil
@@ -206,6 +389,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 		chaos.SetNextStart(time.Now())
 		duration := nextTime.Sub(now)
+		if blockRequeueAfter > 0 && blockRequeueAfter < duration {
+			duration = blockRequeueAfter
+		}
 		r.Log.Info("Requeue request", "after", duration)
 		return ctrl.Result{RequeueAfter: duration}, nil
 	}
@@ -231,6 +417,11 @@ func applyAction(
 	// Start to apply action
 	r.Log.Info("Performing Action")
 
+	if err := profile.Start(chaos); err != nil {
+		r.Log.Error(err, "failed to start the profile")
+		return err
+	}
+
 	if err := r.Apply(ctx, req, chaos); err != nil {
 		r.Log.Error(err, "failed to apply chaos action")
 
@@ -248,7 +439,72 @@ func applyAction(
 
 	status.Experiment.StartTime = &metav1.Time{Time: time.Now()}
 	status.Experiment.Phase = v1alpha1.ExperimentPhaseRunning
+	status.Experiment.Reason = emptyString
 	status.Experiment.Duration = duration.String()
+	status.Probes = nil
+	return nil
+}
+
+// abort recovers the chaos because the steady-state probe has failed
+func abort(
+	ctx context.Context,
+	r *Reconciler,
+	req ctrl.Request,
+	chaos v1alpha1.InnerSchedulerObject,
+	breached *v1alpha1.ProbeStatus,
+) error {
+	status := chaos.GetStatus()
+	r.Log.Info("Aborting", "probe", breached.Name, "message", breached.Message)
+
+	if err := r.Recover(ctx, req, chaos); err != nil {
+		r.Log.Error(err, "failed to abort chaos")
+		return err
+	}
+
+	reason := fmt.Sprintf("probe %s failed: %s", breached.Name, breached.Message)
+	r.Event(chaos, v1.EventTypeWarning, probe.EventChaosAborted, reason)
+
+	now := time.Now()
+	status.Experiment.EndTime = &metav1.Time{Time: now}
+	if status.Experiment.StartTime != nil {
+		status.Experiment.Duration = now.Sub(status.Experiment.StartTime.Time).String()
+	}
+	status.Experiment.Phase = v1alpha1.ExperimentPhaseAborted
+	status.Experiment.Reason = reason
+	status.Scheduler.FinishRecords(v1alpha1.ScheduleResultAborted, reason)
+	status.FailedMessage = emptyString
+	return nil
+}
+
+// scheduleNextStart returns the start time of the round after the one started at start.
+// The ticks during the round are skipped if the concurrent rounds are forbidden.
+func scheduleNextStart(scheduler v1alpha1.SchedulerSpec, start time.Time, duration time.Duration) (*time.Time, error) {
+	if scheduler.GetConcurrencyPolicy() == v1alpha1.ForbidConcurrent {
+		return utils.NextTimeNotBefore(scheduler, start, start.Add(duration))
+	}
+	return utils.NextTime(scheduler, start)
+}
+
+// skipRound records the round scheduled at scheduledTime as skipped, and moves the next
+// start time to the first tick after now
+func skipRound(chaos v1alpha1.InnerSchedulerObject, scheduledTime time.Time, now time.Time, message string) error {
+	scheduler := chaos.GetScheduler()
+
+	notBefore := now
+	if scheduler.GetConcurrencyPolicy() == v1alpha1.ForbidConcurrent && chaos.GetNextRecover().After(notBefore) {
+		notBefore = chaos.GetNextRecover()
+	}
+	nextStart, err := utils.NextTimeNotBefore(*scheduler, scheduledTime, notBefore)
+	if err != nil {
+		return err
+	}
+
+	chaos.GetStatus().Scheduler.SetRecord(v1alpha1.ScheduleRecord{
+		ScheduledTime: metav1.NewTime(scheduledTime),
+		Result:        v1alpha1.ScheduleResultSkipped,
+		Message:       message,
+	}, scheduler.GetHistoryLimit())
+	chaos.SetNextStart(*nextStart)
 	return nil
 }
 
@@ -0,0 +1,430 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+)
+
+const (
+	// LabelWorkflow is the label key of the chaos objects created by a workflow,
+	// whose value is the name of the workflow
+	LabelWorkflow = "chaos-mesh.org/workflow"
+
+	// EventWorkflowFailed is the event reason when the workflow fails
+	EventWorkflowFailed = "WorkflowFailed"
+)
+
+// Reconciler reconciles a Workflow object
+type Reconciler struct {
+	Scheme *runtime.Scheme
+	ctx.Context
+}
+
+// NewReconciler creates a new reconciler for workflow
+func NewReconciler(mgr ctrl.Manager) *Reconciler {
+	return &Reconciler{
+		Scheme: mgr.GetScheme(),
+		Context: ctx.Context{
+			Client:        mgr.GetClient(),
+			Reader:        mgr.GetAPIReader(),
+			EventRecorder: mgr.GetEventRecorderFor("workflow-controller"),
+			Log:           ctrl.Log.WithName("controllers").WithName("workflow"),
+		},
+	}
+}
+
+// SetupWithManager registers the controller to manager, and watches
+// all the chaos kinds owned by workflows
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Workflow{})
+	for _, kind := range v1alpha1.AllKinds() {
+		builder = builder.Owns(kind.Chaos.DeepCopyObject())
+	}
+	return builder.Complete(r)
+}
+
+// Reconcile expands the steps of the workflow and drives them forward
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	if !common.ControllerCfg.ClusterScoped && req.Namespace != common.ControllerCfg.TargetNamespace {
+		// NOOP
+		r.Log.Info("ignore workflow which belongs to an unexpected namespace within namespace scoped mode",
+			"workflowName", req.Name, "expectedNamespace", common.ControllerCfg.TargetNamespace, "actualNamespace", req.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	r.Log.Info("Reconciling a workflow", "name", req.Name, "namespace", req.Namespace)
+	c := context.Background()
+
+	workflow := &v1alpha1.Workflow{}
+	if err := r.Client.Get(c, req.NamespacedName, workflow); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("workflow not found")
+			return ctrl.Result{}, nil
+		}
+		r.Log.Error(err, "unable to get workflow")
+		return ctrl.Result{}, err
+	}
+
+	// The chaos objects are deleted by the garbage collector through the owner references
+	if workflow.IsDeleted() {
+		return ctrl.Result{}, nil
+	}
+
+	status := &workflow.Status
+	if status.Phase == v1alpha1.WorkflowPhaseSucceeded || status.Phase == v1alpha1.WorkflowPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	e := &executor{
+		Reconciler: r,
+		ctx:        c,
+		workflow:   workflow,
+		now:        time.Now(),
+	}
+
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: e.now}
+	}
+
+	var phase v1alpha1.WorkflowPhase
+	if err := workflow.Validate(); err != nil {
+		r.Log.Error(err, "invalid workflow")
+		r.Event(workflow, v1.EventTypeWarning, EventWorkflowFailed, err.Error())
+		phase = v1alpha1.WorkflowPhaseFailed
+	} else {
+		var err error
+		phase, err = e.run(workflow.Spec.Entry)
+		if err != nil {
+			r.Log.Error(err, "failed to run workflow")
+			r.Event(workflow, v1.EventTypeWarning, EventWorkflowFailed, err.Error())
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	status.Phase = phase
+	if phase == v1alpha1.WorkflowPhaseFailed {
+		e.abort()
+	}
+	if phase == v1alpha1.WorkflowPhaseSucceeded || phase == v1alpha1.WorkflowPhaseFailed {
+		status.EndTime = &metav1.Time{Time: e.now}
+	}
+
+	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &v1alpha1.Workflow{}
+		if err := r.Client.Get(c, req.NamespacedName, latest); err != nil {
+			return err
+		}
+		latest.Status = *status
+		return r.Client.Update(c, latest)
+	})
+	if updateError != nil {
+		r.Log.Error(updateError, "unable to update workflow status")
+		return ctrl.Result{}, updateError
+	}
+
+	return ctrl.Result{RequeueAfter: e.requeueAfter}, nil
+}
+
+// executor runs the steps of a workflow once in a reconcile
+type executor struct {
+	*Reconciler
+
+	ctx      context.Context
+	workflow *v1alpha1.Workflow
+	now      time.Time
+
+	// requeueAfter is the shortest time to wait until a running step ends
+	requeueAfter time.Duration
+}
+
+// run drives the template forward and returns its current phase
+func (e *executor) run(name string) (v1alpha1.WorkflowPhase, error) {
+	template := e.workflow.GetTemplate(name)
+	if template == nil {
+		return "", fmt.Errorf("template %s not found", name)
+	}
+
+	status := &e.workflow.Status
+	node := status.GetNode(name)
+	if node == nil {
+		status.Nodes = append(status.Nodes, v1alpha1.WorkflowNodeStatus{
+			Name:      name,
+			Type:      template.Type,
+			Phase:     v1alpha1.WorkflowPhaseRunning,
+			StartTime: &metav1.Time{Time: e.now},
+		})
+		node = status.GetNode(name)
+	}
+	if node.Phase == v1alpha1.WorkflowPhaseSucceeded || node.Phase == v1alpha1.WorkflowPhaseFailed {
+		return node.Phase, nil
+	}
+
+	var phase v1alpha1.WorkflowPhase
+	var err error
+	switch template.Type {
+	case v1alpha1.TemplateTypeSerial:
+		phase, err = e.runSerial(template)
+	case v1alpha1.TemplateTypeParallel:
+		phase, err = e.runParallel(template)
+	case v1alpha1.TemplateTypeSuspend:
+		phase, err = e.runSuspend(template, node)
+	case v1alpha1.TemplateTypeChaos:
+		phase, err = e.runChaos(template, node)
+	default:
+		err = fmt.Errorf("unknown template type %s", template.Type)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// the children may have appended to the nodes, so the node should be fetched again
+	node = status.GetNode(name)
+	node.Phase = phase
+	if phase == v1alpha1.WorkflowPhaseSucceeded || phase == v1alpha1.WorkflowPhaseFailed {
+		node.EndTime = &metav1.Time{Time: e.now}
+	}
+
+	return phase, nil
+}
+
+// runSerial starts the next child after the previous one succeeded
+func (e *executor) runSerial(template *v1alpha1.Template) (v1alpha1.WorkflowPhase, error) {
+	for _, child := range template.Children {
+		phase, err := e.run(child)
+		if err != nil {
+			return "", err
+		}
+		if phase != v1alpha1.WorkflowPhaseSucceeded {
+			return phase, nil
+		}
+	}
+
+	return v1alpha1.WorkflowPhaseSucceeded, nil
+}
+
+// runParallel starts all the children at the same time
+func (e *executor) runParallel(template *v1alpha1.Template) (v1alpha1.WorkflowPhase, error) {
+	result := v1alpha1.WorkflowPhaseSucceeded
+	for _, child := range template.Children {
+		phase, err := e.run(child)
+		if err != nil {
+			return "", err
+		}
+		switch phase {
+		case v1alpha1.WorkflowPhaseFailed:
+			result = v1alpha1.WorkflowPhaseFailed
+		case v1alpha1.WorkflowPhaseRunning:
+			if result != v1alpha1.WorkflowPhaseFailed {
+				result = v1alpha1.WorkflowPhaseRunning
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// runSuspend waits until the duration passed
+func (e *executor) runSuspend(template *v1alpha1.Template, node *v1alpha1.WorkflowNodeStatus) (v1alpha1.WorkflowPhase, error) {
+	if e.waitFor(template, node) {
+		return v1alpha1.WorkflowPhaseRunning, nil
+	}
+	return v1alpha1.WorkflowPhaseSucceeded, nil
+}
+
+// runChaos creates the chaos object, and deletes it to recover the chaos after the duration
+func (e *executor) runChaos(template *v1alpha1.Template, node *v1alpha1.WorkflowNodeStatus) (v1alpha1.WorkflowPhase, error) {
+	if node.ChaosRef == "" {
+		name, err := e.createChaos(template)
+		if err != nil {
+			return "", err
+		}
+		node.ChaosRef = name
+	}
+
+	chaos, err := template.EmbedChaos.NewChaos()
+	if err != nil {
+		return "", err
+	}
+	key := types.NamespacedName{
+		Namespace: e.workflow.Namespace,
+		Name:      node.ChaosRef,
+	}
+	if err := e.Client.Get(e.ctx, key, chaos); err != nil {
+		if apierrors.IsNotFound(err) {
+			node.Message = fmt.Sprintf("chaos %s has been deleted", node.ChaosRef)
+			return v1alpha1.WorkflowPhaseFailed, nil
+		}
+		return "", err
+	}
+
+	status := chaos.GetStatus()
+	node.ChaosPhase = status.Experiment.Phase
+	if node.ChaosPhase == v1alpha1.ExperimentPhaseFailed {
+		node.Message = status.FailedMessage
+		if err := e.deleteChaos(chaos); err != nil {
+			return "", err
+		}
+		return v1alpha1.WorkflowPhaseFailed, nil
+	}
+
+	if e.waitFor(template, node) {
+		return v1alpha1.WorkflowPhaseRunning, nil
+	}
+
+	if err := e.deleteChaos(chaos); err != nil {
+		return "", err
+	}
+	return v1alpha1.WorkflowPhaseSucceeded, nil
+}
+
+// waitFor returns true if the duration of the template hasn't passed,
+// and makes sure the workflow will be reconciled again when it ends
+func (e *executor) waitFor(template *v1alpha1.Template, node *v1alpha1.WorkflowNodeStatus) bool {
+	duration, err := template.GetDuration()
+	if err != nil || duration == nil {
+		return false
+	}
+
+	left := node.StartTime.Add(*duration).Sub(e.now)
+	if left <= 0 {
+		return false
+	}
+	if e.requeueAfter == 0 || left < e.requeueAfter {
+		e.requeueAfter = left
+	}
+	return true
+}
+
+// createChaos creates the chaos object of the template, and returns its name
+func (e *executor) createChaos(template *v1alpha1.Template) (string, error) {
+	chaos, err := template.EmbedChaos.NewChaos()
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s", e.workflow.Name, template.Name)
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return "", err
+	}
+	accessor.SetName(name)
+	accessor.SetNamespace(e.workflow.Namespace)
+	accessor.SetLabels(map[string]string{
+		LabelWorkflow: e.workflow.Name,
+	})
+	if err := controllerutil.SetControllerReference(e.workflow, accessor, e.Scheme); err != nil {
+		return "", err
+	}
+
+	e.Log.Info("creating chaos", "kind", template.EmbedChaos.Kind, "name", name)
+	err = e.Client.Create(e.ctx, chaos)
+	if apierrors.IsAlreadyExists(err) {
+		// the chaos may be created in the last reconciliation whose status failed to update,
+		// but an object with the same name which isn't owned by the workflow must not be adopted
+		err = e.checkOwned(template, name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// checkOwned returns an error if the existing chaos isn't controlled by the workflow
+func (e *executor) checkOwned(template *v1alpha1.Template, name string) error {
+	existing, err := template.EmbedChaos.NewChaos()
+	if err != nil {
+		return err
+	}
+	if err := e.Client.Get(e.ctx, types.NamespacedName{
+		Namespace: e.workflow.Namespace,
+		Name:      name,
+	}, existing); err != nil {
+		return err
+	}
+
+	accessor, err := meta.Accessor(existing)
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(accessor, e.workflow) {
+		return fmt.Errorf("%s %s already exists and isn't owned by workflow %s",
+			template.EmbedChaos.Kind, name, e.workflow.Name)
+	}
+	return nil
+}
+
+// deleteChaos deletes the chaos object, whose finalizers will recover the chaos
+func (e *executor) deleteChaos(chaos v1alpha1.InnerSchedulerObject) error {
+	e.Log.Info("deleting chaos", "name", chaos.GetChaos().Name)
+	if err := e.Client.Delete(e.ctx, chaos); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// abort stops all the running chaos steps after the workflow failed
+func (e *executor) abort() {
+	for i := range e.workflow.Status.Nodes {
+		node := &e.workflow.Status.Nodes[i]
+		if node.Phase != v1alpha1.WorkflowPhaseRunning {
+			continue
+		}
+
+		node.Phase = v1alpha1.WorkflowPhaseFailed
+		node.EndTime = &metav1.Time{Time: e.now}
+		if node.Message == "" {
+			node.Message = "aborted because the workflow failed"
+		}
+
+		if node.ChaosRef == "" {
+			continue
+		}
+		template := e.workflow.GetTemplate(node.Name)
+		if template == nil || template.EmbedChaos == nil {
+			continue
+		}
+		chaos, err := template.EmbedChaos.NewChaos()
+		if err != nil {
+			continue
+		}
+		accessor, err := meta.Accessor(chaos)
+		if err != nil {
+			continue
+		}
+		accessor.SetName(node.ChaosRef)
+		accessor.SetNamespace(e.workflow.Namespace)
+		if err := e.deleteChaos(chaos); err != nil {
+			e.Log.Error(err, "failed to delete chaos", "name", node.ChaosRef)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+)
+
+func TestWorkflow(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecsWithDefaultAndCustomReporters(t,
+		"Workflow Suite",
+		[]Reporter{envtest.NewlineReporter{}})
+}
+
+var _ = BeforeSuite(func(done Done) {
+	logf.SetLogger(zap.LoggerTo(GinkgoWriter, true))
+
+	Expect(v1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+
+	close(done)
+}, 60)
+
+var _ = AfterSuite(func() {
+})
+
+var _ = Describe("Workflow", func() {
+	Context("Reconcile", func() {
+		duration := "1h"
+		key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "workflow"}
+		chaosKey := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: "workflow-kill"}
+
+		newReconciler := func(workflow *v1alpha1.Workflow) *Reconciler {
+			return &Reconciler{
+				Scheme: scheme.Scheme,
+				Context: ctx.Context{
+					Client:        fake.NewFakeClientWithScheme(scheme.Scheme, workflow),
+					EventRecorder: &record.FakeRecorder{},
+					Log:           ctrl.Log.WithName("controllers").WithName("workflow"),
+				},
+			}
+		}
+
+		// rewind moves the start time of the node back, as if the duration of the step has passed
+		rewind := func(r *Reconciler, name string) {
+			workflow := &v1alpha1.Workflow{}
+			Expect(r.Client.Get(context.TODO(), key, workflow)).To(Succeed())
+			node := workflow.Status.GetNode(name)
+			Expect(node).NotTo(BeNil())
+			node.StartTime = &metav1.Time{Time: node.StartTime.Add(-2 * time.Hour)}
+			Expect(r.Client.Update(context.TODO(), workflow)).To(Succeed())
+		}
+
+		getWorkflow := func(r *Reconciler) *v1alpha1.Workflow {
+			workflow := &v1alpha1.Workflow{}
+			Expect(r.Client.Get(context.TODO(), key, workflow)).To(Succeed())
+			return workflow
+		}
+
+		newWorkflow := func(entry v1alpha1.Template) *v1alpha1.Workflow {
+			return &v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: key.Namespace,
+					Name:      key.Name,
+				},
+				Spec: v1alpha1.WorkflowSpec{
+					Entry: "entry",
+					Templates: []v1alpha1.Template{
+						entry,
+						{
+							Name:     "kill",
+							Type:     v1alpha1.TemplateTypeChaos,
+							Duration: &duration,
+							EmbedChaos: &v1alpha1.EmbedChaos{
+								Kind: v1alpha1.KindPodChaos,
+								Spec: runtime.RawExtension{Raw: []byte(`{"action":"pod-kill","mode":"one","scheduler":{"cron":"@every 5s"}}`)},
+							},
+						},
+						{
+							Name:     "wait",
+							Type:     v1alpha1.TemplateTypeSuspend,
+							Duration: &duration,
+						},
+					},
+				},
+			}
+		}
+
+		It("Serial", func() {
+			r := newReconciler(newWorkflow(v1alpha1.Template{
+				Name:     "entry",
+				Type:     v1alpha1.TemplateTypeSerial,
+				Children: []string{"kill", "wait"},
+			}))
+
+			result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			chaos := &v1alpha1.PodChaos{}
+			Expect(r.Client.Get(context.TODO(), chaosKey, chaos)).To(Succeed())
+			Expect(chaos.Labels[LabelWorkflow]).To(Equal(key.Name))
+			Expect(chaos.OwnerReferences).To(HaveLen(1))
+
+			workflow := getWorkflow(r)
+			Expect(workflow.Status.Phase).To(Equal(v1alpha1.WorkflowPhaseRunning))
+			Expect(workflow.Status.GetNode("kill").ChaosRef).To(Equal(chaosKey.Name))
+			Expect(workflow.Status.GetNode("wait")).To(BeNil())
+
+			rewind(r, "kill")
+			_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = r.Client.Get(context.TODO(), chaosKey, chaos)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			workflow = getWorkflow(r)
+			Expect(workflow.Status.GetNode("kill").Phase).To(Equal(v1alpha1.WorkflowPhaseSucceeded))
+			Expect(workflow.Status.GetNode("wait").Phase).To(Equal(v1alpha1.WorkflowPhaseRunning))
+
+			rewind(r, "wait")
+			_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+
+			workflow = getWorkflow(r)
+			Expect(workflow.Status.Phase).To(Equal(v1alpha1.WorkflowPhaseSucceeded))
+			Expect(workflow.Status.EndTime).NotTo(BeNil())
+		})
+
+		It("Conflict", func() {
+			r := newReconciler(newWorkflow(v1alpha1.Template{
+				Name:     "entry",
+				Type:     v1alpha1.TemplateTypeSerial,
+				Children: []string{"kill"},
+			}))
+
+			// an unrelated chaos which happens to have the same name
+			existing := &v1alpha1.PodChaos{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: chaosKey.Namespace,
+					Name:      chaosKey.Name,
+				},
+			}
+			Expect(r.Client.Create(context.TODO(), existing)).To(Succeed())
+
+			result, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			chaos := &v1alpha1.PodChaos{}
+			Expect(r.Client.Get(context.TODO(), chaosKey, chaos)).To(Succeed())
+			Expect(chaos.OwnerReferences).To(BeEmpty())
+			Expect(getWorkflow(r).Status.GetNode("kill")).To(BeNil())
+		})
+
+		It("Parallel", func() {
+			r := newReconciler(newWorkflow(v1alpha1.Template{
+				Name:     "entry",
+				Type:     v1alpha1.TemplateTypeParallel,
+				Children: []string{"kill", "wait"},
+			}))
+
+			_, err := r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+
+			workflow := getWorkflow(r)
+			Expect(workflow.Status.GetNode("kill").Phase).To(Equal(v1alpha1.WorkflowPhaseRunning))
+			Expect(workflow.Status.GetNode("wait").Phase).To(Equal(v1alpha1.WorkflowPhaseRunning))
+
+			// the chaos is deleted by someone else, so the workflow fails
+			chaos := &v1alpha1.PodChaos{}
+			Expect(r.Client.Get(context.TODO(), chaosKey, chaos)).To(Succeed())
+			Expect(r.Client.Delete(context.TODO(), chaos)).To(Succeed())
+
+			_, err = r.Reconcile(ctrl.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+
+			workflow = getWorkflow(r)
+			Expect(workflow.Status.Phase).To(Equal(v1alpha1.WorkflowPhaseFailed))
+			Expect(workflow.Status.GetNode("kill").Phase).To(Equal(v1alpha1.WorkflowPhaseFailed))
+			Expect(workflow.Status.GetNode("wait").Phase).To(Equal(v1alpha1.WorkflowPhaseFailed))
+		})
+	})
+})
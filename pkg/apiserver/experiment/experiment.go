@@ -49,6 +49,7 @@ type ChaosState struct {
 	Paused   int `json:"Paused"`
 	Failed   int `json:"Failed"`
 	Finished int `json:"Finished"`
+	Aborted  int `json:"Aborted"`
 }
 
 // Service defines a handler service for experiments.
@@ -81,6 +82,7 @@ func Register(r *gin.RouterGroup, s *Service) {
 	// TODO: add more api handlers
 	endpoint.GET("", s.listExperiments)
 	endpoint.POST("/new", s.createExperiment)
+	endpoint.POST("/dry-run", s.dryRunExperiment)
 	endpoint.GET("/detail/:uid", s.getExperimentDetail)
 	endpoint.DELETE("/:uid", s.deleteExperiment)
 	endpoint.PUT("/update", s.updateExperiment)
@@ -96,6 +98,9 @@ type Experiment struct {
 	Status        string `json:"status"`
 	UID           string `json:"uid"`
 	FailedMessage string `json:"failed_message,omitempty"`
+
+	// DryRun is the changes planned by the experiment in dry-run mode
+	DryRun *v1alpha1.DryRunStatus `json:"dry_run,omitempty"`
 }
 
 // ExperimentBase is used to identify the unique experiment from API request.
@@ -130,6 +135,35 @@ func (s *Service) createExperiment(c *gin.Context) {
 		return
 	}
 
+	s.create(c, exp)
+}
+
+// @Summary Create a new chaos experiment in dry-run mode.
+// @Description Create a new chaos experiment which only selects the pods and renders the planned changes into its status, without injecting any fault.
+// @Tags experiments
+// @Produce json
+// @Param request body core.ExperimentInfo true "Request body"
+// @Success 200 "create ok"
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /experiments/dry-run [post]
+func (s *Service) dryRunExperiment(c *gin.Context) {
+	exp := &core.ExperimentInfo{}
+	if err := c.ShouldBindJSON(exp); err != nil {
+		c.Status(http.StatusBadRequest)
+		_ = c.Error(utils.ErrInvalidRequest.WrapWithNoMessage(err))
+		return
+	}
+
+	if exp.Annotations == nil {
+		exp.Annotations = make(map[string]string)
+	}
+	exp.Annotations[v1alpha1.DryRunAnnotationKey] = "true"
+
+	s.create(c, exp)
+}
+
+func (s *Service) create(c *gin.Context, exp *core.ExperimentInfo) {
 	createFuncs := map[string]actionFunc{
 		v1alpha1.KindPodChaos:     s.createPodChaos,
 		v1alpha1.KindNetworkChaos: s.createNetworkChaos,
@@ -205,10 +239,15 @@ func (s *Service) createNetworkChaos(exp *core.ExperimentInfo) error {
 		},
 	}
 
-	if exp.Target.NetworkChaos.Action == string(v1alpha1.BandwidthAction) || exp.Target.NetworkChaos.Action == string(v1alpha1.PartitionAction) {
+	switch v1alpha1.NetworkChaosAction(exp.Target.NetworkChaos.Action) {
+	case v1alpha1.BandwidthAction, v1alpha1.PartitionAction, v1alpha1.ResetAction, v1alpha1.RejectAction:
 		chaos.Spec.Direction = v1alpha1.Direction(exp.Target.NetworkChaos.Direction)
 	}
 
+	if exp.Target.NetworkChaos.Action == string(v1alpha1.ResetAction) || exp.Target.NetworkChaos.Action == string(v1alpha1.RejectAction) {
+		chaos.Spec.Reject = exp.Target.NetworkChaos.Reject
+	}
+
 	if exp.Target.NetworkChaos.Action == string(v1alpha1.BandwidthAction) {
 		chaos.Spec.Bandwidth = exp.Target.NetworkChaos.Bandwidth
 	}
@@ -249,6 +288,8 @@ func (s *Service) createIOChaos(exp *core.ExperimentInfo) error {
 			Delay:      exp.Target.IOChaos.Delay,
 			Errno:      exp.Target.IOChaos.Errno,
 			Attr:       exp.Target.IOChaos.Attr,
+			Mistake:    exp.Target.IOChaos.Mistake,
+			Throttle:   exp.Target.IOChaos.Throttle,
 			Path:       exp.Target.IOChaos.Path,
 			Methods:    exp.Target.IOChaos.Methods,
 			Percent:    exp.Target.IOChaos.Percent,
@@ -410,6 +451,7 @@ func (s *Service) getPodChaosDetail(namespace string, name string) (ExperimentDe
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -450,6 +492,8 @@ func (s *Service) getIoChaosDetail(namespace string, name string) (ExperimentDet
 				Delay:      chaos.Spec.Delay,
 				Errno:      chaos.Spec.Errno,
 				Attr:       chaos.Spec.Attr,
+				Mistake:    chaos.Spec.Mistake,
+				Throttle:   chaos.Spec.Throttle,
 				Path:       chaos.Spec.Path,
 				Percent:    chaos.Spec.Percent,
 				Methods:    chaos.Spec.Methods,
@@ -477,6 +521,7 @@ func (s *Service) getIoChaosDetail(namespace string, name string) (ExperimentDet
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -518,6 +563,7 @@ func (s *Service) getNetworkChaosDetail(namespace string, name string) (Experime
 				Duplicate: chaos.Spec.Duplicate,
 				Corrupt:   chaos.Spec.Corrupt,
 				Bandwidth: chaos.Spec.Bandwidth,
+				Reject:    chaos.Spec.Reject,
 				Direction: string(chaos.Spec.Direction),
 			},
 		},
@@ -554,6 +600,7 @@ func (s *Service) getNetworkChaosDetail(namespace string, name string) (Experime
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -615,6 +662,7 @@ func (s *Service) getTimeChaosDetail(namespace string, name string) (ExperimentD
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -674,6 +722,7 @@ func (s *Service) getKernelChaosDetail(namespace string, name string) (Experimen
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -738,6 +787,7 @@ func (s *Service) getStressChaosDetail(namespace string, name string) (Experimen
 			Status:        chaos.GetChaos().Status,
 			UID:           chaos.GetChaos().UID,
 			FailedMessage: chaos.GetStatus().FailedMessage,
+			DryRun:        chaos.GetStatus().DryRun,
 		},
 		ExperimentInfo: info,
 	}, nil
@@ -750,7 +800,7 @@ func (s *Service) getStressChaosDetail(namespace string, name string) (Experimen
 // @Param namespace query string false "namespace"
 // @Param name query string false "name"
 // @Param kind query string false "kind" Enums(PodChaos, IoChaos, NetworkChaos, TimeChaos, KernelChaos, StressChaos)
-// @Param status query string false "status" Enums(Running, Paused, Failed, Finished)
+// @Param status query string false "status" Enums(Running, Paused, Failed, Finished, Aborted)
 // @Success 200 {array} Experiment
 // @Router /experiments [get]
 // @Failure 500 {object} utils.APIError
@@ -968,6 +1018,8 @@ func (s *Service) state(c *gin.Context) {
 					data.Failed++
 				case string(v1alpha1.ExperimentPhaseFinished):
 					data.Finished++
+				case string(v1alpha1.ExperimentPhaseAborted):
+					data.Aborted++
 				}
 				data.Total++
 			}
@@ -1249,6 +1301,8 @@ func (s *Service) updateIOChaos(exp *core.ExperimentInfo) error {
 		Value:      exp.Scope.Value,
 		Delay:      exp.Target.IOChaos.Delay,
 		Errno:      exp.Target.IOChaos.Errno,
+		Mistake:    exp.Target.IOChaos.Mistake,
+		Throttle:   exp.Target.IOChaos.Throttle,
 		Path:       exp.Target.IOChaos.Path,
 		Percent:    exp.Target.IOChaos.Percent,
 		Methods:    exp.Target.IOChaos.Methods,
@@ -0,0 +1,136 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// diskFillFilePrefix is the prefix of the name of the file filling the disk
+const diskFillFilePrefix = "chaos-mesh-disk-fill-"
+
+// ApplyDiskFill creates a file in the directory of the container to consume the free space of the disk.
+// The file is allocated by fallocate(2) under the root of the process, whose path is resolved in the root
+// as it is in the container, and its name only depends on the request, so that it can be removed without
+// any other state even if chaos-daemon has restarted.
+func (s *daemonServer) ApplyDiskFill(ctx context.Context, req *pb.DiskFillRequest) (*empty.Empty, error) {
+	log.Info("Apply disk fill", "request", req)
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	// remove the file created before, so that the free space is counted without it
+	if err := removeDiskFillFile(ctx, pid, req); err != nil {
+		return nil, err
+	}
+
+	total, free, err := diskUsage(ctx, pid, req.Path)
+	if err != nil {
+		log.Error(err, "error while getting the usage of disk", "path", req.Path)
+		return nil, err
+	}
+
+	size := diskFillSize(total, free, req.Size, req.Percent)
+	if size == 0 {
+		log.Info("no free space to fill", "path", req.Path)
+		return &empty.Empty{}, nil
+	}
+
+	path := diskFillFilePath(req)
+	log.Info("fill disk", "pid", pid, "path", path, "size", size)
+
+	if err := fallocate(ctx, pid, path, size); err != nil {
+		log.Error(err, "fallocate error", "path", path)
+		// the file may be partially allocated
+		if rerr := removeDiskFillFile(ctx, pid, req); rerr != nil {
+			log.Error(rerr, "error while removing the file filling disk")
+		}
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// RecoverDiskFill removes the file created by ApplyDiskFill
+func (s *daemonServer) RecoverDiskFill(ctx context.Context, req *pb.DiskFillRequest) (*empty.Empty, error) {
+	log.Info("Recover disk fill", "request", req)
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	if err := removeDiskFillFile(ctx, pid, req); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// removeDiskFillFile removes the file filling disk in the container, it succeeds if the file doesn't exist
+func removeDiskFillFile(ctx context.Context, pid uint32, req *pb.DiskFillRequest) error {
+	path := diskFillFilePath(req)
+	if err := removeFile(ctx, pid, path); err != nil {
+		log.Error(err, "error while removing the file filling disk", "path", path)
+		return err
+	}
+	return nil
+}
+
+// containerRoot returns the path of the root of the process in the daemon
+func containerRoot(pid uint32) string {
+	return fmt.Sprintf("%s/%d/root", defaultProcPrefix, pid)
+}
+
+// runInMountNS executes the command in the mount namespace of the process, so that the paths in the
+// arguments are resolved in the container, and the command should exist in the container
+func runInMountNS(ctx context.Context, pid uint32, name string, args ...string) ([]byte, error) {
+	cmd := bpm.DefaultProcessBuilder(name, args...).SetMountNS(GetNsPath(pid, bpm.MountNS)).SetContext(ctx).Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, encodeOutputToError(output, err)
+	}
+	return output, nil
+}
+
+// diskFillFilePath returns the path of the file filling the disk in the container
+func diskFillFilePath(req *pb.DiskFillRequest) string {
+	return filepath.Join("/", req.Path, diskFillFilePrefix+req.Name)
+}
+
+// diskFillSize returns the size of the file filling the disk, which is limited by the free space.
+// All the free space is filled if neither size nor percent is set.
+func diskFillSize(total uint64, free uint64, size uint64, percent uint32) uint64 {
+	if percent > 0 {
+		size = total / 100 * uint64(percent)
+	} else if size == 0 {
+		return free
+	}
+
+	if size > free {
+		return free
+	}
+	return size
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+)
+
+func diskUsage(ctx context.Context, pid uint32, path string) (uint64, uint64, error) {
+	return 0, 0, errors.New("disk usage isn't supported")
+}
+
+func fallocate(ctx context.Context, pid uint32, path string, size uint64) error {
+	return errors.New("fallocate isn't supported")
+}
+
+func removeFile(ctx context.Context, pid uint32, path string) error {
+	return errors.New("removing file isn't supported")
+}
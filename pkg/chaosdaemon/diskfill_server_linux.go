@@ -0,0 +1,136 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+// openInRoot opens the path under the root by openat2(2) with RESOLVE_IN_ROOT, so that the symlinks
+// and ".." in the path are resolved in the root, as they are in the container whose root it is
+func openInRoot(root string, path string, flags int, mode uint32) (int, error) {
+	// Mock point to return error in unit test
+	if err := mock.On("Openat2Error"); err != nil {
+		if e, ok := err.(error); ok {
+			return -1, e
+		}
+	}
+
+	rootFd, err := unix.Open(root, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	defer unix.Close(rootFd)
+
+	return unix.Openat2(rootFd, path, &unix.OpenHow{
+		Flags:   uint64(flags | unix.O_CLOEXEC),
+		Mode:    uint64(mode),
+		Resolve: unix.RESOLVE_IN_ROOT,
+	})
+}
+
+// diskUsage returns the total and available size of the filesystem of the path in the container.
+// The commands are executed in the mount namespace of the container if openat2(2) isn't supported
+func diskUsage(ctx context.Context, pid uint32, path string) (uint64, uint64, error) {
+	fd, err := openInRoot(containerRoot(pid), path, unix.O_PATH|unix.O_DIRECTORY, 0)
+	if err == unix.ENOSYS {
+		output, err := runInMountNS(ctx, pid, "stat", "-f", "-c", "%S %b %a", path)
+		if err != nil {
+			return 0, 0, err
+		}
+		return parseStatfsOutput(string(output))
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer unix.Close(fd)
+
+	var stat unix.Statfs_t
+	if err := unix.Fstatfs(fd, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	return stat.Blocks * uint64(stat.Frsize), stat.Bavail * uint64(stat.Frsize), nil
+}
+
+// parseStatfsOutput parses the block size, total blocks and available blocks printed by `stat -f`
+func parseStatfsOutput(output string) (uint64, uint64, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 3 {
+		return 0, 0, fmt.Errorf("unexpected output of stat: %s", output)
+	}
+
+	values := make([]uint64, 0, len(fields))
+	for _, field := range fields {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unexpected output of stat: %s", output)
+		}
+		values = append(values, value)
+	}
+
+	return values[1] * values[0], values[2] * values[0], nil
+}
+
+// fallocate creates the file in the container and allocates the blocks of size for it by fallocate(2)
+func fallocate(ctx context.Context, pid uint32, path string, size uint64) error {
+	fd, err := openInRoot(containerRoot(pid), path, unix.O_WRONLY|unix.O_CREAT|unix.O_TRUNC, 0644)
+	if err == unix.ENOSYS {
+		_, err = runInMountNS(ctx, pid, "fallocate", "-l", strconv.FormatUint(size, 10), path)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	// Mock point to return error in unit test
+	if err := mock.On("FallocateError"); err != nil {
+		if e, ok := err.(error); ok {
+			return e
+		}
+	}
+
+	return unix.Fallocate(fd, 0, 0, int64(size))
+}
+
+// removeFile removes the file in the container, it succeeds if the file doesn't exist
+func removeFile(ctx context.Context, pid uint32, path string) error {
+	fd, err := openInRoot(containerRoot(pid), filepath.Dir(path), unix.O_PATH|unix.O_DIRECTORY, 0)
+	if err == unix.ENOSYS {
+		_, err = runInMountNS(ctx, pid, "rm", "-f", path)
+		return err
+	}
+	if err == unix.ENOENT {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	err = unix.Unlinkat(fd, filepath.Base(path), 0)
+	if err != nil && err != unix.ENOENT {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("disk fill server on linux", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	Context("openInRoot", func() {
+		It("should resolve the path in the root", func() {
+			root, err := ioutil.TempDir("", "container-root")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(root)
+
+			Expect(os.Mkdir(filepath.Join(root, "data"), 0755)).To(Succeed())
+			// the absolute symlink points to the directory in the root rather than the one in the daemon
+			Expect(os.Symlink("/data", filepath.Join(root, "link"))).To(Succeed())
+
+			fd, err := openInRoot(root, "/link/file", unix.O_WRONLY|unix.O_CREAT, 0644)
+			if err == unix.ENOSYS {
+				Skip("openat2 isn't supported by the kernel")
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unix.Close(fd)).To(Succeed())
+			_, err = os.Stat(filepath.Join(root, "data", "file"))
+			Expect(err).ToNot(HaveOccurred())
+
+			// ".." can't escape the root
+			fd, err = openInRoot(root, "/../../data/other", unix.O_WRONLY|unix.O_CREAT, 0644)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unix.Close(fd)).To(Succeed())
+			_, err = os.Stat(filepath.Join(root, "data", "other"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("parseStatfsOutput", func() {
+		It("should parse the output of stat", func() {
+			total, free, err := parseStatfsOutput("4096 1000 500\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(uint64(4096000)))
+			Expect(free).To(Equal(uint64(2048000)))
+
+			_, _, err = parseStatfsOutput("4096 1000")
+			Expect(err).To(HaveOccurred())
+			_, _, err = parseStatfsOutput("4096 1000 ?")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ApplyDiskFill without openat2", func() {
+		It("should execute the commands in the mount namespace", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("Openat2Error", unix.ENOSYS)()
+
+			commands := []string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(cmd).To(Equal("nsenter"))
+				Expect(args[0]).To(Equal("-m/proc/9527/ns/mnt"))
+				Expect(args[1]).To(Equal("--"))
+				commands = append(commands, strings.Join(args[2:], " "))
+				if args[2] == "stat" {
+					return exec.Command("echo", "4096 1000 500")
+				}
+				return exec.Command("echo", "-n")
+			})()
+
+			req := &pb.DiskFillRequest{
+				ContainerId: "containerd://container-id",
+				Path:        "/data",
+				Name:        "uid",
+				Percent:     10,
+			}
+			_, err := s.ApplyDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(commands).To(Equal([]string{
+				"rm -f /data/chaos-mesh-disk-fill-uid",
+				"stat -f -c %S %b %a /data",
+				"fallocate -l 409600 /data/chaos-mesh-disk-fill-uid",
+			}))
+
+			commands = []string{}
+			_, err = s.RecoverDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(commands).To(Equal([]string{"rm -f /data/chaos-mesh-disk-fill-uid"}))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("disk fill server", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	Context("diskFillSize", func() {
+		It("should limit the size by free space", func() {
+			Expect(diskFillSize(1000, 500, 100, 0)).To(Equal(uint64(100)))
+			Expect(diskFillSize(1000, 500, 600, 0)).To(Equal(uint64(500)))
+			Expect(diskFillSize(1000, 500, 0, 0)).To(Equal(uint64(500)))
+			Expect(diskFillSize(1000, 500, 0, 10)).To(Equal(uint64(100)))
+			Expect(diskFillSize(1000, 500, 0, 80)).To(Equal(uint64(500)))
+			Expect(diskFillSize(1000, 0, 100, 0)).To(Equal(uint64(0)))
+		})
+	})
+
+	Context("diskFillFilePath", func() {
+		It("should be in the directory", func() {
+			req := &pb.DiskFillRequest{Path: "/var/lib/data/", Name: "uid"}
+			Expect(diskFillFilePath(req)).To(Equal("/var/lib/data/chaos-mesh-disk-fill-uid"))
+			Expect(containerRoot(9527)).To(Equal("/proc/9527/root"))
+		})
+	})
+
+	Context("ApplyDiskFill and RecoverDiskFill", func() {
+		It("should create and remove the file", func() {
+			dir, err := ioutil.TempDir("", "disk-fill")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			// the root of the process itself is the root of the daemon
+			defer mock.With("pid", os.Getpid())()
+
+			req := &pb.DiskFillRequest{
+				ContainerId: "containerd://container-id",
+				Path:        dir,
+				Name:        "uid",
+				Size:        4096,
+			}
+			_, err = s.ApplyDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+
+			info, err := os.Stat(filepath.Join(dir, diskFillFilePrefix+"uid"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Size()).To(Equal(int64(4096)))
+
+			// apply again replaces the file
+			req.Size = 8192
+			_, err = s.ApplyDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+			info, err = os.Stat(filepath.Join(dir, diskFillFilePrefix+"uid"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Size()).To(Equal(int64(8192)))
+
+			_, err = s.RecoverDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = os.Stat(filepath.Join(dir, diskFillFilePrefix+"uid"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			// recover is idempotent
+			_, err = s.RecoverDiskFill(context.TODO(), req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should remove the file if fallocate fails", func() {
+			dir, err := ioutil.TempDir("", "disk-fill")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			file := filepath.Join(dir, diskFillFilePrefix+"uid")
+			defer mock.With("pid", os.Getpid())()
+			defer mock.With("FallocateError", errors.New("no space left on device"))()
+
+			_, err = s.ApplyDiskFill(context.TODO(), &pb.DiskFillRequest{
+				ContainerId: "containerd://container-id",
+				Path:        dir,
+				Name:        "uid",
+				Size:        4096,
+			})
+			Expect(err).To(HaveOccurred())
+			_, err = os.Stat(file)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should fail on get pid", func() {
+			const errorStr = "mock error on Task()"
+			defer mock.With("TaskError", errors.New(errorStr))()
+			_, err := s.RecoverDiskFill(context.TODO(), &pb.DiskFillRequest{
+				ContainerId: "containerd://container-id",
+				Path:        "/data",
+				Name:        "uid",
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(errorStr))
+		})
+	})
+})
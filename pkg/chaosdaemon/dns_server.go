@@ -0,0 +1,103 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	resolvConfPath = "etc/resolv.conf"
+
+	// chaosNameserverMark marks the next line is the nameserver added by chaos mesh
+	chaosNameserverMark = "# chaos-mesh: the next nameserver is injected"
+	// disabledNameserverPrefix is the prefix of the original nameservers which are disabled
+	disabledNameserverPrefix = "# chaos-mesh: "
+)
+
+// SetDNSServer points the resolver of container to the DNS server, or restores it if enable is false.
+// The original nameservers are commented out in /etc/resolv.conf, so that they can be restored
+// without any other state.
+func (s *daemonServer) SetDNSServer(ctx context.Context, req *pb.SetDNSServerRequest) (*empty.Empty, error) {
+	log.Info("Set DNS server", "request", req)
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	// The resolv.conf is usually bind mounted into the container, so it should be written in place
+	path := fmt.Sprintf("%s/%d/root/%s", defaultProcPrefix, pid, resolvConfPath)
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Error(err, "error while reading resolv.conf", "path", path)
+		return nil, err
+	}
+
+	var updated string
+	if req.Enable {
+		updated = injectNameserver(string(content), req.DnsServer)
+	} else {
+		updated = restoreNameserver(string(content))
+	}
+
+	if err := ioutil.WriteFile(path, []byte(updated), 0644); err != nil {
+		log.Error(err, "error while writing resolv.conf", "path", path)
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// injectNameserver replaces the nameservers in resolv.conf with the server
+func injectNameserver(content string, server string) string {
+	lines := strings.Split(restoreNameserver(content), "\n")
+
+	result := make([]string, 0, len(lines)+2)
+	result = append(result, chaosNameserverMark, "nameserver "+server)
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "nameserver") {
+			line = disabledNameserverPrefix + line
+		}
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// restoreNameserver removes the injected nameserver and restores the original ones
+func restoreNameserver(content string) string {
+	lines := strings.Split(content, "\n")
+
+	result := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if line == chaosNameserverMark {
+			// skip the injected nameserver
+			i++
+			continue
+		}
+		result = append(result, strings.TrimPrefix(line, disabledNameserverPrefix))
+	}
+
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("dns server", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	const resolvConf = `nameserver 10.96.0.10
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5
+`
+
+	Context("resolv.conf", func() {
+		It("should inject and restore nameserver", func() {
+			injected := injectNameserver(resolvConf, "10.96.0.100")
+			Expect(injected).To(Equal(chaosNameserverMark + `
+nameserver 10.96.0.100
+# chaos-mesh: nameserver 10.96.0.10
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5
+`))
+			Expect(restoreNameserver(injected)).To(Equal(resolvConf))
+		})
+
+		It("should inject nameserver idempotently", func() {
+			injected := injectNameserver(resolvConf, "10.96.0.100")
+			Expect(injectNameserver(injected, "10.96.0.100")).To(Equal(injected))
+			Expect(restoreNameserver(injectNameserver(injected, "10.96.0.101"))).To(Equal(resolvConf))
+		})
+
+		It("should restore untouched resolv.conf", func() {
+			Expect(restoreNameserver(resolvConf)).To(Equal(resolvConf))
+		})
+	})
+
+	Context("SetDNSServer", func() {
+		It("should fail on get pid", func() {
+			const errorStr = "mock error on Task()"
+			defer mock.With("TaskError", errors.New(errorStr))()
+			_, err := s.SetDNSServer(context.TODO(), &pb.SetDNSServerRequest{
+				ContainerId: "containerd://container-id",
+				DnsServer:   "10.96.0.100",
+				Enable:      true,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(errorStr))
+		})
+	})
+})
@@ -0,0 +1,279 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/shirou/gopsutil/process"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	httpProxyBin = "/usr/local/bin/chaos-http-proxy"
+
+	httpChaosChainPrefix = "CHAOS-HTTP-"
+	natTable             = "nat"
+
+	httpProxyStartTimeout = 10 * time.Second
+)
+
+// ApplyHttpChaos starts a proxy in the network namespace of the container and redirects
+// the requests to the port to it. The proxy will be stopped if the rule is empty.
+func (s *daemonServer) ApplyHttpChaos(ctx context.Context, in *pb.ApplyHttpChaosRequest) (*pb.ApplyHttpChaosResponse, error) {
+	log.Info("applying http chaos", "Request", in)
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, in.ContainerId)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	nsPath := GetNsPath(pid, bpm.NetNS)
+
+	iptables := buildIptablesClient(ctx, nsPath)
+	iptables.table = natTable
+	chain := &iptablesChain{
+		Name: fmt.Sprintf("%s%d", httpChaosChainPrefix, in.Port),
+	}
+
+	if len(in.Rule) == 0 {
+		// stop redirecting the requests before killing the proxy
+		if err := iptables.deleteRule(preroutingJump(chain)); err != nil {
+			log.Error(err, "error while deleting iptables rule", "chain", "PREROUTING")
+			return nil, err
+		}
+		if err := iptables.deleteChain(chain); err != nil {
+			log.Error(err, "error while deleting iptables chain", "chain", chain.Name)
+			return nil, err
+		}
+
+		if in.Instance != 0 {
+			if err := s.killHttpChaos(ctx, in.Instance, in.StartTime); err != nil {
+				return nil, err
+			}
+		}
+
+		return &pb.ApplyHttpChaosResponse{
+			Instance:  0,
+			StartTime: 0,
+		}, nil
+	}
+
+	args := fmt.Sprintf("--listen 0.0.0.0:0 --target 127.0.0.1:%d", in.Port)
+	log.Info("executing", "cmd", httpProxyBin+" "+args)
+	cmd := bpm.DefaultProcessBuilder(httpProxyBin, strings.Split(args, " ")...).
+		EnableSuicide().
+		SetNetNS(nsPath).
+		Build()
+	cmd.Stdin = strings.NewReader(in.Rule)
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.backgroundProcessManager.StartProcess(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.setupHttpProxy(ctx, cmd, stdout, &iptables, chain, in.Port)
+	if err != nil {
+		if kerr := cmd.Process.Kill(); kerr != nil {
+			log.Error(kerr, "kill chaos-http-proxy failed", "request", in)
+		}
+		return nil, err
+	}
+
+	// the requests have been redirected to the new proxy, so it's safe to kill the old one
+	if in.Instance != 0 {
+		if err := s.killHttpChaos(ctx, in.Instance, in.StartTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *daemonServer) setupHttpProxy(ctx context.Context, cmd *bpm.ManagedProcess, stdout io.Reader,
+	iptables *iptablesClient, chain *iptablesChain, port uint32) (*pb.ApplyHttpChaosResponse, error) {
+	procState, err := process.NewProcess(int32(cmd.Process.Pid))
+	if err != nil {
+		return nil, err
+	}
+	ct, err := procState.CreateTime()
+	if err != nil {
+		return nil, err
+	}
+
+	proxyPort, err := readHttpProxyPort(ctx, stdout)
+	if err != nil {
+		return nil, err
+	}
+
+	chain.Rules = []string{
+		fmt.Sprintf("-A %s -p tcp -m tcp --dport %d -j REDIRECT --to-ports %d", chain.Name, port, proxyPort),
+	}
+	if err := iptables.createNewChain(chain); err != nil {
+		log.Error(err, "error while creating iptables chain", "chain", chain.Name)
+		return nil, err
+	}
+	if err := iptables.ensureRule(&iptablesChain{
+		Name: "PREROUTING",
+	}, preroutingJump(chain)); err != nil {
+		log.Error(err, "error while jumping to iptables chain", "chain", chain.Name)
+		return nil, err
+	}
+
+	return &pb.ApplyHttpChaosResponse{
+		Instance:  int64(cmd.Process.Pid),
+		StartTime: ct,
+	}, nil
+}
+
+// readHttpProxyPort reads the port which the proxy listens on from the first line of its stdout,
+// and forwards the rest of its stdout to chaos-daemon
+func readHttpProxyPort(ctx context.Context, stdout io.Reader) (int, error) {
+	type result struct {
+		port int
+		err  error
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpProxyStartTimeout)
+	defer cancel()
+
+	resultCh := make(chan result, 1)
+	go func() {
+		reader := bufio.NewReader(stdout)
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			resultCh <- result{err: fmt.Errorf("failed to read the port of chaos-http-proxy: %v", err)}
+			return
+		}
+
+		port, err := strconv.Atoi(strings.TrimSpace(line))
+		resultCh <- result{port: port, err: err}
+
+		if _, err := io.Copy(os.Stdout, reader); err != nil {
+			log.Error(err, "failed to copy the output of chaos-http-proxy")
+		}
+	}()
+
+	select {
+	case res := <-resultCh:
+		return res.port, res.err
+	case <-ctx.Done():
+		return 0, fmt.Errorf("timeout while waiting for chaos-http-proxy to start: %v", ctx.Err())
+	}
+}
+
+func (s *daemonServer) killHttpChaos(ctx context.Context, pid int64, startTime int64) error {
+	log.Info("killing chaos-http-proxy", "pid", pid)
+
+	err := s.backgroundProcessManager.KillBackgroundProcess(ctx, int(pid), startTime)
+	if err != nil {
+		return err
+	}
+	log.Info("kill chaos-http-proxy successfully")
+	return nil
+}
+
+// preroutingJump is the rule in PREROUTING which jumps to the chain of the port
+func preroutingJump(chain *iptablesChain) string {
+	return "-A PREROUTING -j " + chain.Name
+}
+
+// cleanStaleHttpChaos removes the chains of http chaos in all the network namespaces on the node.
+// The proxies are killed with chaos-daemon, so the chains left when chaos-daemon starts are stale,
+// and all the requests to the ports would be dropped if they were kept.
+func (s *daemonServer) cleanStaleHttpChaos(ctx context.Context) error {
+	pids, err := netNsPids()
+	if err != nil {
+		return err
+	}
+
+	var result error
+	for _, pid := range pids {
+		if err := cleanHttpChaosChains(ctx, pid); err != nil {
+			log.Error(err, "error while cleaning the chains of http chaos", "pid", pid)
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
+}
+
+// cleanHttpChaosChains removes the chains of http chaos in the network namespace of the process
+func cleanHttpChaosChains(ctx context.Context, pid uint32) error {
+	iptables := buildIptablesClient(ctx, GetNsPath(pid, bpm.NetNS))
+	iptables.table = natTable
+
+	out, err := iptables.buildCmd("-S").CombinedOutput()
+	if err != nil {
+		return encodeOutputToError(out, err)
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "-N" || !strings.HasPrefix(fields[1], httpChaosChainPrefix) {
+			continue
+		}
+
+		chain := &iptablesChain{Name: fields[1]}
+		log.Info("removing stale chain of http chaos", "pid", pid, "chain", chain.Name)
+		if err := iptables.deleteRule(preroutingJump(chain)); err != nil {
+			return err
+		}
+		if err := iptables.deleteChain(chain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// netNsPids returns a process in each network namespace on the node
+func netNsPids() ([]uint32, error) {
+	entries, err := ioutil.ReadDir(defaultProcPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[string]bool)
+	var pids []uint32
+	for _, entry := range entries {
+		pid, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		// the process may have exited
+		ns, err := os.Readlink(GetNsPath(uint32(pid), bpm.NetNS))
+		if err != nil || namespaces[ns] {
+			continue
+		}
+		namespaces[ns] = true
+		pids = append(pids, uint32(pid))
+	}
+	return pids, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("http chaos server", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	Context("netNsPids", func() {
+		It("should return a process in each network namespace", func() {
+			pids, err := netNsPids()
+			Expect(err).To(BeNil())
+
+			self, err := os.Readlink(GetNsPath(uint32(os.Getpid()), bpm.NetNS))
+			Expect(err).To(BeNil())
+			namespaces := make(map[string]bool)
+			for _, pid := range pids {
+				ns, err := os.Readlink(GetNsPath(pid, bpm.NetNS))
+				if err != nil {
+					// the process has exited
+					continue
+				}
+				Expect(namespaces[ns]).To(BeFalse())
+				namespaces[ns] = true
+			}
+			Expect(namespaces[self]).To(BeTrue())
+		})
+	})
+
+	Context("ApplyHttpChaos", func() {
+		It("should start proxy and redirect the port", func() {
+			var lock sync.Mutex
+			var rules []string
+
+			defer mock.With("pid", 9527)()
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(cmd).To(Equal("nsenter"))
+				Expect(args[0]).To(Equal("-n/proc/9527/ns/net"))
+				Expect(args[1]).To(Equal("--"))
+				if args[2] == iptablesCmd {
+					Expect(args[3:5]).To(Equal([]string{"-t", natTable}))
+					lock.Lock()
+					rules = append(rules, strings.Join(args[5:], " "))
+					lock.Unlock()
+					return exec.Command("echo", "-n")
+				}
+
+				Expect(args[3]).To(Equal(httpProxyBin))
+				Expect(args[4:]).To(Equal([]string{"--listen", "0.0.0.0:0", "--target", "127.0.0.1:80"}))
+				return exec.Command("sh", "-c", "echo 12345; exec sleep 60")
+			})()
+
+			resp, err := s.ApplyHttpChaos(context.TODO(), &pb.ApplyHttpChaosRequest{
+				Rule:        `{"action":"abort","percent":100}`,
+				Port:        80,
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Instance).NotTo(BeZero())
+			Expect(rules).To(ContainElement("-w -A CHAOS-HTTP-80 -p tcp -m tcp --dport 80 -j REDIRECT --to-ports 12345"))
+			Expect(rules).To(ContainElement("-w -A PREROUTING -j CHAOS-HTTP-80"))
+
+			rules = nil
+			resp, err = s.ApplyHttpChaos(context.TODO(), &pb.ApplyHttpChaosRequest{
+				Port:        80,
+				ContainerId: "containerd://container-id",
+				Instance:    resp.Instance,
+				StartTime:   resp.StartTime,
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Instance).To(BeZero())
+			Expect(rules).To(Equal([]string{
+				"-w -D PREROUTING -j CHAOS-HTTP-80",
+				"-w -F CHAOS-HTTP-80",
+				"-w -X CHAOS-HTTP-80",
+			}))
+		})
+
+		It("should ignore the chain which doesn't exist on recover", func() {
+			var rules []string
+
+			defer mock.With("pid", 9527)()
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				rules = append(rules, strings.Join(args[5:], " "))
+				if args[6] == "-D" {
+					return exec.Command("sh", "-c", "echo 'iptables: Bad rule (does a matching rule exist in that chain?).'; exit 1")
+				}
+				return exec.Command("sh", "-c", "echo 'iptables: No chain/target/match by that name.'; exit 1")
+			})()
+
+			resp, err := s.ApplyHttpChaos(context.TODO(), &pb.ApplyHttpChaosRequest{
+				Port:        80,
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Instance).To(BeZero())
+			Expect(rules).To(Equal([]string{
+				"-w -D PREROUTING -j CHAOS-HTTP-80",
+				"-w -F CHAOS-HTTP-80",
+			}))
+		})
+
+		It("should remove the chains left when chaos-daemon restarts", func() {
+			var lock sync.Mutex
+			var rules []string
+
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(args[2]).To(Equal(iptablesCmd))
+				rule := strings.Join(args[5:], " ")
+				if rule == "-w -S" {
+					// the chain and the jump are left in the network namespace of pod
+					return exec.Command("echo", "-P PREROUTING ACCEPT\n"+
+						"-N CHAOS-HTTP-80\n"+
+						"-A PREROUTING -j CHAOS-HTTP-80\n"+
+						"-A CHAOS-HTTP-80 -p tcp -m tcp --dport 80 -j REDIRECT --to-ports 12345")
+				}
+				if strings.HasPrefix(args[0], "-n/proc/9527/") {
+					lock.Lock()
+					rules = append(rules, rule)
+					lock.Unlock()
+				}
+				return exec.Command("echo", "-n")
+			})()
+
+			// the proxy has been killed with the previous chaos-daemon
+			restarted := &daemonServer{c, bpm.NewBackgroundProcessManager(), newIPSetRefresher()}
+			Expect(cleanHttpChaosChains(context.TODO(), 9527)).To(Succeed())
+			Expect(rules).To(Equal([]string{
+				"-w -D PREROUTING -j CHAOS-HTTP-80",
+				"-w -F CHAOS-HTTP-80",
+				"-w -X CHAOS-HTTP-80",
+			}))
+
+			// all the network namespaces on the node are cleaned
+			Expect(restarted.cleanStaleHttpChaos(context.TODO())).To(Succeed())
+		})
+
+		It("should fail if proxy doesn't print port", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				return exec.Command("echo", "-n")
+			})()
+
+			_, err := s.ApplyHttpChaos(context.TODO(), &pb.ApplyHttpChaosRequest{
+				Rule:        `{"action":"abort","percent":100}`,
+				Port:        80,
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("should fail on get pid", func() {
+			const errorStr = "mock error on Task()"
+			defer mock.With("TaskError", errors.New(errorStr))()
+			_, err := s.ApplyHttpChaos(context.TODO(), &pb.ApplyHttpChaosRequest{
+				Port:        80,
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(errorStr))
+		})
+	})
+})
@@ -41,8 +41,11 @@ func (s *daemonServer) ApplyIoChaos(ctx context.Context, in *pb.ApplyIoChaosRequ
 		}
 	}
 
-	actions := []v1alpha1.IoChaosAction{}
-	json.Unmarshal([]byte(in.Actions), &actions)
+	actions, err := decodeIoChaosActions(in.Actions)
+	if err != nil {
+		log.Error(err, "error while decoding actions", "actions", in.Actions)
+		return nil, err
+	}
 	log.Info("the length of actions", "length", len(actions))
 	if len(actions) == 0 {
 		return &pb.ApplyIoChaosResponse{
@@ -91,6 +94,25 @@ func (s *daemonServer) ApplyIoChaos(ctx context.Context, in *pb.ApplyIoChaosRequ
 	}, nil
 }
 
+// decodeIoChaosActions decodes the actions which are passed to toda, and checks
+// that every mistake action carries its mistake
+func decodeIoChaosActions(input string) ([]v1alpha1.IoChaosAction, error) {
+	actions := []v1alpha1.IoChaosAction{}
+	if len(input) == 0 {
+		return actions, nil
+	}
+	if err := json.Unmarshal([]byte(input), &actions); err != nil {
+		return nil, err
+	}
+
+	for _, action := range actions {
+		if action.Type == v1alpha1.IoMistake && action.MistakeSpec == nil {
+			return nil, fmt.Errorf("mistake of the action on path %s is not specified", action.Path)
+		}
+	}
+	return actions, nil
+}
+
 func (s *daemonServer) killIoChaos(ctx context.Context, pid int64, startTime int64) error {
 	log.Info("killing toda", "pid", pid)
 
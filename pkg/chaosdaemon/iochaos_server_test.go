@@ -0,0 +1,94 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("iochaos server", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	Context("decodeIoChaosActions", func() {
+		It("should decode mistake actions", func() {
+			actions, err := decodeIoChaosActions(`[{"type":"mistake","path":"/data/*","methods":["read","write"],"percent":10,` +
+				`"mistake":{"filling":"flip","maxOccurrences":2,"maxLength":16},"source":"default/iochaos"}]`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actions).To(Equal([]v1alpha1.IoChaosAction{
+				{
+					Type: v1alpha1.IoMistake,
+					Filter: v1alpha1.Filter{
+						Path:    "/data/*",
+						Methods: []v1alpha1.IoMethod{v1alpha1.Read, v1alpha1.Write},
+						Percent: 10,
+					},
+					MistakeSpec: &v1alpha1.MistakeSpec{
+						Filling:        v1alpha1.FlipFilling,
+						MaxOccurrences: 2,
+						MaxLength:      16,
+					},
+					Source: "default/iochaos",
+				},
+			}))
+		})
+
+		It("should decode empty actions", func() {
+			actions, err := decodeIoChaosActions("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actions).To(BeEmpty())
+		})
+
+		It("should fail on mistake action without mistake", func() {
+			_, err := decodeIoChaosActions(`[{"type":"mistake","path":"/data/*","percent":10}]`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail on invalid actions", func() {
+			_, err := decodeIoChaosActions(`{"type":"mistake"}`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ApplyIoChaos", func() {
+		It("should do nothing without actions", func() {
+			resp, err := s.ApplyIoChaos(context.TODO(), &pb.ApplyIoChaosRequest{
+				Actions:     "[]",
+				Volume:      "/data",
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.Instance).To(Equal(int64(0)))
+		})
+
+		It("should fail on invalid mistake action", func() {
+			_, err := s.ApplyIoChaos(context.TODO(), &pb.ApplyIoChaosRequest{
+				Actions:     `[{"type":"mistake","path":"/data/*","percent":10}]`,
+				Volume:      "/data",
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
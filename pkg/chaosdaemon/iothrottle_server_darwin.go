@@ -0,0 +1,30 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func (s *daemonServer) ApplyIoThrottle(context.Context, *pb.IoThrottleRequest) (*pb.IoThrottleResponse, error) {
+	return &pb.IoThrottleResponse{}, nil
+}
+
+func (s *daemonServer) RecoverIoThrottle(context.Context, *pb.IoThrottleRequest) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,430 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/containerd/cgroups"
+	"github.com/golang/protobuf/ptypes/empty"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// unifiedCgroupRoot is the mount point of cgroup v2
+	unifiedCgroupRoot = "/sys/fs/cgroup"
+	// ioMaxFile is the file of cgroup v2 which limits the io of devices
+	ioMaxFile = "io.max"
+	// ioMaxUnlimited removes the limit in io.max
+	ioMaxUnlimited = "max"
+)
+
+var (
+	// ioMaxKeys are the keys of the limits in io.max of cgroup v2
+	ioMaxKeys = []string{"rbps", "wbps", "riops", "wiops"}
+	// blkioThrottleFiles are the files of the limits in blkio cgroup v1
+	blkioThrottleFiles = []string{
+		"blkio.throttle.read_bps_device",
+		"blkio.throttle.write_bps_device",
+		"blkio.throttle.read_iops_device",
+		"blkio.throttle.write_iops_device",
+	}
+)
+
+var deviceNumberRegexp = regexp.MustCompile(`^(\d+):(\d+)$`)
+
+// ApplyIoThrottle limits the bandwidth and IOPS of the block devices on the cgroup of container,
+// and returns the limits before applying, which are restored by RecoverIoThrottle
+func (s *daemonServer) ApplyIoThrottle(ctx context.Context, req *pb.IoThrottleRequest) (*pb.IoThrottleResponse, error) {
+	log.Info("Apply io throttle", "request", req)
+
+	previous, err := s.setIoThrottle(ctx, req, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.IoThrottleResponse{Throttles: previous}, nil
+}
+
+// RecoverIoThrottle restores the limits of the block devices to the ones in the request,
+// which are returned by ApplyIoThrottle
+func (s *daemonServer) RecoverIoThrottle(ctx context.Context, req *pb.IoThrottleRequest) (*empty.Empty, error) {
+	log.Info("Recover io throttle", "request", req)
+
+	if _, err := s.setIoThrottle(ctx, req, true); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// setIoThrottle sets the limits of the devices in the request, and returns the limits before setting.
+// The limits already set are restored if it fails to apply.
+func (s *daemonServer) setIoThrottle(ctx context.Context, req *pb.IoThrottleRequest, recover bool) ([]*pb.IoThrottle, error) {
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	devices := make([]blockDevice, 0, len(req.Throttles))
+	for _, throttle := range req.Throttles {
+		device, err := parseBlockDevice(throttle.Device)
+		if err != nil {
+			log.Error(err, "error while parsing block device", "device", throttle.Device)
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	if cgroups.Mode() == cgroups.Unified {
+		cgroup, err := unifiedCgroupPath(int(pid))
+		if err != nil {
+			log.Error(err, "error while getting cgroup", "pid", pid)
+			return nil, err
+		}
+
+		path := filepath.Join(unifiedCgroupRoot, cgroup, ioMaxFile)
+		limits, err := readIoMax(path)
+		if err != nil {
+			log.Error(err, "error while reading io.max", "path", path)
+			return nil, err
+		}
+		previous := previousThrottles(devices, req.Throttles, limits)
+
+		if err := writeIoMax(path, devices, req.Throttles, recover); err != nil {
+			if !recover {
+				if rerr := writeIoMax(path, devices, previous, true); rerr != nil {
+					log.Error(rerr, "error while restoring io.max", "path", path)
+				}
+			}
+			return nil, err
+		}
+		return previous, nil
+	}
+
+	id, err := s.crClient.FormatContainerID(ctx, req.ContainerId)
+	if err != nil {
+		return nil, err
+	}
+	cgroup, err := findValidCgroup(pidPath(int(pid)), id)
+	if err != nil {
+		return nil, err
+	}
+	control, err := cgroups.Load(cgroups.SingleSubsystem(cgroups.V1, cgroups.Blkio), cgroups.StaticPath(cgroup))
+	if err != nil {
+		log.Error(err, "error while loading blkio cgroup", "cgroup", cgroup)
+		return nil, err
+	}
+
+	path, err := blkioPath(cgroup)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := readBlkioThrottle(path)
+	if err != nil {
+		log.Error(err, "error while reading blkio throttle", "path", path)
+		return nil, err
+	}
+	previous := previousThrottles(devices, req.Throttles, limits)
+
+	err = control.Update(&specs.LinuxResources{
+		BlockIO: blkioThrottle(devices, req.Throttles, recover),
+	})
+	if err != nil {
+		if !recover {
+			rerr := control.Update(&specs.LinuxResources{
+				BlockIO: blkioThrottle(devices, previous, true),
+			})
+			if rerr != nil {
+				log.Error(rerr, "error while restoring blkio throttle", "cgroup", cgroup)
+			}
+		}
+		return nil, err
+	}
+	return previous, nil
+}
+
+type blockDevice struct {
+	major int64
+	minor int64
+}
+
+// parseBlockDevice parses the major and minor number of a block device,
+// which is either the path of the device or in the form of "major:minor"
+func parseBlockDevice(device string) (blockDevice, error) {
+	if matches := deviceNumberRegexp.FindStringSubmatch(device); matches != nil {
+		major, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return blockDevice{}, err
+		}
+		minor, err := strconv.ParseInt(matches[2], 10, 64)
+		if err != nil {
+			return blockDevice{}, err
+		}
+		return blockDevice{major: major, minor: minor}, nil
+	}
+
+	info, err := os.Stat(device)
+	if err != nil {
+		return blockDevice{}, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || info.Mode()&os.ModeDevice == 0 || info.Mode()&os.ModeCharDevice != 0 {
+		return blockDevice{}, fmt.Errorf("%s is not a block device", device)
+	}
+
+	return blockDevice{
+		major: int64(unix.Major(uint64(stat.Rdev))),
+		minor: int64(unix.Minor(uint64(stat.Rdev))),
+	}, nil
+}
+
+// blkioThrottle builds the throttle settings of cgroup v1. Only the limits set in the request
+// are written when applying, and all the limits are written when recovering, where a rate of 0
+// removes the limit.
+func blkioThrottle(devices []blockDevice, throttles []*pb.IoThrottle, recover bool) *specs.LinuxBlockIO {
+	blkio := &specs.LinuxBlockIO{}
+	for i, throttle := range throttles {
+		device := devices[i]
+		for _, t := range []struct {
+			list *[]specs.LinuxThrottleDevice
+			rate uint64
+		}{
+			{list: &blkio.ThrottleReadBpsDevice, rate: throttle.ReadBps},
+			{list: &blkio.ThrottleWriteBpsDevice, rate: throttle.WriteBps},
+			{list: &blkio.ThrottleReadIOPSDevice, rate: throttle.ReadIops},
+			{list: &blkio.ThrottleWriteIOPSDevice, rate: throttle.WriteIops},
+		} {
+			if !recover && t.rate == 0 {
+				continue
+			}
+
+			throttleDevice := specs.LinuxThrottleDevice{Rate: t.rate}
+			throttleDevice.Major = device.major
+			throttleDevice.Minor = device.minor
+			*t.list = append(*t.list, throttleDevice)
+		}
+	}
+	return blkio
+}
+
+// ioMaxLine builds the line written into io.max of cgroup v2. Only the limits set in the request
+// are written when applying, and all the limits are written when recovering, where a rate of 0
+// removes the limit.
+func ioMaxLine(device blockDevice, throttle *pb.IoThrottle, recover bool) string {
+	fields := []string{fmt.Sprintf("%d:%d", device.major, device.minor)}
+	for i, rate := range ioLimits(throttle) {
+		if *rate != 0 {
+			fields = append(fields, fmt.Sprintf("%s=%d", ioMaxKeys[i], *rate))
+		} else if recover {
+			fields = append(fields, ioMaxKeys[i]+"="+ioMaxUnlimited)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// writeIoMax writes the limits of the devices into io.max
+func writeIoMax(path string, devices []blockDevice, throttles []*pb.IoThrottle, recover bool) error {
+	for i, throttle := range throttles {
+		// the kernel only accepts one device in a write
+		line := ioMaxLine(devices[i], throttle, recover)
+		if err := ioutil.WriteFile(path, []byte(line), 0644); err != nil {
+			log.Error(err, "error while writing io.max", "path", path, "line", line)
+			return err
+		}
+	}
+	return nil
+}
+
+// readIoMax reads the limits of the devices in io.max, the limits which are
+// "max" are 0, and the devices without any limit are absent
+func readIoMax(path string) (map[blockDevice]*pb.IoThrottle, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[blockDevice]*pb.IoThrottle)
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		device, err := parseBlockDevice(fields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		throttle := &pb.IoThrottle{}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 || kv[1] == ioMaxUnlimited {
+				continue
+			}
+			for i, key := range ioMaxKeys {
+				if kv[0] != key {
+					continue
+				}
+				if *ioLimits(throttle)[i], err = strconv.ParseUint(kv[1], 10, 64); err != nil {
+					return nil, err
+				}
+			}
+		}
+		limits[device] = throttle
+	}
+	return limits, nil
+}
+
+// readBlkioThrottle reads the limits of the devices in the throttle files of blkio cgroup,
+// the devices without any limit are absent
+func readBlkioThrottle(path string) (map[blockDevice]*pb.IoThrottle, error) {
+	limits := make(map[blockDevice]*pb.IoThrottle)
+	for i, file := range blkioThrottleFiles {
+		content, err := ioutil.ReadFile(filepath.Join(path, file))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("invalid line %q in %s", line, file)
+			}
+			device, err := parseBlockDevice(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			rate, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := limits[device]; !ok {
+				limits[device] = &pb.IoThrottle{}
+			}
+			*ioLimits(limits[device])[i] = rate
+		}
+	}
+	return limits, nil
+}
+
+// blkioPath returns the path of the cgroup in the hierarchy of blkio
+func blkioPath(cgroup string) (string, error) {
+	subsystems, err := cgroups.V1()
+	if err != nil {
+		return "", err
+	}
+	for _, subsystem := range subsystems {
+		if p, ok := subsystem.(interface{ Path(string) string }); ok && subsystem.Name() == cgroups.Blkio {
+			return p.Path(cgroup), nil
+		}
+	}
+	return "", fmt.Errorf("never found the hierarchy of blkio")
+}
+
+// previousThrottles returns the limits of the devices in the request, where a rate of 0 means unlimited
+func previousThrottles(devices []blockDevice, throttles []*pb.IoThrottle, limits map[blockDevice]*pb.IoThrottle) []*pb.IoThrottle {
+	previous := make([]*pb.IoThrottle, 0, len(throttles))
+	for i, throttle := range throttles {
+		p := &pb.IoThrottle{Device: throttle.Device}
+		if limit, ok := limits[devices[i]]; ok {
+			p.ReadBps = limit.ReadBps
+			p.WriteBps = limit.WriteBps
+			p.ReadIops = limit.ReadIops
+			p.WriteIops = limit.WriteIops
+		}
+		previous = append(previous, p)
+	}
+	return previous
+}
+
+// ioLimits returns the limits of throttle in the order of ioMaxKeys and blkioThrottleFiles
+func ioLimits(throttle *pb.IoThrottle) []*uint64 {
+	return []*uint64{&throttle.ReadBps, &throttle.WriteBps, &throttle.ReadIops, &throttle.WriteIops}
+}
+
+// unifiedCgroupPath returns the cgroup v2 path of the process, which is the entry with hierarchy
+// ID 0 in /proc/<pid>/cgroup. Like the paths of cgroup v1, it's localized based on the root of the
+// cgroup2 mount of the process, as the entry is relative to the cgroup namespace of the process.
+func unifiedCgroupPath(pid int) (string, error) {
+	cgroup, err := os.Open(fmt.Sprintf("%s/%d/cgroup", defaultProcPrefix, pid))
+	if err != nil {
+		return "", err
+	}
+	defer cgroup.Close()
+
+	mountinfo, err := os.Open(fmt.Sprintf("%s/%d/mountinfo", defaultProcPrefix, pid))
+	if err != nil {
+		return "", err
+	}
+	defer mountinfo.Close()
+
+	return parseUnifiedCgroupPath(cgroup, mountinfo)
+}
+
+func parseUnifiedCgroupPath(cgroup io.Reader, mountinfo io.Reader) (string, error) {
+	var path string
+	s := bufio.NewScanner(cgroup)
+	for s.Scan() {
+		if p := strings.TrimPrefix(s.Text(), "0::"); p != s.Text() {
+			path = p
+			break
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", fmt.Errorf("never found cgroup v2 path")
+	}
+
+	dest, err := unifiedCgroupDestination(mountinfo)
+	if err != nil {
+		return "", err
+	}
+	return localizeCgroupPath(dest, path)
+}
+
+// unifiedCgroupDestination returns the root of the cgroup2 mount in the mountinfo
+func unifiedCgroupDestination(mountinfo io.Reader) (string, error) {
+	s := bufio.NewScanner(mountinfo)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		// the optional fields end with a separator "-", which is followed by the filesystem type
+		for i := 6; i < len(fields)-1; i++ {
+			if fields[i] == "-" && fields[i+1] == "cgroup2" {
+				return fields[3], nil
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("never found the mount of cgroup2")
+}
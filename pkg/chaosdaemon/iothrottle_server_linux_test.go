@@ -0,0 +1,220 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
+)
+
+var _ = Describe("io throttle server", func() {
+	defer mock.With("MockContainerdClient", &MockClient{})()
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	throttles := []*pb.IoThrottle{
+		{
+			Device:   "8:0",
+			ReadBps:  1048576,
+			WriteBps: 2097152,
+		},
+		{
+			Device:    "/dev/sdb",
+			WriteIops: 100,
+		},
+	}
+	devices := []blockDevice{{major: 8, minor: 0}, {major: 8, minor: 16}}
+
+	throttleDevice := func(major int64, minor int64, rate uint64) specs.LinuxThrottleDevice {
+		device := specs.LinuxThrottleDevice{Rate: rate}
+		device.Major = major
+		device.Minor = minor
+		return device
+	}
+
+	Context("parseBlockDevice", func() {
+		It("should parse device number", func() {
+			device, err := parseBlockDevice("259:1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(device).To(Equal(blockDevice{major: 259, minor: 1}))
+		})
+
+		It("should fail on character device", func() {
+			_, err := parseBlockDevice("/dev/null")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail on non-existent device", func() {
+			_, err := parseBlockDevice("/dev/chaos-mesh-non-existent")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("blkioThrottle", func() {
+		It("should only set the limits in the request", func() {
+			Expect(blkioThrottle(devices, throttles, false)).To(Equal(&specs.LinuxBlockIO{
+				ThrottleReadBpsDevice:   []specs.LinuxThrottleDevice{throttleDevice(8, 0, 1048576)},
+				ThrottleWriteBpsDevice:  []specs.LinuxThrottleDevice{throttleDevice(8, 0, 2097152)},
+				ThrottleWriteIOPSDevice: []specs.LinuxThrottleDevice{throttleDevice(8, 16, 100)},
+			}))
+		})
+
+		It("should write all the limits on recover", func() {
+			Expect(blkioThrottle(devices, throttles, true)).To(Equal(&specs.LinuxBlockIO{
+				ThrottleReadBpsDevice:   []specs.LinuxThrottleDevice{throttleDevice(8, 0, 1048576), throttleDevice(8, 16, 0)},
+				ThrottleWriteBpsDevice:  []specs.LinuxThrottleDevice{throttleDevice(8, 0, 2097152), throttleDevice(8, 16, 0)},
+				ThrottleReadIOPSDevice:  []specs.LinuxThrottleDevice{throttleDevice(8, 0, 0), throttleDevice(8, 16, 0)},
+				ThrottleWriteIOPSDevice: []specs.LinuxThrottleDevice{throttleDevice(8, 0, 0), throttleDevice(8, 16, 100)},
+			}))
+		})
+	})
+
+	Context("ioMaxLine", func() {
+		It("should only set the limits in the request", func() {
+			Expect(ioMaxLine(devices[0], throttles[0], false)).To(Equal("8:0 rbps=1048576 wbps=2097152"))
+			Expect(ioMaxLine(devices[1], throttles[1], false)).To(Equal("8:16 wiops=100"))
+		})
+
+		It("should write all the limits on recover", func() {
+			Expect(ioMaxLine(devices[1], throttles[1], true)).To(Equal("8:16 rbps=max wbps=max riops=max wiops=100"))
+			Expect(ioMaxLine(devices[1], &pb.IoThrottle{}, true)).To(Equal("8:16 rbps=max wbps=max riops=max wiops=max"))
+		})
+	})
+
+	Context("readIoMax and readBlkioThrottle", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "io-throttle")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should read the limits in io.max", func() {
+			path := filepath.Join(dir, ioMaxFile)
+			content := "8:0 rbps=1048576 wbps=max riops=max wiops=max\n8:16 rbps=max wbps=max riops=max wiops=100\n"
+			Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+
+			limits, err := readIoMax(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limits).To(Equal(map[blockDevice]*pb.IoThrottle{
+				{major: 8, minor: 0}:  {ReadBps: 1048576},
+				{major: 8, minor: 16}: {WriteIops: 100},
+			}))
+		})
+
+		It("should read the limits in blkio", func() {
+			for file, content := range map[string]string{
+				"blkio.throttle.read_bps_device":   "8:0 1048576\n",
+				"blkio.throttle.write_bps_device":  "",
+				"blkio.throttle.read_iops_device":  "",
+				"blkio.throttle.write_iops_device": "8:0 50\n8:16 100\n",
+			} {
+				Expect(ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0644)).To(Succeed())
+			}
+
+			limits, err := readBlkioThrottle(dir)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(limits).To(Equal(map[blockDevice]*pb.IoThrottle{
+				{major: 8, minor: 0}:  {ReadBps: 1048576, WriteIops: 50},
+				{major: 8, minor: 16}: {WriteIops: 100},
+			}))
+		})
+	})
+
+	Context("previousThrottles", func() {
+		It("should return the limits of the devices in the request", func() {
+			limits := map[blockDevice]*pb.IoThrottle{
+				{major: 8, minor: 0}:  {ReadBps: 4096, WriteIops: 10},
+				{major: 8, minor: 32}: {ReadBps: 8192},
+			}
+			Expect(previousThrottles(devices, throttles, limits)).To(Equal([]*pb.IoThrottle{
+				{Device: "8:0", ReadBps: 4096, WriteIops: 10},
+				{Device: "/dev/sdb"},
+			}))
+		})
+	})
+
+	Context("parseUnifiedCgroupPath", func() {
+		const cgroup = "0::/kubepods/pod-uid/container-id\n"
+
+		It("should keep the path without cgroup namespace", func() {
+			mountinfo := "29 1 0:26 / /sys/fs/cgroup rw,nosuid shared:4 - cgroup2 cgroup2 rw,nsdelegate\n"
+			path, err := parseUnifiedCgroupPath(strings.NewReader(cgroup), strings.NewReader(mountinfo))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/kubepods/pod-uid/container-id"))
+		})
+
+		It("should localize the path based on the root of mount", func() {
+			mountinfo := "1050 1044 0:26 /kubepods/pod-uid/container-id /sys/fs/cgroup ro,nosuid - cgroup2 cgroup rw\n"
+			path, err := parseUnifiedCgroupPath(strings.NewReader(cgroup), strings.NewReader(mountinfo))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/kubepods/pod-uid/container-id"))
+
+			mountinfo = "1050 1044 0:26 /kubepods /sys/fs/cgroup ro,nosuid - cgroup2 cgroup rw\n"
+			path, err = parseUnifiedCgroupPath(strings.NewReader(cgroup), strings.NewReader(mountinfo))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/pod-uid/container-id"))
+		})
+
+		It("should fail without cgroup2 mount", func() {
+			mountinfo := "35 25 0:30 / /sys/fs/cgroup/blkio rw,nosuid shared:15 - cgroup cgroup rw,blkio\n"
+			_, err := parseUnifiedCgroupPath(strings.NewReader(cgroup), strings.NewReader(mountinfo))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ApplyIoThrottle", func() {
+		It("should fail on get pid", func() {
+			const errorStr = "mock error on Task()"
+			defer mock.With("TaskError", errors.New(errorStr))()
+			_, err := s.ApplyIoThrottle(context.TODO(), &pb.IoThrottleRequest{
+				ContainerId: "containerd://container-id",
+				Throttles:   throttles,
+			})
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal(errorStr))
+		})
+
+		It("should fail on invalid device", func() {
+			_, err := s.ApplyIoThrottle(context.TODO(), &pb.IoThrottleRequest{
+				ContainerId: "containerd://container-id",
+				Throttles: []*pb.IoThrottle{
+					{
+						Device:  "/dev/null",
+						ReadBps: 1048576,
+					},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -16,7 +16,9 @@ package chaosdaemon
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
@@ -28,6 +30,11 @@ const (
 	ipsetExistErr        = "set with the same name already exists"
 	ipExistErr           = "it's already added"
 	ipsetNewNameExistErr = "a set with the new name already exists"
+
+	// ipv6IPSetSuffix is appended to the name of ipset to hold IPv6 cidrs,
+	// the name of ipset generated by controller is no longer than 27 bytes,
+	// so the temp name with "old" is still in 31 bytes.
+	ipv6IPSetSuffix = "6"
 )
 
 func (s *daemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (*empty.Empty, error) {
@@ -39,19 +46,119 @@ func (s *daemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (
 		return nil, err
 	}
 
+	ipsets, interval, err := resolveIPSets(ctx, pid, req.Ipsets)
+	if err != nil {
+		log.Error(err, "error while resolving domains")
+		return nil, err
+	}
+
+	s.ipsetRefresher.Lock()
+	defer s.ipsetRefresher.Unlock()
+
+	// all the ipsets of the container are flushed by this request, so the domains
+	// of previous request are not refreshed any more
+	s.ipsetRefresher.stop(req.ContainerId)
+
+	err = flushIPSets(ctx, pid, ipsets)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasDomains(req.Ipsets) {
+		s.ipsetRefresher.start(req.ContainerId, interval, func(ctx context.Context) (time.Duration, error) {
+			pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+			if err != nil {
+				return 0, err
+			}
+
+			latest, interval, err := resolveIPSets(ctx, pid, req.Ipsets)
+			if err != nil {
+				log.Error(err, "error while resolving domains", "containerID", req.ContainerId)
+				return minResolveInterval, nil
+			}
+			if equalIPSets(latest, ipsets) {
+				return interval, nil
+			}
+
+			s.ipsetRefresher.Lock()
+			defer s.ipsetRefresher.Unlock()
+
+			// the refreshing may be stopped by another request while resolving
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+
+			log.Info("update ipsets as the addresses of domains change", "containerID", req.ContainerId, "ipsets", latest)
+			err = flushIPSets(ctx, pid, latest)
+			if err != nil {
+				log.Error(err, "error while updating ipsets", "containerID", req.ContainerId)
+				return minResolveInterval, nil
+			}
+
+			ipsets = latest
+			return interval, nil
+		})
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// flushIPSets flushes the ipsets in the network namespace of the process
+func flushIPSets(ctx context.Context, pid uint32, ipsets []*pb.IPSet) error {
 	nsPath := GetNsPath(pid, bpm.NetNS)
+	ipv6 := IPv6Enabled(pid)
+
+	for _, ipset := range ipsets {
+		v4Cidrs, v6Cidrs := splitCIDRsByFamily(ipset.Cidrs)
 
-	for _, ipset := range req.Ipsets {
-		err := flushIPSet(ctx, nsPath, ipset)
+		err := flushIPSet(ctx, nsPath, &pb.IPSet{Name: ipset.Name, Cidrs: v4Cidrs}, false)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		if !ipv6 {
+			if len(v6Cidrs) > 0 {
+				log.Info("ignore IPv6 cidrs as IPv6 is not enabled", "ipset", ipset.Name, "cidrs", v6Cidrs)
+			}
+			continue
+		}
+
+		// the IPv6 ipset is always created, so that the ip6tables rules can refer to it
+		err = flushIPSet(ctx, nsPath, &pb.IPSet{Name: ipv6IPSetName(ipset.Name), Cidrs: v6Cidrs}, true)
+		if err != nil {
+			return err
 		}
 	}
 
-	return &empty.Empty{}, nil
+	return nil
+}
+
+// ipv6IPSetName returns the name of ipset which contains the IPv6 cidrs of the ipset
+func ipv6IPSetName(name string) string {
+	return name + ipv6IPSetSuffix
+}
+
+// splitCIDRsByFamily splits the cidrs into IPv4 cidrs and IPv6 cidrs,
+// as an ipset can only contain the addresses of one family
+func splitCIDRsByFamily(cidrs []string) ([]string, []string) {
+	v4Cidrs, v6Cidrs := []string{}, []string{}
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			ip = net.ParseIP(cidr)
+		}
+
+		if ip != nil && ip.To4() == nil {
+			v6Cidrs = append(v6Cidrs, cidr)
+		} else {
+			v4Cidrs = append(v4Cidrs, cidr)
+		}
+	}
+
+	return v4Cidrs, v6Cidrs
 }
 
-func flushIPSet(ctx context.Context, nsPath string, set *pb.IPSet) error {
+func flushIPSet(ctx context.Context, nsPath string, set *pb.IPSet, ipv6 bool) error {
 	name := set.Name
 
 	// If the ipset already exists, the ipset will be renamed to this temp name.
@@ -59,7 +166,7 @@ func flushIPSet(ctx context.Context, nsPath string, set *pb.IPSet) error {
 
 	// the ipset while existing iptables rules are using them can not be deleted,.
 	// so we creates an temp ipset and swap it with existing one.
-	if err := createIPSet(ctx, nsPath, tmpName); err != nil {
+	if err := createIPSet(ctx, nsPath, tmpName, ipv6); err != nil {
 		return err
 	}
 
@@ -75,13 +182,25 @@ func flushIPSet(ctx context.Context, nsPath string, set *pb.IPSet) error {
 	return err
 }
 
-func createIPSet(ctx context.Context, nsPath string, name string) error {
+func createIPSet(ctx context.Context, nsPath string, name string, ipv6 bool) error {
 	// ipset name cannot be longer than 31 bytes
 	if len(name) > 31 {
 		name = name[:31]
 	}
 
-	cmd := bpm.DefaultProcessBuilder("ipset", "create", name, "hash:net").
+	if ok, err := tryNetlink(nsPath, func() error { return netlinkCreateIPSet(name, ipv6) }); ok {
+		if err != nil {
+			log.Error(err, "ipset create error", "name", name)
+		}
+		return err
+	}
+
+	args := []string{"create", name, "hash:net"}
+	if ipv6 {
+		args = append(args, "family", "inet6")
+	}
+
+	cmd := bpm.DefaultProcessBuilder("ipset", args...).
 		SetNetNS(nsPath).
 		SetContext(ctx).
 		Build()
@@ -114,6 +233,13 @@ func createIPSet(ctx context.Context, nsPath string, name string) error {
 }
 
 func addCIDRsToIPSet(ctx context.Context, nsPath string, name string, cidrs []string) error {
+	if ok, err := tryNetlink(nsPath, func() error { return netlinkAddCIDRsToIPSet(name, cidrs) }); ok {
+		if err != nil {
+			log.Error(err, "ipset add error", "name", name)
+		}
+		return err
+	}
+
 	for _, cidr := range cidrs {
 		cmd := bpm.DefaultProcessBuilder("ipset", "add", name, cidr).SetNetNS(nsPath).SetContext(ctx).Build()
 
@@ -133,6 +259,13 @@ func addCIDRsToIPSet(ctx context.Context, nsPath string, name string, cidrs []st
 }
 
 func renameIPSet(ctx context.Context, nsPath string, oldName string, newName string) error {
+	if ok, err := tryNetlink(nsPath, func() error { return netlinkRenameIPSet(oldName, newName) }); ok {
+		if err != nil {
+			log.Error(err, "rename ipset failed", "oldName", oldName, "newName", newName)
+		}
+		return err
+	}
+
 	cmd := bpm.DefaultProcessBuilder("ipset", "rename", oldName, newName).SetNetNS(nsPath).SetContext(ctx).Build()
 
 	log.Info("rename ipset", "command", cmd.String())
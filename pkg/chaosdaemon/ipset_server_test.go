@@ -32,7 +32,7 @@ var _ = Describe("ipset server", func() {
 	defer mock.With("MockContainerdClient", &MockClient{})()
 	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
 	m := bpm.NewBackgroundProcessManager()
-	s := &daemonServer{c, m}
+	s := &daemonServer{c, m, newIPSetRefresher()}
 
 	Context("createIPSet", func() {
 		It("should work", func() {
@@ -46,7 +46,17 @@ var _ = Describe("ipset server", func() {
 				Expect(args[5]).To(Equal("hash:net"))
 				return exec.Command("echo", "mock command")
 			})()
-			err := createIPSet(context.TODO(), "nsPath", "name")
+			err := createIPSet(context.TODO(), "nsPath", "name", false)
+			Expect(err).To(BeNil())
+		})
+
+		It("should create IPv6 ipset", func() {
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(cmd).To(Equal("nsenter"))
+				Expect(args[2:]).To(Equal([]string{"ipset", "create", "name6", "hash:net", "family", "inet6"}))
+				return exec.Command("echo", "mock command")
+			})()
+			err := createIPSet(context.TODO(), "nsPath", "name6", true)
 			Expect(err).To(BeNil())
 		})
 
@@ -65,7 +75,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", ipsetExistErr)
 			})()
-			err = createIPSet(context.TODO(), "nsPath", "name")
+			err = createIPSet(context.TODO(), "nsPath", "name", false)
 			Expect(err).To(BeNil())
 		})
 
@@ -80,7 +90,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(context.Context, string, ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", "fail msg")
 			})()
-			err = createIPSet(context.TODO(), "nsPath", "name")
+			err = createIPSet(context.TODO(), "nsPath", "name", false)
 			Expect(err).ToNot(BeNil())
 		})
 
@@ -95,7 +105,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(context.Context, string, ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", ipsetExistErr)
 			})()
-			err = createIPSet(context.TODO(), "nsPath", "name")
+			err = createIPSet(context.TODO(), "nsPath", "name", false)
 			Expect(err).ToNot(BeNil())
 		})
 	})
@@ -214,6 +224,59 @@ exit 1
 			Expect(err).To(BeNil())
 		})
 
+		It("should split mixed IPv4 and IPv6 cidrs", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("IPv6Enabled", true)()
+
+			commands := [][]string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				commands = append(commands, args[2:])
+				return exec.Command("echo", "mock command")
+			})()
+			_, err := s.FlushIPSets(context.TODO(), &pb.IPSetsRequest{
+				Ipsets: []*pb.IPSet{{
+					Name:  "ipset-name",
+					Cidrs: []string{"1.1.1.1/32", "fd00::1/128", "10.0.0.0/8", "fd00:1::/64"},
+				}},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(commands).To(Equal([][]string{
+				{"ipset", "create", "ipset-nameold", "hash:net"},
+				{"ipset", "add", "ipset-nameold", "1.1.1.1/32"},
+				{"ipset", "add", "ipset-nameold", "10.0.0.0/8"},
+				{"ipset", "rename", "ipset-nameold", "ipset-name"},
+				{"ipset", "create", "ipset-name6old", "hash:net", "family", "inet6"},
+				{"ipset", "add", "ipset-name6old", "fd00::1/128"},
+				{"ipset", "add", "ipset-name6old", "fd00:1::/64"},
+				{"ipset", "rename", "ipset-name6old", "ipset-name6"},
+			}))
+		})
+
+		It("should ignore IPv6 cidrs if IPv6 is disabled", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("IPv6Enabled", false)()
+
+			commands := [][]string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				commands = append(commands, args[2:])
+				return exec.Command("echo", "mock command")
+			})()
+			_, err := s.FlushIPSets(context.TODO(), &pb.IPSetsRequest{
+				Ipsets: []*pb.IPSet{{
+					Name:  "ipset-name",
+					Cidrs: []string{"1.1.1.1/32", "fd00::1/128"},
+				}},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(commands).To(Equal([][]string{
+				{"ipset", "create", "ipset-nameold", "hash:net"},
+				{"ipset", "add", "ipset-nameold", "1.1.1.1/32"},
+				{"ipset", "rename", "ipset-nameold", "ipset-name"},
+			}))
+		})
+
 		It("should fail on get pid", func() {
 			const errorStr = "mock get pid error"
 			defer mock.With("TaskError", errors.New(errorStr))()
@@ -16,6 +16,7 @@ package chaosdaemon
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -25,9 +26,12 @@ import (
 )
 
 const (
-	iptablesCmd = "iptables"
+	iptablesCmd  = "iptables"
+	ip6tablesCmd = "ip6tables"
 
 	iptablesChainAlreadyExistErr = "iptables: Chain already exists."
+	iptablesChainNotExistErr     = "No chain/target/match by that name"
+	iptablesRuleNotExistErr      = "does a matching rule exist in that chain?"
 )
 
 func (s *daemonServer) SetIptablesChains(ctx context.Context, req *pb.IptablesChainsRequest) (*empty.Empty, error) {
@@ -54,12 +58,31 @@ func (s *daemonServer) SetIptablesChains(ctx context.Context, req *pb.IptablesCh
 		return nil, err
 	}
 
+	if IPv6Enabled(pid) {
+		ip6tables := buildIp6tablesClient(ctx, nsPath)
+		err = ip6tables.initializeEnv()
+		if err != nil {
+			log.Error(err, "error while initializing ip6tables")
+			return nil, err
+		}
+
+		err = ip6tables.setIptablesChains(req.Chains)
+		if err != nil {
+			log.Error(err, "error while setting ip6tables chains")
+			return nil, err
+		}
+	}
+
 	return &empty.Empty{}, nil
 }
 
 type iptablesClient struct {
 	ctx    context.Context
 	nsPath string
+	// table is the iptables table to operate on, the default table (filter) is used if it's empty
+	table string
+	// ipv6 represents whether to operate ip6tables, whose rules match the IPv6 ipsets
+	ipv6 bool
 }
 
 type iptablesChain struct {
@@ -69,11 +92,34 @@ type iptablesChain struct {
 
 func buildIptablesClient(ctx context.Context, nsPath string) iptablesClient {
 	return iptablesClient{
-		ctx,
-		nsPath,
+		ctx:    ctx,
+		nsPath: nsPath,
 	}
 }
 
+// buildIp6tablesClient builds a client to operate ip6tables, the ipsets in chains
+// are replaced with the corresponding IPv6 ipsets
+func buildIp6tablesClient(ctx context.Context, nsPath string) iptablesClient {
+	return iptablesClient{
+		ctx:    ctx,
+		nsPath: nsPath,
+		ipv6:   true,
+	}
+}
+
+func (iptables *iptablesClient) buildCmd(args ...string) *bpm.ManagedProcess {
+	args = append([]string{"-w"}, args...)
+	if len(iptables.table) > 0 {
+		args = append([]string{"-t", iptables.table}, args...)
+	}
+
+	cmd := iptablesCmd
+	if iptables.ipv6 {
+		cmd = ip6tablesCmd
+	}
+	return bpm.DefaultProcessBuilder(cmd, args...).SetNetNS(iptables.nsPath).SetContext(iptables.ctx).Build()
+}
+
 func (iptables *iptablesClient) setIptablesChains(chains []*pb.Chain) error {
 	for _, chain := range chains {
 		err := iptables.setIptablesChain(chain)
@@ -95,9 +141,43 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 		return fmt.Errorf("unknown chain direction %d", chain.Direction)
 	}
 
-	rules := []string{}
+	filter := iptables.buildTrafficFilter(chain.Protocol, chain.SourcePort, chain.DestinationPort)
+	// the packets are matched randomly with the probability, which is a fraction in iptables
+	if chain.Probability > 0 && chain.Probability < 100 {
+		filter = append(filter, "-m", "statistic", "--mode", "random",
+			"--probability", strconv.FormatFloat(float64(chain.Probability)/100, 'f', -1, 32))
+	}
+	target := iptables.buildTarget(chain.Target, chain.RejectWith)
+
+	matches := [][]string{}
 	for _, ipset := range chain.Ipsets {
-		rules = append(rules, fmt.Sprintf("-A %s -m set --match-set %s %s -j %s -w 5", chain.Name, ipset, matchPart, chain.Target))
+		if iptables.ipv6 {
+			ipset = ipv6IPSetName(ipset)
+		}
+		matches = append(matches, []string{"-m", "set", "--match-set", ipset, matchPart})
+	}
+	// the chain without ipsets only works on the traffic matched by protocol and ports
+	if len(chain.Ipsets) == 0 && len(filter) > 0 {
+		matches = append(matches, []string{})
+	}
+
+	deviceMatch := []string{}
+	if len(chain.Device) > 0 && chain.Device != allDevices {
+		if chain.Direction == pb.Chain_INPUT {
+			deviceMatch = append(deviceMatch, "-i", chain.Device)
+		} else {
+			deviceMatch = append(deviceMatch, "-o", chain.Device)
+		}
+	}
+
+	rules := []string{}
+	for _, match := range matches {
+		rule := append([]string{"-A", chain.Name}, deviceMatch...)
+		rule = append(rule, match...)
+		rule = append(rule, filter...)
+		rule = append(rule, target...)
+		rule = append(rule, "-w", "5")
+		rules = append(rules, strings.Join(rule, " "))
 	}
 	err := iptables.createNewChain(&iptablesChain{
 		Name:  chain.Name,
@@ -127,6 +207,48 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 	return nil
 }
 
+// buildTarget builds the jump of rules, the ICMP errors of REJECT target are converted into
+// the ICMPv6 ones for ip6tables
+func (iptables *iptablesClient) buildTarget(target string, rejectWith string) []string {
+	jump := []string{"-j", target}
+	if target != "REJECT" || len(rejectWith) == 0 {
+		return jump
+	}
+
+	if iptables.ipv6 {
+		switch rejectWith {
+		case "icmp-port-unreachable":
+			rejectWith = "icmp6-port-unreachable"
+		case "icmp-host-unreachable":
+			rejectWith = "icmp6-addr-unreachable"
+		}
+	}
+
+	return append(jump, "--reject-with", rejectWith)
+}
+
+// buildTrafficFilter builds the matches of protocol and ports, the port ranges like "8000-9000"
+// are converted into the form of iptables "8000:9000"
+func (iptables *iptablesClient) buildTrafficFilter(protocol string, sourcePort string, destinationPort string) []string {
+	filter := []string{}
+	if len(protocol) > 0 {
+		if iptables.ipv6 && protocol == "icmp" {
+			protocol = "ipv6-icmp"
+		}
+		filter = append(filter, "-p", protocol)
+	}
+
+	if len(sourcePort) > 0 {
+		filter = append(filter, "-m", "multiport", "--sports", strings.Replace(sourcePort, "-", ":", -1))
+	}
+
+	if len(destinationPort) > 0 {
+		filter = append(filter, "-m", "multiport", "--dports", strings.Replace(destinationPort, "-", ":", -1))
+	}
+
+	return filter
+}
+
 func (iptables *iptablesClient) initializeEnv() error {
 	for _, direction := range []string{"INPUT", "OUTPUT"} {
 		chainName := "CHAOS-" + direction
@@ -150,7 +272,7 @@ func (iptables *iptablesClient) initializeEnv() error {
 
 // createNewChain will cover existing chain
 func (iptables *iptablesClient) createNewChain(chain *iptablesChain) error {
-	cmd := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-N", chain.Name).SetNetNS(iptables.nsPath).SetContext(iptables.ctx).Build()
+	cmd := iptables.buildCmd("-N", chain.Name)
 	out, err := cmd.CombinedOutput()
 
 	if (err == nil && len(out) == 0) ||
@@ -183,7 +305,7 @@ func (iptables *iptablesClient) deleteAndWriteRules(chain *iptablesChain) error
 }
 
 func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) error {
-	cmd := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-S", chain.Name).SetNetNS(iptables.nsPath).SetContext(iptables.ctx).Build()
+	cmd := iptables.buildCmd("-S", chain.Name)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return encodeOutputToError(out, err)
@@ -195,7 +317,7 @@ func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) er
 	}
 
 	// TODO: lock on every container but not on chaos-daemon's `/run/xtables.lock`
-	cmd = bpm.DefaultProcessBuilder(iptablesCmd, strings.Split("-w "+rule, " ")...).SetNetNS(iptables.nsPath).SetContext(iptables.ctx).Build()
+	cmd = iptables.buildCmd(strings.Split(rule, " ")...)
 	out, err = cmd.CombinedOutput()
 	if err != nil {
 		return encodeOutputToError(out, err)
@@ -205,7 +327,7 @@ func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) er
 }
 
 func (iptables *iptablesClient) flushIptablesChain(chain *iptablesChain) error {
-	cmd := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-F", chain.Name).SetNetNS(iptables.nsPath).SetContext(iptables.ctx).Build()
+	cmd := iptables.buildCmd("-F", chain.Name)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return encodeOutputToError(out, err)
@@ -213,3 +335,34 @@ func (iptables *iptablesClient) flushIptablesChain(chain *iptablesChain) error {
 
 	return nil
 }
+
+// deleteRule removes the rule, which is in the form of "-A CHAIN ...", it's a no-op if the rule doesn't exist
+func (iptables *iptablesClient) deleteRule(rule string) error {
+	args := strings.Split(rule, " ")
+	args[0] = "-D"
+	cmd := iptables.buildCmd(args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil && !strings.Contains(string(out), iptablesRuleNotExistErr) &&
+		!strings.Contains(string(out), iptablesChainNotExistErr) {
+		return encodeOutputToError(out, err)
+	}
+
+	return nil
+}
+
+// deleteChain flushes and removes the chain, it's a no-op if the chain doesn't exist.
+// The chain must not be referenced by any rule.
+func (iptables *iptablesClient) deleteChain(chain *iptablesChain) error {
+	for _, op := range []string{"-F", "-X"} {
+		cmd := iptables.buildCmd(op, chain.Name)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if strings.Contains(string(out), iptablesChainNotExistErr) {
+				return nil
+			}
+			return encodeOutputToError(out, err)
+		}
+	}
+
+	return nil
+}
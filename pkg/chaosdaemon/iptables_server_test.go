@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,7 +33,7 @@ var _ = Describe("iptables server", func() {
 	defer mock.With("MockContainerdClient", &MockClient{})()
 	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
 	m := bpm.NewBackgroundProcessManager()
-	s := &daemonServer{c, m}
+	s := &daemonServer{c, m, newIPSetRefresher()}
 
 	Context("FlushIptables", func() {
 		It("should work", func() {
@@ -55,6 +56,97 @@ var _ = Describe("iptables server", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("should set ip6tables chains with IPv6 ipsets", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("IPv6Enabled", true)()
+
+			commands := map[string][]string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(args[0]).To(Equal("-n/proc/9527/ns/net"))
+				commands[args[2]] = append(commands[args[2]], strings.Join(args[3:], " "))
+				return exec.Command("echo", "-n")
+			})()
+			_, err := s.SetIptablesChains(context.TODO(), &pb.IptablesChainsRequest{
+				Chains: []*pb.Chain{{
+					Name:      "TEST",
+					Direction: pb.Chain_OUTPUT,
+					Ipsets:    []string{"ipset-name"},
+					Target:    "DROP",
+				}},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(commands[iptablesCmd]).To(ContainElement("-w -A TEST -m set --match-set ipset-name dst -j DROP -w 5"))
+			Expect(commands[ip6tablesCmd]).To(ContainElement("-w -A TEST -m set --match-set ipset-name6 dst -j DROP -w 5"))
+			Expect(commands[ip6tablesCmd]).To(ContainElement("-w -A CHAOS-OUTPUT -j TEST"))
+		})
+
+		It("should set chains with protocol and ports", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("IPv6Enabled", true)()
+
+			commands := map[string][]string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				commands[args[2]] = append(commands[args[2]], strings.Join(args[3:], " "))
+				return exec.Command("echo", "-n")
+			})()
+			_, err := s.SetIptablesChains(context.TODO(), &pb.IptablesChainsRequest{
+				Chains: []*pb.Chain{{
+					Name:            "TEST",
+					Direction:       pb.Chain_INPUT,
+					Ipsets:          []string{"ipset-name"},
+					Target:          "DROP",
+					Protocol:        "tcp",
+					SourcePort:      "80,8000-9000",
+					DestinationPort: "5432",
+				}, {
+					Name:      "ICMP",
+					Direction: pb.Chain_OUTPUT,
+					Target:    "DROP",
+					Protocol:  "icmp",
+				}},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(commands[iptablesCmd]).To(ContainElement("-w -A TEST -m set --match-set ipset-name src -p tcp -m multiport --sports 80,8000:9000 -m multiport --dports 5432 -j DROP -w 5"))
+			Expect(commands[iptablesCmd]).To(ContainElement("-w -A ICMP -p icmp -j DROP -w 5"))
+			Expect(commands[ip6tablesCmd]).To(ContainElement("-w -A ICMP -p ipv6-icmp -j DROP -w 5"))
+		})
+
+		It("should set chains with reject target and probability", func() {
+			defer mock.With("pid", 9527)()
+			defer mock.With("IPv6Enabled", true)()
+
+			commands := map[string][]string{}
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				commands[args[2]] = append(commands[args[2]], strings.Join(args[3:], " "))
+				return exec.Command("echo", "-n")
+			})()
+			_, err := s.SetIptablesChains(context.TODO(), &pb.IptablesChainsRequest{
+				Chains: []*pb.Chain{{
+					Name:        "RESET",
+					Direction:   pb.Chain_INPUT,
+					Ipsets:      []string{"ipset-name"},
+					Target:      "REJECT",
+					Protocol:    "tcp",
+					RejectWith:  "tcp-reset",
+					Probability: 12.5,
+				}, {
+					Name:       "REJECT",
+					Direction:  pb.Chain_OUTPUT,
+					Ipsets:     []string{"ipset-name"},
+					Target:     "REJECT",
+					RejectWith: "icmp-host-unreachable",
+				}},
+				ContainerId: "containerd://container-id",
+			})
+			Expect(err).To(BeNil())
+			Expect(commands[iptablesCmd]).To(ContainElement("-w -A RESET -m set --match-set ipset-name src -p tcp -m statistic --mode random --probability 0.125 -j REJECT --reject-with tcp-reset -w 5"))
+			Expect(commands[ip6tablesCmd]).To(ContainElement("-w -A RESET -m set --match-set ipset-name6 src -p tcp -m statistic --mode random --probability 0.125 -j REJECT --reject-with tcp-reset -w 5"))
+			Expect(commands[iptablesCmd]).To(ContainElement("-w -A REJECT -m set --match-set ipset-name dst -j REJECT --reject-with icmp-host-unreachable -w 5"))
+			Expect(commands[ip6tablesCmd]).To(ContainElement("-w -A REJECT -m set --match-set ipset-name6 dst -j REJECT --reject-with icmp6-addr-unreachable -w 5"))
+		})
+
 		It("should fail on get pid", func() {
 			const errorStr = "mock error on Task()"
 			defer mock.With("TaskError", errors.New(errorStr))()
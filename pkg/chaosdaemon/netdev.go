@@ -0,0 +1,121 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+)
+
+const (
+	// allDevices represents all the network devices in the pod except the loopback device
+	allDevices = "*"
+)
+
+// networkDevice represents a network interface in the network namespace
+type networkDevice struct {
+	index    int
+	name     string
+	loopback bool
+}
+
+// ifbName returns the name of ifb device to shape the ingress traffic of the device,
+// which is identified by the index of device as the name of device may be too long
+func (d *networkDevice) ifbName() string {
+	return fmt.Sprintf("%s%d", ifbDevicePrefix, d.index)
+}
+
+// isIfb returns whether it's an ifb device created by chaos-daemon
+func (d *networkDevice) isIfb() bool {
+	return strings.HasPrefix(d.name, ifbDevicePrefix)
+}
+
+// listNetworkDevices lists all the network devices in the network namespace
+func listNetworkDevices(ctx context.Context, nsPath string) ([]networkDevice, error) {
+	var devices []networkDevice
+	ok, err := tryNetlink(nsPath, func() error {
+		var err error
+		devices, err = netlinkListDevices()
+		return err
+	})
+	if ok {
+		return devices, err
+	}
+
+	cmd := bpm.DefaultProcessBuilder("ip", "-o", "link", "show").SetNetNS(nsPath).SetContext(ctx).Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, encodeOutputToError(output, err)
+	}
+
+	return parseNetworkDevices(string(output))
+}
+
+// parseNetworkDevices parses the output of `ip -o link show`, whose lines are like
+// `2: eth0@if12: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP ...`
+func parseNetworkDevices(output string) ([]networkDevice, error) {
+	devices := []networkDevice{}
+	for _, line := range strings.Split(output, "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		fields := strings.SplitN(line, ": ", 3)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("unexpected output of ip link: %s", line)
+		}
+
+		index, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected output of ip link: %s", line)
+		}
+
+		devices = append(devices, networkDevice{
+			index:    index,
+			name:     strings.SplitN(fields[1], "@", 2)[0],
+			loopback: strings.Contains(fields[2], "LOOPBACK"),
+		})
+	}
+
+	return devices, nil
+}
+
+// resolveDevices returns the devices requested, the default device is used if it's empty
+// and all the devices except the loopback device and ifb devices are returned for `*`
+func resolveDevices(requested string, devices []networkDevice) ([]networkDevice, error) {
+	if requested == allDevices {
+		resolved := []networkDevice{}
+		for _, device := range devices {
+			if !device.loopback && !device.isIfb() {
+				resolved = append(resolved, device)
+			}
+		}
+		return resolved, nil
+	}
+
+	if len(requested) == 0 {
+		requested = defaultDevice
+	}
+	for _, device := range devices {
+		if device.name == requested {
+			return []networkDevice{device}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("network device %s not found", requested)
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+)
+
+// netlinkEnabled indicates whether the tc qdiscs, tc filters and ipsets are programmed through netlink,
+// otherwise the commands are executed in the network namespace of the container.
+// TODO: program the iptables rules through nftables
+var netlinkEnabled = false
+
+// tryNetlink runs the function through netlink in the network namespace if netlink is enabled,
+// it returns false if the operation should fallback to execute the commands
+func tryNetlink(nsPath string, fn func() error) (bool, error) {
+	if !netlinkEnabled {
+		return false, nil
+	}
+
+	err := withNetNS(nsPath, fn)
+	if err != nil && isNetlinkUnsupported(err) {
+		log.Info("netlink is not supported, fallback to execute commands", "error", err.Error())
+		return false, nil
+	}
+
+	return true, err
+}
+
+// isNetlinkUnsupported returns whether the error means the operation can't be done through netlink
+func isNetlinkUnsupported(err error) bool {
+	return err == netlink.ErrNotImplemented || err == syscall.EOPNOTSUPP || err == syscall.EPROTONOSUPPORT
+}
+
+// parseTcHandle parses the handle in the parent or handle argument of tc, like "root",
+// "parent 3:4" or "handle 4:", whose numbers are hexadecimal as they are in tc
+func parseTcHandle(arg string) (uint32, error) {
+	fields := strings.Fields(arg)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty tc handle")
+	}
+
+	value := fields[len(fields)-1]
+	if value == "root" {
+		return netlink.HANDLE_ROOT, nil
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid tc handle %s", value)
+	}
+
+	major, err := strconv.ParseUint(parts[0], 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tc handle %s", value)
+	}
+
+	var minor uint64
+	if len(parts[1]) > 0 {
+		minor, err = strconv.ParseUint(parts[1], 16, 16)
+		if err != nil {
+			return 0, fmt.Errorf("invalid tc handle %s", value)
+		}
+	}
+
+	return netlink.MakeHandle(uint16(major), uint16(minor)), nil
+}
+
+// parseCIDR parses the cidr or the ip address, which is regarded as a host cidr
+func parseCIDR(cidr string) (*net.IPNet, error) {
+	if ip := net.ParseIP(cidr); ip != nil {
+		bits := net.IPv6len * 8
+		if ip.To4() != nil {
+			bits = net.IPv4len * 8
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipnet, err := net.ParseCIDR(cidr)
+	return ipnet, err
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"github.com/vishvananda/netlink"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func withNetNS(nsPath string, fn func() error) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkListDevices() ([]networkDevice, error) {
+	return nil, netlink.ErrNotImplemented
+}
+
+func netlinkFlushQdisc(device string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddNetem(device string, parentArg string, handleArg string, netem *pb.Netem) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddTbf(device string, parentArg string, handleArg string, tbf *pb.Tbf) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddPrio(device string, parentArg string, handleArg string, band int) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkDeleteQdisc(device string, parentArg string, handleArg string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddSfq(device string, parentArg string, handleArg string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkRedirectIngress(device string, ifb string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkDeleteIfb(device string, ifb string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkCreateIPSet(name string, ipv6 bool) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddCIDRsToIPSet(name string, cidrs []string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkRenameIPSet(oldName string, newName string) error {
+	return netlink.ErrNotImplemented
+}
+
+func netlinkAddBasicFilter(device string, parentArg string, classid string, filter tcFilter, ipv6 bool) error {
+	return netlink.ErrNotImplemented
+}
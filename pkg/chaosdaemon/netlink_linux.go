@@ -0,0 +1,695 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// sizeofSfqQopt is the size of struct tc_sfq_qopt, the zero values mean the default values of kernel
+	sizeofSfqQopt = 20
+
+	// the ipset protocol over nfnetlink, see include/uapi/linux/netfilter/ipset/ip_set.h
+	nfnlSubsysIPSet = 6
+	ipsetProtocol   = 6
+
+	ipsetCmdCreate = 2
+	ipsetCmdFlush  = 4
+	ipsetCmdRename = 5
+	ipsetCmdSwap   = 6
+	ipsetCmdAdd    = 9
+
+	ipsetAttrProtocol = 1
+	ipsetAttrSetName  = 2
+	ipsetAttrTypeName = 3
+	ipsetAttrSetName2 = ipsetAttrTypeName
+	ipsetAttrRevision = 4
+	ipsetAttrFamily   = 5
+	ipsetAttrData     = 7
+	ipsetAttrIP       = 1
+	ipsetAttrCidr     = 3
+	ipsetAttrIPAddrV4 = 1
+	ipsetAttrIPAddrV6 = 2
+
+	ipsetErrExistSetName2 = syscall.Errno(4101)
+
+	// hashNetRevision is the revision of hash:net supported by all the kernels
+	hashNetRevision = 0
+
+	nlaFlagNetByteOrder = 1 << 14
+
+	// the basic classifier with ematches, see include/uapi/linux/pkt_cls.h and include/uapi/linux/tc_ematch
+	tcaBasicClassid   = 1
+	tcaBasicEmatches  = 2
+	tcaEmatchTreeHdr  = 1
+	tcaEmatchTreeList = 2
+
+	tcfEmProgTc   = 2
+	tcfEmCmp      = 1
+	tcfEmIPSet    = 8
+	tcfEmRelAnd   = 1
+	tcfEmInvert   = 4
+	tcfEmAlignU8  = 1
+	tcfEmAlignU16 = 2
+	tcfEmOpndEq   = 0
+	tcfEmOpndGt   = 1
+	tcfEmOpndLt   = 2
+
+	tcfLayerNetwork = 1
+
+	// the sockopt of ipset to get the index of ipset by name, see include/uapi/linux/netfilter/ipset/ip_set.h
+	soIPSet          = 83
+	ipsetOpVersion   = 0x100
+	ipsetOpGetByName = 6
+	ipsetMaxNameLen  = 32
+	ipsetInvalidID   = 0xffff
+	ipsetDimOne      = 1
+	ipsetDimOneSrc   = 1 << ipsetDimOne
+)
+
+// ematch is an extended match of the basic classifier
+type ematch struct {
+	kind   uint16
+	invert bool
+	data   []byte
+}
+
+// newCmpEmatch creates an ematch comparing the value at the offset of the network header, the field is
+// read in network byte order and compared without the mask
+func newCmpEmatch(align uint8, offset int, opnd uint8, value uint32, invert bool) ematch {
+	// struct tcf_em_cmp
+	data := make([]byte, 12)
+	native := nl.NativeEndian()
+	native.PutUint32(data[0:4], value)
+	native.PutUint16(data[8:10], uint16(offset))
+	data[10] = align
+	data[11] = tcfLayerNetwork | opnd<<4
+
+	return ematch{kind: tcfEmCmp, invert: invert, data: data}
+}
+
+// newIPSetEmatch creates an ematch matching the source of packets with the ipset
+func newIPSetEmatch(index uint16) ematch {
+	// struct xt_set_info
+	data := make([]byte, 4)
+	nl.NativeEndian().PutUint16(data[0:2], index)
+	data[2] = ipsetDimOne
+	data[3] = ipsetDimOneSrc
+
+	return ematch{kind: tcfEmIPSet, data: data}
+}
+
+// convertFilterToEmatches converts the filter into the lists of ematches like convertFilterToEmatch, and the
+// packets matched by all the ematches in any of the lists are matched by the filter. The ematches of a list
+// are joined with AND, so that there is a list for every combination of the source and destination ports
+func convertFilterToEmatches(filter tcFilter, ipv6 bool, ipsetIndex uint16) ([][]ematch, error) {
+	protocolOffset, headerLength := filterOffsets(ipv6)
+
+	matches := []ematch{}
+	if len(filter.ipset) > 0 {
+		matches = append(matches, newIPSetEmatch(ipsetIndex))
+	}
+
+	if len(filter.protocol) > 0 {
+		matches = append(matches, newCmpEmatch(tcfEmAlignU8, protocolOffset, tcfEmOpndEq, uint32(protocolNumber(filter.protocol, ipv6)), false))
+	}
+
+	lists := [][]ematch{matches}
+	for _, ports := range []struct {
+		value  string
+		offset int
+	}{
+		{filter.sourcePort, headerLength},
+		{filter.destinationPort, headerLength + 2},
+	} {
+		if len(ports.value) == 0 {
+			continue
+		}
+
+		alternatives, err := convertPortsToEmatches(ports.value, ports.offset)
+		if err != nil {
+			return nil, err
+		}
+
+		product := [][]ematch{}
+		for _, list := range lists {
+			for _, alternative := range alternatives {
+				combination := append([]ematch{}, list...)
+				product = append(product, append(combination, alternative...))
+			}
+		}
+		lists = product
+	}
+
+	return lists, nil
+}
+
+// convertPortsToEmatches converts the ports like "80,8000-9000" into the alternative lists of ematches,
+// a port range is matched as it's neither less than the lower bound nor greater than the upper bound
+func convertPortsToEmatches(ports string, offset int) ([][]ematch, error) {
+	alternatives := [][]ematch{}
+	for _, part := range strings.Split(ports, ",") {
+		bounds := strings.Split(part, "-")
+		lower, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %s", part)
+		}
+
+		if len(bounds) == 1 {
+			alternatives = append(alternatives, []ematch{newCmpEmatch(tcfEmAlignU16, offset, tcfEmOpndEq, uint32(lower), false)})
+			continue
+		}
+
+		upper, err := strconv.ParseUint(bounds[1], 10, 16)
+		if err != nil || len(bounds) > 2 {
+			return nil, fmt.Errorf("invalid port range %s", part)
+		}
+		alternatives = append(alternatives, []ematch{
+			newCmpEmatch(tcfEmAlignU16, offset, tcfEmOpndLt, uint32(lower), true),
+			newCmpEmatch(tcfEmAlignU16, offset, tcfEmOpndGt, uint32(upper), true),
+		})
+	}
+
+	return alternatives, nil
+}
+
+// newEmatchTree creates the TCA_BASIC_EMATCHES attribute with the ematches joined with AND
+func newEmatchTree(matches []ematch) *nl.RtAttr {
+	native := nl.NativeEndian()
+
+	tree := nl.NewRtAttr(tcaBasicEmatches, nil)
+
+	// struct tcf_ematch_tree_hdr
+	hdr := make([]byte, 4)
+	native.PutUint16(hdr[0:2], uint16(len(matches)))
+	native.PutUint16(hdr[2:4], tcfEmProgTc)
+	nl.NewRtAttrChild(tree, tcaEmatchTreeHdr, hdr)
+
+	list := nl.NewRtAttrChild(tree, tcaEmatchTreeList, nil)
+	for index, match := range matches {
+		// the relation of the last ematch is TCF_EM_REL_END
+		var flags uint16
+		if index < len(matches)-1 {
+			flags = tcfEmRelAnd
+		}
+		if match.invert {
+			flags |= tcfEmInvert
+		}
+
+		// struct tcf_ematch_hdr, followed by the data of ematch
+		data := make([]byte, 8, 8+len(match.data))
+		native.PutUint16(data[2:4], match.kind)
+		native.PutUint16(data[4:6], flags)
+		nl.NewRtAttrChild(list, index+1, append(data, match.data...))
+	}
+
+	return tree
+}
+
+// ipsetIndex gets the index of the ipset by name through the sockopt of ipset like tc, which is required by the ematch
+func ipsetIndex(name string) (uint16, error) {
+	if len(name) >= ipsetMaxNameLen {
+		return 0, fmt.Errorf("ipset name %s is too long", name)
+	}
+
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_RAW, unix.IPPROTO_RAW)
+	if err != nil {
+		return 0, err
+	}
+	defer unix.Close(fd)
+
+	native := nl.NativeEndian()
+
+	// struct ip_set_req_version
+	version := make([]byte, 8)
+	native.PutUint32(version[0:4], ipsetOpVersion)
+	if err := getIPSetSockopt(fd, version); err != nil {
+		return 0, err
+	}
+
+	// struct ip_set_req_get_set
+	req := make([]byte, 8+ipsetMaxNameLen)
+	native.PutUint32(req[0:4], ipsetOpGetByName)
+	copy(req[4:8], version[4:8])
+	copy(req[8:], name)
+	if err := getIPSetSockopt(fd, req); err != nil {
+		return 0, err
+	}
+
+	index := native.Uint16(req[8:10])
+	if index == ipsetInvalidID {
+		return 0, fmt.Errorf("ipset %s not found", name)
+	}
+
+	return index, nil
+}
+
+// getIPSetSockopt sends the request of ipset, and the response is written back into the request
+func getIPSetSockopt(fd int, req []byte) error {
+	length := uint32(len(req))
+	_, _, errno := unix.Syscall6(unix.SYS_GETSOCKOPT, uintptr(fd), unix.SOL_IP, soIPSet,
+		uintptr(unsafe.Pointer(&req[0])), uintptr(unsafe.Pointer(&length)), 0)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// withNetNS runs the function in the network namespace, so that the netlink requests
+// in the function are sent to the network namespace of the container
+func withNetNS(nsPath string, fn func() error) error {
+	errCh := make(chan error, 1)
+
+	// the function runs in a dedicated goroutine, as the thread is not reusable if the
+	// network namespace can't be restored, and it's terminated with the locked goroutine
+	go func() {
+		runtime.LockOSThread()
+
+		origin, err := netns.Get()
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer origin.Close()
+
+		target, err := netns.GetFromPath(nsPath)
+		if err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer target.Close()
+
+		if err := netns.Set(target); err != nil {
+			runtime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+
+		err = fn()
+
+		if restoreErr := netns.Set(origin); restoreErr != nil {
+			log.Error(restoreErr, "error while restoring network namespace")
+		} else {
+			runtime.UnlockOSThread()
+		}
+
+		errCh <- err
+	}()
+
+	return <-errCh
+}
+
+func netlinkQdiscAttrs(device string, parentArg string, handleArg string) (netlink.QdiscAttrs, error) {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return netlink.QdiscAttrs{}, err
+	}
+
+	parent, err := parseTcHandle(parentArg)
+	if err != nil {
+		return netlink.QdiscAttrs{}, err
+	}
+
+	handle, err := parseTcHandle(handleArg)
+	if err != nil {
+		return netlink.QdiscAttrs{}, err
+	}
+
+	return netlink.QdiscAttrs{
+		LinkIndex: link.Attrs().Index,
+		Parent:    parent,
+		Handle:    handle,
+	}, nil
+}
+
+func netlinkListDevices() ([]networkDevice, error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []networkDevice{}
+	for _, link := range links {
+		attrs := link.Attrs()
+		devices = append(devices, networkDevice{
+			index:    attrs.Index,
+			name:     attrs.Name,
+			loopback: attrs.Flags&net.FlagLoopback != 0,
+		})
+	}
+
+	return devices, nil
+}
+
+// netlinkFlushQdisc deletes the root qdisc of the device, which is ignored if not exist
+func netlinkFlushQdisc(device string) error {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.QdiscDel(&netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_ROOT,
+		},
+	})
+	if err != nil && err != syscall.ENOENT {
+		return err
+	}
+
+	return nil
+}
+
+// netlinkAddNetem adds a netem qdisc, the distribution, rate and slot of netem are not supported
+// by the netlink library, so that they fallback to execute tc, which also loads the distribution tables
+func netlinkAddNetem(device string, parentArg string, handleArg string, netem *pb.Netem) error {
+	if len(netem.Distribution) > 0 || netem.Rate > 0 || netem.SlotMin > 0 {
+		return netlink.ErrNotImplemented
+	}
+
+	attrs, err := netlinkQdiscAttrs(device, parentArg, handleArg)
+	if err != nil {
+		return err
+	}
+
+	return netlink.QdiscAdd(netlink.NewNetem(attrs, ToNetlinkNetemAttrs(netem)))
+}
+
+func netlinkAddTbf(device string, parentArg string, handleArg string, tbf *pb.Tbf) error {
+	attrs, err := netlinkQdiscAttrs(device, parentArg, handleArg)
+	if err != nil {
+		return err
+	}
+
+	// the rates are bits per second as they are passed to tc without unit, while the
+	// rates in netlink are bytes per second, and the burst is converted to ticks like tc
+	rate := tbf.Rate / 8
+	return netlink.QdiscAdd(&netlink.Tbf{
+		QdiscAttrs: attrs,
+		Rate:       rate,
+		Limit:      tbf.Limit,
+		Buffer:     uint32(netlink.Xmittime(rate, tbf.Buffer)),
+		Peakrate:   tbf.PeakRate / 8,
+		Minburst:   tbf.MinBurst,
+	})
+}
+
+func netlinkAddPrio(device string, parentArg string, handleArg string, band int) error {
+	attrs, err := netlinkQdiscAttrs(device, parentArg, handleArg)
+	if err != nil {
+		return err
+	}
+
+	return netlink.QdiscAdd(&netlink.Prio{
+		QdiscAttrs:  attrs,
+		Bands:       uint8(band),
+		PriorityMap: [16]uint8{1, 2, 2, 2, 1, 2, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1},
+	})
+}
+
+// netlinkDeleteQdisc deletes the qdisc with its children, which is ignored if not exist
+func netlinkDeleteQdisc(device string, parentArg string, handleArg string) error {
+	attrs, err := netlinkQdiscAttrs(device, parentArg, handleArg)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.QdiscDel(&netlink.GenericQdisc{QdiscAttrs: attrs})
+	if err != nil && err != syscall.ENOENT {
+		return err
+	}
+
+	return nil
+}
+
+// netlinkAddSfq adds a sfq qdisc, which is not supported by the netlink library
+func netlinkAddSfq(device string, parentArg string, handleArg string) error {
+	attrs, err := netlinkQdiscAttrs(device, parentArg, handleArg)
+	if err != nil {
+		return err
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_NEWQDISC, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(attrs.LinkIndex),
+		Handle:  attrs.Handle,
+		Parent:  attrs.Parent,
+	})
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated("sfq")))
+	req.AddData(nl.NewRtAttr(nl.TCA_OPTIONS, make([]byte, sizeofSfqQopt)))
+
+	_, err = req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+// netlinkRedirectIngress creates the ifb device and redirects all the ingress traffic of the device to it
+func netlinkRedirectIngress(device string, ifb string) error {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: ifb}})
+	if err != nil {
+		return err
+	}
+
+	ifbLink, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.LinkSetUp(ifbLink)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.QdiscAdd(&netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_INGRESS,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the u32 filter without selector matches all the packets
+	return netlink.FilterAdd(&netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.MakeHandle(0xffff, 0),
+			Protocol:  unix.ETH_P_ALL,
+		},
+		RedirIndex: ifbLink.Attrs().Index,
+	})
+}
+
+// netlinkDeleteIfb removes the ingress qdisc of the device and the ifb device, which is ignored if not exist
+func netlinkDeleteIfb(device string, ifb string) error {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return err
+	}
+
+	err = netlink.QdiscDel(&netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_INGRESS,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+		},
+	})
+	if err != nil && err != syscall.ENOENT && err != syscall.EINVAL {
+		return err
+	}
+
+	ifbLink, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	return netlink.LinkDel(ifbLink)
+}
+
+func newIPSetRequest(cmd int, flags int, name string) *nl.NetlinkRequest {
+	req := nl.NewNetlinkRequest((nfnlSubsysIPSet<<8)|cmd, flags|unix.NLM_F_ACK)
+	req.AddData(&nl.Nfgenmsg{
+		NfgenFamily: unix.AF_INET,
+		Version:     nl.NFNETLINK_V0,
+	})
+	req.AddData(nl.NewRtAttr(ipsetAttrProtocol, nl.Uint8Attr(ipsetProtocol)))
+	req.AddData(nl.NewRtAttr(ipsetAttrSetName, nl.ZeroTerminated(name)))
+
+	return req
+}
+
+// netlinkCreateIPSet creates a hash:net ipset, or flushes it if it already exists
+func netlinkCreateIPSet(name string, ipv6 bool) error {
+	family := uint8(unix.AF_INET)
+	if ipv6 {
+		family = unix.AF_INET6
+	}
+
+	req := newIPSetRequest(ipsetCmdCreate, unix.NLM_F_CREATE|unix.NLM_F_EXCL, name)
+	req.AddData(nl.NewRtAttr(ipsetAttrTypeName, nl.ZeroTerminated("hash:net")))
+	req.AddData(nl.NewRtAttr(ipsetAttrRevision, nl.Uint8Attr(hashNetRevision)))
+	req.AddData(nl.NewRtAttr(ipsetAttrFamily, nl.Uint8Attr(family)))
+
+	_, err := req.Execute(unix.NETLINK_NETFILTER, 0)
+	if err != syscall.EEXIST {
+		return err
+	}
+
+	_, err = newIPSetRequest(ipsetCmdFlush, 0, name).Execute(unix.NETLINK_NETFILTER, 0)
+	return err
+}
+
+// netlinkAddCIDRsToIPSet adds the cidrs to the ipset, the cidrs which are already added are ignored
+func netlinkAddCIDRsToIPSet(name string, cidrs []string) error {
+	for _, cidr := range cidrs {
+		ipnet, err := parseCIDR(cidr)
+		if err != nil {
+			return err
+		}
+
+		ip := nl.NewRtAttr(ipsetAttrIP|nl.NLA_F_NESTED, nil)
+		if v4 := ipnet.IP.To4(); v4 != nil {
+			nl.NewRtAttrChild(ip, ipsetAttrIPAddrV4|nlaFlagNetByteOrder, v4)
+		} else {
+			nl.NewRtAttrChild(ip, ipsetAttrIPAddrV6|nlaFlagNetByteOrder, ipnet.IP.To16())
+		}
+		ones, _ := ipnet.Mask.Size()
+
+		data := nl.NewRtAttr(ipsetAttrData|nl.NLA_F_NESTED, nil)
+		data.AddChild(ip)
+		nl.NewRtAttrChild(data, ipsetAttrCidr, nl.Uint8Attr(uint8(ones)))
+
+		// without NLM_F_EXCL, the kernel ignores the cidr which is already added
+		req := newIPSetRequest(ipsetCmdAdd, 0, name)
+		req.AddData(data)
+
+		_, err = req.Execute(unix.NETLINK_NETFILTER, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// netlinkRenameIPSet renames the ipset, or swaps them with each other if the new ipset already exists
+func netlinkRenameIPSet(oldName string, newName string) error {
+	req := newIPSetRequest(ipsetCmdRename, 0, oldName)
+	req.AddData(nl.NewRtAttr(ipsetAttrSetName2, nl.ZeroTerminated(newName)))
+
+	_, err := req.Execute(unix.NETLINK_NETFILTER, 0)
+	if err != ipsetErrExistSetName2 {
+		return err
+	}
+
+	req = newIPSetRequest(ipsetCmdSwap, 0, oldName)
+	req.AddData(nl.NewRtAttr(ipsetAttrSetName2, nl.ZeroTerminated(newName)))
+
+	_, err = req.Execute(unix.NETLINK_NETFILTER, 0)
+	return err
+}
+
+// netlinkAddBasicFilter adds the basic filters with ematches, which classify the traffic from the source
+// matched by the filter into the class
+func netlinkAddBasicFilter(device string, parentArg string, classid string, filter tcFilter, ipv6 bool) error {
+	link, err := netlink.LinkByName(device)
+	if err != nil {
+		return err
+	}
+
+	parent, err := parseTcHandle(parentArg)
+	if err != nil {
+		return err
+	}
+
+	class, err := parseTcHandle(classid)
+	if err != nil {
+		return err
+	}
+
+	var index uint16
+	if len(filter.ipset) > 0 {
+		name := filter.ipset
+		if ipv6 {
+			name = ipv6IPSetName(name)
+		}
+
+		index, err = ipsetIndex(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	lists, err := convertFilterToEmatches(filter, ipv6, index)
+	if err != nil {
+		return err
+	}
+
+	protocol := uint16(unix.ETH_P_IP)
+	if ipv6 {
+		protocol = unix.ETH_P_IPV6
+	}
+
+	for _, matches := range lists {
+		// the kernel allocates the priority of filter as it's zero
+		req := nl.NewNetlinkRequest(unix.RTM_NEWTFILTER, unix.NLM_F_CREATE|unix.NLM_F_EXCL|unix.NLM_F_ACK)
+		req.AddData(&nl.TcMsg{
+			Family:  nl.FAMILY_ALL,
+			Ifindex: int32(link.Attrs().Index),
+			Parent:  parent,
+			Info:    netlink.MakeHandle(0, nl.Swap16(protocol)),
+		})
+		req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated("basic")))
+
+		options := nl.NewRtAttr(nl.TCA_OPTIONS, nil)
+		nl.NewRtAttrChild(options, tcaBasicClassid, nl.Uint32Attr(class))
+		options.AddChild(newEmatchTree(matches))
+		req.AddData(options)
+
+		_, err = req.Execute(unix.NETLINK_ROUTE, 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,460 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// setupNetlinkTest creates a private network namespace with a veth pair veth0 and veth1,
+// the test is skipped if the network namespace can't be created
+func setupNetlinkTest(t *testing.T) (string, func()) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		t.Skipf("can't get the network namespace: %v", err)
+	}
+	defer origin.Close()
+
+	ns, err := netns.New()
+	if err != nil {
+		t.Skipf("can't create a network namespace: %v", err)
+	}
+	if err := netns.Set(origin); err != nil {
+		t.Fatalf("can't restore the network namespace: %v", err)
+	}
+
+	nsPath := fmt.Sprintf("/proc/%d/fd/%d", os.Getpid(), int(ns))
+	err = withNetNS(nsPath, func() error {
+		veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "veth0"}, PeerName: "veth1"}
+		if err := netlink.LinkAdd(veth); err != nil {
+			return err
+		}
+		return netlink.LinkSetUp(veth)
+	})
+	if err != nil {
+		ns.Close()
+		t.Skipf("can't create veth devices: %v", err)
+	}
+
+	netlinkEnabled = true
+	return nsPath, func() {
+		netlinkEnabled = false
+		ns.Close()
+	}
+}
+
+func listQdiscs(g *WithT, nsPath string, device string) []netlink.Qdisc {
+	var qdiscs []netlink.Qdisc
+	err := withNetNS(nsPath, func() error {
+		link, err := netlink.LinkByName(device)
+		if err != nil {
+			return err
+		}
+		qdiscs, err = netlink.QdiscList(link)
+		return err
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return qdiscs
+}
+
+func Test_parseTcHandle(t *testing.T) {
+	g := NewWithT(t)
+
+	cases := []struct {
+		arg    string
+		handle uint32
+	}{
+		{"root", netlink.HANDLE_ROOT},
+		{"parent 1:", netlink.MakeHandle(1, 0)},
+		{"parent 3:4", netlink.MakeHandle(3, 4)},
+		{"handle 10:", netlink.MakeHandle(0x10, 0)},
+		{"ffff:", netlink.MakeHandle(0xffff, 0)},
+	}
+	for _, c := range cases {
+		handle, err := parseTcHandle(c.arg)
+		g.Expect(err).ShouldNot(HaveOccurred(), c.arg)
+		g.Expect(handle).To(Equal(c.handle), c.arg)
+	}
+
+	for _, arg := range []string{"", "handle 1", "parent x:1", "parent 1:10000"} {
+		_, err := parseTcHandle(arg)
+		g.Expect(err).Should(HaveOccurred(), arg)
+	}
+}
+
+func Test_parseCIDR(t *testing.T) {
+	g := NewWithT(t)
+
+	ipnet, err := parseCIDR("10.0.0.1")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ipnet.String()).To(Equal("10.0.0.1/32"))
+
+	ipnet, err = parseCIDR("fd00::1")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ipnet.String()).To(Equal("fd00::1/128"))
+
+	ipnet, err = parseCIDR("10.0.0.0/8")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ipnet.String()).To(Equal("10.0.0.0/8"))
+
+	_, err = parseCIDR("10.0.0")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func Test_NetlinkListDevices(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	devices, err := listNetworkDevices(context.TODO(), nsPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	names := map[string]bool{}
+	for _, device := range devices {
+		names[device.name] = device.loopback
+	}
+	g.Expect(names).To(Equal(map[string]bool{"lo": true, "veth0": false, "veth1": false}))
+}
+
+func Test_NetlinkTbf(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	client := buildTcClient(context.TODO(), nsPath, "veth0")
+	err := client.addTc("root", "handle 1:", &pb.Tc{
+		Type: pb.Tc_BANDWIDTH,
+		Tbf:  &pb.Tbf{Rate: 8 * 1024 * 1024, Limit: 20971520, Buffer: 10000},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	qdiscs := listQdiscs(g, nsPath, "veth0")
+	g.Expect(qdiscs).To(HaveLen(1))
+	tbf, ok := qdiscs[0].(*netlink.Tbf)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(tbf.Handle).To(Equal(netlink.MakeHandle(1, 0)))
+	g.Expect(tbf.Rate).To(Equal(uint64(1024 * 1024)))
+	g.Expect(tbf.Limit).To(Equal(uint32(20971520)))
+
+	g.Expect(client.flush()).Should(Succeed())
+	for _, qdisc := range listQdiscs(g, nsPath, "veth0") {
+		g.Expect(qdisc.Type()).NotTo(Equal("tbf"))
+	}
+
+	// flush is idempotent
+	g.Expect(client.flush()).Should(Succeed())
+}
+
+func Test_NetlinkNetem(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	client := buildTcClient(context.TODO(), nsPath, "veth0")
+	classes, err := client.addTcs([]*pb.Tc{
+		{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}},
+		{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 100000}, Ipset: "A"},
+	})
+	if err == syscall.ENOENT {
+		t.Skip("netem, prio or sfq is not supported by the kernel")
+	}
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(classes).To(HaveLen(1))
+	g.Expect(classes[0].classid).To(Equal("2:4"))
+
+	qdiscs := map[uint32]netlink.Qdisc{}
+	for _, qdisc := range listQdiscs(g, nsPath, "veth0") {
+		qdiscs[qdisc.Attrs().Handle] = qdisc
+	}
+
+	netem, ok := qdiscs[netlink.MakeHandle(1, 0)].(*netlink.Netem)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(netem.Parent).To(Equal(uint32(netlink.HANDLE_ROOT)))
+
+	prio, ok := qdiscs[netlink.MakeHandle(2, 0)].(*netlink.Prio)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(prio.Parent).To(Equal(netlink.MakeHandle(1, 0)))
+	g.Expect(prio.Bands).To(Equal(uint8(4)))
+
+	for index := uint16(1); index <= 3; index++ {
+		sfq := qdiscs[netlink.MakeHandle(2+index, 0)]
+		g.Expect(sfq).NotTo(BeNil())
+		g.Expect(sfq.Type()).To(Equal("sfq"))
+		g.Expect(sfq.Attrs().Parent).To(Equal(netlink.MakeHandle(2, index)))
+	}
+
+	netem, ok = qdiscs[netlink.MakeHandle(6, 0)].(*netlink.Netem)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(netem.Parent).To(Equal(netlink.MakeHandle(2, 4)))
+}
+
+func Test_NetlinkDeleteQdisc(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	err := withNetNS(nsPath, func() error { return netlinkAddPrio("veth0", "root", "handle 1:", 3) })
+	if err == syscall.ENOENT {
+		t.Skip("prio is not supported by the kernel")
+	}
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = withNetNS(nsPath, func() error { return netlinkDeleteQdisc("veth0", "root", "handle 1:") })
+	g.Expect(err).ShouldNot(HaveOccurred())
+	for _, qdisc := range listQdiscs(g, nsPath, "veth0") {
+		g.Expect(qdisc.Type()).NotTo(Equal("prio"))
+	}
+
+	// the prio can be added again after it's deleted
+	err = withNetNS(nsPath, func() error { return netlinkAddPrio("veth0", "root", "handle 1:", 3) })
+	g.Expect(err).ShouldNot(HaveOccurred())
+}
+
+func Test_NetlinkRedirectIngress(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	client := buildTcClient(context.TODO(), nsPath, "veth0")
+	err := client.redirectIngress("chaos-ifb3")
+	if err == syscall.EOPNOTSUPP {
+		t.Skip("ifb is not supported by the kernel")
+	}
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var filters []netlink.Filter
+	var ifbIndex int
+	err = withNetNS(nsPath, func() error {
+		ifb, err := netlink.LinkByName("chaos-ifb3")
+		if err != nil {
+			return err
+		}
+		ifbIndex = ifb.Attrs().Index
+
+		link, err := netlink.LinkByName("veth0")
+		if err != nil {
+			return err
+		}
+		filters, err = netlink.FilterList(link, netlink.MakeHandle(0xffff, 0))
+		return err
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filters).To(HaveLen(1))
+	u32, ok := filters[0].(*netlink.U32)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(u32.RedirIndex).To(Equal(ifbIndex))
+
+	hasIngress := false
+	for _, qdisc := range listQdiscs(g, nsPath, "veth0") {
+		if qdisc.Type() == "ingress" {
+			hasIngress = true
+		}
+	}
+	g.Expect(hasIngress).To(BeTrue())
+
+	g.Expect(client.deleteIfb("chaos-ifb3")).Should(Succeed())
+	for _, qdisc := range listQdiscs(g, nsPath, "veth0") {
+		g.Expect(qdisc.Type()).NotTo(Equal("ingress"))
+	}
+	devices, err := listNetworkDevices(context.TODO(), nsPath)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	for _, device := range devices {
+		g.Expect(device.isIfb()).To(BeFalse())
+	}
+
+	// deleting the ifb device is idempotent
+	g.Expect(client.deleteIfb("chaos-ifb3")).Should(Succeed())
+}
+
+func Test_NetlinkFlushIPSet(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	err := withNetNS(nsPath, func() error { return netlinkCreateIPSet("probe", false) })
+	if err != nil {
+		t.Skipf("ipset is not supported by the kernel: %v", err)
+	}
+
+	ctx := context.TODO()
+	g.Expect(flushIPSet(ctx, nsPath, &pb.IPSet{Name: "test", Cidrs: []string{"10.0.0.1", "10.1.0.0/16"}}, false)).Should(Succeed())
+	// the existing ipset is swapped with the new one
+	g.Expect(flushIPSet(ctx, nsPath, &pb.IPSet{Name: "test", Cidrs: []string{"10.0.0.2", "10.0.0.2"}}, false)).Should(Succeed())
+	g.Expect(flushIPSet(ctx, nsPath, &pb.IPSet{Name: "test6", Cidrs: []string{"fd00::1", "fd00::/64"}}, true)).Should(Succeed())
+
+	// the IPv6 cidrs can't be added into the IPv4 ipset
+	g.Expect(flushIPSet(ctx, nsPath, &pb.IPSet{Name: "test", Cidrs: []string{"fd00::1"}}, false)).ShouldNot(Succeed())
+}
+
+func Test_convertFilterToEmatches(t *testing.T) {
+	g := NewWithT(t)
+
+	lists, err := convertFilterToEmatches(tcFilter{ipset: "A", protocol: "tcp"}, false, 3)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(lists).To(Equal([][]ematch{{
+		newIPSetEmatch(3),
+		newCmpEmatch(tcfEmAlignU8, 9, tcfEmOpndEq, 6, false),
+	}}))
+
+	lists, err = convertFilterToEmatches(tcFilter{protocol: "icmp"}, true, 0)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(lists).To(Equal([][]ematch{{newCmpEmatch(tcfEmAlignU8, 6, tcfEmOpndEq, 58, false)}}))
+
+	// a list for every combination of the source and destination ports
+	lists, err = convertFilterToEmatches(tcFilter{protocol: "udp", sourcePort: "53,8000-9000", destinationPort: "80"}, false, 0)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	protocol := newCmpEmatch(tcfEmAlignU8, 9, tcfEmOpndEq, 17, false)
+	destination := newCmpEmatch(tcfEmAlignU16, 22, tcfEmOpndEq, 80, false)
+	g.Expect(lists).To(Equal([][]ematch{
+		{protocol, newCmpEmatch(tcfEmAlignU16, 20, tcfEmOpndEq, 53, false), destination},
+		{protocol, newCmpEmatch(tcfEmAlignU16, 20, tcfEmOpndLt, 8000, true), newCmpEmatch(tcfEmAlignU16, 20, tcfEmOpndGt, 9000, true), destination},
+	}))
+
+	_, err = convertFilterToEmatches(tcFilter{sourcePort: "80-"}, false, 0)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func Test_newEmatchTree(t *testing.T) {
+	g := NewWithT(t)
+
+	native := nl.NativeEndian()
+	tree := newEmatchTree([]ematch{
+		newIPSetEmatch(3),
+		newCmpEmatch(tcfEmAlignU16, 20, tcfEmOpndLt, 8000, true),
+	})
+
+	attrs, err := nl.ParseRouteAttr(tree.Serialize()[unix.SizeofRtAttr:])
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(attrs).To(HaveLen(2))
+
+	// struct tcf_ematch_tree_hdr with the number of ematches and TCF_EM_PROG_TC
+	g.Expect(attrs[0].Attr.Type).To(Equal(uint16(tcaEmatchTreeHdr)))
+	g.Expect(native.Uint16(attrs[0].Value[0:2])).To(Equal(uint16(2)))
+	g.Expect(native.Uint16(attrs[0].Value[2:4])).To(Equal(uint16(tcfEmProgTc)))
+
+	g.Expect(attrs[1].Attr.Type).To(Equal(uint16(tcaEmatchTreeList)))
+	matches, err := nl.ParseRouteAttr(attrs[1].Value)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(matches).To(HaveLen(2))
+
+	// the ematches are numbered from 1, and each of them is a struct tcf_ematch_hdr followed by the data
+	g.Expect(matches[0].Attr.Type).To(Equal(uint16(1)))
+	g.Expect(native.Uint16(matches[0].Value[2:4])).To(Equal(uint16(tcfEmIPSet)))
+	g.Expect(native.Uint16(matches[0].Value[4:6])).To(Equal(uint16(tcfEmRelAnd)))
+	g.Expect(native.Uint16(matches[0].Value[8:10])).To(Equal(uint16(3)))
+	g.Expect(matches[0].Value[10:]).To(Equal([]byte{ipsetDimOne, ipsetDimOneSrc}))
+
+	g.Expect(matches[1].Attr.Type).To(Equal(uint16(2)))
+	g.Expect(native.Uint16(matches[1].Value[2:4])).To(Equal(uint16(tcfEmCmp)))
+	g.Expect(native.Uint16(matches[1].Value[4:6])).To(Equal(uint16(tcfEmInvert)))
+	g.Expect(native.Uint32(matches[1].Value[8:12])).To(Equal(uint32(8000)))
+	g.Expect(native.Uint32(matches[1].Value[12:16])).To(Equal(uint32(0)))
+	g.Expect(native.Uint16(matches[1].Value[16:18])).To(Equal(uint16(20)))
+	g.Expect(matches[1].Value[18:]).To(Equal([]byte{tcfEmAlignU16, tcfLayerNetwork | tcfEmOpndLt<<4}))
+}
+
+func Test_NetlinkIPSetIndex(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	err := withNetNS(nsPath, func() error { return netlinkCreateIPSet("probe", false) })
+	if err != nil {
+		t.Skipf("ipset is not supported by the kernel: %v", err)
+	}
+	g.Expect(withNetNS(nsPath, func() error { return netlinkCreateIPSet("A", false) })).Should(Succeed())
+
+	var probe, a uint16
+	err = withNetNS(nsPath, func() error {
+		var err error
+		if probe, err = ipsetIndex("probe"); err != nil {
+			return err
+		}
+		a, err = ipsetIndex("A")
+		return err
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(a).NotTo(Equal(probe))
+
+	err = withNetNS(nsPath, func() error {
+		_, err := ipsetIndex("B")
+		return err
+	})
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func Test_NetlinkAddBasicFilter(t *testing.T) {
+	g := NewWithT(t)
+
+	nsPath, cleanup := setupNetlinkTest(t)
+	defer cleanup()
+
+	err := withNetNS(nsPath, func() error {
+		if err := netlinkAddPrio("veth0", "root", "handle 1:", 4); err != nil {
+			return err
+		}
+		return netlinkCreateIPSet("A", false)
+	})
+	if err != nil {
+		t.Skipf("prio or ipset is not supported by the kernel: %v", err)
+	}
+
+	client := buildTcClient(context.TODO(), nsPath, "veth0")
+	err = client.addFilter("1:", "1:4", tcFilter{ipset: "A", protocol: "tcp", sourcePort: "80,8000-9000"}, false)
+	if err == syscall.ENOENT {
+		t.Skip("the basic classifier or ematch is not supported by the kernel")
+	}
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	var filters []netlink.Filter
+	err = withNetNS(nsPath, func() error {
+		link, err := netlink.LinkByName("veth0")
+		if err != nil {
+			return err
+		}
+		filters, err = netlink.FilterList(link, netlink.MakeHandle(1, 0))
+		return err
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	// a filter for every port
+	g.Expect(filters).To(HaveLen(2))
+	for _, filter := range filters {
+		g.Expect(filter.Type()).To(Equal("basic"))
+		g.Expect(filter.Attrs().Protocol).To(Equal(uint16(unix.ETH_P_IP)))
+	}
+}
@@ -48,7 +48,7 @@ func (x Chain_Direction) String() string {
 	return proto.EnumName(Chain_Direction_name, int32(x))
 }
 func (Chain_Direction) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{16, 0}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{16, 0}
 }
 
 type ContainerAction_Action int32
@@ -71,7 +71,7 @@ func (x ContainerAction_Action) String() string {
 	return proto.EnumName(ContainerAction_Action_name, int32(x))
 }
 func (ContainerAction_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{18, 0}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{18, 0}
 }
 
 type ExecStressRequest_Scope int32
@@ -94,7 +94,7 @@ func (x ExecStressRequest_Scope) String() string {
 	return proto.EnumName(ExecStressRequest_Scope_name, int32(x))
 }
 func (ExecStressRequest_Scope) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{19, 0}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{19, 0}
 }
 
 type Tc_Type int32
@@ -117,7 +117,7 @@ func (x Tc_Type) String() string {
 	return proto.EnumName(Tc_Type_name, int32(x))
 }
 func (Tc_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{25, 0}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{32, 0}
 }
 
 type TcHandle struct {
@@ -132,7 +132,7 @@ func (m *TcHandle) Reset()         { *m = TcHandle{} }
 func (m *TcHandle) String() string { return proto.CompactTextString(m) }
 func (*TcHandle) ProtoMessage()    {}
 func (*TcHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{0}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{0}
 }
 func (m *TcHandle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcHandle.Unmarshal(m, b)
@@ -178,7 +178,7 @@ func (m *ContainerRequest) Reset()         { *m = ContainerRequest{} }
 func (m *ContainerRequest) String() string { return proto.CompactTextString(m) }
 func (*ContainerRequest) ProtoMessage()    {}
 func (*ContainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{1}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{1}
 }
 func (m *ContainerRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContainerRequest.Unmarshal(m, b)
@@ -223,7 +223,7 @@ func (m *ContainerResponse) Reset()         { *m = ContainerResponse{} }
 func (m *ContainerResponse) String() string { return proto.CompactTextString(m) }
 func (*ContainerResponse) ProtoMessage()    {}
 func (*ContainerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{2}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{2}
 }
 func (m *ContainerResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContainerResponse.Unmarshal(m, b)
@@ -264,7 +264,7 @@ func (m *NetemRequest) Reset()         { *m = NetemRequest{} }
 func (m *NetemRequest) String() string { return proto.CompactTextString(m) }
 func (*NetemRequest) ProtoMessage()    {}
 func (*NetemRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{3}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{3}
 }
 func (m *NetemRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NetemRequest.Unmarshal(m, b)
@@ -313,31 +313,43 @@ func (m *NetemRequest) GetParent() *TcHandle {
 }
 
 type Netem struct {
-	Time                 uint32    `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
-	Jitter               uint32    `protobuf:"varint,2,opt,name=jitter,proto3" json:"jitter,omitempty"`
-	DelayCorr            float32   `protobuf:"fixed32,3,opt,name=delay_corr,json=delayCorr,proto3" json:"delay_corr,omitempty"`
-	Limit                uint32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Loss                 float32   `protobuf:"fixed32,5,opt,name=loss,proto3" json:"loss,omitempty"`
-	LossCorr             float32   `protobuf:"fixed32,6,opt,name=loss_corr,json=lossCorr,proto3" json:"loss_corr,omitempty"`
-	Gap                  uint32    `protobuf:"varint,7,opt,name=gap,proto3" json:"gap,omitempty"`
-	Duplicate            float32   `protobuf:"fixed32,8,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
-	DuplicateCorr        float32   `protobuf:"fixed32,9,opt,name=duplicate_corr,json=duplicateCorr,proto3" json:"duplicate_corr,omitempty"`
-	Reorder              float32   `protobuf:"fixed32,10,opt,name=reorder,proto3" json:"reorder,omitempty"`
-	ReorderCorr          float32   `protobuf:"fixed32,11,opt,name=reorder_corr,json=reorderCorr,proto3" json:"reorder_corr,omitempty"`
-	Corrupt              float32   `protobuf:"fixed32,12,opt,name=corrupt,proto3" json:"corrupt,omitempty"`
-	CorruptCorr          float32   `protobuf:"fixed32,13,opt,name=corrupt_corr,json=corruptCorr,proto3" json:"corrupt_corr,omitempty"`
-	Parent               *TcHandle `protobuf:"bytes,14,opt,name=parent,proto3" json:"parent,omitempty"`
-	Handle               *TcHandle `protobuf:"bytes,15,opt,name=handle,proto3" json:"handle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Time          uint32    `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Jitter        uint32    `protobuf:"varint,2,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	DelayCorr     float32   `protobuf:"fixed32,3,opt,name=delay_corr,json=delayCorr,proto3" json:"delay_corr,omitempty"`
+	Limit         uint32    `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Loss          float32   `protobuf:"fixed32,5,opt,name=loss,proto3" json:"loss,omitempty"`
+	LossCorr      float32   `protobuf:"fixed32,6,opt,name=loss_corr,json=lossCorr,proto3" json:"loss_corr,omitempty"`
+	Gap           uint32    `protobuf:"varint,7,opt,name=gap,proto3" json:"gap,omitempty"`
+	Duplicate     float32   `protobuf:"fixed32,8,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	DuplicateCorr float32   `protobuf:"fixed32,9,opt,name=duplicate_corr,json=duplicateCorr,proto3" json:"duplicate_corr,omitempty"`
+	Reorder       float32   `protobuf:"fixed32,10,opt,name=reorder,proto3" json:"reorder,omitempty"`
+	ReorderCorr   float32   `protobuf:"fixed32,11,opt,name=reorder_corr,json=reorderCorr,proto3" json:"reorder_corr,omitempty"`
+	Corrupt       float32   `protobuf:"fixed32,12,opt,name=corrupt,proto3" json:"corrupt,omitempty"`
+	CorruptCorr   float32   `protobuf:"fixed32,13,opt,name=corrupt_corr,json=corruptCorr,proto3" json:"corrupt_corr,omitempty"`
+	Parent        *TcHandle `protobuf:"bytes,14,opt,name=parent,proto3" json:"parent,omitempty"`
+	Handle        *TcHandle `protobuf:"bytes,15,opt,name=handle,proto3" json:"handle,omitempty"`
+	// distribution is the distribution of jitter, e.g. normal, pareto or paretonormal
+	Distribution string `protobuf:"bytes,16,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	// rate is the rate of the link in bytes per second
+	Rate           uint64 `protobuf:"varint,17,opt,name=rate,proto3" json:"rate,omitempty"`
+	PacketOverhead int32  `protobuf:"varint,18,opt,name=packet_overhead,json=packetOverhead,proto3" json:"packet_overhead,omitempty"`
+	CellSize       uint32 `protobuf:"varint,19,opt,name=cell_size,json=cellSize,proto3" json:"cell_size,omitempty"`
+	CellOverhead   int32  `protobuf:"varint,20,opt,name=cell_overhead,json=cellOverhead,proto3" json:"cell_overhead,omitempty"`
+	// the packets are delivered in slots, whose delay is between slot_min and slot_max in us
+	SlotMin              uint32   `protobuf:"varint,21,opt,name=slot_min,json=slotMin,proto3" json:"slot_min,omitempty"`
+	SlotMax              uint32   `protobuf:"varint,22,opt,name=slot_max,json=slotMax,proto3" json:"slot_max,omitempty"`
+	SlotPackets          uint32   `protobuf:"varint,23,opt,name=slot_packets,json=slotPackets,proto3" json:"slot_packets,omitempty"`
+	SlotBytes            uint32   `protobuf:"varint,24,opt,name=slot_bytes,json=slotBytes,proto3" json:"slot_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Netem) Reset()         { *m = Netem{} }
 func (m *Netem) String() string { return proto.CompactTextString(m) }
 func (*Netem) ProtoMessage()    {}
 func (*Netem) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{4}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{4}
 }
 func (m *Netem) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Netem.Unmarshal(m, b)
@@ -462,6 +474,69 @@ func (m *Netem) GetHandle() *TcHandle {
 	return nil
 }
 
+func (m *Netem) GetDistribution() string {
+	if m != nil {
+		return m.Distribution
+	}
+	return ""
+}
+
+func (m *Netem) GetRate() uint64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *Netem) GetPacketOverhead() int32 {
+	if m != nil {
+		return m.PacketOverhead
+	}
+	return 0
+}
+
+func (m *Netem) GetCellSize() uint32 {
+	if m != nil {
+		return m.CellSize
+	}
+	return 0
+}
+
+func (m *Netem) GetCellOverhead() int32 {
+	if m != nil {
+		return m.CellOverhead
+	}
+	return 0
+}
+
+func (m *Netem) GetSlotMin() uint32 {
+	if m != nil {
+		return m.SlotMin
+	}
+	return 0
+}
+
+func (m *Netem) GetSlotMax() uint32 {
+	if m != nil {
+		return m.SlotMax
+	}
+	return 0
+}
+
+func (m *Netem) GetSlotPackets() uint32 {
+	if m != nil {
+		return m.SlotPackets
+	}
+	return 0
+}
+
+func (m *Netem) GetSlotBytes() uint32 {
+	if m != nil {
+		return m.SlotBytes
+	}
+	return 0
+}
+
 type TbfRequest struct {
 	Tbf                  *Tbf     `protobuf:"bytes,1,opt,name=tbf,proto3" json:"tbf,omitempty"`
 	ContainerId          string   `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
@@ -474,7 +549,7 @@ func (m *TbfRequest) Reset()         { *m = TbfRequest{} }
 func (m *TbfRequest) String() string { return proto.CompactTextString(m) }
 func (*TbfRequest) ProtoMessage()    {}
 func (*TbfRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{5}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{5}
 }
 func (m *TbfRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TbfRequest.Unmarshal(m, b)
@@ -523,7 +598,7 @@ func (m *Tbf) Reset()         { *m = Tbf{} }
 func (m *Tbf) String() string { return proto.CompactTextString(m) }
 func (*Tbf) ProtoMessage()    {}
 func (*Tbf) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{6}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{6}
 }
 func (m *Tbf) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Tbf.Unmarshal(m, b)
@@ -590,7 +665,7 @@ func (m *QdiscRequest) Reset()         { *m = QdiscRequest{} }
 func (m *QdiscRequest) String() string { return proto.CompactTextString(m) }
 func (*QdiscRequest) ProtoMessage()    {}
 func (*QdiscRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{7}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{7}
 }
 func (m *QdiscRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QdiscRequest.Unmarshal(m, b)
@@ -638,7 +713,7 @@ func (m *Qdisc) Reset()         { *m = Qdisc{} }
 func (m *Qdisc) String() string { return proto.CompactTextString(m) }
 func (*Qdisc) ProtoMessage()    {}
 func (*Qdisc) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{8}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{8}
 }
 func (m *Qdisc) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Qdisc.Unmarshal(m, b)
@@ -698,7 +773,7 @@ func (m *EmatchFilterRequest) Reset()         { *m = EmatchFilterRequest{} }
 func (m *EmatchFilterRequest) String() string { return proto.CompactTextString(m) }
 func (*EmatchFilterRequest) ProtoMessage()    {}
 func (*EmatchFilterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{9}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{9}
 }
 func (m *EmatchFilterRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EmatchFilterRequest.Unmarshal(m, b)
@@ -745,7 +820,7 @@ func (m *EmatchFilter) Reset()         { *m = EmatchFilter{} }
 func (m *EmatchFilter) String() string { return proto.CompactTextString(m) }
 func (*EmatchFilter) ProtoMessage()    {}
 func (*EmatchFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{10}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{10}
 }
 func (m *EmatchFilter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EmatchFilter.Unmarshal(m, b)
@@ -798,7 +873,7 @@ func (m *TcFilterRequest) Reset()         { *m = TcFilterRequest{} }
 func (m *TcFilterRequest) String() string { return proto.CompactTextString(m) }
 func (*TcFilterRequest) ProtoMessage()    {}
 func (*TcFilterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{11}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{11}
 }
 func (m *TcFilterRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcFilterRequest.Unmarshal(m, b)
@@ -843,7 +918,7 @@ func (m *TcFilter) Reset()         { *m = TcFilter{} }
 func (m *TcFilter) String() string { return proto.CompactTextString(m) }
 func (*TcFilter) ProtoMessage()    {}
 func (*TcFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{12}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{12}
 }
 func (m *TcFilter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcFilter.Unmarshal(m, b)
@@ -882,7 +957,7 @@ func (m *IPSetsRequest) Reset()         { *m = IPSetsRequest{} }
 func (m *IPSetsRequest) String() string { return proto.CompactTextString(m) }
 func (*IPSetsRequest) ProtoMessage()    {}
 func (*IPSetsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{13}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{13}
 }
 func (m *IPSetsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPSetsRequest.Unmarshal(m, b)
@@ -917,8 +992,11 @@ func (m *IPSetsRequest) GetContainerId() string {
 }
 
 type IPSet struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Cidrs                []string `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	Name  string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Cidrs []string `protobuf:"bytes,2,rep,name=cidrs,proto3" json:"cidrs,omitempty"`
+	// domains are resolved in the container, and the ipset is updated
+	// when the addresses of domains change
+	Domains              []string `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -928,7 +1006,7 @@ func (m *IPSet) Reset()         { *m = IPSet{} }
 func (m *IPSet) String() string { return proto.CompactTextString(m) }
 func (*IPSet) ProtoMessage()    {}
 func (*IPSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{14}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{14}
 }
 func (m *IPSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IPSet.Unmarshal(m, b)
@@ -962,6 +1040,13 @@ func (m *IPSet) GetCidrs() []string {
 	return nil
 }
 
+func (m *IPSet) GetDomains() []string {
+	if m != nil {
+		return m.Domains
+	}
+	return nil
+}
+
 type IptablesChainsRequest struct {
 	Chains               []*Chain `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
 	ContainerId          string   `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
@@ -974,7 +1059,7 @@ func (m *IptablesChainsRequest) Reset()         { *m = IptablesChainsRequest{} }
 func (m *IptablesChainsRequest) String() string { return proto.CompactTextString(m) }
 func (*IptablesChainsRequest) ProtoMessage()    {}
 func (*IptablesChainsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{15}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{15}
 }
 func (m *IptablesChainsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IptablesChainsRequest.Unmarshal(m, b)
@@ -1009,20 +1094,28 @@ func (m *IptablesChainsRequest) GetContainerId() string {
 }
 
 type Chain struct {
-	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Direction            Chain_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=pb.Chain_Direction" json:"direction,omitempty"`
-	Ipsets               []string        `protobuf:"bytes,3,rep,name=ipsets,proto3" json:"ipsets,omitempty"`
-	Target               string          `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Name            string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Direction       Chain_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=pb.Chain_Direction" json:"direction,omitempty"`
+	Ipsets          []string        `protobuf:"bytes,3,rep,name=ipsets,proto3" json:"ipsets,omitempty"`
+	Target          string          `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Protocol        string          `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SourcePort      string          `protobuf:"bytes,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationPort string          `protobuf:"bytes,7,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Device          string          `protobuf:"bytes,8,opt,name=device,proto3" json:"device,omitempty"`
+	// reject_with is the reply of REJECT target, e.g. tcp-reset or icmp-port-unreachable
+	RejectWith string `protobuf:"bytes,9,opt,name=reject_with,json=rejectWith,proto3" json:"reject_with,omitempty"`
+	// probability is the percentage of packets to match, all packets are matched if it's 0
+	Probability          float32  `protobuf:"fixed32,10,opt,name=probability,proto3" json:"probability,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Chain) Reset()         { *m = Chain{} }
 func (m *Chain) String() string { return proto.CompactTextString(m) }
 func (*Chain) ProtoMessage()    {}
 func (*Chain) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{16}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{16}
 }
 func (m *Chain) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chain.Unmarshal(m, b)
@@ -1070,6 +1163,48 @@ func (m *Chain) GetTarget() string {
 	return ""
 }
 
+func (m *Chain) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *Chain) GetSourcePort() string {
+	if m != nil {
+		return m.SourcePort
+	}
+	return ""
+}
+
+func (m *Chain) GetDestinationPort() string {
+	if m != nil {
+		return m.DestinationPort
+	}
+	return ""
+}
+
+func (m *Chain) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *Chain) GetRejectWith() string {
+	if m != nil {
+		return m.RejectWith
+	}
+	return ""
+}
+
+func (m *Chain) GetProbability() float32 {
+	if m != nil {
+		return m.Probability
+	}
+	return 0
+}
+
 type TimeRequest struct {
 	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	Sec                  int64    `protobuf:"varint,2,opt,name=sec,proto3" json:"sec,omitempty"`
@@ -1084,7 +1219,7 @@ func (m *TimeRequest) Reset()         { *m = TimeRequest{} }
 func (m *TimeRequest) String() string { return proto.CompactTextString(m) }
 func (*TimeRequest) ProtoMessage()    {}
 func (*TimeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{17}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{17}
 }
 func (m *TimeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TimeRequest.Unmarshal(m, b)
@@ -1143,7 +1278,7 @@ func (m *ContainerAction) Reset()         { *m = ContainerAction{} }
 func (m *ContainerAction) String() string { return proto.CompactTextString(m) }
 func (*ContainerAction) ProtoMessage()    {}
 func (*ContainerAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{18}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{18}
 }
 func (m *ContainerAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ContainerAction.Unmarshal(m, b)
@@ -1183,7 +1318,7 @@ func (m *ExecStressRequest) Reset()         { *m = ExecStressRequest{} }
 func (m *ExecStressRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecStressRequest) ProtoMessage()    {}
 func (*ExecStressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{19}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{19}
 }
 func (m *ExecStressRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecStressRequest.Unmarshal(m, b)
@@ -1236,7 +1371,7 @@ func (m *ExecStressResponse) Reset()         { *m = ExecStressResponse{} }
 func (m *ExecStressResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecStressResponse) ProtoMessage()    {}
 func (*ExecStressResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{20}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{20}
 }
 func (m *ExecStressResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecStressResponse.Unmarshal(m, b)
@@ -1282,7 +1417,7 @@ func (m *CancelStressRequest) Reset()         { *m = CancelStressRequest{} }
 func (m *CancelStressRequest) String() string { return proto.CompactTextString(m) }
 func (*CancelStressRequest) ProtoMessage()    {}
 func (*CancelStressRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{21}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{21}
 }
 func (m *CancelStressRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CancelStressRequest.Unmarshal(m, b)
@@ -1331,7 +1466,7 @@ func (m *ApplyIoChaosRequest) Reset()         { *m = ApplyIoChaosRequest{} }
 func (m *ApplyIoChaosRequest) String() string { return proto.CompactTextString(m) }
 func (*ApplyIoChaosRequest) ProtoMessage()    {}
 func (*ApplyIoChaosRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{22}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{22}
 }
 func (m *ApplyIoChaosRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApplyIoChaosRequest.Unmarshal(m, b)
@@ -1398,7 +1533,7 @@ func (m *ApplyIoChaosResponse) Reset()         { *m = ApplyIoChaosResponse{} }
 func (m *ApplyIoChaosResponse) String() string { return proto.CompactTextString(m) }
 func (*ApplyIoChaosResponse) ProtoMessage()    {}
 func (*ApplyIoChaosResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{23}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{23}
 }
 func (m *ApplyIoChaosResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ApplyIoChaosResponse.Unmarshal(m, b)
@@ -1432,6 +1567,407 @@ func (m *ApplyIoChaosResponse) GetStartTime() int64 {
 	return 0
 }
 
+type ApplyHttpChaosRequest struct {
+	Rule                 string   `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	ContainerId          string   `protobuf:"bytes,3,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Instance             int64    `protobuf:"varint,4,opt,name=instance,proto3" json:"instance,omitempty"`
+	StartTime            int64    `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyHttpChaosRequest) Reset()         { *m = ApplyHttpChaosRequest{} }
+func (m *ApplyHttpChaosRequest) String() string { return proto.CompactTextString(m) }
+func (*ApplyHttpChaosRequest) ProtoMessage()    {}
+func (*ApplyHttpChaosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{24}
+}
+func (m *ApplyHttpChaosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyHttpChaosRequest.Unmarshal(m, b)
+}
+func (m *ApplyHttpChaosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyHttpChaosRequest.Marshal(b, m, deterministic)
+}
+func (dst *ApplyHttpChaosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyHttpChaosRequest.Merge(dst, src)
+}
+func (m *ApplyHttpChaosRequest) XXX_Size() int {
+	return xxx_messageInfo_ApplyHttpChaosRequest.Size(m)
+}
+func (m *ApplyHttpChaosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyHttpChaosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyHttpChaosRequest proto.InternalMessageInfo
+
+func (m *ApplyHttpChaosRequest) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *ApplyHttpChaosRequest) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *ApplyHttpChaosRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *ApplyHttpChaosRequest) GetInstance() int64 {
+	if m != nil {
+		return m.Instance
+	}
+	return 0
+}
+
+func (m *ApplyHttpChaosRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+type ApplyHttpChaosResponse struct {
+	Instance             int64    `protobuf:"varint,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	StartTime            int64    `protobuf:"varint,2,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApplyHttpChaosResponse) Reset()         { *m = ApplyHttpChaosResponse{} }
+func (m *ApplyHttpChaosResponse) String() string { return proto.CompactTextString(m) }
+func (*ApplyHttpChaosResponse) ProtoMessage()    {}
+func (*ApplyHttpChaosResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{25}
+}
+func (m *ApplyHttpChaosResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApplyHttpChaosResponse.Unmarshal(m, b)
+}
+func (m *ApplyHttpChaosResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApplyHttpChaosResponse.Marshal(b, m, deterministic)
+}
+func (dst *ApplyHttpChaosResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApplyHttpChaosResponse.Merge(dst, src)
+}
+func (m *ApplyHttpChaosResponse) XXX_Size() int {
+	return xxx_messageInfo_ApplyHttpChaosResponse.Size(m)
+}
+func (m *ApplyHttpChaosResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApplyHttpChaosResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApplyHttpChaosResponse proto.InternalMessageInfo
+
+func (m *ApplyHttpChaosResponse) GetInstance() int64 {
+	if m != nil {
+		return m.Instance
+	}
+	return 0
+}
+
+func (m *ApplyHttpChaosResponse) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+type SetDNSServerRequest struct {
+	ContainerId          string   `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	DnsServer            string   `protobuf:"bytes,2,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
+	Enable               bool     `protobuf:"varint,3,opt,name=enable,proto3" json:"enable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDNSServerRequest) Reset()         { *m = SetDNSServerRequest{} }
+func (m *SetDNSServerRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDNSServerRequest) ProtoMessage()    {}
+func (*SetDNSServerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{26}
+}
+func (m *SetDNSServerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDNSServerRequest.Unmarshal(m, b)
+}
+func (m *SetDNSServerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDNSServerRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetDNSServerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDNSServerRequest.Merge(dst, src)
+}
+func (m *SetDNSServerRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDNSServerRequest.Size(m)
+}
+func (m *SetDNSServerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDNSServerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDNSServerRequest proto.InternalMessageInfo
+
+func (m *SetDNSServerRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *SetDNSServerRequest) GetDnsServer() string {
+	if m != nil {
+		return m.DnsServer
+	}
+	return ""
+}
+
+func (m *SetDNSServerRequest) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+type DiskFillRequest struct {
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// path is the directory in the container where the file is created
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// name identifies the file filling the disk
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// size is the size of the file in bytes
+	Size uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	// percent is the size of the file as the percentage of the capacity
+	Percent              uint32   `protobuf:"varint,5,opt,name=percent,proto3" json:"percent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiskFillRequest) Reset()         { *m = DiskFillRequest{} }
+func (m *DiskFillRequest) String() string { return proto.CompactTextString(m) }
+func (*DiskFillRequest) ProtoMessage()    {}
+func (*DiskFillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{27}
+}
+func (m *DiskFillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiskFillRequest.Unmarshal(m, b)
+}
+func (m *DiskFillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiskFillRequest.Marshal(b, m, deterministic)
+}
+func (dst *DiskFillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiskFillRequest.Merge(dst, src)
+}
+func (m *DiskFillRequest) XXX_Size() int {
+	return xxx_messageInfo_DiskFillRequest.Size(m)
+}
+func (m *DiskFillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiskFillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiskFillRequest proto.InternalMessageInfo
+
+func (m *DiskFillRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *DiskFillRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DiskFillRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DiskFillRequest) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *DiskFillRequest) GetPercent() uint32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+type IoThrottleRequest struct {
+	ContainerId          string        `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Throttles            []*IoThrottle `protobuf:"bytes,2,rep,name=throttles,proto3" json:"throttles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *IoThrottleRequest) Reset()         { *m = IoThrottleRequest{} }
+func (m *IoThrottleRequest) String() string { return proto.CompactTextString(m) }
+func (*IoThrottleRequest) ProtoMessage()    {}
+func (*IoThrottleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{28}
+}
+func (m *IoThrottleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IoThrottleRequest.Unmarshal(m, b)
+}
+func (m *IoThrottleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IoThrottleRequest.Marshal(b, m, deterministic)
+}
+func (dst *IoThrottleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IoThrottleRequest.Merge(dst, src)
+}
+func (m *IoThrottleRequest) XXX_Size() int {
+	return xxx_messageInfo_IoThrottleRequest.Size(m)
+}
+func (m *IoThrottleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IoThrottleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IoThrottleRequest proto.InternalMessageInfo
+
+func (m *IoThrottleRequest) GetContainerId() string {
+	if m != nil {
+		return m.ContainerId
+	}
+	return ""
+}
+
+func (m *IoThrottleRequest) GetThrottles() []*IoThrottle {
+	if m != nil {
+		return m.Throttles
+	}
+	return nil
+}
+
+type IoThrottleResponse struct {
+	// throttles are the limits of the devices in the request before applying,
+	// a rate of 0 means unlimited
+	Throttles            []*IoThrottle `protobuf:"bytes,1,rep,name=throttles,proto3" json:"throttles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *IoThrottleResponse) Reset()         { *m = IoThrottleResponse{} }
+func (m *IoThrottleResponse) String() string { return proto.CompactTextString(m) }
+func (*IoThrottleResponse) ProtoMessage()    {}
+func (*IoThrottleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{29}
+}
+func (m *IoThrottleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IoThrottleResponse.Unmarshal(m, b)
+}
+func (m *IoThrottleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IoThrottleResponse.Marshal(b, m, deterministic)
+}
+func (dst *IoThrottleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IoThrottleResponse.Merge(dst, src)
+}
+func (m *IoThrottleResponse) XXX_Size() int {
+	return xxx_messageInfo_IoThrottleResponse.Size(m)
+}
+func (m *IoThrottleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IoThrottleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IoThrottleResponse proto.InternalMessageInfo
+
+func (m *IoThrottleResponse) GetThrottles() []*IoThrottle {
+	if m != nil {
+		return m.Throttles
+	}
+	return nil
+}
+
+type IoThrottle struct {
+	// device is the path of block device, or its major and minor number
+	Device               string   `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"`
+	ReadBps              uint64   `protobuf:"varint,2,opt,name=read_bps,json=readBps,proto3" json:"read_bps,omitempty"`
+	WriteBps             uint64   `protobuf:"varint,3,opt,name=write_bps,json=writeBps,proto3" json:"write_bps,omitempty"`
+	ReadIops             uint64   `protobuf:"varint,4,opt,name=read_iops,json=readIops,proto3" json:"read_iops,omitempty"`
+	WriteIops            uint64   `protobuf:"varint,5,opt,name=write_iops,json=writeIops,proto3" json:"write_iops,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IoThrottle) Reset()         { *m = IoThrottle{} }
+func (m *IoThrottle) String() string { return proto.CompactTextString(m) }
+func (*IoThrottle) ProtoMessage()    {}
+func (*IoThrottle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{30}
+}
+func (m *IoThrottle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IoThrottle.Unmarshal(m, b)
+}
+func (m *IoThrottle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IoThrottle.Marshal(b, m, deterministic)
+}
+func (dst *IoThrottle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IoThrottle.Merge(dst, src)
+}
+func (m *IoThrottle) XXX_Size() int {
+	return xxx_messageInfo_IoThrottle.Size(m)
+}
+func (m *IoThrottle) XXX_DiscardUnknown() {
+	xxx_messageInfo_IoThrottle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IoThrottle proto.InternalMessageInfo
+
+func (m *IoThrottle) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *IoThrottle) GetReadBps() uint64 {
+	if m != nil {
+		return m.ReadBps
+	}
+	return 0
+}
+
+func (m *IoThrottle) GetWriteBps() uint64 {
+	if m != nil {
+		return m.WriteBps
+	}
+	return 0
+}
+
+func (m *IoThrottle) GetReadIops() uint64 {
+	if m != nil {
+		return m.ReadIops
+	}
+	return 0
+}
+
+func (m *IoThrottle) GetWriteIops() uint64 {
+	if m != nil {
+		return m.WriteIops
+	}
+	return 0
+}
+
 type TcsRequest struct {
 	Tcs                  []*Tc    `protobuf:"bytes,1,rep,name=tcs,proto3" json:"tcs,omitempty"`
 	ContainerId          string   `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
@@ -1444,7 +1980,7 @@ func (m *TcsRequest) Reset()         { *m = TcsRequest{} }
 func (m *TcsRequest) String() string { return proto.CompactTextString(m) }
 func (*TcsRequest) ProtoMessage()    {}
 func (*TcsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{24}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{31}
 }
 func (m *TcsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TcsRequest.Unmarshal(m, b)
@@ -1483,6 +2019,11 @@ type Tc struct {
 	Netem                *Netem   `protobuf:"bytes,2,opt,name=netem,proto3" json:"netem,omitempty"`
 	Tbf                  *Tbf     `protobuf:"bytes,3,opt,name=tbf,proto3" json:"tbf,omitempty"`
 	Ipset                string   `protobuf:"bytes,4,opt,name=ipset,proto3" json:"ipset,omitempty"`
+	Protocol             string   `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SourcePort           string   `protobuf:"bytes,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationPort      string   `protobuf:"bytes,7,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Ingress              bool     `protobuf:"varint,8,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Device               string   `protobuf:"bytes,9,opt,name=device,proto3" json:"device,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1492,7 +2033,7 @@ func (m *Tc) Reset()         { *m = Tc{} }
 func (m *Tc) String() string { return proto.CompactTextString(m) }
 func (*Tc) ProtoMessage()    {}
 func (*Tc) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaosdaemon_d32cf42dab093cba, []int{25}
+	return fileDescriptor_chaosdaemon_86a0b474696b4d85, []int{32}
 }
 func (m *Tc) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Tc.Unmarshal(m, b)
@@ -1540,6 +2081,41 @@ func (m *Tc) GetIpset() string {
 	return ""
 }
 
+func (m *Tc) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
+	}
+	return ""
+}
+
+func (m *Tc) GetSourcePort() string {
+	if m != nil {
+		return m.SourcePort
+	}
+	return ""
+}
+
+func (m *Tc) GetDestinationPort() string {
+	if m != nil {
+		return m.DestinationPort
+	}
+	return ""
+}
+
+func (m *Tc) GetIngress() bool {
+	if m != nil {
+		return m.Ingress
+	}
+	return false
+}
+
+func (m *Tc) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*TcHandle)(nil), "pb.TcHandle")
 	proto.RegisterType((*ContainerRequest)(nil), "pb.ContainerRequest")
@@ -1565,6 +2141,13 @@ func init() {
 	proto.RegisterType((*CancelStressRequest)(nil), "pb.CancelStressRequest")
 	proto.RegisterType((*ApplyIoChaosRequest)(nil), "pb.ApplyIoChaosRequest")
 	proto.RegisterType((*ApplyIoChaosResponse)(nil), "pb.ApplyIoChaosResponse")
+	proto.RegisterType((*ApplyHttpChaosRequest)(nil), "pb.ApplyHttpChaosRequest")
+	proto.RegisterType((*ApplyHttpChaosResponse)(nil), "pb.ApplyHttpChaosResponse")
+	proto.RegisterType((*SetDNSServerRequest)(nil), "pb.SetDNSServerRequest")
+	proto.RegisterType((*DiskFillRequest)(nil), "pb.DiskFillRequest")
+	proto.RegisterType((*IoThrottleRequest)(nil), "pb.IoThrottleRequest")
+	proto.RegisterType((*IoThrottleResponse)(nil), "pb.IoThrottleResponse")
+	proto.RegisterType((*IoThrottle)(nil), "pb.IoThrottle")
 	proto.RegisterType((*TcsRequest)(nil), "pb.TcsRequest")
 	proto.RegisterType((*Tc)(nil), "pb.Tc")
 	proto.RegisterEnum("pb.Chain_Direction", Chain_Direction_name, Chain_Direction_value)
@@ -1595,6 +2178,12 @@ type ChaosDaemonClient interface {
 	ExecStressors(ctx context.Context, in *ExecStressRequest, opts ...grpc.CallOption) (*ExecStressResponse, error)
 	CancelStressors(ctx context.Context, in *CancelStressRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	ApplyIoChaos(ctx context.Context, in *ApplyIoChaosRequest, opts ...grpc.CallOption) (*ApplyIoChaosResponse, error)
+	ApplyHttpChaos(ctx context.Context, in *ApplyHttpChaosRequest, opts ...grpc.CallOption) (*ApplyHttpChaosResponse, error)
+	SetDNSServer(ctx context.Context, in *SetDNSServerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ApplyDiskFill(ctx context.Context, in *DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RecoverDiskFill(ctx context.Context, in *DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ApplyIoThrottle(ctx context.Context, in *IoThrottleRequest, opts ...grpc.CallOption) (*IoThrottleResponse, error)
+	RecoverIoThrottle(ctx context.Context, in *IoThrottleRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type chaosDaemonClient struct {
@@ -1695,6 +2284,60 @@ func (c *chaosDaemonClient) ApplyIoChaos(ctx context.Context, in *ApplyIoChaosRe
 	return out, nil
 }
 
+func (c *chaosDaemonClient) ApplyHttpChaos(ctx context.Context, in *ApplyHttpChaosRequest, opts ...grpc.CallOption) (*ApplyHttpChaosResponse, error) {
+	out := new(ApplyHttpChaosResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/ApplyHttpChaos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) SetDNSServer(ctx context.Context, in *SetDNSServerRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/SetDNSServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) ApplyDiskFill(ctx context.Context, in *DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/ApplyDiskFill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) RecoverDiskFill(ctx context.Context, in *DiskFillRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/RecoverDiskFill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) ApplyIoThrottle(ctx context.Context, in *IoThrottleRequest, opts ...grpc.CallOption) (*IoThrottleResponse, error) {
+	out := new(IoThrottleResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/ApplyIoThrottle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) RecoverIoThrottle(ctx context.Context, in *IoThrottleRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/RecoverIoThrottle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChaosDaemonServer is the server API for ChaosDaemon service.
 type ChaosDaemonServer interface {
 	SetTcs(context.Context, *TcsRequest) (*empty.Empty, error)
@@ -1707,6 +2350,12 @@ type ChaosDaemonServer interface {
 	ExecStressors(context.Context, *ExecStressRequest) (*ExecStressResponse, error)
 	CancelStressors(context.Context, *CancelStressRequest) (*empty.Empty, error)
 	ApplyIoChaos(context.Context, *ApplyIoChaosRequest) (*ApplyIoChaosResponse, error)
+	ApplyHttpChaos(context.Context, *ApplyHttpChaosRequest) (*ApplyHttpChaosResponse, error)
+	SetDNSServer(context.Context, *SetDNSServerRequest) (*empty.Empty, error)
+	ApplyDiskFill(context.Context, *DiskFillRequest) (*empty.Empty, error)
+	RecoverDiskFill(context.Context, *DiskFillRequest) (*empty.Empty, error)
+	ApplyIoThrottle(context.Context, *IoThrottleRequest) (*IoThrottleResponse, error)
+	RecoverIoThrottle(context.Context, *IoThrottleRequest) (*empty.Empty, error)
 }
 
 func RegisterChaosDaemonServer(s *grpc.Server, srv ChaosDaemonServer) {
@@ -1893,6 +2542,114 @@ func _ChaosDaemon_ApplyIoChaos_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChaosDaemon_ApplyHttpChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyHttpChaosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).ApplyHttpChaos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/ApplyHttpChaos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).ApplyHttpChaos(ctx, req.(*ApplyHttpChaosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_SetDNSServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDNSServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).SetDNSServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/SetDNSServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).SetDNSServer(ctx, req.(*SetDNSServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_ApplyDiskFill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskFillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).ApplyDiskFill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/ApplyDiskFill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).ApplyDiskFill(ctx, req.(*DiskFillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_RecoverDiskFill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiskFillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).RecoverDiskFill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/RecoverDiskFill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).RecoverDiskFill(ctx, req.(*DiskFillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_ApplyIoThrottle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IoThrottleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).ApplyIoThrottle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/ApplyIoThrottle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).ApplyIoThrottle(ctx, req.(*IoThrottleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_RecoverIoThrottle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IoThrottleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).RecoverIoThrottle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/RecoverIoThrottle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).RecoverIoThrottle(ctx, req.(*IoThrottleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ChaosDaemon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.ChaosDaemon",
 	HandlerType: (*ChaosDaemonServer)(nil),
@@ -1937,99 +2694,159 @@ var _ChaosDaemon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplyIoChaos",
 			Handler:    _ChaosDaemon_ApplyIoChaos_Handler,
 		},
+		{
+			MethodName: "ApplyHttpChaos",
+			Handler:    _ChaosDaemon_ApplyHttpChaos_Handler,
+		},
+		{
+			MethodName: "SetDNSServer",
+			Handler:    _ChaosDaemon_SetDNSServer_Handler,
+		},
+		{
+			MethodName: "ApplyDiskFill",
+			Handler:    _ChaosDaemon_ApplyDiskFill_Handler,
+		},
+		{
+			MethodName: "RecoverDiskFill",
+			Handler:    _ChaosDaemon_RecoverDiskFill_Handler,
+		},
+		{
+			MethodName: "ApplyIoThrottle",
+			Handler:    _ChaosDaemon_ApplyIoThrottle_Handler,
+		},
+		{
+			MethodName: "RecoverIoThrottle",
+			Handler:    _ChaosDaemon_RecoverIoThrottle_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chaosdaemon.proto",
 }
 
-func init() { proto.RegisterFile("chaosdaemon.proto", fileDescriptor_chaosdaemon_d32cf42dab093cba) }
-
-var fileDescriptor_chaosdaemon_d32cf42dab093cba = []byte{
-	// 1364 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6e, 0xdb, 0xc6,
-	0x16, 0x36, 0x45, 0x49, 0x16, 0x8f, 0x24, 0x5b, 0x1e, 0x3b, 0xbe, 0x8c, 0x7d, 0xef, 0x8d, 0x43,
-	0x24, 0x45, 0x80, 0x02, 0x4a, 0xe3, 0x02, 0x5d, 0x74, 0xd1, 0xd6, 0x91, 0x95, 0x44, 0x4d, 0x22,
-	0xbb, 0x63, 0x06, 0x05, 0x0a, 0x14, 0x06, 0x7f, 0x46, 0x36, 0x63, 0x8a, 0x64, 0x38, 0xa3, 0x20,
-	0x46, 0x57, 0x7d, 0x86, 0xae, 0xba, 0x68, 0x17, 0x45, 0x9f, 0xa1, 0xcf, 0x57, 0xcc, 0x99, 0xa1,
-	0x44, 0xd9, 0xb2, 0xab, 0x66, 0xc5, 0x39, 0x7f, 0xdf, 0x9c, 0x9f, 0xe1, 0x37, 0x03, 0x1b, 0xc1,
-	0xb9, 0x97, 0xf2, 0xd0, 0x63, 0xe3, 0x34, 0xe9, 0x66, 0x79, 0x2a, 0x52, 0x52, 0xc9, 0xfc, 0x9d,
-	0xdd, 0xb3, 0x34, 0x3d, 0x8b, 0xd9, 0x63, 0xd4, 0xf8, 0x93, 0xd1, 0x63, 0x36, 0xce, 0xc4, 0xa5,
-	0x72, 0x70, 0xbe, 0x80, 0x86, 0x1b, 0xbc, 0xf0, 0x92, 0x30, 0x66, 0x64, 0x0b, 0x6a, 0x63, 0xef,
-	0x6d, 0x9a, 0xdb, 0xc6, 0x9e, 0xf1, 0xa8, 0x4d, 0x95, 0x80, 0xda, 0x28, 0x49, 0x73, 0xbb, 0xa2,
-	0xb5, 0x52, 0x70, 0x7c, 0xe8, 0xf4, 0xd2, 0x44, 0x78, 0x51, 0xc2, 0x72, 0xca, 0xde, 0x4d, 0x18,
-	0x17, 0xe4, 0x53, 0xa8, 0x7b, 0x81, 0x88, 0xd2, 0x04, 0x01, 0x9a, 0xfb, 0x9b, 0xdd, 0xcc, 0xef,
-	0x4e, 0xbd, 0x0e, 0xd0, 0x44, 0xb5, 0x0b, 0xb9, 0x0f, 0xad, 0xa0, 0x30, 0x9d, 0x46, 0x21, 0xa2,
-	0x5b, 0xb4, 0x39, 0xd5, 0x0d, 0x42, 0xe7, 0x21, 0x6c, 0x94, 0xf6, 0xe0, 0x59, 0x9a, 0x70, 0x46,
-	0x3a, 0x60, 0x66, 0x51, 0xa8, 0x53, 0x94, 0x4b, 0xe7, 0x77, 0x03, 0x5a, 0x43, 0x26, 0xd8, 0xb8,
-	0xc8, 0xe3, 0x1e, 0xd4, 0x12, 0x29, 0xeb, 0x34, 0x2c, 0x99, 0x86, 0x72, 0x50, 0xfa, 0x25, 0xf6,
-	0x26, 0x0f, 0xa0, 0x7e, 0x8e, 0x5d, 0xb1, 0x4d, 0x04, 0x69, 0x49, 0x90, 0xa2, 0x53, 0x54, 0xdb,
-	0xa4, 0x57, 0xe6, 0xe5, 0x2c, 0x11, 0x76, 0x75, 0x91, 0x97, 0xb2, 0x39, 0x7f, 0x99, 0x50, 0xc3,
-	0xfd, 0x09, 0x81, 0xaa, 0x88, 0xc6, 0x4c, 0x67, 0x8f, 0x6b, 0xb2, 0x0d, 0xf5, 0xb7, 0x91, 0x10,
-	0xac, 0x68, 0xb0, 0x96, 0xc8, 0xff, 0x00, 0x42, 0x16, 0x7b, 0x97, 0xa7, 0x41, 0x9a, 0xe7, 0x98,
-	0x45, 0x85, 0x5a, 0xa8, 0xe9, 0xa5, 0x39, 0x8e, 0x25, 0x8e, 0xc6, 0x91, 0xda, 0xb9, 0x4d, 0x95,
-	0x20, 0x37, 0x88, 0x53, 0xce, 0xed, 0x1a, 0xba, 0xe3, 0x9a, 0xec, 0x82, 0x25, 0xbf, 0x0a, 0xa7,
-	0x8e, 0x86, 0x86, 0x54, 0x20, 0x4c, 0x07, 0xcc, 0x33, 0x2f, 0xb3, 0x57, 0x55, 0x3b, 0xcf, 0xbc,
-	0x8c, 0xfc, 0x17, 0xac, 0x70, 0x92, 0xc5, 0x51, 0xe0, 0x09, 0x66, 0x37, 0xf4, 0xb6, 0x85, 0x82,
-	0x3c, 0x84, 0xb5, 0xa9, 0xa0, 0x10, 0x2d, 0x74, 0x69, 0x4f, 0xb5, 0x08, 0x6b, 0xc3, 0x6a, 0xce,
-	0xd2, 0x3c, 0x64, 0xb9, 0x0d, 0x68, 0x2f, 0x44, 0xd9, 0x7b, 0xbd, 0x54, 0xe1, 0x4d, 0x34, 0x37,
-	0xb5, 0xae, 0x08, 0x96, 0xa6, 0x49, 0x26, 0xec, 0x96, 0x0a, 0xd6, 0xa2, 0x1a, 0x1c, 0x2e, 0x55,
-	0x70, 0x5b, 0x05, 0x6b, 0x1d, 0x06, 0xcf, 0x46, 0xb2, 0x76, 0xf3, 0x48, 0x4a, 0xe3, 0x5d, 0xbf,
-	0x79, 0xbc, 0xce, 0xb7, 0x00, 0xae, 0x3f, 0x2a, 0x8e, 0xd5, 0x5d, 0x30, 0x85, 0x3f, 0xd2, 0x87,
-	0x6a, 0x15, 0x03, 0xfc, 0x11, 0x95, 0xba, 0x65, 0x0e, 0xf3, 0xcf, 0x06, 0x98, 0xae, 0x3f, 0x92,
-	0x13, 0xca, 0x65, 0x67, 0x25, 0x4c, 0x95, 0xe2, 0x7a, 0x36, 0xcb, 0x4a, 0x79, 0x96, 0xdb, 0x50,
-	0xf7, 0x27, 0xa3, 0x11, 0x53, 0xc3, 0x6f, 0x53, 0x2d, 0xc9, 0x79, 0x66, 0xcc, 0xbb, 0x38, 0x45,
-	0x98, 0x2a, 0xc2, 0x34, 0xa4, 0x82, 0x4a, 0xa8, 0x5d, 0xb0, 0xc6, 0x51, 0x72, 0xea, 0x4f, 0x72,
-	0x2e, 0xf0, 0x14, 0xb4, 0x69, 0x63, 0x1c, 0x25, 0x4f, 0xa5, 0xec, 0x50, 0x68, 0x7d, 0x17, 0x46,
-	0x3c, 0x28, 0xfd, 0x28, 0xef, 0xa4, 0x5c, 0xfe, 0x51, 0x94, 0x83, 0xd2, 0x2f, 0x53, 0xd7, 0x4f,
-	0x50, 0xc3, 0x90, 0x52, 0xe3, 0x8d, 0xa5, 0x1a, 0x5f, 0xb9, 0xe5, 0xbf, 0x92, 0xff, 0xc9, 0x65,
-	0xa6, 0xfe, 0x3d, 0x8b, 0xe2, 0x5a, 0xea, 0xbc, 0xfc, 0x8c, 0xdb, 0xd5, 0x3d, 0x53, 0xea, 0xe4,
-	0xda, 0xf1, 0x61, 0xb3, 0x3f, 0xf6, 0x44, 0x70, 0xfe, 0x2c, 0x8a, 0xc5, 0x8c, 0x88, 0x1e, 0x41,
-	0x7d, 0x84, 0x0a, 0x9d, 0x4a, 0x47, 0x6e, 0x32, 0xe7, 0xa8, 0xed, 0xcb, 0x14, 0x98, 0x43, 0xab,
-	0x1c, 0xaa, 0x58, 0x52, 0x04, 0xe7, 0x88, 0x6d, 0x51, 0x25, 0x94, 0xaa, 0xaf, 0xdc, 0x52, 0xfd,
-	0x27, 0xb0, 0x1a, 0xc4, 0x1e, 0xe7, 0x51, 0xb8, 0x90, 0x56, 0x0a, 0xa3, 0xf3, 0x03, 0xac, 0xbb,
-	0xc1, 0x7c, 0x4d, 0x0f, 0xae, 0xd4, 0xa4, 0x23, 0xff, 0x7d, 0x3d, 0x9f, 0x49, 0xc6, 0xd7, 0xb5,
-	0x2c, 0x35, 0x33, 0xe7, 0x0d, 0xb4, 0x07, 0xc7, 0x27, 0x4c, 0xf0, 0x22, 0x97, 0xfb, 0x50, 0x8f,
-	0x32, 0xce, 0x04, 0xb7, 0x8d, 0x3d, 0xb3, 0x38, 0x38, 0xe8, 0x42, 0xb5, 0x61, 0x99, 0x44, 0x9e,
-	0x40, 0x0d, 0x63, 0xe4, 0x64, 0x13, 0x4f, 0xb3, 0xa2, 0x45, 0x71, 0x2d, 0xbb, 0x1c, 0x44, 0x61,
-	0xce, 0xed, 0x0a, 0x8e, 0x5b, 0x09, 0xce, 0x8f, 0x70, 0x67, 0x90, 0x09, 0xcf, 0x8f, 0x19, 0xef,
-	0x9d, 0x7b, 0x51, 0x52, 0xce, 0x28, 0x40, 0x45, 0x39, 0x23, 0x74, 0xa1, 0xda, 0xb0, 0x4c, 0x46,
-	0x7f, 0x18, 0x50, 0xc3, 0xa0, 0x85, 0x29, 0x3d, 0x01, 0x2b, 0x8c, 0x72, 0xa6, 0x6e, 0x38, 0x19,
-	0xbd, 0xa6, 0x6f, 0x38, 0x19, 0xd1, 0x3d, 0x2c, 0x4c, 0x74, 0xe6, 0x25, 0x7f, 0x61, 0xdd, 0x28,
-	0x13, 0xcb, 0x28, 0xba, 0xb3, 0x0d, 0x75, 0xe1, 0xe5, 0x67, 0x4c, 0xb1, 0xb7, 0x45, 0xb5, 0xe4,
-	0x38, 0x60, 0x4d, 0x71, 0x88, 0x05, 0xb5, 0xc1, 0xf0, 0xf8, 0x8d, 0xdb, 0x59, 0x21, 0x00, 0xf5,
-	0xa3, 0x37, 0xae, 0x5c, 0x1b, 0xce, 0x07, 0x68, 0xba, 0xd1, 0x98, 0xcd, 0x2a, 0x9f, 0x2f, 0xcb,
-	0xb8, 0x7e, 0x97, 0x75, 0xc0, 0xe4, 0x2c, 0xc0, 0x94, 0x4d, 0x2a, 0x97, 0x58, 0x9e, 0x54, 0x99,
-	0xa8, 0xc2, 0x35, 0xd9, 0x83, 0x56, 0x10, 0x5f, 0x9c, 0x46, 0x21, 0x3f, 0x1d, 0x7b, 0xfc, 0x42,
-	0x33, 0x0b, 0x04, 0xf1, 0xc5, 0x20, 0xe4, 0xaf, 0x3d, 0x7e, 0xe1, 0x30, 0x58, 0xbf, 0x72, 0x9b,
-	0x93, 0xfd, 0xb9, 0x2b, 0x7f, 0x6d, 0x7f, 0x67, 0xc1, 0x95, 0xdf, 0x9d, 0xbf, 0xf9, 0x9d, 0xff,
-	0x43, 0x5d, 0x47, 0x37, 0xa0, 0xfa, 0x72, 0xf0, 0xea, 0x95, 0x2a, 0xf0, 0x79, 0xdf, 0x3d, 0x1e,
-	0x1c, 0x76, 0x0c, 0xe7, 0x37, 0x03, 0x36, 0xfa, 0x1f, 0x58, 0x70, 0x22, 0x72, 0xc6, 0xa7, 0x13,
-	0x7e, 0x02, 0x35, 0x1e, 0xa4, 0x19, 0xd3, 0x1b, 0xed, 0xe2, 0x2f, 0x7d, 0xd5, 0xab, 0x7b, 0x22,
-	0x5d, 0xa8, 0xf2, 0x2c, 0x75, 0xb9, 0x52, 0xee, 0xb2, 0xbc, 0xe1, 0x38, 0x46, 0xa5, 0x39, 0xd7,
-	0x14, 0x33, 0x53, 0x38, 0xf7, 0xa0, 0x86, 0x28, 0xa4, 0x0d, 0x56, 0xef, 0x68, 0xe8, 0x1e, 0x0c,
-	0x86, 0x7d, 0xda, 0x59, 0x21, 0xab, 0x60, 0x1e, 0x1f, 0xc9, 0xfc, 0x86, 0x40, 0xca, 0x1b, 0xeb,
-	0x77, 0xc9, 0x0e, 0x34, 0xa2, 0x84, 0x0b, 0x2f, 0x09, 0x8a, 0x53, 0x33, 0x95, 0xd5, 0x86, 0x5e,
-	0x2e, 0xe4, 0xdc, 0xf4, 0x18, 0x66, 0x0a, 0xe7, 0x08, 0x36, 0x7b, 0xd2, 0x2d, 0x9e, 0x2f, 0xf8,
-	0xe3, 0x01, 0xff, 0x34, 0x60, 0xf3, 0x20, 0xcb, 0xe2, 0xcb, 0x41, 0xda, 0x93, 0x2f, 0xc2, 0x02,
-	0xd1, 0x86, 0x55, 0x35, 0x02, 0xae, 0x01, 0x0b, 0x51, 0x76, 0xea, 0x7d, 0x1a, 0x4f, 0x34, 0x98,
-	0x45, 0xb5, 0x74, 0xed, 0x70, 0x99, 0xd7, 0x0f, 0x57, 0x39, 0xcd, 0x2a, 0x66, 0x72, 0x43, 0x9a,
-	0xb5, 0xab, 0x69, 0x1e, 0xc3, 0xd6, 0x7c, 0x96, 0x37, 0x74, 0xd2, 0x5c, 0xba, 0xf0, 0x01, 0x80,
-	0x1b, 0x94, 0xca, 0x35, 0x45, 0x50, 0x10, 0x42, 0x5d, 0x31, 0x1b, 0x95, 0xaa, 0x65, 0xa8, 0xe0,
-	0x57, 0x03, 0x2a, 0x6e, 0x40, 0xee, 0xe9, 0x8b, 0x48, 0x1d, 0xba, 0xa6, 0x02, 0xe9, 0xba, 0x97,
-	0x19, 0xd3, 0xb7, 0xd2, 0xf4, 0xad, 0x59, 0xb9, 0xe1, 0xad, 0xa9, 0x5f, 0x0d, 0xe6, 0x82, 0x57,
-	0xc3, 0x16, 0xd4, 0x90, 0x0f, 0x34, 0x09, 0x28, 0xc1, 0xd9, 0x83, 0xaa, 0xc4, 0x97, 0xbf, 0xff,
-	0xb0, 0xef, 0xf6, 0x5f, 0x77, 0x56, 0xe4, 0x49, 0x7c, 0x7a, 0x30, 0x3c, 0xfc, 0x7e, 0x70, 0xe8,
-	0xbe, 0xe8, 0x18, 0xfb, 0xbf, 0xd4, 0xa0, 0x89, 0x2d, 0x3b, 0xc4, 0xa7, 0xbe, 0xfc, 0x09, 0x4f,
-	0x98, 0x70, 0x03, 0x4e, 0xd6, 0x54, 0x82, 0x45, 0x0b, 0x76, 0xb6, 0xbb, 0xea, 0xed, 0xdf, 0x2d,
-	0xde, 0xfe, 0xdd, 0xbe, 0x7c, 0xfb, 0x3b, 0x2b, 0xe4, 0x4b, 0x68, 0x3e, 0x8b, 0x27, 0xfc, 0x5c,
-	0x11, 0x3b, 0xd9, 0x98, 0x32, 0xf8, 0x12, 0xb1, 0x2f, 0x60, 0xe3, 0x84, 0x89, 0x79, 0x22, 0x26,
-	0x77, 0x11, 0x61, 0x11, 0x39, 0xdf, 0x9a, 0x45, 0x5b, 0x66, 0x1e, 0x8d, 0xd9, 0xd1, 0x68, 0xc4,
-	0x99, 0x20, 0xeb, 0x58, 0xc0, 0x8c, 0xde, 0x6e, 0x89, 0xfd, 0x0a, 0x36, 0x28, 0x0b, 0xd2, 0xf7,
-	0x2c, 0xff, 0xb8, 0xf8, 0xaf, 0xa1, 0x3d, 0x25, 0xaa, 0x97, 0x51, 0x1c, 0x93, 0xad, 0x39, 0xee,
-	0xfa, 0x67, 0x80, 0x6f, 0x4a, 0x74, 0xf8, 0x9c, 0x89, 0xe3, 0x28, 0xbc, 0x01, 0xe2, 0xce, 0x15,
-	0xad, 0x3a, 0xe7, 0x88, 0xd0, 0x9e, 0x31, 0x49, 0x9a, 0x73, 0x72, 0x67, 0x21, 0xab, 0xed, 0x6c,
-	0x5f, 0x55, 0x4f, 0x11, 0x0e, 0x61, 0xbd, 0xcc, 0x1d, 0x12, 0xe3, 0x3f, 0xb8, 0xdb, 0x75, 0x42,
-	0xb9, 0xa5, 0x92, 0x1e, 0xb4, 0xca, 0x7f, 0xa2, 0x82, 0x58, 0xc0, 0x20, 0x3b, 0xf6, 0x75, 0x43,
-	0x91, 0x8a, 0x5f, 0x47, 0xd8, 0xcf, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x00, 0x06, 0x71, 0x93,
-	0x86, 0x0e, 0x00, 0x00,
+func init() { proto.RegisterFile("chaosdaemon.proto", fileDescriptor_chaosdaemon_86a0b474696b4d85) }
+
+var fileDescriptor_chaosdaemon_86a0b474696b4d85 = []byte{
+	// 1940 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x5f, 0x73, 0xe3, 0x48,
+	0x11, 0x5f, 0xf9, 0x5f, 0xac, 0xb6, 0x9d, 0x38, 0x93, 0x4d, 0x4e, 0x9b, 0x3d, 0xd8, 0x9c, 0xb8,
+	0x83, 0xa5, 0xa0, 0x7c, 0x5c, 0xa8, 0xe2, 0x81, 0x07, 0x20, 0xff, 0xf6, 0xd6, 0xec, 0x6d, 0x12,
+	0x14, 0x6f, 0x5d, 0x15, 0x55, 0x94, 0x4b, 0x96, 0xc6, 0xf1, 0x6c, 0x64, 0x49, 0xa7, 0x19, 0xef,
+	0x6d, 0x8e, 0x27, 0x5e, 0xf9, 0x04, 0x3c, 0x00, 0x4f, 0xbc, 0xf2, 0xc4, 0x27, 0xe1, 0x43, 0xf0,
+	0x3d, 0xa8, 0xee, 0x19, 0xc9, 0x72, 0xe2, 0xe4, 0xbc, 0x4b, 0x71, 0x4f, 0x9e, 0xfe, 0xf5, 0x9f,
+	0xe9, 0xe9, 0x1e, 0x75, 0xf7, 0x18, 0x36, 0x83, 0x89, 0x9f, 0xc8, 0xd0, 0xe7, 0xd3, 0x24, 0xee,
+	0xa5, 0x59, 0xa2, 0x12, 0x56, 0x49, 0x47, 0xbb, 0x8f, 0x2f, 0x93, 0xe4, 0x32, 0xe2, 0x9f, 0x12,
+	0x32, 0x9a, 0x8d, 0x3f, 0xe5, 0xd3, 0x54, 0x5d, 0x6b, 0x01, 0xf7, 0x17, 0xd0, 0x1c, 0x04, 0xcf,
+	0xfd, 0x38, 0x8c, 0x38, 0x7b, 0x08, 0xf5, 0xa9, 0xff, 0x3a, 0xc9, 0x1c, 0x6b, 0xcf, 0x7a, 0xda,
+	0xf1, 0x34, 0x41, 0xa8, 0x88, 0x93, 0xcc, 0xa9, 0x18, 0x14, 0x09, 0x77, 0x04, 0xdd, 0xa3, 0x24,
+	0x56, 0xbe, 0x88, 0x79, 0xe6, 0xf1, 0xaf, 0x66, 0x5c, 0x2a, 0xf6, 0x13, 0x68, 0xf8, 0x81, 0x12,
+	0x49, 0x4c, 0x06, 0x5a, 0xfb, 0x5b, 0xbd, 0x74, 0xd4, 0x2b, 0xa4, 0x0e, 0x88, 0xe5, 0x19, 0x11,
+	0xf6, 0x11, 0xb4, 0x83, 0x9c, 0x35, 0x14, 0x21, 0x59, 0xb7, 0xbd, 0x56, 0x81, 0xf5, 0x43, 0xf7,
+	0x13, 0xd8, 0x2c, 0xed, 0x21, 0xd3, 0x24, 0x96, 0x9c, 0x75, 0xa1, 0x9a, 0x8a, 0xd0, 0xb8, 0x88,
+	0x4b, 0xf7, 0xef, 0x16, 0xb4, 0x4f, 0xb9, 0xe2, 0xd3, 0xdc, 0x8f, 0x27, 0x50, 0x8f, 0x91, 0x36,
+	0x6e, 0xd8, 0xe8, 0x86, 0x16, 0xd0, 0xf8, 0x0a, 0x7b, 0xb3, 0x8f, 0xa1, 0x31, 0xa1, 0xa8, 0x38,
+	0x55, 0x32, 0xd2, 0x46, 0x23, 0x79, 0xa4, 0x3c, 0xc3, 0x43, 0xa9, 0xd4, 0xcf, 0x78, 0xac, 0x9c,
+	0xda, 0x32, 0x29, 0xcd, 0x73, 0xff, 0x5d, 0x87, 0x3a, 0xed, 0xcf, 0x18, 0xd4, 0x94, 0x98, 0x72,
+	0xe3, 0x3d, 0xad, 0xd9, 0x0e, 0x34, 0x5e, 0x0b, 0xa5, 0x78, 0x1e, 0x60, 0x43, 0xb1, 0xef, 0x01,
+	0x84, 0x3c, 0xf2, 0xaf, 0x87, 0x41, 0x92, 0x65, 0xe4, 0x45, 0xc5, 0xb3, 0x09, 0x39, 0x4a, 0x32,
+	0x4a, 0x4b, 0x24, 0xa6, 0x42, 0xef, 0xdc, 0xf1, 0x34, 0x81, 0x1b, 0x44, 0x89, 0x94, 0x4e, 0x9d,
+	0xc4, 0x69, 0xcd, 0x1e, 0x83, 0x8d, 0xbf, 0xda, 0x4e, 0x83, 0x18, 0x4d, 0x04, 0xc8, 0x4c, 0x17,
+	0xaa, 0x97, 0x7e, 0xea, 0xac, 0xe9, 0x70, 0x5e, 0xfa, 0x29, 0xfb, 0x10, 0xec, 0x70, 0x96, 0x46,
+	0x22, 0xf0, 0x15, 0x77, 0x9a, 0x66, 0xdb, 0x1c, 0x60, 0x9f, 0xc0, 0x7a, 0x41, 0x68, 0x8b, 0x36,
+	0x89, 0x74, 0x0a, 0x94, 0xcc, 0x3a, 0xb0, 0x96, 0xf1, 0x24, 0x0b, 0x79, 0xe6, 0x00, 0xf1, 0x73,
+	0x12, 0x63, 0x6f, 0x96, 0x5a, 0xbd, 0x45, 0xec, 0x96, 0xc1, 0x72, 0x65, 0x64, 0xcd, 0x52, 0xe5,
+	0xb4, 0xb5, 0xb2, 0x21, 0x75, 0xe2, 0x68, 0xa9, 0x95, 0x3b, 0x5a, 0xd9, 0x60, 0xa4, 0x3c, 0x4f,
+	0xc9, 0xfa, 0xdd, 0x29, 0x29, 0xa5, 0x77, 0xe3, 0x9e, 0xf4, 0xba, 0xd0, 0x0e, 0x85, 0x54, 0x99,
+	0x18, 0xcd, 0xe8, 0x5a, 0x77, 0xe9, 0x9e, 0x2c, 0x60, 0x18, 0xf1, 0x0c, 0x23, 0xb5, 0xb9, 0x67,
+	0x3d, 0xad, 0x79, 0xb4, 0x66, 0x3f, 0x82, 0x8d, 0xd4, 0x0f, 0xae, 0xb8, 0x1a, 0x26, 0x6f, 0x78,
+	0x36, 0xe1, 0x7e, 0xe8, 0xb0, 0x3d, 0xeb, 0x69, 0xdd, 0x5b, 0xd7, 0xf0, 0x99, 0x41, 0x31, 0x35,
+	0x01, 0x8f, 0xa2, 0xa1, 0x14, 0xdf, 0x70, 0x67, 0x8b, 0x72, 0xd0, 0x44, 0xe0, 0x42, 0x7c, 0xc3,
+	0xd9, 0x0f, 0xa0, 0x43, 0xcc, 0xc2, 0xc6, 0x43, 0xb2, 0xd1, 0x46, 0xb0, 0xb0, 0xf0, 0x08, 0x9a,
+	0x32, 0x4a, 0xd4, 0x70, 0x2a, 0x62, 0x67, 0x9b, 0x0c, 0xac, 0x21, 0xfd, 0x52, 0xc4, 0x73, 0x96,
+	0xff, 0xd6, 0xd9, 0x29, 0xb1, 0xfc, 0xb7, 0x18, 0x47, 0x62, 0x69, 0x77, 0xa4, 0xf3, 0x01, 0xb1,
+	0x5b, 0x88, 0x9d, 0x6b, 0x08, 0xaf, 0x1f, 0x89, 0x8c, 0xae, 0x15, 0x97, 0x8e, 0x43, 0x02, 0x36,
+	0x22, 0x87, 0x08, 0xb8, 0xbf, 0x05, 0x18, 0x8c, 0xc6, 0xf9, 0x17, 0xf7, 0x08, 0xaa, 0x6a, 0x34,
+	0x36, 0xdf, 0xdb, 0x1a, 0xc5, 0x72, 0x34, 0xf6, 0x10, 0x5b, 0xe5, 0x3b, 0xff, 0x93, 0x05, 0xd5,
+	0xc1, 0x68, 0x5c, 0x84, 0xd2, 0x2a, 0x85, 0xb2, 0xb8, 0xe6, 0x95, 0xf2, 0x35, 0xdf, 0x81, 0xc6,
+	0x68, 0x36, 0x1e, 0x73, 0xfd, 0x5d, 0x74, 0x3c, 0x43, 0x61, 0x3c, 0x53, 0xee, 0x5f, 0x0d, 0xc9,
+	0x4c, 0x8d, 0xcc, 0x34, 0x11, 0xf0, 0xd0, 0xd4, 0x63, 0xb0, 0xa7, 0x22, 0x1e, 0x8e, 0x66, 0x99,
+	0x54, 0xf4, 0x81, 0x74, 0xbc, 0xe6, 0x54, 0xc4, 0x87, 0x48, 0xbb, 0x1e, 0xb4, 0x7f, 0x17, 0x0a,
+	0x19, 0x94, 0x6a, 0xc8, 0x57, 0x48, 0x97, 0x6b, 0x88, 0x16, 0xd0, 0xf8, 0x2a, 0xe7, 0xfa, 0x23,
+	0xd4, 0x49, 0xa5, 0x74, 0x27, 0xad, 0x95, 0xee, 0x64, 0xe5, 0x9e, 0x3b, 0x89, 0x25, 0xe4, 0x3a,
+	0xd5, 0x65, 0xc9, 0xf6, 0x68, 0x8d, 0x98, 0x9f, 0x5d, 0x4a, 0xa7, 0xb6, 0x57, 0x45, 0x0c, 0xd7,
+	0xee, 0x08, 0xb6, 0x4e, 0xa6, 0xbe, 0x0a, 0x26, 0xcf, 0x44, 0xa4, 0xe6, 0x35, 0xfa, 0x29, 0x34,
+	0xc6, 0x04, 0x18, 0x57, 0xba, 0xb8, 0xc9, 0x82, 0xa0, 0xe1, 0xaf, 0x72, 0xc0, 0x0c, 0xda, 0x65,
+	0x55, 0xdd, 0x40, 0x54, 0x30, 0x21, 0xdb, 0xb6, 0xa7, 0x89, 0xd2, 0xe9, 0x2b, 0xf7, 0x9c, 0xfe,
+	0x87, 0xb0, 0x16, 0x44, 0xbe, 0x94, 0x22, 0x5c, 0x5a, 0x71, 0x73, 0xa6, 0xfb, 0x7b, 0xd8, 0x18,
+	0x04, 0x8b, 0x67, 0xfa, 0xf8, 0xc6, 0x99, 0x8c, 0xe6, 0xbb, 0x9f, 0xe7, 0x67, 0xd0, 0xcc, 0xd5,
+	0x56, 0xcb, 0x99, 0xfb, 0x0a, 0x3a, 0xfd, 0xf3, 0x0b, 0xae, 0x64, 0xee, 0xcb, 0x47, 0xd0, 0x10,
+	0xa9, 0xc4, 0x6f, 0xca, 0xda, 0xab, 0xe6, 0x17, 0x87, 0x44, 0x3c, 0xc3, 0x58, 0xc5, 0x91, 0x17,
+	0x50, 0x27, 0x1d, 0xcc, 0x6c, 0xec, 0x9b, 0x86, 0x61, 0x7b, 0xb4, 0xc6, 0x28, 0x07, 0x22, 0xcc,
+	0xa4, 0x53, 0xa1, 0x74, 0x6b, 0x02, 0x8b, 0x66, 0x98, 0x4c, 0x7d, 0x11, 0x4b, 0xa7, 0x4a, 0x78,
+	0x4e, 0xba, 0x7f, 0x80, 0xed, 0x7e, 0xaa, 0xfc, 0x51, 0xc4, 0xe5, 0xd1, 0x04, 0x91, 0x92, 0xaf,
+	0x01, 0x01, 0x65, 0x5f, 0x49, 0xc4, 0x33, 0x8c, 0x55, 0x7c, 0xfd, 0x4f, 0x05, 0xea, 0xa4, 0xb4,
+	0xd4, 0xd9, 0xcf, 0xc0, 0x0e, 0x45, 0xc6, 0xf5, 0x58, 0x80, 0xda, 0xeb, 0x66, 0x2c, 0x40, 0x8d,
+	0xde, 0x71, 0xce, 0xf2, 0xe6, 0x52, 0xf8, 0x71, 0x9b, 0x10, 0xea, 0x83, 0x18, 0x0a, 0x71, 0xe5,
+	0x67, 0x97, 0x5c, 0xb7, 0x3c, 0xdb, 0x33, 0x14, 0xdb, 0x85, 0x26, 0xcd, 0x32, 0x41, 0x12, 0xd1,
+	0x67, 0x6d, 0x7b, 0x05, 0xcd, 0x9e, 0x40, 0x4b, 0x26, 0xb3, 0x2c, 0xe0, 0xc3, 0x34, 0xc9, 0x14,
+	0x75, 0x3f, 0xdb, 0x03, 0x0d, 0x9d, 0x27, 0x99, 0x62, 0x3f, 0x86, 0x6e, 0xc8, 0xa5, 0x12, 0xb1,
+	0x8f, 0x7b, 0x6b, 0xa9, 0x35, 0x92, 0xda, 0x28, 0xe1, 0x24, 0xba, 0x03, 0x8d, 0x90, 0xbf, 0x11,
+	0x81, 0xee, 0x8a, 0xb6, 0x67, 0x28, 0xdc, 0x23, 0xe3, 0xaf, 0x79, 0xa0, 0x86, 0x5f, 0x0b, 0x35,
+	0xa1, 0x7e, 0x68, 0x7b, 0xa0, 0xa1, 0x2f, 0x85, 0x9a, 0xb0, 0x3d, 0x68, 0xa5, 0x59, 0x32, 0xf2,
+	0x47, 0x22, 0x12, 0xea, 0xda, 0x34, 0xc4, 0x32, 0xe4, 0xba, 0x60, 0x17, 0xa1, 0x60, 0x36, 0xd4,
+	0xfb, 0xa7, 0xe7, 0xaf, 0x06, 0xdd, 0x07, 0x0c, 0xa0, 0x71, 0xf6, 0x6a, 0x80, 0x6b, 0xcb, 0x7d,
+	0x0b, 0xad, 0x81, 0x98, 0xf2, 0x79, 0xf2, 0x16, 0x33, 0x63, 0xdd, 0x9e, 0x61, 0xba, 0x50, 0x95,
+	0x3c, 0xa0, 0xa8, 0x57, 0x3d, 0x5c, 0x52, 0x86, 0x10, 0xaa, 0x12, 0x44, 0x6b, 0xb6, 0x07, 0xed,
+	0x20, 0xba, 0x1a, 0x8a, 0x50, 0x0e, 0xa7, 0xbe, 0xbc, 0x32, 0x65, 0x13, 0x82, 0xe8, 0xaa, 0x1f,
+	0xca, 0x97, 0xbe, 0xbc, 0x72, 0x39, 0x6c, 0xdc, 0x98, 0xe2, 0xd8, 0xfe, 0xc2, 0xa8, 0xb7, 0xbe,
+	0xbf, 0xbb, 0x64, 0xd4, 0xeb, 0x2d, 0x4e, 0x7c, 0xee, 0xf7, 0xa1, 0x61, 0xb4, 0x9b, 0x50, 0x7b,
+	0xd1, 0xff, 0xe2, 0x0b, 0x7d, 0xc0, 0xcf, 0x4f, 0x06, 0xe7, 0xfd, 0xe3, 0xae, 0xe5, 0xfe, 0xcd,
+	0x82, 0xcd, 0x93, 0xb7, 0x3c, 0xb8, 0x50, 0x19, 0x97, 0xc5, 0x25, 0xfd, 0x0c, 0xea, 0x32, 0x48,
+	0x52, 0x6e, 0x36, 0x7a, 0x4c, 0xf5, 0xea, 0xa6, 0x54, 0xef, 0x02, 0x45, 0x3c, 0x2d, 0x59, 0xba,
+	0x28, 0x95, 0x85, 0x8b, 0xf2, 0x21, 0xd8, 0x92, 0xb4, 0x92, 0x4c, 0x9a, 0xfa, 0x39, 0x07, 0xdc,
+	0x27, 0x50, 0x27, 0x2b, 0xac, 0x03, 0xf6, 0xd1, 0xd9, 0xe9, 0xe0, 0xa0, 0x7f, 0x7a, 0xe2, 0x75,
+	0x1f, 0xb0, 0x35, 0xa8, 0x9e, 0x9f, 0xa1, 0x7f, 0xa7, 0xc0, 0xca, 0x1b, 0x9b, 0x79, 0x74, 0x17,
+	0x9a, 0x22, 0x96, 0xca, 0x8f, 0x83, 0xfc, 0xe2, 0x17, 0xb4, 0xde, 0xd0, 0xcf, 0x14, 0xe6, 0xcd,
+	0xa4, 0x61, 0x0e, 0xb8, 0x67, 0xb0, 0x75, 0x84, 0x62, 0xd1, 0xe2, 0x81, 0xdf, 0xdf, 0xe0, 0x3f,
+	0x2c, 0xd8, 0x3a, 0x48, 0xd3, 0xe8, 0xba, 0x9f, 0x1c, 0xe1, 0x4b, 0x20, 0xb7, 0xe8, 0xc0, 0x9a,
+	0x4e, 0x81, 0x34, 0x06, 0x73, 0x12, 0x23, 0xf5, 0x26, 0x89, 0x66, 0xc6, 0x98, 0xed, 0x19, 0xea,
+	0xd6, 0xe5, 0xaa, 0xde, 0xbe, 0x5c, 0x65, 0x37, 0x6b, 0xe4, 0xc9, 0x1d, 0x6e, 0xd6, 0x6f, 0xba,
+	0x79, 0x0e, 0x0f, 0x17, 0xbd, 0xbc, 0x23, 0x92, 0xd5, 0x95, 0x0f, 0xfe, 0x57, 0x0b, 0xb6, 0xc9,
+	0xe4, 0x73, 0xa5, 0xd2, 0x85, 0xa3, 0xe3, 0x48, 0x31, 0x8b, 0x8a, 0x92, 0x84, 0x6b, 0xc4, 0xe8,
+	0x33, 0xd7, 0x13, 0x05, 0xad, 0xff, 0xbf, 0x07, 0xf6, 0x60, 0xe7, 0xa6, 0x77, 0xff, 0xf3, 0x91,
+	0x13, 0xd8, 0xba, 0xe0, 0xea, 0xf8, 0xf4, 0xe2, 0x82, 0x67, 0x6f, 0xe6, 0xad, 0x70, 0x85, 0xaa,
+	0x80, 0xef, 0x8a, 0x58, 0x0e, 0x25, 0xe9, 0x99, 0xbc, 0xdb, 0x61, 0x2c, 0xb5, 0x21, 0xbc, 0x12,
+	0x3c, 0xc6, 0x66, 0x41, 0x31, 0x68, 0x7a, 0x86, 0x72, 0xff, 0x6c, 0xc1, 0xc6, 0xb1, 0x90, 0x57,
+	0xcf, 0x44, 0x14, 0xbd, 0xc3, 0x6e, 0x18, 0x6c, 0x5f, 0x4d, 0xcc, 0x3e, 0xb4, 0x2e, 0xfa, 0x44,
+	0xb5, 0xd4, 0x27, 0x18, 0xd4, 0x68, 0x08, 0xd6, 0xd5, 0x87, 0xd6, 0x78, 0x6f, 0x53, 0x9e, 0x05,
+	0xd8, 0x83, 0xf5, 0xb8, 0x96, 0x93, 0x6e, 0x08, 0x9b, 0xfd, 0x64, 0x30, 0xc9, 0x12, 0xa5, 0xa2,
+	0x77, 0xa9, 0x88, 0x3f, 0x05, 0x5b, 0x19, 0x2d, 0xdd, 0x3e, 0x5b, 0xfb, 0xeb, 0xd4, 0xa0, 0xe7,
+	0xc6, 0xe6, 0x02, 0xee, 0x21, 0xb0, 0xf2, 0x2e, 0x26, 0x67, 0x0b, 0x36, 0xac, 0x6f, 0xb3, 0xf1,
+	0x17, 0x0b, 0x60, 0xce, 0x29, 0xf5, 0x10, 0x6b, 0xa1, 0x87, 0x3c, 0x82, 0x66, 0xc6, 0xfd, 0x70,
+	0x38, 0x4a, 0x25, 0x85, 0xaa, 0x86, 0x0f, 0x26, 0x3f, 0x3c, 0x4c, 0xe9, 0xf9, 0xf6, 0x75, 0x26,
+	0x14, 0x27, 0x5e, 0x95, 0x78, 0x4d, 0x02, 0x0c, 0x93, 0xf4, 0x44, 0x92, 0xca, 0x7c, 0xe0, 0x45,
+	0xa0, 0x9f, 0xa4, 0x34, 0xc2, 0x6b, 0x4d, 0xe2, 0xd6, 0x89, 0xab, 0x6d, 0x21, 0xdb, 0xed, 0x03,
+	0x0c, 0x82, 0x52, 0x91, 0xa8, 0xaa, 0x20, 0x3f, 0x50, 0x43, 0x0f, 0x3b, 0x1e, 0x42, 0xab, 0xcc,
+	0x00, 0xff, 0xaa, 0x40, 0x65, 0x10, 0xb0, 0x27, 0x66, 0x36, 0xd5, 0xa5, 0xba, 0xa5, 0x8d, 0xf4,
+	0x06, 0xd7, 0x29, 0x37, 0x83, 0x6a, 0xf1, 0x32, 0xaf, 0xdc, 0xf1, 0x32, 0x37, 0x0f, 0x89, 0xea,
+	0x92, 0x87, 0xc4, 0x43, 0xa8, 0xd3, 0x20, 0x60, 0xba, 0xbf, 0x26, 0xbe, 0xb3, 0xe6, 0xef, 0xc0,
+	0x9a, 0x88, 0x2f, 0xb1, 0x4e, 0x53, 0xf7, 0x6f, 0x7a, 0x39, 0x59, 0x4a, 0xa9, 0x5d, 0x4e, 0xa9,
+	0xbb, 0x07, 0x35, 0x3c, 0x39, 0xb6, 0xf3, 0xd3, 0x93, 0xc1, 0xc9, 0xcb, 0xee, 0x03, 0xec, 0x2c,
+	0x87, 0x07, 0xa7, 0xc7, 0x5f, 0xf6, 0x8f, 0x07, 0xcf, 0xbb, 0xd6, 0xfe, 0x3f, 0x9b, 0xd0, 0xa2,
+	0x7a, 0x70, 0x4c, 0x7f, 0xd9, 0x60, 0x53, 0xbd, 0xe0, 0x6a, 0x10, 0x48, 0xb6, 0xae, 0x43, 0x97,
+	0x27, 0x67, 0x77, 0xa7, 0xa7, 0xff, 0xc3, 0xe9, 0xe5, 0xff, 0xe1, 0xf4, 0x4e, 0xf0, 0x3f, 0x1c,
+	0xf7, 0x01, 0xfb, 0x25, 0xb4, 0x9e, 0x45, 0x33, 0x39, 0xd1, 0x53, 0x28, 0xdb, 0x2c, 0xc6, 0xcd,
+	0x15, 0x74, 0x9f, 0xc3, 0xe6, 0x05, 0x57, 0x8b, 0xb3, 0x21, 0x7b, 0x44, 0x16, 0x96, 0xcd, 0x8b,
+	0xf7, 0x7a, 0xd1, 0x41, 0xcf, 0xc5, 0x94, 0x9f, 0x8d, 0xc7, 0x98, 0x96, 0x0d, 0x3a, 0xc0, 0x7c,
+	0x5c, 0xb9, 0x47, 0xf7, 0x57, 0xb0, 0xe9, 0xf1, 0x00, 0x1f, 0xb9, 0xef, 0xa7, 0xff, 0x6b, 0xe8,
+	0x14, 0x83, 0xc7, 0x0b, 0x11, 0x45, 0xec, 0xe1, 0xc2, 0x2c, 0xf2, 0xed, 0x06, 0x7e, 0x53, 0x1a,
+	0x6f, 0x3e, 0xe7, 0xea, 0x5c, 0x84, 0x77, 0x98, 0xd8, 0xbe, 0x81, 0xea, 0x82, 0x40, 0x16, 0x3a,
+	0xf3, 0xc9, 0x20, 0xc9, 0x24, 0xdb, 0x5e, 0x3a, 0xa5, 0xec, 0xee, 0xdc, 0x84, 0x0b, 0x0b, 0xc7,
+	0xb0, 0x51, 0x9e, 0x05, 0xd0, 0xc6, 0x07, 0xb4, 0xdb, 0xed, 0x01, 0xe1, 0x9e, 0x93, 0x1c, 0x41,
+	0xbb, 0xdc, 0x59, 0xb5, 0x89, 0x25, 0x13, 0xc1, 0xae, 0x73, 0x9b, 0x51, 0xb8, 0xd2, 0x87, 0xf5,
+	0xc5, 0x6e, 0xa5, 0xaf, 0xc4, 0xd2, 0xfe, 0xba, 0xbb, 0xbb, 0x8c, 0x55, 0x98, 0x3a, 0x80, 0x76,
+	0xb9, 0x49, 0x69, 0x7f, 0x96, 0xb4, 0xad, 0x7b, 0x6f, 0x47, 0x87, 0xcc, 0xe7, 0xad, 0x87, 0xd1,
+	0xeb, 0xe1, 0x46, 0x23, 0xba, 0x3f, 0xb9, 0xe6, 0x76, 0xbd, 0xaf, 0x85, 0x43, 0xd8, 0x30, 0x91,
+	0x2a, 0xaa, 0xf8, 0xf6, 0x8d, 0x7a, 0x5f, 0x4e, 0xef, 0xed, 0x8e, 0x41, 0xe9, 0xcd, 0xef, 0xf8,
+	0x2a, 0x56, 0xee, 0xf0, 0x64, 0xd4, 0x20, 0xe4, 0xe7, 0xff, 0x1d, 0x00, 0x2a, 0x83, 0x0a, 0x45,
+	0xe8, 0x15, 0x00, 0x00,
 }
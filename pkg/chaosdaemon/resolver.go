@@ -0,0 +1,370 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// minResolveInterval and maxResolveInterval bound the interval to resolve the domains again,
+	// which is the minimum TTL of the dns records
+	minResolveInterval = 5 * time.Second
+	maxResolveInterval = 5 * time.Minute
+
+	dnsTimeout = 5 * time.Second
+
+	hostsPath = "etc/hosts"
+)
+
+// domainResolver resolves domains in the same way as the container, with the hosts and resolv.conf
+// of the container, and the dns queries are sent from the network namespace of the container
+type domainResolver struct {
+	nsPath string
+	config *dns.ClientConfig
+	hosts  map[string][]net.IP
+}
+
+// newDomainResolver creates a resolver with the hosts and resolv.conf under the root directory of the container
+func newDomainResolver(root string, nsPath string) (*domainResolver, error) {
+	config, err := dns.ClientConfigFromFile(filepath.Join(root, resolvConfPath))
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := readHosts(filepath.Join(root, hostsPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domainResolver{
+		nsPath: nsPath,
+		config: config,
+		hosts:  hosts,
+	}, nil
+}
+
+// readHosts reads the addresses of hostnames in the hosts file, which is ignored if not exist
+func readHosts(path string) (map[string][]net.IP, error) {
+	hosts := map[string][]net.IP{}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hosts, nil
+		}
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if index := strings.Index(line, "#"); index >= 0 {
+			line = line[:index]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		ip := net.ParseIP(fields[0])
+		if ip == nil {
+			continue
+		}
+		for _, name := range fields[1:] {
+			name = strings.ToLower(dns.Fqdn(name))
+			hosts[name] = append(hosts[name], ip)
+		}
+	}
+
+	return hosts, nil
+}
+
+// resolve returns the cidrs of the domain, and the TTL of them
+func (r *domainResolver) resolve(ctx context.Context, domain string) ([]string, time.Duration, error) {
+	if ips, ok := r.hosts[strings.ToLower(dns.Fqdn(domain))]; ok {
+		cidrs := []string{}
+		for _, ip := range ips {
+			cidrs = append(cidrs, hostCIDR(ip))
+		}
+		return cidrs, maxResolveInterval, nil
+	}
+
+	for _, name := range r.config.NameList(domain) {
+		cidrs, ttl, err := r.lookup(ctx, name)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(cidrs) > 0 {
+			return cidrs, ttl, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("no address found for %s", domain)
+}
+
+// lookup queries both the IPv4 and IPv6 addresses of the fully qualified name
+func (r *domainResolver) lookup(ctx context.Context, name string) ([]string, time.Duration, error) {
+	cidrs := []string{}
+	ttl := maxResolveInterval
+
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		msg := new(dns.Msg)
+		msg.SetQuestion(name, qtype)
+
+		resp, err := r.exchange(ctx, msg)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for _, answer := range resp.Answer {
+			var ip net.IP
+			switch record := answer.(type) {
+			case *dns.A:
+				ip = record.A
+			case *dns.AAAA:
+				ip = record.AAAA
+			default:
+				continue
+			}
+
+			cidrs = append(cidrs, hostCIDR(ip))
+			if recordTTL := time.Duration(answer.Header().Ttl) * time.Second; recordTTL < ttl {
+				ttl = recordTTL
+			}
+		}
+	}
+
+	return cidrs, ttl, nil
+}
+
+// exchange sends the query to the nameservers in order, until one of them answers
+func (r *domainResolver) exchange(ctx context.Context, msg *dns.Msg) (*dns.Msg, error) {
+	err := fmt.Errorf("no nameserver is configured")
+	for _, server := range r.config.Servers {
+		address := net.JoinHostPort(server, r.config.Port)
+
+		var resp *dns.Msg
+		resp, err = r.exchangeWith(ctx, "udp", address, msg)
+		if err == nil && resp.Truncated {
+			resp, err = r.exchangeWith(ctx, "tcp", address, msg)
+		}
+		if err != nil {
+			continue
+		}
+
+		if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+			err = fmt.Errorf("nameserver %s answers %s", address, dns.RcodeToString[resp.Rcode])
+			continue
+		}
+
+		return resp, nil
+	}
+
+	return nil, err
+}
+
+// exchangeWith sends the query through the connection created in the network namespace of the container
+func (r *domainResolver) exchangeWith(ctx context.Context, network string, address string, msg *dns.Msg) (*dns.Msg, error) {
+	var conn net.Conn
+	err := withNetNS(r.nsPath, func() error {
+		var err error
+		dialer := &net.Dialer{Timeout: dnsTimeout}
+		conn, err = dialer.DialContext(ctx, network, address)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	co := &dns.Conn{Conn: conn}
+	defer co.Close()
+
+	err = co.SetDeadline(time.Now().Add(dnsTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	err = co.WriteMsg(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := co.ReadMsg()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Id != msg.Id {
+		return nil, dns.ErrId
+	}
+
+	return resp, nil
+}
+
+// hostCIDR returns the cidr with full mask of the ip
+func hostCIDR(ip net.IP) string {
+	ipnet, _ := parseCIDR(ip.String())
+	return ipnet.String()
+}
+
+// hasDomains returns whether there are domains in the ipsets
+func hasDomains(ipsets []*pb.IPSet) bool {
+	for _, ipset := range ipsets {
+		if len(ipset.Domains) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIPSets resolves the domains of ipsets in the container, and returns the ipsets with the
+// addresses of domains added into the cidrs and the interval to resolve them again
+func resolveIPSets(ctx context.Context, pid uint32, ipsets []*pb.IPSet) ([]*pb.IPSet, time.Duration, error) {
+	if !hasDomains(ipsets) {
+		return ipsets, maxResolveInterval, nil
+	}
+
+	root := fmt.Sprintf("%s/%d/root", defaultProcPrefix, pid)
+	resolver, err := newDomainResolver(root, GetNsPath(pid, bpm.NetNS))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resolver.resolveIPSets(ctx, ipsets)
+}
+
+func (r *domainResolver) resolveIPSets(ctx context.Context, ipsets []*pb.IPSet) ([]*pb.IPSet, time.Duration, error) {
+	interval := maxResolveInterval
+	resolved := make([]*pb.IPSet, 0, len(ipsets))
+	for _, ipset := range ipsets {
+		domainCidrs := []string{}
+		for _, domain := range ipset.Domains {
+			cidrs, ttl, err := r.resolve(ctx, domain)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			domainCidrs = append(domainCidrs, cidrs...)
+			if ttl < interval {
+				interval = ttl
+			}
+		}
+		// the order of records may change between queries
+		sort.Strings(domainCidrs)
+
+		resolved = append(resolved, &pb.IPSet{
+			Name:  ipset.Name,
+			Cidrs: append(append([]string{}, ipset.Cidrs...), domainCidrs...),
+		})
+	}
+
+	if interval < minResolveInterval {
+		interval = minResolveInterval
+	}
+
+	return resolved, interval, nil
+}
+
+// equalIPSets returns whether the ipsets have the same names and cidrs
+func equalIPSets(a []*pb.IPSet, b []*pb.IPSet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Name != b[i].Name || len(a[i].Cidrs) != len(b[i].Cidrs) {
+			return false
+		}
+		for j := range a[i].Cidrs {
+			if a[i].Cidrs[j] != b[i].Cidrs[j] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// ipsetRefresher refreshes the ipsets of containers periodically, so that the ipsets follow the changes
+// of dns records. There is at most one refreshing loop for a container, and the lock should be held
+// while starting or stopping the loop, or flushing the ipsets
+type ipsetRefresher struct {
+	sync.Mutex
+
+	// cancels maps the container id to the function to stop its refreshing loop
+	cancels map[string]context.CancelFunc
+}
+
+func newIPSetRefresher() *ipsetRefresher {
+	return &ipsetRefresher{
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// start starts the refreshing loop of the container, which calls the refresh function after the interval.
+// The refresh function returns the interval to call it again, or an error to stop the loop
+func (r *ipsetRefresher) start(containerID string, interval time.Duration, refresh func(ctx context.Context) (time.Duration, error)) {
+	r.stop(containerID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancels[containerID] = cancel
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+
+			var err error
+			interval, err = refresh(ctx)
+			if err != nil {
+				log.Info("stop refreshing ipsets", "containerID", containerID, "reason", err.Error())
+
+				r.Lock()
+				// the loop has not been replaced or stopped by others if the context is not canceled
+				if ctx.Err() == nil {
+					delete(r.cancels, containerID)
+				}
+				r.Unlock()
+
+				cancel()
+				return
+			}
+		}
+	}()
+}
+
+// stop stops the refreshing loop of the container if exists
+func (r *ipsetRefresher) stop(containerID string) {
+	if cancel, ok := r.cancels[containerID]; ok {
+		cancel()
+		delete(r.cancels, containerID)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func setupResolverTest(t *testing.T, resolvConf string, hosts string) (*domainResolver, func()) {
+	g := NewWithT(t)
+
+	root, err := ioutil.TempDir("", "resolver")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(os.MkdirAll(filepath.Join(root, "etc"), 0755)).Should(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(root, resolvConfPath), []byte(resolvConf), 0644)).Should(Succeed())
+	g.Expect(ioutil.WriteFile(filepath.Join(root, hostsPath), []byte(hosts), 0644)).Should(Succeed())
+
+	resolver, err := newDomainResolver(root, "/proc/self/ns/net")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return resolver, func() {
+		os.RemoveAll(root)
+	}
+}
+
+func Test_resolveWithHosts(t *testing.T) {
+	g := NewWithT(t)
+
+	resolver, cleanup := setupResolverTest(t, "nameserver 127.0.0.1\n", `127.0.0.1 localhost
+# 10.0.0.100 commented
+10.0.0.1 web web.default.svc.cluster.local
+fd00::1 web
+`)
+	defer cleanup()
+
+	cidrs, ttl, err := resolver.resolve(context.TODO(), "web")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"10.0.0.1/32", "fd00::1/128"}))
+	g.Expect(ttl).To(Equal(maxResolveInterval))
+
+	cidrs, _, err = resolver.resolve(context.TODO(), "Web.Default.svc.cluster.local.")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"10.0.0.1/32"}))
+}
+
+func Test_resolveWithNameserver(t *testing.T) {
+	g := NewWithT(t)
+
+	if err := withNetNS("/proc/self/ns/net", func() error { return nil }); err != nil {
+		t.Skipf("can't enter the network namespace: %v", err)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: conn, NotifyStartedFunc: func() { close(started) }, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		question := req.Question[0]
+		if question.Name != "web.default.svc.cluster.local." {
+			resp.SetRcode(req, dns.RcodeNameError)
+		} else if question.Qtype == dns.TypeA {
+			resp.Answer = append(resp.Answer,
+				&dns.A{Hdr: dns.RR_Header{Name: question.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 30}, A: net.ParseIP("10.0.0.3")},
+				&dns.A{Hdr: dns.RR_Header{Name: question.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 10}, A: net.ParseIP("10.0.0.2")},
+			)
+		}
+		w.WriteMsg(resp)
+	})}
+	go server.ActivateAndServe()
+	defer server.Shutdown()
+	<-started
+
+	resolver, cleanup := setupResolverTest(t, `nameserver 127.0.0.1
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5
+`, "")
+	defer cleanup()
+	resolver.config.Port = strconv.Itoa(conn.LocalAddr().(*net.UDPAddr).Port)
+
+	cidrs, ttl, err := resolver.resolve(context.TODO(), "web")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"10.0.0.3/32", "10.0.0.2/32"}))
+	g.Expect(ttl).To(Equal(10 * time.Second))
+
+	_, _, err = resolver.resolve(context.TODO(), "db")
+	g.Expect(err).Should(HaveOccurred())
+
+	ipsets, interval, err := resolver.resolveIPSets(context.TODO(), []*pb.IPSet{
+		{Name: "a", Cidrs: []string{"172.16.0.0/16"}, Domains: []string{"web"}},
+		{Name: "b", Cidrs: []string{"172.17.0.1/32"}},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(interval).To(Equal(10 * time.Second))
+	g.Expect(equalIPSets(ipsets, []*pb.IPSet{
+		{Name: "a", Cidrs: []string{"172.16.0.0/16", "10.0.0.2/32", "10.0.0.3/32"}},
+		{Name: "b", Cidrs: []string{"172.17.0.1/32"}},
+	})).To(BeTrue())
+}
+
+func Test_ipsetRefresher(t *testing.T) {
+	g := NewWithT(t)
+
+	refresher := newIPSetRefresher()
+
+	t.Run("stop on error", func(t *testing.T) {
+		calls := make(chan struct{}, 10)
+		refresher.Lock()
+		refresher.start("a", time.Millisecond, func(ctx context.Context) (time.Duration, error) {
+			calls <- struct{}{}
+			if len(calls) == 3 {
+				return 0, errors.New("container not found")
+			}
+			return time.Millisecond, nil
+		})
+		refresher.Unlock()
+
+		g.Eventually(func() int {
+			refresher.Lock()
+			defer refresher.Unlock()
+			return len(refresher.cancels)
+		}).Should(Equal(0))
+		g.Consistently(calls).Should(HaveLen(3))
+	})
+
+	t.Run("stop and replace", func(t *testing.T) {
+		running := make(chan struct{})
+		stopped := make(chan struct{})
+		refresher.Lock()
+		refresher.start("b", time.Millisecond, func(ctx context.Context) (time.Duration, error) {
+			close(running)
+			<-ctx.Done()
+			close(stopped)
+			return 0, ctx.Err()
+		})
+		refresher.Unlock()
+		<-running
+
+		refresher.Lock()
+		refresher.start("b", time.Hour, func(ctx context.Context) (time.Duration, error) {
+			return time.Hour, nil
+		})
+		g.Expect(refresher.cancels).To(HaveLen(1))
+		refresher.Unlock()
+
+		g.Eventually(stopped).Should(BeClosed())
+
+		refresher.Lock()
+		refresher.stop("b")
+		g.Expect(refresher.cancels).To(BeEmpty())
+		refresher.Unlock()
+	})
+}
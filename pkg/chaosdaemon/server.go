@@ -42,6 +42,8 @@ type Config struct {
 	Host      string
 	Runtime   string
 	Profiling bool
+	// Netlink enables programming tc rules and ipsets through netlink instead of executing commands
+	Netlink bool
 }
 
 // Get the http address
@@ -58,6 +60,7 @@ func (c *Config) GrpcAddr() string {
 type daemonServer struct {
 	crClient                 ContainerRuntimeInfoClient
 	backgroundProcessManager bpm.BackgroundProcessManager
+	ipsetRefresher           *ipsetRefresher
 }
 
 func newDaemonServer(containerRuntime string) (*daemonServer, error) {
@@ -69,6 +72,7 @@ func newDaemonServer(containerRuntime string) (*daemonServer, error) {
 	return &daemonServer{
 		crClient:                 crClient,
 		backgroundProcessManager: bpm.NewBackgroundProcessManager(),
+		ipsetRefresher:           newIPSetRefresher(),
 	}, nil
 }
 
@@ -78,6 +82,10 @@ func newGRPCServer(containerRuntime string, reg prometheus.Registerer) (*grpc.Se
 		return nil, err
 	}
 
+	if err := ds.cleanStaleHttpChaos(context.Background()); err != nil {
+		log.Error(err, "failed to clean the stale http chaos")
+	}
+
 	grpcMetrics := grpc_prometheus.NewServerMetrics()
 	grpcMetrics.EnableHandlingTimeHistogram(
 		grpc_prometheus.WithHistogramBuckets([]float64{0.001, 0.01, 0.1, 0.3, 0.6, 1, 3, 6, 10}),
@@ -110,6 +118,8 @@ type RegisterGatherer interface {
 func StartServer(conf *Config, reg RegisterGatherer) error {
 	g := &errgroup.Group{}
 
+	netlinkEnabled = conf.Netlink
+
 	httpBindAddr := conf.HttpAddr()
 	httpServer := newHTTPServerBuilder().Addr(httpBindAddr).Metrics(reg).Profiling(conf.Profiling).Build()
 
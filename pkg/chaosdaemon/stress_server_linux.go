@@ -175,14 +175,11 @@ func existingPath(paths map[string]string, pid int, suffix string) cgroups.Path
 		if err != nil {
 			return errorPath(err)
 		}
-		rel, err := filepath.Rel(dest, p)
+		path, err := localizeCgroupPath(dest, p)
 		if err != nil {
 			return errorPath(err)
 		}
-		if rel == "." {
-			rel = dest
-		}
-		paths[n] = filepath.Join("/", rel)
+		paths[n] = path
 	}
 	return func(name cgroups.Name) (string, error) {
 		root, ok := paths[string(name)]
@@ -198,6 +195,18 @@ func existingPath(paths map[string]string, pid int, suffix string) cgroups.Path
 	}
 }
 
+// localizeCgroupPath localizes the cgroup path of process based on the root of the cgroup mount
+func localizeCgroupPath(dest string, path string) (string, error) {
+	rel, err := filepath.Rel(dest, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		rel = dest
+	}
+	return filepath.Join("/", rel), nil
+}
+
 func parseCgroupFile(path string) (map[string]string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -30,9 +30,26 @@ import (
 const (
 	ruleNotExist             = "Cannot delete qdisc with handle of zero."
 	ruleNotExistLowerVersion = "RTNETLINK answers: No such file or directory"
+
+	ingressNotExist             = "Cannot find specified qdisc on specified device."
+	ingressNotExistLowerVersion = "RTNETLINK answers: Invalid argument"
+	deviceNotExist              = "Cannot find device"
+
+	defaultDevice = "eth0"
+	// ifbDevicePrefix is the prefix of devices in the pod network namespace to shape the ingress traffic
+	ifbDevicePrefix = "chaos-ifb"
+
+	icmpv6ProtocolNumber = 58
 )
 
-func generateQdiscArgs(action string, qdisc *pb.Qdisc) ([]string, error) {
+// protocolNumbers maps the protocols in TrafficFilter to the protocol numbers in IP header
+var protocolNumbers = map[string]int{
+	"icmp": 1,
+	"tcp":  6,
+	"udp":  17,
+}
+
+func generateQdiscArgs(action string, device string, qdisc *pb.Qdisc) ([]string, error) {
 
 	if qdisc == nil {
 		return nil, fmt.Errorf("qdisc is required")
@@ -42,7 +59,11 @@ func generateQdiscArgs(action string, qdisc *pb.Qdisc) ([]string, error) {
 		return nil, fmt.Errorf("qdisc.Type is required")
 	}
 
-	args := []string{"qdisc", action, "dev", "eth0"}
+	if device == "" {
+		device = defaultDevice
+	}
+
+	args := []string{"qdisc", action, "dev", device}
 
 	if qdisc.Parent == nil {
 		args = append(args, "root")
@@ -75,14 +96,197 @@ func (s *daemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 		return nil, status.Errorf(codes.Internal, "get pid from containerID error: %v", err)
 	}
 	nsPath := GetNsPath(pid, bpm.NetNS)
-	client := buildTcClient(ctx, nsPath)
-	err = client.flush()
+	ipv6 := IPv6Enabled(pid)
+
+	devices, err := listNetworkDevices(ctx, nsPath)
 	if err != nil {
-		log.Error(err, "error while flushing client")
+		log.Error(err, "error while listing network devices")
 		return &empty.Empty{}, err
 	}
 
-	// tc rules are split into two different kinds according to whether it has filter.
+	// all the devices are flushed, so that the devices which are not requested any more are recovered
+	for _, device := range devices {
+		if device.loopback || device.isIfb() {
+			continue
+		}
+
+		client := buildTcClient(ctx, nsPath, device.name)
+		err = client.flush()
+		if err != nil {
+			log.Error(err, "error while flushing client", "device", device.name)
+			return &empty.Empty{}, err
+		}
+
+		// the ingress traffic is redirected to an ifb device, so the ifb device is removed to
+		// recover the ingress traffic
+		err = client.deleteIfb(device.ifbName())
+		if err != nil {
+			log.Error(err, "error while deleting ifb device", "device", device.name)
+			return &empty.Empty{}, err
+		}
+	}
+
+	deviceTcs := map[string][]*pb.Tc{}
+	for _, tc := range in.Tcs {
+		resolved, err := resolveDevices(tc.Device, devices)
+		if err != nil {
+			log.Error(err, "error while resolving network device")
+			return &empty.Empty{}, err
+		}
+
+		for _, device := range resolved {
+			deviceTcs[device.name] = append(deviceTcs[device.name], tc)
+		}
+	}
+
+	chains := []*pb.Chain{}
+	for _, device := range devices {
+		tcs, ok := deviceTcs[device.name]
+		if !ok {
+			continue
+		}
+
+		deviceChains, err := setDeviceTcs(ctx, nsPath, device, tcs, ipv6)
+		if err != nil {
+			return &empty.Empty{}, err
+		}
+
+		for _, chain := range deviceChains {
+			chain.Name = fmt.Sprintf("TC-TABLES-%d", len(chains))
+			chains = append(chains, chain)
+		}
+	}
+
+	// the egress traffic is classified by iptables, and the iptables chain has been initialized
+	// by previous grpc request to set iptables and iptables rules are recovered by previous
+	// call too, so there is no need to remove these rules here
+	iptables := buildIptablesClient(ctx, nsPath)
+	err = iptables.setIptablesChains(chains)
+	if err != nil {
+		log.Error(err, "error while setting iptables")
+		return &empty.Empty{}, err
+	}
+
+	// the IPv6 packets are classified by the same chains on ip6tables, which match the IPv6 ipsets
+	if ipv6 {
+		ip6tables := buildIp6tablesClient(ctx, nsPath)
+		err = ip6tables.setIptablesChains(chains)
+		if err != nil {
+			log.Error(err, "error while setting ip6tables")
+			return &empty.Empty{}, err
+		}
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// setDeviceTcs sets the tc rules on the device, and returns the iptables chains to classify the egress traffic
+func setDeviceTcs(ctx context.Context, nsPath string, device networkDevice, tcs []*pb.Tc, ipv6 bool) ([]*pb.Chain, error) {
+	egressTcs := []*pb.Tc{}
+	ingressTcs := []*pb.Tc{}
+	for _, tc := range tcs {
+		if tc.Ingress {
+			ingressTcs = append(ingressTcs, tc)
+		} else {
+			egressTcs = append(egressTcs, tc)
+		}
+	}
+
+	client := buildTcClient(ctx, nsPath, device.name)
+	classes, err := client.addTcs(egressTcs)
+	if err != nil {
+		return nil, err
+	}
+
+	chains := []*pb.Chain{}
+	for _, class := range classes {
+		ipsets := []string{}
+		if class.filter.ipset != "" {
+			ipsets = append(ipsets, class.filter.ipset)
+		}
+		chains = append(chains, &pb.Chain{
+			Direction:       pb.Chain_OUTPUT,
+			Ipsets:          ipsets,
+			Target:          fmt.Sprintf("CLASSIFY --set-class %s", class.classid),
+			Protocol:        class.filter.protocol,
+			SourcePort:      class.filter.sourcePort,
+			DestinationPort: class.filter.destinationPort,
+			Device:          device.name,
+		})
+	}
+
+	if len(ingressTcs) == 0 {
+		return chains, nil
+	}
+
+	// the ingress traffic can't be shaped directly, so it's redirected to an ifb device,
+	// and the tc rules are set on the egress of the ifb device
+	err = client.redirectIngress(device.ifbName())
+	if err != nil {
+		log.Error(err, "error while redirecting ingress traffic")
+		return nil, err
+	}
+
+	ifb := buildTcClient(ctx, nsPath, device.ifbName())
+	classes, err = ifb.addTcs(ingressTcs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the packets on ifb device don't pass the netfilter, so they are classified by tc filters
+	for _, class := range classes {
+		err = ifb.addFilter(class.parent, class.classid, class.filter, false)
+		if err != nil {
+			log.Error(err, "error while adding filter")
+			return nil, err
+		}
+
+		if ipv6 {
+			err = ifb.addFilter(class.parent, class.classid, class.filter, true)
+			if err != nil {
+				log.Error(err, "error while adding filter")
+				return nil, err
+			}
+		}
+	}
+
+	return chains, nil
+}
+
+// tcFilter represents the traffic which a tc rule works on,
+// the tc rules with the same filter are piped in the same band
+type tcFilter struct {
+	ipset           string
+	protocol        string
+	sourcePort      string
+	destinationPort string
+}
+
+// tcClass represents a band of the prio qdisc, to which the traffic matched by the filter should be classified
+type tcClass struct {
+	filter  tcFilter
+	parent  string
+	classid string
+}
+
+type tcClient struct {
+	ctx    context.Context
+	nsPath string
+	device string
+}
+
+func buildTcClient(ctx context.Context, nsPath string, device string) tcClient {
+	return tcClient{
+		ctx,
+		nsPath,
+		device,
+	}
+}
+
+// addTcs sets the tc rules on the device, and returns the classes which the filtered traffic should be classified to
+func (c *tcClient) addTcs(tcs []*pb.Tc) ([]tcClass, error) {
+	// tc rules are split into two different kinds according to whether it has filter, which consists of
+	// the ipset, protocol and ports.
 	// all tc rules without filter are called `globalTc` and the tc rules with filter will be called `filterTc`.
 	// the `globalTc` rules will be piped one by one from root, and the last `globalTc` will be connected with a PRIO
 	// qdisc, which has `3 + len(filterTc)` bands. Then the 4.. bands will be connected to `filterTc` and a filter will
@@ -107,14 +311,24 @@ func (s *daemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 	//  iptables -A TC-TABLES-1 -m set --match-set B dst -j CLASSIFY --set-class 3:5 -w 5
 
 	globalTc := []*pb.Tc{}
-	filterTc := map[string][]*pb.Tc{}
-
-	for _, tc := range in.Tcs {
-		if tc.Ipset == "" {
+	filters := []tcFilter{}
+	filterTc := map[tcFilter][]*pb.Tc{}
+
+	for _, tc := range tcs {
+		filter := tcFilter{
+			ipset:           tc.Ipset,
+			protocol:        tc.Protocol,
+			sourcePort:      tc.SourcePort,
+			destinationPort: tc.DestinationPort,
+		}
+		if filter == (tcFilter{}) {
 			globalTc = append(globalTc, tc)
 		} else {
-			// TODO: support multiple tc with one ipset
-			filterTc[tc.Ipset] = append(filterTc[tc.Ipset], tc)
+			// TODO: support multiple tc with one filter
+			if _, ok := filterTc[filter]; !ok {
+				filters = append(filters, filter)
+			}
+			filterTc[filter] = append(filterTc[filter], tc)
 		}
 	}
 
@@ -126,34 +340,28 @@ func (s *daemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 
 		handleArg := fmt.Sprintf("handle %d:", index+1)
 
-		err := client.addTc(parentArg, handleArg, tc)
+		err := c.addTc(parentArg, handleArg, tc)
 		if err != nil {
 			log.Error(err, "error while adding tc")
-			return &empty.Empty{}, err
+			return nil, err
 		}
 	}
 
 	parent := len(globalTc)
 	band := 3 + len(filterTc) // 3 handlers for normal sfq on prio qdisc
-	err = client.addPrio(parent, band)
+	err := c.addPrio(parent, band)
 	if err != nil {
 		log.Error(err, "error while adding prio")
-		return &empty.Empty{}, err
+		return nil, err
 	}
 
 	parent++
 
-	index := 0
 	currentHandler := parent + 3 // 3 handlers for sfq on prio qdisc
 
-	iptables := buildIptablesClient(ctx, nsPath)
-
-	// iptables chain has been initialized by previous grpc request to set iptables
-	// and iptables rules are recovered by previous call too, so there is no need
-	// to remove these rules here
-	chains := []*pb.Chain{}
-	for ipset, tcs := range filterTc {
-		for i, tc := range tcs {
+	classes := []tcClass{}
+	for index, filter := range filters {
+		for i, tc := range filterTc[filter] {
 			parentArg := fmt.Sprintf("parent %d:%d", parent, index+4)
 			if i > 0 {
 				parentArg = fmt.Sprintf("parent %d:", currentHandler)
@@ -162,51 +370,86 @@ func (s *daemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Em
 			currentHandler++
 			handleArg := fmt.Sprintf("handle %d:", currentHandler)
 
-			err := client.addTc(parentArg, handleArg, tc)
+			err := c.addTc(parentArg, handleArg, tc)
 			if err != nil {
 				log.Error(err, "error while adding tc")
-				return &empty.Empty{}, err
+				return nil, err
 			}
 		}
 
-		chains = append(chains, &pb.Chain{
-			Name:      fmt.Sprintf("TC-TABLES-%d", index),
-			Direction: pb.Chain_OUTPUT,
-			Ipsets:    []string{ipset},
-			Target:    fmt.Sprintf("CLASSIFY --set-class %d:%d", parent, index+4),
+		classes = append(classes, tcClass{
+			filter:  filter,
+			parent:  fmt.Sprintf("%d:", parent),
+			classid: fmt.Sprintf("%d:%d", parent, index+4),
 		})
+	}
+
+	return classes, nil
+}
 
-		index++
+func (c *tcClient) flush() error {
+	if ok, err := tryNetlink(c.nsPath, func() error { return netlinkFlushQdisc(c.device) }); ok {
+		return err
 	}
-	err = iptables.setIptablesChains(chains)
+
+	cmd := bpm.DefaultProcessBuilder("tc", "qdisc", "del", "dev", c.device, "root").SetNetNS(c.nsPath).SetContext(c.ctx).Build()
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		log.Error(err, "error while setting iptables")
-		return &empty.Empty{}, err
+		if (!strings.Contains(string(output), ruleNotExistLowerVersion)) && (!strings.Contains(string(output), ruleNotExist)) {
+			return encodeOutputToError(output, err)
+		}
 	}
-
-	return &empty.Empty{}, nil
+	return nil
 }
 
-type tcClient struct {
-	ctx    context.Context
-	nsPath string
-}
+// redirectIngress creates the ifb device and redirects all the ingress traffic of the device to it
+func (c *tcClient) redirectIngress(ifb string) error {
+	log.Info("redirecting ingress", "device", c.device, "ifb", ifb)
 
-func buildTcClient(ctx context.Context, nsPath string) tcClient {
-	return tcClient{
-		ctx,
-		nsPath,
+	if ok, err := tryNetlink(c.nsPath, func() error { return netlinkRedirectIngress(c.device, ifb) }); ok {
+		return err
 	}
+
+	commands := [][]string{
+		{"ip", "link", "add", ifb, "type", "ifb"},
+		{"ip", "link", "set", "dev", ifb, "up"},
+		{"tc", "qdisc", "add", "dev", c.device, "handle", "ffff:", "ingress"},
+		{"tc", "filter", "add", "dev", c.device, "parent", "ffff:", "protocol", "all", "u32", "match", "u32", "0", "0",
+			"action", "mirred", "egress", "redirect", "dev", ifb},
+	}
+	for _, command := range commands {
+		cmd := bpm.DefaultProcessBuilder(command[0], command[1:]...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return encodeOutputToError(output, err)
+		}
+	}
+
+	return nil
 }
 
-func (c *tcClient) flush() error {
-	cmd := bpm.DefaultProcessBuilder("tc", "qdisc", "del", "dev", "eth0", "root").SetNetNS(c.nsPath).SetContext(c.ctx).Build()
+// deleteIfb removes the ingress qdisc of the device and the ifb device, which is ignored if not exist
+func (c *tcClient) deleteIfb(ifb string) error {
+	if ok, err := tryNetlink(c.nsPath, func() error { return netlinkDeleteIfb(c.device, ifb) }); ok {
+		return err
+	}
+
+	cmd := bpm.DefaultProcessBuilder("tc", "qdisc", "del", "dev", c.device, "ingress").SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		if (!strings.Contains(string(output), ruleNotExistLowerVersion)) && (!strings.Contains(string(output), ruleNotExist)) {
+		if !strings.Contains(string(output), ingressNotExist) && !strings.Contains(string(output), ingressNotExistLowerVersion) {
+			return encodeOutputToError(output, err)
+		}
+	}
+
+	cmd = bpm.DefaultProcessBuilder("ip", "link", "del", ifb).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		if !strings.Contains(string(output), deviceNotExist) {
 			return encodeOutputToError(output, err)
 		}
 	}
+
 	return nil
 }
 
@@ -247,7 +490,31 @@ func (c *tcClient) addPrio(parent int, band int) error {
 	if parent > 0 {
 		parentArg = fmt.Sprintf("parent %d:", parent)
 	}
-	args := fmt.Sprintf("qdisc add dev eth0 %s handle %d: prio bands %d priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1", parentArg, parent+1, band)
+
+	handleArg := fmt.Sprintf("handle %d:", parent+1)
+	ok, err := tryNetlink(c.nsPath, func() error {
+		err := netlinkAddPrio(c.device, parentArg, handleArg, band)
+		if err != nil {
+			return err
+		}
+
+		for index := 1; index <= 3; index++ {
+			err := netlinkAddSfq(c.device, fmt.Sprintf("parent %d:%d", parent+1, index), fmt.Sprintf("handle %d:", parent+1+index))
+			if err != nil {
+				// remove the partial prio, otherwise the commands fail to add it again on fallback
+				if delErr := netlinkDeleteQdisc(c.device, parentArg, handleArg); delErr != nil {
+					log.Error(delErr, "error while deleting prio qdisc", "parent", parentArg)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if ok {
+		return err
+	}
+
+	args := fmt.Sprintf("qdisc add dev %s %s handle %d: prio bands %d priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1", c.device, parentArg, parent+1, band)
 	cmd := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -255,7 +522,7 @@ func (c *tcClient) addPrio(parent int, band int) error {
 	}
 
 	for index := 1; index <= 3; index++ {
-		args := fmt.Sprintf("qdisc add dev eth0 parent %d:%d handle %d: sfq", parent+1, index, parent+1+index)
+		args := fmt.Sprintf("qdisc add dev %s parent %d:%d handle %d: sfq", c.device, parent+1, index, parent+1+index)
 		cmd := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 		output, err := cmd.CombinedOutput()
 		if err != nil {
@@ -269,7 +536,11 @@ func (c *tcClient) addPrio(parent int, band int) error {
 func (c *tcClient) addNetem(parent string, handle string, netem *pb.Netem) error {
 	log.Info("adding netem", "parent", parent, "handle", handle)
 
-	args := fmt.Sprintf("qdisc add dev eth0 %s %s netem %s", parent, handle, convertNetemToArgs(netem))
+	if ok, err := tryNetlink(c.nsPath, func() error { return netlinkAddNetem(c.device, parent, handle, netem) }); ok {
+		return err
+	}
+
+	args := fmt.Sprintf("qdisc add dev %s %s %s netem %s", c.device, parent, handle, convertNetemToArgs(netem))
 	cmd := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -281,7 +552,11 @@ func (c *tcClient) addNetem(parent string, handle string, netem *pb.Netem) error
 func (c *tcClient) addTbf(parent string, handle string, tbf *pb.Tbf) error {
 	log.Info("adding tbf", "parent", parent, "handle", handle)
 
-	args := fmt.Sprintf("qdisc add dev eth0 %s %s tbf %s", parent, handle, convertTbfToArgs(tbf))
+	if ok, err := tryNetlink(c.nsPath, func() error { return netlinkAddTbf(c.device, parent, handle, tbf) }); ok {
+		return err
+	}
+
+	args := fmt.Sprintf("qdisc add dev %s %s %s tbf %s", c.device, parent, handle, convertTbfToArgs(tbf))
 	cmd := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -290,12 +565,23 @@ func (c *tcClient) addTbf(parent string, handle string, tbf *pb.Tbf) error {
 	return nil
 }
 
-func (c *tcClient) addFilter(parent string, classid string, ipset string) error {
-	log.Info("adding filter", "parent", parent, "classid", classid, "ipset", ipset)
+// addFilter classifies the traffic from the source matched by the filter into the class
+func (c *tcClient) addFilter(parent string, classid string, filter tcFilter, ipv6 bool) error {
+	log.Info("adding filter", "parent", parent, "classid", classid, "filter", filter, "ipv6", ipv6)
 
-	args := strings.Split(fmt.Sprintf("filter add dev eth0 %s basic match", parent), " ")
-	args = append(args, fmt.Sprintf("ipset(%s dst)", ipset))
-	args = append(args, strings.Split(classid, " ")...)
+	if ok, err := tryNetlink(c.nsPath, func() error {
+		return netlinkAddBasicFilter(c.device, parent, classid, filter, ipv6)
+	}); ok {
+		return err
+	}
+
+	protocol := "ip"
+	if ipv6 {
+		protocol = "ipv6"
+	}
+
+	args := []string{"filter", "add", "dev", c.device, "parent", parent, "protocol", protocol,
+		"basic", "match", convertFilterToEmatch(filter, ipv6), "classid", classid}
 	cmd := bpm.DefaultProcessBuilder("tc", args...).SetNetNS(c.nsPath).SetContext(c.ctx).Build()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -304,6 +590,68 @@ func (c *tcClient) addFilter(parent string, classid string, ipset string) error
 	return nil
 }
 
+// convertFilterToEmatch converts the filter into the ematch expression on the source of packets.
+// The packets are redirected from ingress before the transport header is parsed, so the ports are
+// matched with the offset of transport header, assuming there is no IPv4 options or IPv6 extension headers
+func convertFilterToEmatch(filter tcFilter, ipv6 bool) string {
+	protocolOffset, headerLength := filterOffsets(ipv6)
+
+	matches := []string{}
+	if len(filter.ipset) > 0 {
+		ipset := filter.ipset
+		if ipv6 {
+			ipset = ipv6IPSetName(ipset)
+		}
+		matches = append(matches, fmt.Sprintf("ipset(%s src)", ipset))
+	}
+
+	if len(filter.protocol) > 0 {
+		matches = append(matches, fmt.Sprintf("cmp(u8 at %d layer network eq %d)", protocolOffset, protocolNumber(filter.protocol, ipv6)))
+	}
+
+	if len(filter.sourcePort) > 0 {
+		matches = append(matches, convertPortsToEmatch(filter.sourcePort, headerLength))
+	}
+
+	if len(filter.destinationPort) > 0 {
+		matches = append(matches, convertPortsToEmatch(filter.destinationPort, headerLength+2))
+	}
+
+	return strings.Join(matches, " and ")
+}
+
+// filterOffsets returns the offsets of the protocol field and the transport header from the network header
+func filterOffsets(ipv6 bool) (int, int) {
+	if ipv6 {
+		return 6, 40
+	}
+	return 9, 20
+}
+
+// protocolNumber returns the protocol number of the protocol in TrafficFilter
+func protocolNumber(protocol string, ipv6 bool) int {
+	if ipv6 && protocol == "icmp" {
+		return icmpv6ProtocolNumber
+	}
+	return protocolNumbers[protocol]
+}
+
+// convertPortsToEmatch converts the ports like "80,8000-9000" into the ematch expression
+func convertPortsToEmatch(ports string, offset int) string {
+	matches := []string{}
+	for _, part := range strings.Split(ports, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) == 1 {
+			matches = append(matches, fmt.Sprintf("cmp(u16 at %d layer network eq %s)", offset, bounds[0]))
+		} else {
+			matches = append(matches, fmt.Sprintf("(not cmp(u16 at %d layer network lt %s) and not cmp(u16 at %d layer network gt %s))",
+				offset, bounds[0], offset, bounds[1]))
+		}
+	}
+
+	return "(" + strings.Join(matches, " or ") + ")"
+}
+
 func convertNetemToArgs(netem *pb.Netem) string {
 	args := ""
 	if netem.Time > 0 {
@@ -314,6 +662,11 @@ func convertNetemToArgs(netem *pb.Netem) string {
 			if netem.DelayCorr > 0 {
 				args = fmt.Sprintf("%s %f", args, netem.DelayCorr)
 			}
+
+			// distribution only works on the jitter
+			if len(netem.Distribution) > 0 {
+				args = fmt.Sprintf("%s distribution %s", args, netem.Distribution)
+			}
 		}
 
 		// reordering not possible without specifying some delay
@@ -354,6 +707,29 @@ func convertNetemToArgs(netem *pb.Netem) string {
 		}
 	}
 
+	if netem.Rate > 0 {
+		args = fmt.Sprintf("%s rate %dbps", args, netem.Rate)
+		if netem.PacketOverhead != 0 || netem.CellSize > 0 {
+			args = fmt.Sprintf("%s %d", args, netem.PacketOverhead)
+			if netem.CellSize > 0 {
+				args = fmt.Sprintf("%s %d %d", args, netem.CellSize, netem.CellOverhead)
+			}
+		}
+	}
+
+	if netem.SlotMin > 0 {
+		args = fmt.Sprintf("%s slot %dus", args, netem.SlotMin)
+		if netem.SlotMax > netem.SlotMin {
+			args = fmt.Sprintf("%s %dus", args, netem.SlotMax)
+		}
+		if netem.SlotPackets > 0 {
+			args = fmt.Sprintf("%s packets %d", args, netem.SlotPackets)
+		}
+		if netem.SlotBytes > 0 {
+			args = fmt.Sprintf("%s bytes %d", args, netem.SlotBytes)
+		}
+	}
+
 	trimedArgs := []string{}
 
 	for _, part := range strings.Split(args, " ") {
@@ -14,7 +14,10 @@
 package chaosdaemon
 
 import (
+	"context"
 	"errors"
+	"os/exec"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -30,7 +33,7 @@ func commonTcTest(t *testing.T, fpname, errString string, tcFunc func(s *daemonS
 	defer mock.With("MockContainerdClient", &MockClient{})()
 	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
 	m := bpm.NewBackgroundProcessManager()
-	s := &daemonServer{c, m}
+	s := &daemonServer{c, m, newIPSetRefresher()}
 
 	if errString == "" {
 		defer mock.With(fpname, true)()
@@ -55,14 +58,14 @@ func Test_generateQdiscArgs(t *testing.T) {
 
 	t.Run("without parent and handle", func(t *testing.T) {
 
-		args, err := generateQdiscArgs("add", &pb.Qdisc{Type: typ})
+		args, err := generateQdiscArgs("add", "", &pb.Qdisc{Type: typ})
 
 		g.Expect(err).To(BeNil())
 		g.Expect(args).To(Equal([]string{"qdisc", "add", "dev", "eth0", "root", "handle", "1:0", typ}))
 	})
 
 	t.Run("with parent and handle", func(t *testing.T) {
-		args, err := generateQdiscArgs("add", &pb.Qdisc{
+		args, err := generateQdiscArgs("add", "net1", &pb.Qdisc{
 			Type: typ,
 			Parent: &pb.TcHandle{
 				Major: 1,
@@ -75,7 +78,7 @@ func Test_generateQdiscArgs(t *testing.T) {
 		})
 
 		g.Expect(err).To(BeNil())
-		g.Expect(args).To(Equal([]string{"qdisc", "add", "dev", "eth0", "parent", "1:1", "handle", "10:0", typ}))
+		g.Expect(args).To(Equal([]string{"qdisc", "add", "dev", "net1", "parent", "1:1", "handle", "10:0", typ}))
 	})
 }
 
@@ -185,6 +188,58 @@ func Test_convertNetemToArgs(t *testing.T) {
 		g.Expect(args).To(Equal("corrupt 10.000000 50.000000"))
 	})
 
+	t.Run("convert delay distribution", func(t *testing.T) {
+		args := convertNetemToArgs(&pb.Netem{
+			Time:         1000,
+			Distribution: "normal",
+		})
+		g.Expect(args).To(Equal("delay 1000"))
+
+		args = convertNetemToArgs(&pb.Netem{
+			Time:         1000,
+			Jitter:       10000,
+			DelayCorr:    25,
+			Distribution: "paretonormal",
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 25.000000 distribution paretonormal"))
+	})
+
+	t.Run("convert rate", func(t *testing.T) {
+		args := convertNetemToArgs(&pb.Netem{
+			Rate: 12500,
+		})
+		g.Expect(args).To(Equal("rate 12500bps"))
+
+		args = convertNetemToArgs(&pb.Netem{
+			Rate:           12500,
+			PacketOverhead: -4,
+		})
+		g.Expect(args).To(Equal("rate 12500bps -4"))
+
+		args = convertNetemToArgs(&pb.Netem{
+			Rate:         12500,
+			CellSize:     53,
+			CellOverhead: 5,
+		})
+		g.Expect(args).To(Equal("rate 12500bps 0 53 5"))
+	})
+
+	t.Run("convert slot", func(t *testing.T) {
+		args := convertNetemToArgs(&pb.Netem{
+			SlotMin: 800,
+		})
+		g.Expect(args).To(Equal("slot 800us"))
+
+		args = convertNetemToArgs(&pb.Netem{
+			Time:        1000,
+			SlotMin:     800,
+			SlotMax:     1600,
+			SlotPackets: 32,
+			SlotBytes:   65536,
+		})
+		g.Expect(args).To(Equal("delay 1000 slot 800us 1600us packets 32 bytes 65536"))
+	})
+
 	t.Run("complicate cases", func(t *testing.T) {
 		args := convertNetemToArgs(&pb.Netem{
 			Time:        1000,
@@ -197,3 +252,184 @@ func Test_convertNetemToArgs(t *testing.T) {
 		g.Expect(args).To(Equal("delay 1000 10000 reorder 5.000000 gap 10 corrupt 10.000000 50.000000"))
 	})
 }
+
+const mockLinks = `1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1000\    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00
+3: eth0@if10: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1450 qdisc noqueue state UP mode DEFAULT group default \    link/ether 9e:a9:30:4a:9c:e8 brd ff:ff:ff:ff:ff:ff link-netnsid 0
+4: net1@if11: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP mode DEFAULT group default \    link/ether 2a:1c:90:4f:11:02 brd ff:ff:ff:ff:ff:ff link-netnsid 0
+5: chaos-ifb3: <BROADCAST,NOARP,UP,LOWER_UP> mtu 1500 qdisc prio state UNKNOWN mode DEFAULT group default qlen 32\    link/ether 6e:1e:2c:b8:41:31 brd ff:ff:ff:ff:ff:ff
+`
+
+func setupTcTest() (*daemonServer, *[]string, func()) {
+	finalizers := []mock.Finalizer{}
+	finalizers = append(finalizers, mock.With("MockContainerdClient", &MockClient{}))
+	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
+	m := bpm.NewBackgroundProcessManager()
+	s := &daemonServer{c, m, newIPSetRefresher()}
+
+	finalizers = append(finalizers, mock.With("pid", 9527))
+	finalizers = append(finalizers, mock.With("IPv6Enabled", true))
+
+	commands := []string{}
+	finalizers = append(finalizers, mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+		command := strings.Join(args[2:], " ")
+		commands = append(commands, command)
+		if command == "ip -o link show" {
+			return exec.Command("echo", "-n", mockLinks)
+		}
+		return exec.Command("echo", "-n")
+	}))
+
+	return s, &commands, func() {
+		for _, finalizer := range finalizers {
+			finalizer()
+		}
+	}
+}
+
+func Test_SetTcsWithFilter(t *testing.T) {
+	g := NewWithT(t)
+
+	s, commands, cleanup := setupTcTest()
+	defer cleanup()
+
+	_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+		Tcs: []*pb.Tc{{
+			Type:            pb.Tc_NETEM,
+			Netem:           &pb.Netem{Time: 50000},
+			Protocol:        "tcp",
+			DestinationPort: "5432",
+		}},
+		ContainerId: "containerd://container-id",
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(*commands).To(ContainElement("tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 50000"))
+	g.Expect(*commands).To(ContainElement("iptables -w -A TC-TABLES-0 -o eth0 -p tcp -m multiport --dports 5432 -j CLASSIFY --set-class 1:4 -w 5"))
+	g.Expect(*commands).NotTo(ContainElement(ContainSubstring("dev net1 parent")))
+}
+
+func Test_SetTcsOnIngress(t *testing.T) {
+	g := NewWithT(t)
+
+	s, commands, cleanup := setupTcTest()
+	defer cleanup()
+
+	t.Run("shape ingress", func(t *testing.T) {
+		*commands = []string{}
+		_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+			Tcs: []*pb.Tc{{
+				Type:            pb.Tc_NETEM,
+				Netem:           &pb.Netem{Time: 50000},
+				Ipset:           "A",
+				Protocol:        "tcp",
+				DestinationPort: "5432",
+				Ingress:         true,
+			}},
+			ContainerId: "containerd://container-id",
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(*commands).To(ContainElement("ip link add chaos-ifb3 type ifb"))
+		g.Expect(*commands).To(ContainElement("tc filter add dev eth0 parent ffff: protocol all u32 match u32 0 0 action mirred egress redirect dev chaos-ifb3"))
+		g.Expect(*commands).To(ContainElement("tc qdisc add dev chaos-ifb3 parent 1:4 handle 5: netem delay 50000"))
+		g.Expect(*commands).To(ContainElement("tc filter add dev chaos-ifb3 parent 1: protocol ip basic match ipset(A src) and cmp(u8 at 9 layer network eq 6) and (cmp(u16 at 22 layer network eq 5432)) classid 1:4"))
+		g.Expect(*commands).To(ContainElement("tc filter add dev chaos-ifb3 parent 1: protocol ipv6 basic match ipset(A6 src) and cmp(u8 at 6 layer network eq 6) and (cmp(u16 at 42 layer network eq 5432)) classid 1:4"))
+		g.Expect(*commands).NotTo(ContainElement(ContainSubstring("CLASSIFY")))
+	})
+
+	t.Run("recover ingress", func(t *testing.T) {
+		*commands = []string{}
+		_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+			Tcs:         []*pb.Tc{},
+			ContainerId: "containerd://container-id",
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(*commands).To(ContainElement("tc qdisc del dev eth0 ingress"))
+		g.Expect(*commands).To(ContainElement("ip link del chaos-ifb3"))
+		g.Expect(*commands).To(ContainElement("tc qdisc del dev net1 root"))
+		g.Expect(*commands).To(ContainElement("ip link del chaos-ifb4"))
+		g.Expect(*commands).NotTo(ContainElement("tc qdisc del dev lo root"))
+		g.Expect(*commands).NotTo(ContainElement(ContainSubstring("type ifb")))
+	})
+}
+
+func Test_SetTcsOnDevices(t *testing.T) {
+	g := NewWithT(t)
+
+	s, commands, cleanup := setupTcTest()
+	defer cleanup()
+
+	t.Run("specified device", func(t *testing.T) {
+		*commands = []string{}
+		_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+			Tcs: []*pb.Tc{{
+				Type:   pb.Tc_NETEM,
+				Netem:  &pb.Netem{Time: 50000},
+				Ipset:  "A",
+				Device: "net1",
+			}},
+			ContainerId: "containerd://container-id",
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(*commands).To(ContainElement("tc qdisc add dev net1 parent 1:4 handle 5: netem delay 50000"))
+		g.Expect(*commands).To(ContainElement("iptables -w -A TC-TABLES-0 -o net1 -m set --match-set A dst -j CLASSIFY --set-class 1:4 -w 5"))
+		g.Expect(*commands).NotTo(ContainElement(ContainSubstring("dev eth0 parent")))
+	})
+
+	t.Run("all devices", func(t *testing.T) {
+		*commands = []string{}
+		_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+			Tcs: []*pb.Tc{{
+				Type:   pb.Tc_NETEM,
+				Netem:  &pb.Netem{Time: 50000},
+				Ipset:  "A",
+				Device: "*",
+			}},
+			ContainerId: "containerd://container-id",
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(*commands).To(ContainElement("tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 50000"))
+		g.Expect(*commands).To(ContainElement("tc qdisc add dev net1 parent 1:4 handle 5: netem delay 50000"))
+		g.Expect(*commands).To(ContainElement("iptables -w -A TC-TABLES-0 -o eth0 -m set --match-set A dst -j CLASSIFY --set-class 1:4 -w 5"))
+		g.Expect(*commands).To(ContainElement("iptables -w -A TC-TABLES-1 -o net1 -m set --match-set A dst -j CLASSIFY --set-class 1:4 -w 5"))
+		g.Expect(*commands).NotTo(ContainElement(ContainSubstring("dev lo parent")))
+		g.Expect(*commands).NotTo(ContainElement(ContainSubstring("dev chaos-ifb3 parent")))
+	})
+
+	t.Run("device not found", func(t *testing.T) {
+		_, err := s.SetTcs(context.TODO(), &pb.TcsRequest{
+			Tcs: []*pb.Tc{{
+				Type:   pb.Tc_NETEM,
+				Netem:  &pb.Netem{Time: 50000},
+				Device: "net2",
+			}},
+			ContainerId: "containerd://container-id",
+		})
+		g.Expect(err).NotTo(BeNil())
+		g.Expect(err.Error()).To(Equal("network device net2 not found"))
+	})
+}
+
+func Test_parseNetworkDevices(t *testing.T) {
+	g := NewWithT(t)
+
+	devices, err := parseNetworkDevices(mockLinks)
+	g.Expect(err).To(BeNil())
+	g.Expect(devices).To(Equal([]networkDevice{
+		{index: 1, name: "lo", loopback: true},
+		{index: 3, name: "eth0"},
+		{index: 4, name: "net1"},
+		{index: 5, name: "chaos-ifb3"},
+	}))
+
+	_, err = parseNetworkDevices("unexpected output")
+	g.Expect(err).NotTo(BeNil())
+}
+
+func Test_convertFilterToEmatch(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(convertFilterToEmatch(tcFilter{ipset: "A"}, false)).To(Equal("ipset(A src)"))
+	g.Expect(convertFilterToEmatch(tcFilter{protocol: "icmp"}, true)).To(Equal("cmp(u8 at 6 layer network eq 58)"))
+	g.Expect(convertFilterToEmatch(tcFilter{protocol: "udp", sourcePort: "53,8000-9000"}, false)).To(Equal(
+		"cmp(u8 at 9 layer network eq 17) and (cmp(u16 at 20 layer network eq 53) or " +
+			"(not cmp(u16 at 20 layer network lt 8000) and not cmp(u16 at 20 layer network gt 9000)))"))
+}
@@ -29,7 +29,7 @@ var _ = Describe("time server", func() {
 	defer mock.With("MockContainerdClient", &MockClient{})()
 	c, _ := CreateContainerRuntimeInfoClient(containerRuntimeContainerd)
 	m := bpm.NewBackgroundProcessManager()
-	s := &daemonServer{c, m}
+	s := &daemonServer{c, m, newIPSetRefresher()}
 
 	Context("SetTimeOffset", func() {
 		It("should work", func() {
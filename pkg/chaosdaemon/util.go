@@ -189,6 +189,17 @@ func GetNsPath(pid uint32, typ bpm.NsType) string {
 	return fmt.Sprintf("%s/%d/ns/%s", defaultProcPrefix, pid, string(typ))
 }
 
+// IPv6Enabled returns whether IPv6 is enabled in the network namespace of the process.
+// The `if_inet6` only exists when IPv6 is supported by the kernel
+func IPv6Enabled(pid uint32) bool {
+	if enabled := mock.On("IPv6Enabled"); enabled != nil {
+		return enabled.(bool)
+	}
+
+	_, err := os.Stat(fmt.Sprintf("%s/%d/net/if_inet6", defaultProcPrefix, pid))
+	return err == nil
+}
+
 // ContainerKillByContainerID kills container according to container id
 func (c DockerClient) ContainerKillByContainerID(ctx context.Context, containerID string) error {
 	if len(containerID) < len(dockerProtocolPrefix) {
@@ -0,0 +1,278 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: chaosdns.proto
+
+package chaosdns
+
+import (
+	fmt "fmt"
+
+	proto "github.com/golang/protobuf/proto"
+
+	math "math"
+
+	empty "github.com/golang/protobuf/ptypes/empty"
+
+	context "golang.org/x/net/context"
+
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type SetDNSChaosRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Action               string   `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Scope                string   `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	ErrorCode            string   `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Patterns             []string `protobuf:"bytes,5,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	PodIps               []string `protobuf:"bytes,6,rep,name=pod_ips,json=podIps,proto3" json:"pod_ips,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDNSChaosRequest) Reset()         { *m = SetDNSChaosRequest{} }
+func (m *SetDNSChaosRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDNSChaosRequest) ProtoMessage()    {}
+func (*SetDNSChaosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdns_bc4c210f3f1a5e73, []int{0}
+}
+func (m *SetDNSChaosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDNSChaosRequest.Unmarshal(m, b)
+}
+func (m *SetDNSChaosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDNSChaosRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetDNSChaosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDNSChaosRequest.Merge(dst, src)
+}
+func (m *SetDNSChaosRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDNSChaosRequest.Size(m)
+}
+func (m *SetDNSChaosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDNSChaosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDNSChaosRequest proto.InternalMessageInfo
+
+func (m *SetDNSChaosRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetDNSChaosRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *SetDNSChaosRequest) GetScope() string {
+	if m != nil {
+		return m.Scope
+	}
+	return ""
+}
+
+func (m *SetDNSChaosRequest) GetErrorCode() string {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ""
+}
+
+func (m *SetDNSChaosRequest) GetPatterns() []string {
+	if m != nil {
+		return m.Patterns
+	}
+	return nil
+}
+
+func (m *SetDNSChaosRequest) GetPodIps() []string {
+	if m != nil {
+		return m.PodIps
+	}
+	return nil
+}
+
+type CancelDNSChaosRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelDNSChaosRequest) Reset()         { *m = CancelDNSChaosRequest{} }
+func (m *CancelDNSChaosRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelDNSChaosRequest) ProtoMessage()    {}
+func (*CancelDNSChaosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaosdns_bc4c210f3f1a5e73, []int{1}
+}
+func (m *CancelDNSChaosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelDNSChaosRequest.Unmarshal(m, b)
+}
+func (m *CancelDNSChaosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelDNSChaosRequest.Marshal(b, m, deterministic)
+}
+func (dst *CancelDNSChaosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelDNSChaosRequest.Merge(dst, src)
+}
+func (m *CancelDNSChaosRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelDNSChaosRequest.Size(m)
+}
+func (m *CancelDNSChaosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelDNSChaosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelDNSChaosRequest proto.InternalMessageInfo
+
+func (m *CancelDNSChaosRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*SetDNSChaosRequest)(nil), "chaosdns.SetDNSChaosRequest")
+	proto.RegisterType((*CancelDNSChaosRequest)(nil), "chaosdns.CancelDNSChaosRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ChaosDNSClient is the client API for ChaosDNS service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaosDNSClient interface {
+	SetDNSChaos(ctx context.Context, in *SetDNSChaosRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	CancelDNSChaos(ctx context.Context, in *CancelDNSChaosRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type chaosDNSClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaosDNSClient(cc *grpc.ClientConn) ChaosDNSClient {
+	return &chaosDNSClient{cc}
+}
+
+func (c *chaosDNSClient) SetDNSChaos(ctx context.Context, in *SetDNSChaosRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/chaosdns.ChaosDNS/SetDNSChaos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDNSClient) CancelDNSChaos(ctx context.Context, in *CancelDNSChaosRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/chaosdns.ChaosDNS/CancelDNSChaos", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChaosDNSServer is the server API for ChaosDNS service.
+type ChaosDNSServer interface {
+	SetDNSChaos(context.Context, *SetDNSChaosRequest) (*empty.Empty, error)
+	CancelDNSChaos(context.Context, *CancelDNSChaosRequest) (*empty.Empty, error)
+}
+
+func RegisterChaosDNSServer(s *grpc.Server, srv ChaosDNSServer) {
+	s.RegisterService(&_ChaosDNS_serviceDesc, srv)
+}
+
+func _ChaosDNS_SetDNSChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDNSChaosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDNSServer).SetDNSChaos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chaosdns.ChaosDNS/SetDNSChaos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDNSServer).SetDNSChaos(ctx, req.(*SetDNSChaosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDNS_CancelDNSChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelDNSChaosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDNSServer).CancelDNSChaos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chaosdns.ChaosDNS/CancelDNSChaos",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDNSServer).CancelDNSChaos(ctx, req.(*CancelDNSChaosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ChaosDNS_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "chaosdns.ChaosDNS",
+	HandlerType: (*ChaosDNSServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetDNSChaos",
+			Handler:    _ChaosDNS_SetDNSChaos_Handler,
+		},
+		{
+			MethodName: "CancelDNSChaos",
+			Handler:    _ChaosDNS_CancelDNSChaos_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chaosdns.proto",
+}
+
+func init() { proto.RegisterFile("chaosdns.proto", fileDescriptor_chaosdns_bc4c210f3f1a5e73) }
+
+var fileDescriptor_chaosdns_bc4c210f3f1a5e73 = []byte{
+	// 265 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xd1, 0x4a, 0xf3, 0x40,
+	0x10, 0x85, 0xff, 0xfc, 0x6d, 0x63, 0x32, 0x42, 0x2f, 0x06, 0xad, 0x4b, 0x54, 0x2c, 0xb9, 0x2a,
+	0x08, 0x29, 0xe8, 0x23, 0xa4, 0xbd, 0x10, 0xa1, 0x17, 0xc9, 0x03, 0x94, 0x74, 0x77, 0xac, 0x85,
+	0x76, 0x67, 0xdd, 0xdd, 0x5e, 0xf8, 0x32, 0x3e, 0x80, 0x4f, 0x29, 0xdd, 0xd8, 0xaa, 0xa8, 0xe0,
+	0xdd, 0x9c, 0x6f, 0x0e, 0xc3, 0x99, 0x03, 0x7d, 0xf9, 0xd8, 0xb0, 0x53, 0xda, 0x15, 0xc6, 0xb2,
+	0x67, 0x4c, 0xf6, 0x3a, 0x3b, 0x5f, 0x32, 0x2f, 0xd7, 0x34, 0x0e, 0x7c, 0xb1, 0x7d, 0x18, 0xd3,
+	0xc6, 0xf8, 0xe7, 0xd6, 0x96, 0xbf, 0x46, 0x80, 0x35, 0xf9, 0xc9, 0xac, 0x2e, 0x77, 0xfe, 0x8a,
+	0x9e, 0xb6, 0xe4, 0x3c, 0x22, 0x74, 0x75, 0xb3, 0x21, 0x11, 0x0d, 0xa3, 0x51, 0x5a, 0x85, 0x19,
+	0x07, 0x10, 0x37, 0xd2, 0xaf, 0x58, 0x8b, 0xff, 0x81, 0xbe, 0x2b, 0x3c, 0x81, 0x9e, 0x93, 0x6c,
+	0x48, 0x74, 0x02, 0x6e, 0x05, 0x5e, 0x02, 0x90, 0xb5, 0x6c, 0xe7, 0x92, 0x15, 0x89, 0x6e, 0x58,
+	0xa5, 0x81, 0x94, 0xac, 0x08, 0x33, 0x48, 0x4c, 0xe3, 0x3d, 0x59, 0xed, 0x44, 0x6f, 0xd8, 0x19,
+	0xa5, 0xd5, 0x41, 0xe3, 0x19, 0x1c, 0x19, 0x56, 0xf3, 0x95, 0x71, 0x22, 0x0e, 0xab, 0xd8, 0xb0,
+	0xba, 0x33, 0x2e, 0xbf, 0x86, 0xd3, 0xb2, 0xd1, 0x92, 0xd6, 0x7f, 0x88, 0x7b, 0xf3, 0x12, 0x41,
+	0x12, 0x4c, 0x93, 0x59, 0x8d, 0x53, 0x38, 0xfe, 0xf4, 0x25, 0x5e, 0x14, 0x87, 0xb6, 0xbe, 0x3f,
+	0x9f, 0x0d, 0x8a, 0xb6, 0xb1, 0x62, 0xdf, 0x58, 0x31, 0xdd, 0x35, 0x96, 0xff, 0xc3, 0x7b, 0xe8,
+	0x7f, 0x0d, 0x80, 0x57, 0x1f, 0x97, 0x7e, 0x8c, 0xf6, 0xfb, 0xb1, 0x45, 0x1c, 0xc8, 0xed, 0xdb,
+	0x00, 0x4a, 0x2d, 0x55, 0xb1, 0xba, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdns
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+)
+
+// NewSetDNSChaosRequest builds the request which sets the rule of chaos on the pods
+func NewSetDNSChaosRequest(chaos *v1alpha1.DNSChaos, podIPs []string) (*pb.SetDNSChaosRequest, error) {
+	name, err := cache.MetaNamespaceKeyFunc(chaos)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetDNSChaosRequest{
+		Name:      name,
+		Action:    string(chaos.Spec.Action),
+		Scope:     string(chaos.Spec.Scope),
+		ErrorCode: string(chaos.Spec.GetErrorCode()),
+		Patterns:  chaos.Spec.Patterns,
+		PodIps:    podIPs,
+	}, nil
+}
+
+// Resync restores the rules of the running chaos from their records of pods. The rules are only
+// kept in memory, so it should be called before serving the grpc requests after the server starts.
+func (s *Server) Resync(ctx context.Context, c client.Reader, opts ...client.ListOption) error {
+	var chaosList v1alpha1.DNSChaosList
+	if err := c.List(ctx, &chaosList, opts...); err != nil {
+		return err
+	}
+
+	for index := range chaosList.Items {
+		chaos := &chaosList.Items[index]
+		if chaos.Status.Experiment.Phase != v1alpha1.ExperimentPhaseRunning {
+			continue
+		}
+
+		podIPs := make([]string, 0, len(chaos.Status.Experiment.PodRecords))
+		for _, record := range chaos.Status.Experiment.PodRecords {
+			if len(record.PodIP) > 0 {
+				podIPs = append(podIPs, record.PodIP)
+			}
+		}
+
+		req, err := NewSetDNSChaosRequest(chaos, podIPs)
+		if err != nil {
+			return err
+		}
+		if _, err := s.SetDNSChaos(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
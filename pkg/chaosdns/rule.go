@@ -0,0 +1,106 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdns
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/miekg/dns"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+)
+
+// rule is the compiled SetDNSChaosRequest
+type rule struct {
+	name     string
+	action   v1alpha1.DNSChaosAction
+	scope    v1alpha1.DNSChaosScope
+	rcode    int
+	patterns []*regexp.Regexp
+}
+
+func newRule(req *pb.SetDNSChaosRequest) (*rule, error) {
+	r := &rule{
+		name:   req.Name,
+		action: v1alpha1.DNSChaosAction(req.Action),
+		scope:  v1alpha1.DNSChaosScope(req.Scope),
+	}
+
+	switch r.action {
+	case v1alpha1.ErrorAction, v1alpha1.RandomAction:
+	default:
+		return nil, fmt.Errorf("unknown dns chaos action %s", req.Action)
+	}
+
+	switch r.scope {
+	case v1alpha1.OuterScope, v1alpha1.InnerScope, v1alpha1.AllScope:
+	case "":
+		r.scope = v1alpha1.OuterScope
+	default:
+		return nil, fmt.Errorf("unknown dns chaos scope %s", req.Scope)
+	}
+
+	switch v1alpha1.DNSErrorCode(req.ErrorCode) {
+	case v1alpha1.NXDomainErrorCode:
+		r.rcode = dns.RcodeNameError
+	case v1alpha1.ServFailErrorCode, "":
+		r.rcode = dns.RcodeServerFailure
+	default:
+		return nil, fmt.Errorf("unknown dns error code %s", req.ErrorCode)
+	}
+
+	for _, pattern := range req.Patterns {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// compilePattern compiles the domain pattern in which `*` matches any characters
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSuffix(strings.ToLower(pattern), ".")
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("empty domain pattern")
+	}
+
+	expr := strings.Replace(regexp.QuoteMeta(pattern), `\*`, ".*", -1)
+	return regexp.Compile("^" + expr + "$")
+}
+
+// match returns whether the rule works on the domain, which is a fully qualified name
+func (r *rule) match(domain string, clusterDomain string) bool {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+
+	inner := domain == clusterDomain || strings.HasSuffix(domain, "."+clusterDomain)
+	if (r.scope == v1alpha1.InnerScope && !inner) || (r.scope == v1alpha1.OuterScope && inner) {
+		return false
+	}
+
+	if len(r.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(domain) {
+			return true
+		}
+	}
+	return false
+}
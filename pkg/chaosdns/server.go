@@ -0,0 +1,225 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdns
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/miekg/dns"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
+)
+
+var log = ctrl.Log.WithName("chaos-dns-server")
+
+//go:generate protoc -I pb pb/chaosdns.proto --go_out=plugins=grpc:pb
+
+// DefaultForwardTimeout is the timeout of the requests forwarded to the upstream server
+const DefaultForwardTimeout = 2 * time.Second
+
+// Server is a DNS server which injects faults into the requests from the pods under chaos,
+// and forwards the other requests to the upstream server
+type Server struct {
+	upstream      string
+	clusterDomain string
+
+	mu sync.RWMutex
+	// rules maps the name of chaos to its rule
+	rules map[string]*rule
+	// pods maps the IP of pod to the name of chaos injected into it
+	pods map[string]string
+}
+
+// NewServer creates a DNS server which forwards the requests to the upstream address, e.g. 10.96.0.10:53
+func NewServer(upstream string, clusterDomain string) *Server {
+	return &Server{
+		upstream:      upstream,
+		clusterDomain: strings.TrimSuffix(strings.ToLower(clusterDomain), "."),
+		rules:         make(map[string]*rule),
+		pods:          make(map[string]string),
+	}
+}
+
+// SetDNSChaos sets the rule of chaos on the pods, the pods which are not in the
+// request any more are recovered
+func (s *Server) SetDNSChaos(ctx context.Context, req *pb.SetDNSChaosRequest) (*empty.Empty, error) {
+	log.Info("set dns chaos", "request", req)
+
+	r, err := newRule(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeRule(req.Name)
+	s.rules[req.Name] = r
+	for _, ip := range req.PodIps {
+		s.pods[ip] = req.Name
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// CancelDNSChaos recovers all the pods under the chaos
+func (s *Server) CancelDNSChaos(ctx context.Context, req *pb.CancelDNSChaosRequest) (*empty.Empty, error) {
+	log.Info("cancel dns chaos", "request", req)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeRule(req.Name)
+
+	return &empty.Empty{}, nil
+}
+
+// removeRule should be called with the lock held
+func (s *Server) removeRule(name string) {
+	delete(s.rules, name)
+	for ip, chaos := range s.pods {
+		if chaos == name {
+			delete(s.pods, ip)
+		}
+	}
+}
+
+func (s *Server) getRule(addr net.Addr) *rule {
+	var ip net.IP
+	switch addr := addr.(type) {
+	case *net.UDPAddr:
+		ip = addr.IP
+	case *net.TCPAddr:
+		ip = addr.IP
+	default:
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name, ok := s.pods[ip.String()]
+	if !ok {
+		return nil
+	}
+	return s.rules[name]
+}
+
+// ServeDNS implements dns.Handler
+func (s *Server) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	r := s.getRule(w.RemoteAddr())
+	if r != nil && len(req.Question) > 0 && r.match(req.Question[0].Name, s.clusterDomain) {
+		log.V(1).Info("inject dns chaos", "chaos", r.name, "client", w.RemoteAddr().String(), "question", req.Question[0].Name)
+		s.writeMsg(w, r.response(req))
+		return
+	}
+
+	s.writeMsg(w, s.forward(w, req))
+}
+
+func (s *Server) forward(w dns.ResponseWriter, req *dns.Msg) *dns.Msg {
+	client := &dns.Client{
+		Net:     w.LocalAddr().Network(),
+		Timeout: DefaultForwardTimeout,
+	}
+
+	resp, _, err := client.Exchange(req, s.upstream)
+	if err != nil {
+		log.Error(err, "fail to forward request", "upstream", s.upstream)
+		resp = new(dns.Msg)
+		resp.SetRcode(req, dns.RcodeServerFailure)
+	}
+	return resp
+}
+
+func (s *Server) writeMsg(w dns.ResponseWriter, msg *dns.Msg) {
+	if err := w.WriteMsg(msg); err != nil {
+		log.Error(err, "fail to write response", "client", w.RemoteAddr().String())
+	}
+}
+
+// response builds the response of the request under chaos
+func (r *rule) response(req *dns.Msg) *dns.Msg {
+	resp := new(dns.Msg)
+	if r.action == v1alpha1.ErrorAction {
+		resp.SetRcode(req, r.rcode)
+		return resp
+	}
+
+	resp.SetReply(req)
+	for _, question := range req.Question {
+		header := dns.RR_Header{
+			Name:   question.Name,
+			Rrtype: question.Qtype,
+			Class:  dns.ClassINET,
+			Ttl:    0,
+		}
+
+		switch question.Qtype {
+		case dns.TypeA:
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: header, A: randomIP(net.IPv4len)})
+		case dns.TypeAAAA:
+			resp.Answer = append(resp.Answer, &dns.AAAA{Hdr: header, AAAA: randomIP(net.IPv6len)})
+		}
+	}
+	return resp
+}
+
+func randomIP(length int) net.IP {
+	ip := make(net.IP, length)
+	for i := range ip {
+		ip[i] = byte(rand.Intn(256))
+	}
+	return ip
+}
+
+// ListenAndServe serves DNS requests on the address with both udp and tcp,
+// and serves the grpc requests on the grpc address
+func (s *Server) ListenAndServe(addr string, grpcAddr string) error {
+	errCh := make(chan error, 3)
+
+	for _, network := range []string{"udp", "tcp"} {
+		server := &dns.Server{Addr: addr, Net: network, Handler: s}
+		go func() {
+			log.Info("Starting dns server", "address", server.Addr, "network", server.Net)
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(utils.TimeoutServerInterceptor))
+	pb.RegisterChaosDNSServer(grpcServer, s)
+	reflection.Register(grpcServer)
+	go func() {
+		log.Info("Starting grpc server", "address", grpcAddr)
+		errCh <- grpcServer.Serve(lis)
+	}()
+
+	return <-errCh
+}
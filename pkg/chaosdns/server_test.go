@@ -0,0 +1,244 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdns
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/miekg/dns"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdns/pb"
+)
+
+const upstreamIP = "10.0.0.1"
+
+func startDNSServer(g *GomegaWithT, handler dns.Handler) (string, func()) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: conn, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go server.ActivateAndServe()
+	<-started
+
+	return conn.LocalAddr().String(), func() {
+		server.Shutdown()
+	}
+}
+
+func newTestServer(g *GomegaWithT) (*Server, string, func()) {
+	upstream, stopUpstream := startDNSServer(g, dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		resp.Answer = append(resp.Answer, &dns.A{
+			Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET},
+			A:   net.ParseIP(upstreamIP),
+		})
+		w.WriteMsg(resp)
+	}))
+
+	s := NewServer(upstream, "cluster.local")
+	addr, stop := startDNSServer(g, s)
+
+	return s, addr, func() {
+		stop()
+		stopUpstream()
+	}
+}
+
+func query(g *GomegaWithT, addr string, name string) *dns.Msg {
+	req := new(dns.Msg)
+	req.SetQuestion(dns.Fqdn(name), dns.TypeA)
+
+	resp, _, err := new(dns.Client).Exchange(req, addr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	return resp
+}
+
+func answer(resp *dns.Msg) string {
+	if len(resp.Answer) == 0 {
+		return ""
+	}
+	return resp.Answer[0].(*dns.A).A.String()
+}
+
+func TestForward(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, addr, cleanup := newTestServer(g)
+	defer cleanup()
+
+	resp := query(g, addr, "google.com")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeSuccess))
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+}
+
+func TestErrorAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, addr, cleanup := newTestServer(g)
+	defer cleanup()
+
+	_, err := s.SetDNSChaos(context.TODO(), &pb.SetDNSChaosRequest{
+		Name:      "default/chaos",
+		Action:    "error",
+		Scope:     "all",
+		ErrorCode: "NXDOMAIN",
+		Patterns:  []string{"*.chaos-mesh.org"},
+		PodIps:    []string{"127.0.0.1"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resp := query(g, addr, "www.chaos-mesh.org")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeNameError))
+
+	resp = query(g, addr, "chaos-mesh.org")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeSuccess))
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+
+	_, err = s.CancelDNSChaos(context.TODO(), &pb.CancelDNSChaosRequest{Name: "default/chaos"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resp = query(g, addr, "www.chaos-mesh.org")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeSuccess))
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+}
+
+func TestRandomAction(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, addr, cleanup := newTestServer(g)
+	defer cleanup()
+
+	_, err := s.SetDNSChaos(context.TODO(), &pb.SetDNSChaosRequest{
+		Name:   "default/chaos",
+		Action: "random",
+		Scope:  "outer",
+		PodIps: []string{"127.0.0.1"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resp := query(g, addr, "google.com")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeSuccess))
+	g.Expect(resp.Answer).To(HaveLen(1))
+	g.Expect(answer(resp)).NotTo(Equal(upstreamIP))
+
+	// the inner domains are out of the scope
+	resp = query(g, addr, "web.default.svc.cluster.local")
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+}
+
+func TestOtherPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, addr, cleanup := newTestServer(g)
+	defer cleanup()
+
+	_, err := s.SetDNSChaos(context.TODO(), &pb.SetDNSChaosRequest{
+		Name:   "default/chaos",
+		Action: "error",
+		Scope:  "all",
+		PodIps: []string{"10.1.1.1"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	resp := query(g, addr, "google.com")
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+}
+
+func TestResync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	s, addr, cleanup := newTestServer(g)
+	defer cleanup()
+
+	newClient := func(phase v1alpha1.ExperimentPhase) client.Reader {
+		chaos := &v1alpha1.DNSChaos{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "chaos"},
+			Spec: v1alpha1.DNSChaosSpec{
+				Action:    v1alpha1.ErrorAction,
+				Scope:     v1alpha1.AllScope,
+				ErrorCode: v1alpha1.NXDomainErrorCode,
+			},
+		}
+		chaos.Status.Experiment.Phase = phase
+		chaos.Status.Experiment.PodRecords = []v1alpha1.PodStatus{
+			{Namespace: metav1.NamespaceDefault, Name: "pod", PodIP: "127.0.0.1"},
+		}
+
+		scheme := runtime.NewScheme()
+		g.Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		return fake.NewFakeClientWithScheme(scheme, chaos)
+	}
+
+	// the finished chaos is ignored
+	g.Expect(s.Resync(context.TODO(), newClient(v1alpha1.ExperimentPhaseFinished))).To(Succeed())
+	resp := query(g, addr, "google.com")
+	g.Expect(answer(resp)).To(Equal(upstreamIP))
+
+	g.Expect(s.Resync(context.TODO(), newClient(v1alpha1.ExperimentPhaseRunning))).To(Succeed())
+	resp = query(g, addr, "google.com")
+	g.Expect(resp.Rcode).To(Equal(dns.RcodeNameError))
+}
+
+func TestRuleMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type TestCase struct {
+		name    string
+		scope   string
+		pattern []string
+		domain  string
+		expect  bool
+	}
+	tcs := []TestCase{
+		{name: "all domains", scope: "all", domain: "google.com.", expect: true},
+		{name: "outer scope", scope: "outer", domain: "google.com.", expect: true},
+		{name: "outer scope with inner domain", scope: "outer", domain: "web.default.svc.cluster.local.", expect: false},
+		{name: "inner scope", scope: "inner", domain: "web.default.svc.cluster.local.", expect: true},
+		{name: "inner scope with outer domain", scope: "inner", domain: "google.com.", expect: false},
+		{name: "exact pattern", scope: "all", pattern: []string{"google.com"}, domain: "Google.com.", expect: true},
+		{name: "exact pattern mismatched", scope: "all", pattern: []string{"google.com"}, domain: "www.google.com.", expect: false},
+		{name: "wildcard pattern", scope: "all", pattern: []string{"*.google.com"}, domain: "mail.www.google.com.", expect: true},
+		{name: "wildcard in the middle", scope: "inner", pattern: []string{"web-*.default.svc.cluster.local"}, domain: "web-1.default.svc.cluster.local.", expect: true},
+		{name: "multiple patterns", scope: "all", pattern: []string{"google.com", "*.org"}, domain: "chaos-mesh.org.", expect: true},
+	}
+
+	for _, tc := range tcs {
+		r, err := newRule(&pb.SetDNSChaosRequest{Action: "error", Scope: tc.scope, Patterns: tc.pattern})
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(r.match(tc.domain, "cluster.local")).To(Equal(tc.expect), tc.name)
+	}
+}
+
+func TestInvalidRule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := newRule(&pb.SetDNSChaosRequest{Action: "unknown"})
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = newRule(&pb.SetDNSChaosRequest{Action: "error", ErrorCode: "REFUSED"})
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = newRule(&pb.SetDNSChaosRequest{Action: "error", Patterns: []string{""}})
+	g.Expect(err).Should(HaveOccurred())
+}
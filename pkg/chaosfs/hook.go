@@ -21,6 +21,8 @@ import (
 )
 
 type InjuredHookContext struct {
+	// mistake corrupts the data read from the file if it's not nil
+	mistake *mistake
 }
 
 type InjuredHook struct {
@@ -47,23 +49,37 @@ func (h *InjuredHook) PostOpen(int32, hookfs.HookContext) (bool, error) {
 
 func (h *InjuredHook) PreRead(path string, length int64, offset int64) ([]byte, bool, hookfs.HookContext, error) {
 	ctx := &InjuredHookContext{}
-	err := faultInject(path, "read")
+	fc, err := inject(path, "read")
 	if err != nil {
 		return nil, true, ctx, err
 	}
+	if fc != nil {
+		ctx.mistake = fc.mistake
+	}
 	return nil, false, ctx, nil
 }
 
+// PostRead corrupts the data read from the file with the mistake of the rule hit in PreRead
 func (h *InjuredHook) PostRead(realRetCode int32, realBuf []byte, prehookCtx hookfs.HookContext) ([]byte, bool, error) {
-	return nil, false, nil
+	ctx, ok := prehookCtx.(*InjuredHookContext)
+	if !ok || ctx.mistake == nil || realRetCode != int32(fuse.OK) {
+		return nil, false, nil
+	}
+
+	ctx.mistake.corrupt(realBuf)
+	return realBuf, true, nil
 }
 
+// PreWrite corrupts the data in place before it's written into the file, if the rule hit has a mistake
 func (h *InjuredHook) PreWrite(path string, buf []byte, offset int64) (bool, hookfs.HookContext, error) {
 	ctx := &InjuredHookContext{}
-	err := faultInject(path, "write")
+	fc, err := inject(path, "write")
 	if err != nil {
 		return true, ctx, err
 	}
+	if fc != nil && fc.mistake != nil {
+		fc.mistake.corrupt(buf)
+	}
 	return false, ctx, nil
 }
 
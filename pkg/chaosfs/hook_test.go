@@ -15,6 +15,7 @@ package chaosfs
 
 import (
 	"errors"
+	"regexp"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -25,10 +26,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("open", &faultContext{
+			faultMap.Store("open", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			_, _, err := h.PreOpen("", 0)
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(Equal(err))
@@ -55,16 +56,37 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("read", &faultContext{
+			faultMap.Store("read", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			_, f, _, err := h.PreRead("", 0, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
 			Expect(err).To(Equal(err))
 		})
 
+		It("should inject the rule matching the path", func() {
+			e := errors.New("mock error")
+			h := &InjuredHook{}
+			faultMap.Store("read", []*faultContext{
+				{
+					errno: e,
+					pct:   100,
+					path:  regexp.MustCompile(`^data/`),
+				},
+				{
+					pct: 0,
+				},
+			})
+			_, f, _, err := h.PreRead("data/wal", 0, 0)
+			Expect(f).To(Equal(true))
+			Expect(err).To(Equal(e))
+			_, f, _, err = h.PreRead("log/wal", 0, 0)
+			Expect(f).To(Equal(false))
+			Expect(err).To(BeNil())
+		})
+
 		It("should skip", func() {
 			h := &InjuredHook{}
 			faultMap.Delete("read")
@@ -87,10 +109,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("write", &faultContext{
+			faultMap.Store("write", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreWrite("", nil, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -119,10 +141,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("mkdir", &faultContext{
+			faultMap.Store("mkdir", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreMkdir("", 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -151,10 +173,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("rmdir", &faultContext{
+			faultMap.Store("rmdir", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreRmdir("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -183,10 +205,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("opendir", &faultContext{
+			faultMap.Store("opendir", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreOpenDir("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -215,10 +237,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("fsync", &faultContext{
+			faultMap.Store("fsync", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreFsync("", 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -247,10 +269,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("flush", &faultContext{
+			faultMap.Store("flush", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreFlush("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -279,10 +301,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("release", &faultContext{
+			faultMap.Store("release", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _ := h.PreRelease("")
 			Expect(f).To(Equal(false))
 		})
@@ -308,10 +330,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("truncate", &faultContext{
+			faultMap.Store("truncate", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreTruncate("", 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -340,10 +362,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("getattr", &faultContext{
+			faultMap.Store("getattr", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreGetAttr("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -372,10 +394,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("chown", &faultContext{
+			faultMap.Store("chown", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreChown("", 0, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -404,10 +426,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("chmod", &faultContext{
+			faultMap.Store("chmod", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreChmod("", 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -436,10 +458,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("utimens", &faultContext{
+			faultMap.Store("utimens", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreUtimens("", nil, nil)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -468,10 +490,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("allocate", &faultContext{
+			faultMap.Store("allocate", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreAllocate("", 0, 0, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -500,10 +522,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("getlk", &faultContext{
+			faultMap.Store("getlk", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreGetLk("", 0, nil, 0, nil)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -532,10 +554,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("setlk", &faultContext{
+			faultMap.Store("setlk", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreSetLk("", 0, nil, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -564,10 +586,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("setlkw", &faultContext{
+			faultMap.Store("setlkw", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreSetLkw("", 0, nil, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -596,10 +618,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("statfs", &faultContext{
+			faultMap.Store("statfs", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreStatFs("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -628,10 +650,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("readlink", &faultContext{
+			faultMap.Store("readlink", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreReadlink("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -660,10 +682,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("symlink", &faultContext{
+			faultMap.Store("symlink", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreSymlink("", "")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -692,10 +714,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("create", &faultContext{
+			faultMap.Store("create", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreCreate("", 0, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -724,10 +746,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("access", &faultContext{
+			faultMap.Store("access", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreAccess("", 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -756,10 +778,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("link", &faultContext{
+			faultMap.Store("link", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreLink("", "")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -788,10 +810,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("mknod", &faultContext{
+			faultMap.Store("mknod", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreMknod("", 0, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -820,10 +842,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("rename", &faultContext{
+			faultMap.Store("rename", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreRename("", "")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -852,10 +874,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("unlink", &faultContext{
+			faultMap.Store("unlink", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreUnlink("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -884,10 +906,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("getxattr", &faultContext{
+			faultMap.Store("getxattr", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreGetXAttr("", "")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -916,10 +938,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("listxattr", &faultContext{
+			faultMap.Store("listxattr", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreListXAttr("")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -948,10 +970,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("removexattr", &faultContext{
+			faultMap.Store("removexattr", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreRemoveXAttr("", "")
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
@@ -980,10 +1002,10 @@ var _ = Describe("hook", func() {
 		It("should work", func() {
 			e := errors.New("mock error")
 			h := &InjuredHook{}
-			faultMap.Store("setxattr", &faultContext{
+			faultMap.Store("setxattr", []*faultContext{{
 				errno: e,
 				pct:   100,
-			})
+			}})
 			f, _, err := h.PreSetXAttr("", "", nil, 0)
 			Expect(f).To(Equal(true))
 			Expect(err).ToNot(BeNil())
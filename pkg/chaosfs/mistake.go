@@ -0,0 +1,89 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosfs
+
+import (
+	"fmt"
+	"math/rand"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosfs/pb"
+)
+
+const (
+	zeroFilling   = "zero"
+	randomFilling = "random"
+	flipFilling   = "flip"
+)
+
+// mistake replaces the data in the buffers of read and write with wrong data
+type mistake struct {
+	filling        string
+	maxOccurrences int
+	maxLength      int
+}
+
+func newMistake(in *pb.Mistake) (*mistake, error) {
+	switch in.Filling {
+	case zeroFilling, randomFilling, flipFilling:
+	default:
+		return nil, fmt.Errorf("unsupported filling %s", in.Filling)
+	}
+
+	if in.MaxOccurrences < 1 || in.MaxLength < 1 {
+		return nil, fmt.Errorf("max occurrences and max length of mistake should be at least 1")
+	}
+
+	return &mistake{
+		filling:        in.Filling,
+		maxOccurrences: int(in.MaxOccurrences),
+		maxLength:      int(in.MaxLength),
+	}, nil
+}
+
+// corrupt fills [1, maxOccurrences] segments of the buffer in place, each of which has [1, maxLength] bytes.
+// The segments may overlap, and every byte is filled at most once, so that the flipped bytes aren't flipped back
+func (m *mistake) corrupt(buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
+
+	corrupted := make([]bool, len(buf))
+	occurrences := rand.Intn(m.maxOccurrences) + 1
+	for i := 0; i < occurrences; i++ {
+		length := rand.Intn(m.maxLength) + 1
+		if length > len(buf) {
+			length = len(buf)
+		}
+
+		start := rand.Intn(len(buf) - length + 1)
+		for j := start; j < start+length; j++ {
+			corrupted[j] = true
+		}
+	}
+
+	for i := range buf {
+		if !corrupted[i] {
+			continue
+		}
+
+		switch m.filling {
+		case zeroFilling:
+			buf[i] = 0
+		case randomFilling:
+			buf[i] = byte(rand.Intn(256))
+		case flipFilling:
+			buf[i] = ^buf[i]
+		}
+	}
+}
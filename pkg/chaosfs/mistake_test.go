@@ -0,0 +1,197 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosfs
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethercflow/hookfs/hookfs"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hanwen/go-fuse/fuse"
+	"github.com/hanwen/go-fuse/fuse/nodefs"
+	"github.com/hanwen/go-fuse/fuse/pathfs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosfs/pb"
+)
+
+// corruptedBytes returns the indexes of the bytes which are different in the two buffers
+func corruptedBytes(origin []byte, buf []byte) []int {
+	indexes := []int{}
+	for i := range origin {
+		if origin[i] != buf[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+var _ = Describe("mistake", func() {
+	Context("newMistake", func() {
+		It("should validate the mistake", func() {
+			m, err := newMistake(&pb.Mistake{Filling: "flip", MaxOccurrences: 2, MaxLength: 16})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m).To(Equal(&mistake{filling: flipFilling, maxOccurrences: 2, maxLength: 16}))
+
+			_, err = newMistake(&pb.Mistake{Filling: "one", MaxOccurrences: 1, MaxLength: 1})
+			Expect(err).To(HaveOccurred())
+
+			_, err = newMistake(&pb.Mistake{Filling: "zero", MaxOccurrences: 0, MaxLength: 1})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("corrupt", func() {
+		It("should flip the bits of the segments", func() {
+			origin := bytes.Repeat([]byte{0x5a}, 1024)
+			for i := 0; i < 100; i++ {
+				buf := append([]byte{}, origin...)
+				(&mistake{filling: flipFilling, maxOccurrences: 3, maxLength: 8}).corrupt(buf)
+
+				indexes := corruptedBytes(origin, buf)
+				Expect(len(indexes)).To(BeNumerically(">=", 1))
+				Expect(len(indexes)).To(BeNumerically("<=", 24))
+				for _, index := range indexes {
+					Expect(buf[index]).To(Equal(byte(0xa5)))
+				}
+			}
+		})
+
+		It("should fill the segments with zero", func() {
+			origin := bytes.Repeat([]byte{0xff}, 1024)
+			buf := append([]byte{}, origin...)
+			(&mistake{filling: zeroFilling, maxOccurrences: 1, maxLength: 16}).corrupt(buf)
+
+			indexes := corruptedBytes(origin, buf)
+			Expect(len(indexes)).To(BeNumerically(">=", 1))
+			Expect(len(indexes)).To(BeNumerically("<=", 16))
+			// the zero bytes are continuous in one segment
+			Expect(indexes[len(indexes)-1] - indexes[0]).To(Equal(len(indexes) - 1))
+		})
+
+		It("should corrupt the short buffer", func() {
+			buf := []byte{0x0f}
+			(&mistake{filling: flipFilling, maxOccurrences: 4, maxLength: 16}).corrupt(buf)
+			Expect(buf).To(Equal([]byte{0xf0}))
+
+			(&mistake{filling: randomFilling, maxOccurrences: 4, maxLength: 16}).corrupt([]byte{})
+		})
+	})
+
+	Context("on the FUSE mount", func() {
+		var original, mountpoint string
+		var fuseServer *fuse.Server
+
+		BeforeEach(func() {
+			// the faults set by other tests would be injected into the operations on the mount
+			_, err := (&server{}).RecoverAll(context.TODO(), &empty.Empty{})
+			Expect(err).ToNot(HaveOccurred())
+
+			original, err = ioutil.TempDir("", "chaosfs-original")
+			Expect(err).ToNot(HaveOccurred())
+			mountpoint, err = ioutil.TempDir("", "chaosfs-mountpoint")
+			Expect(err).ToNot(HaveOccurred())
+
+			fs, err := hookfs.NewHookFs(original, mountpoint, &InjuredHook{})
+			Expect(err).ToNot(HaveOccurred())
+			conn := nodefs.NewFileSystemConnector(pathfs.NewPathNodeFs(fs, nil).Root(), nodefs.NewOptions())
+			fuseServer, err = fuse.NewServer(conn.RawFS(), mountpoint, &fuse.MountOptions{Name: "chaosfs"})
+			if err != nil {
+				os.RemoveAll(original)
+				os.RemoveAll(mountpoint)
+				Skip("can't mount the FUSE filesystem: " + err.Error())
+			}
+
+			go fuseServer.Serve()
+			Expect(fuseServer.WaitMount()).To(Succeed())
+		})
+
+		AfterEach(func() {
+			_, err := (&server{}).RecoverAll(context.TODO(), &empty.Empty{})
+			Expect(err).ToNot(HaveOccurred())
+			if fuseServer != nil {
+				Expect(fuseServer.Unmount()).To(Succeed())
+				fuseServer = nil
+			}
+			os.RemoveAll(original)
+			os.RemoveAll(mountpoint)
+		})
+
+		It("should corrupt the data read from the file", func() {
+			origin := bytes.Repeat([]byte{0x5a}, 4096)
+			Expect(ioutil.WriteFile(filepath.Join(original, "data"), origin, 0644)).To(Succeed())
+
+			m := &mistake{filling: flipFilling, maxOccurrences: 2, maxLength: 16}
+			faultMap.Store("read", []*faultContext{{pct: 100, mistake: m}})
+
+			buf, err := ioutil.ReadFile(filepath.Join(mountpoint, "data"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf).To(HaveLen(len(origin)))
+
+			indexes := corruptedBytes(origin, buf)
+			Expect(len(indexes)).To(BeNumerically(">=", 1))
+			Expect(len(indexes)).To(BeNumerically("<=", 32))
+			for _, index := range indexes {
+				Expect(buf[index]).To(Equal(byte(0xa5)))
+			}
+
+			// the file itself isn't corrupted
+			buf, err = ioutil.ReadFile(filepath.Join(original, "data"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf).To(Equal(origin))
+		})
+
+		It("should corrupt the data written into the file", func() {
+			origin := bytes.Repeat([]byte{0x5a}, 4096)
+
+			m := &mistake{filling: zeroFilling, maxOccurrences: 2, maxLength: 16}
+			faultMap.Store("write", []*faultContext{{pct: 100, mistake: m}})
+
+			Expect(ioutil.WriteFile(filepath.Join(mountpoint, "data"), origin, 0644)).To(Succeed())
+
+			buf, err := ioutil.ReadFile(filepath.Join(original, "data"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf).To(HaveLen(len(origin)))
+
+			indexes := corruptedBytes(origin, buf)
+			Expect(len(indexes)).To(BeNumerically(">=", 1))
+			Expect(len(indexes)).To(BeNumerically("<=", 32))
+			for _, index := range indexes {
+				Expect(buf[index]).To(Equal(byte(0)))
+			}
+		})
+
+		It("should not corrupt the files not matching the path", func() {
+			origin := bytes.Repeat([]byte{0x5a}, 4096)
+			Expect(ioutil.WriteFile(filepath.Join(original, "data"), origin, 0644)).To(Succeed())
+
+			f, err := newFaultContext(&pb.Rule{
+				Path:    "^other$",
+				Pct:     100,
+				Mistake: &pb.Mistake{Filling: "flip", MaxOccurrences: 1, MaxLength: 16},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			faultMap.Store("read", []*faultContext{f})
+
+			buf, err := ioutil.ReadFile(filepath.Join(mountpoint, "data"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf).To(Equal(origin))
+		})
+	})
+})
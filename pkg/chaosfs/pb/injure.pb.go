@@ -29,12 +29,22 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type Request struct {
-	Methods              []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
-	Errno                uint32   `protobuf:"varint,2,opt,name=errno,proto3" json:"errno,omitempty"`
-	Random               bool     `protobuf:"varint,3,opt,name=random,proto3" json:"random,omitempty"`
-	Pct                  uint32   `protobuf:"varint,4,opt,name=pct,proto3" json:"pct,omitempty"`
-	Path                 string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
-	Delay                uint32   `protobuf:"varint,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	Methods []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	Errno   uint32   `protobuf:"varint,2,opt,name=errno,proto3" json:"errno,omitempty"`
+	Random  bool     `protobuf:"varint,3,opt,name=random,proto3" json:"random,omitempty"`
+	Pct     uint32   `protobuf:"varint,4,opt,name=pct,proto3" json:"pct,omitempty"`
+	Path    string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	Delay   uint32   `protobuf:"varint,6,opt,name=delay,proto3" json:"delay,omitempty"`
+	// jitter is the variation of delay
+	Jitter uint32 `protobuf:"varint,7,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	// distribution of the delay with jitter, which is uniform or normal
+	Distribution string `protobuf:"bytes,8,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	// rules are matched in order, and the first rule matching the path is injected.
+	// The rule is made of the fields above if it's empty. The rules are merged into
+	// the rules set before, where a rule replaces the one with the same path, and
+	// the others are appended, but the rules of a path are kept before the rules
+	// matching all the files.
+	Rules                []*Rule  `protobuf:"bytes,9,rep,name=rules,proto3" json:"rules,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -44,7 +54,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_injure_1d89429f7ab71d2e, []int{0}
+	return fileDescriptor_injure_35e875c53819c9cc, []int{0}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Request.Unmarshal(m, b)
@@ -106,6 +116,179 @@ func (m *Request) GetDelay() uint32 {
 	return 0
 }
 
+func (m *Request) GetJitter() uint32 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *Request) GetDistribution() string {
+	if m != nil {
+		return m.Distribution
+	}
+	return ""
+}
+
+func (m *Request) GetRules() []*Rule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
+type Rule struct {
+	Path         string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Errno        uint32 `protobuf:"varint,2,opt,name=errno,proto3" json:"errno,omitempty"`
+	Random       bool   `protobuf:"varint,3,opt,name=random,proto3" json:"random,omitempty"`
+	Pct          uint32 `protobuf:"varint,4,opt,name=pct,proto3" json:"pct,omitempty"`
+	Delay        uint32 `protobuf:"varint,5,opt,name=delay,proto3" json:"delay,omitempty"`
+	Jitter       uint32 `protobuf:"varint,6,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	Distribution string `protobuf:"bytes,7,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	// mistake corrupts the data of read and write instead of returning an error
+	Mistake              *Mistake `protobuf:"bytes,8,opt,name=mistake,proto3" json:"mistake,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Rule) Reset()         { *m = Rule{} }
+func (m *Rule) String() string { return proto.CompactTextString(m) }
+func (*Rule) ProtoMessage()    {}
+func (*Rule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_injure_35e875c53819c9cc, []int{1}
+}
+func (m *Rule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Rule.Unmarshal(m, b)
+}
+func (m *Rule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Rule.Marshal(b, m, deterministic)
+}
+func (dst *Rule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rule.Merge(dst, src)
+}
+func (m *Rule) XXX_Size() int {
+	return xxx_messageInfo_Rule.Size(m)
+}
+func (m *Rule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rule proto.InternalMessageInfo
+
+func (m *Rule) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Rule) GetErrno() uint32 {
+	if m != nil {
+		return m.Errno
+	}
+	return 0
+}
+
+func (m *Rule) GetRandom() bool {
+	if m != nil {
+		return m.Random
+	}
+	return false
+}
+
+func (m *Rule) GetPct() uint32 {
+	if m != nil {
+		return m.Pct
+	}
+	return 0
+}
+
+func (m *Rule) GetDelay() uint32 {
+	if m != nil {
+		return m.Delay
+	}
+	return 0
+}
+
+func (m *Rule) GetJitter() uint32 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *Rule) GetDistribution() string {
+	if m != nil {
+		return m.Distribution
+	}
+	return ""
+}
+
+func (m *Rule) GetMistake() *Mistake {
+	if m != nil {
+		return m.Mistake
+	}
+	return nil
+}
+
+type Mistake struct {
+	// filling is how the wrong data is generated, which is zero, random or flip
+	Filling string `protobuf:"bytes,1,opt,name=filling,proto3" json:"filling,omitempty"`
+	// max_occurrences is the max number of the wrong data segments in one buffer
+	MaxOccurrences uint32 `protobuf:"varint,2,opt,name=max_occurrences,json=maxOccurrences,proto3" json:"max_occurrences,omitempty"`
+	// max_length is the max length of each wrong data segment in bytes
+	MaxLength            uint32   `protobuf:"varint,3,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Mistake) Reset()         { *m = Mistake{} }
+func (m *Mistake) String() string { return proto.CompactTextString(m) }
+func (*Mistake) ProtoMessage()    {}
+func (*Mistake) Descriptor() ([]byte, []int) {
+	return fileDescriptor_injure_35e875c53819c9cc, []int{2}
+}
+func (m *Mistake) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Mistake.Unmarshal(m, b)
+}
+func (m *Mistake) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Mistake.Marshal(b, m, deterministic)
+}
+func (dst *Mistake) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Mistake.Merge(dst, src)
+}
+func (m *Mistake) XXX_Size() int {
+	return xxx_messageInfo_Mistake.Size(m)
+}
+func (m *Mistake) XXX_DiscardUnknown() {
+	xxx_messageInfo_Mistake.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Mistake proto.InternalMessageInfo
+
+func (m *Mistake) GetFilling() string {
+	if m != nil {
+		return m.Filling
+	}
+	return ""
+}
+
+func (m *Mistake) GetMaxOccurrences() uint32 {
+	if m != nil {
+		return m.MaxOccurrences
+	}
+	return 0
+}
+
+func (m *Mistake) GetMaxLength() uint32 {
+	if m != nil {
+		return m.MaxLength
+	}
+	return 0
+}
+
 type Response struct {
 	Methods              []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -117,7 +300,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_injure_1d89429f7ab71d2e, []int{1}
+	return fileDescriptor_injure_35e875c53819c9cc, []int{3}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -155,7 +338,7 @@ func (m *InjectedResponse) Reset()         { *m = InjectedResponse{} }
 func (m *InjectedResponse) String() string { return proto.CompactTextString(m) }
 func (*InjectedResponse) ProtoMessage()    {}
 func (*InjectedResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_injure_1d89429f7ab71d2e, []int{2}
+	return fileDescriptor_injure_35e875c53819c9cc, []int{4}
 }
 func (m *InjectedResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InjectedResponse.Unmarshal(m, b)
@@ -184,6 +367,8 @@ func (m *InjectedResponse) GetInjected() bool {
 
 func init() {
 	proto.RegisterType((*Request)(nil), "injure.Request")
+	proto.RegisterType((*Rule)(nil), "injure.Rule")
+	proto.RegisterType((*Mistake)(nil), "injure.Mistake")
 	proto.RegisterType((*Response)(nil), "injure.Response")
 	proto.RegisterType((*InjectedResponse)(nil), "injure.InjectedResponse")
 }
@@ -425,28 +610,38 @@ var _Injure_serviceDesc = grpc.ServiceDesc{
 	Metadata: "injure.proto",
 }
 
-func init() { proto.RegisterFile("injure.proto", fileDescriptor_injure_1d89429f7ab71d2e) }
-
-var fileDescriptor_injure_1d89429f7ab71d2e = []byte{
-	// 319 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0xd7, 0xfd, 0xe9, 0xba, 0x57, 0x87, 0x23, 0xc8, 0x08, 0xf3, 0x52, 0x82, 0x87, 0x9e,
-	0x32, 0x50, 0x06, 0xe2, 0x61, 0xe0, 0x41, 0x61, 0x07, 0x2f, 0xf1, 0x13, 0x74, 0xeb, 0xeb, 0xfe,
-	0xd0, 0x36, 0x35, 0x4d, 0x85, 0x7d, 0x08, 0xbf, 0xa9, 0x1f, 0x42, 0x9a, 0x34, 0x13, 0x84, 0x0a,
-	0xbb, 0xe5, 0x79, 0x79, 0x9e, 0x27, 0x6f, 0x7e, 0x81, 0xcb, 0x7d, 0x7e, 0xa8, 0x14, 0xf2, 0x42,
-	0x49, 0x2d, 0x89, 0x6f, 0xd5, 0xec, 0x66, 0x2b, 0xe5, 0x36, 0xc5, 0xb9, 0x99, 0xae, 0xab, 0xf7,
-	0x39, 0x66, 0x85, 0x3e, 0x5a, 0x13, 0xfb, 0xf2, 0x60, 0x28, 0xf0, 0xa3, 0xc2, 0x52, 0x13, 0x0a,
-	0xc3, 0x0c, 0xf5, 0x4e, 0x26, 0x25, 0xf5, 0xc2, 0x5e, 0x34, 0x12, 0x4e, 0x92, 0x6b, 0x18, 0xa0,
-	0x52, 0xb9, 0xa4, 0xdd, 0xd0, 0x8b, 0xc6, 0xc2, 0x0a, 0x32, 0x05, 0x5f, 0xc5, 0x79, 0x22, 0x33,
-	0xda, 0x0b, 0xbd, 0x28, 0x10, 0x8d, 0x22, 0x13, 0xe8, 0x15, 0x1b, 0x4d, 0xfb, 0xc6, 0x5b, 0x1f,
-	0x09, 0x81, 0x7e, 0x11, 0xeb, 0x1d, 0x1d, 0x84, 0x5e, 0x34, 0x12, 0xe6, 0x5c, 0x77, 0x26, 0x98,
-	0xc6, 0x47, 0xea, 0xdb, 0x4e, 0x23, 0xd8, 0x2d, 0x04, 0x02, 0xcb, 0x42, 0xe6, 0x25, 0xb6, 0xef,
-	0xc3, 0x38, 0x4c, 0x56, 0xf9, 0x01, 0x37, 0x1a, 0x93, 0x93, 0x7b, 0x06, 0xc1, 0xbe, 0x99, 0x51,
-	0xcf, 0xec, 0x73, 0xd2, 0x77, 0xdf, 0x5d, 0xf0, 0x57, 0x86, 0x06, 0x59, 0xc0, 0xf0, 0xb5, 0x79,
-	0xd5, 0x94, 0x5b, 0x32, 0xdc, 0x91, 0xe1, 0xcf, 0x35, 0x99, 0xd9, 0x84, 0x37, 0x1c, 0x5d, 0x37,
-	0xeb, 0x90, 0x25, 0x80, 0xc0, 0x8d, 0xfc, 0x44, 0xf5, 0x94, 0xa6, 0xad, 0xc9, 0x96, 0x39, 0xeb,
-	0x90, 0x47, 0x18, 0x37, 0x79, 0x7b, 0x3b, 0xb9, 0xfa, 0xbd, 0xc4, 0xd0, 0xff, 0x27, 0xbb, 0x80,
-	0xe0, 0x0d, 0xf5, 0x4b, 0x5c, 0xa5, 0xfa, 0x9c, 0xd8, 0x03, 0x5c, 0xb8, 0x58, 0xbd, 0xf3, 0x19,
-	0xc9, 0x25, 0x04, 0x0e, 0x6f, 0xeb, 0x53, 0xa9, 0xab, 0xfb, 0xfb, 0x11, 0xac, 0xb3, 0xf6, 0x8d,
-	0xf7, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff, 0xfe, 0x81, 0x47, 0x82, 0x90, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("injure.proto", fileDescriptor_injure_35e875c53819c9cc) }
+
+var fileDescriptor_injure_35e875c53819c9cc = []byte{
+	// 472 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xe1, 0x6a, 0xdb, 0x30,
+	0x10, 0xc7, 0xe3, 0x26, 0xb1, 0x9d, 0x4b, 0xb2, 0x04, 0x31, 0x8a, 0xc8, 0x18, 0x18, 0x31, 0x98,
+	0xf7, 0xc5, 0x85, 0x8c, 0xc2, 0xd8, 0x87, 0xc2, 0x3e, 0x6c, 0x50, 0x58, 0x19, 0x68, 0x0f, 0x30,
+	0x1c, 0xfb, 0x9a, 0x38, 0x95, 0x2d, 0x4f, 0x96, 0x47, 0xfa, 0xa4, 0x7b, 0x89, 0xed, 0x1d, 0x86,
+	0x65, 0xd9, 0xa5, 0xa3, 0x29, 0x94, 0x7e, 0xf3, 0xff, 0xaf, 0xbb, 0xd3, 0xfd, 0x74, 0x67, 0x98,
+	0x65, 0xc5, 0xbe, 0x56, 0x18, 0x95, 0x4a, 0x6a, 0x49, 0xdc, 0x56, 0xad, 0x5e, 0x6d, 0xa5, 0xdc,
+	0x0a, 0x3c, 0x33, 0xee, 0xa6, 0xbe, 0x3e, 0xc3, 0xbc, 0xd4, 0xb7, 0x6d, 0x10, 0xfb, 0xeb, 0x80,
+	0xc7, 0xf1, 0x67, 0x8d, 0x95, 0x26, 0x14, 0xbc, 0x1c, 0xf5, 0x4e, 0xa6, 0x15, 0x75, 0x82, 0x61,
+	0x38, 0xe1, 0x9d, 0x24, 0x2f, 0x61, 0x8c, 0x4a, 0x15, 0x92, 0x9e, 0x04, 0x4e, 0x38, 0xe7, 0xad,
+	0x20, 0xa7, 0xe0, 0xaa, 0xb8, 0x48, 0x65, 0x4e, 0x87, 0x81, 0x13, 0xfa, 0xdc, 0x2a, 0xb2, 0x84,
+	0x61, 0x99, 0x68, 0x3a, 0x32, 0xb1, 0xcd, 0x27, 0x21, 0x30, 0x2a, 0x63, 0xbd, 0xa3, 0xe3, 0xc0,
+	0x09, 0x27, 0xdc, 0x7c, 0x37, 0x35, 0x53, 0x14, 0xf1, 0x2d, 0x75, 0xdb, 0x9a, 0x46, 0x34, 0x35,
+	0xf7, 0x99, 0xd6, 0xa8, 0xa8, 0x67, 0x6c, 0xab, 0x08, 0x83, 0x59, 0x9a, 0x55, 0x5a, 0x65, 0x9b,
+	0x5a, 0x67, 0xb2, 0xa0, 0xbe, 0xa9, 0x74, 0xcf, 0x23, 0x0c, 0xc6, 0xaa, 0x16, 0x58, 0xd1, 0x49,
+	0x30, 0x0c, 0xa7, 0xeb, 0x59, 0x64, 0x9f, 0x83, 0xd7, 0x02, 0x79, 0x7b, 0xc4, 0x7e, 0x3b, 0x30,
+	0x6a, 0x74, 0xdf, 0x92, 0x73, 0xbf, 0xa5, 0x67, 0x61, 0xf6, 0x48, 0xe3, 0x87, 0x91, 0xdc, 0x47,
+	0x91, 0xbc, 0x07, 0x90, 0xde, 0x81, 0x97, 0x67, 0x95, 0x8e, 0x6f, 0xd0, 0x10, 0x4f, 0xd7, 0x8b,
+	0x0e, 0xea, 0xaa, 0xb5, 0x79, 0x77, 0xce, 0x6e, 0xc0, 0xb3, 0x5e, 0x33, 0xc8, 0xeb, 0x4c, 0x88,
+	0xac, 0xd8, 0x5a, 0xbc, 0x4e, 0x92, 0xb7, 0xb0, 0xc8, 0xe3, 0xc3, 0x0f, 0x99, 0x24, 0xb5, 0x52,
+	0x58, 0x24, 0x58, 0x59, 0xd6, 0x17, 0x79, 0x7c, 0xf8, 0x76, 0xe7, 0x92, 0xd7, 0x00, 0x4d, 0xa0,
+	0xc0, 0x62, 0xab, 0x77, 0x06, 0x7c, 0xce, 0x27, 0x79, 0x7c, 0xf8, 0x6a, 0x0c, 0xf6, 0x06, 0x7c,
+	0x8e, 0x55, 0x29, 0x8b, 0x0a, 0x8f, 0xaf, 0x0d, 0x8b, 0x60, 0x79, 0x59, 0xec, 0x31, 0xd1, 0x98,
+	0xf6, 0xd1, 0x2b, 0xf0, 0x33, 0xeb, 0x99, 0xe6, 0x7c, 0xde, 0xeb, 0xf5, 0x9f, 0x13, 0x70, 0x2f,
+	0x0d, 0x1e, 0x39, 0x07, 0xef, 0xca, 0x2e, 0xdf, 0x69, 0xd4, 0x2e, 0x70, 0xd4, 0x2d, 0x70, 0xf4,
+	0xb9, 0x59, 0xe0, 0xd5, 0xb2, 0x9f, 0xaf, 0xad, 0xcd, 0x06, 0xe4, 0x02, 0x80, 0x63, 0x22, 0x7f,
+	0xa1, 0xfa, 0x24, 0xc4, 0xd1, 0xcc, 0x23, 0x3e, 0x1b, 0x90, 0x8f, 0x30, 0xb7, 0xf9, 0xed, 0xed,
+	0x64, 0x71, 0x77, 0x89, 0xf9, 0x49, 0x1e, 0xc9, 0x3d, 0x07, 0xff, 0x3b, 0xea, 0x2f, 0x71, 0x2d,
+	0xf4, 0x53, 0xd2, 0x3e, 0xc0, 0xb4, 0x4b, 0x6b, 0x7a, 0x7e, 0x42, 0xe6, 0x05, 0xf8, 0xdd, 0xf3,
+	0x1e, 0x45, 0xa5, 0x5d, 0xb9, 0xff, 0x07, 0xc1, 0x06, 0x1b, 0xd7, 0xc4, 0xbe, 0xff, 0x37, 0x00,
+	0x42, 0x26, 0x64, 0xd3, 0x37, 0x04, 0x00, 0x00,
 }
@@ -15,6 +15,7 @@ package chaosfs
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"net"
 	"os"
@@ -39,22 +40,145 @@ var log = ctrl.Log.WithName("fuse-server")
 //go:generate protoc -I pb pb/injure.proto --go_out=plugins=grpc:pb
 
 var (
+	// faultMap maps the method to its ordered rules, which is []*faultContext
 	faultMap sync.Map
+	// faultLock serializes the updates of the rules in faultMap
+	faultLock sync.Mutex
 
 	methods map[string]bool
 )
 
+const (
+	uniformDistribution = "uniform"
+	normalDistribution  = "normal"
+)
+
 func init() {
 	faultMap = sync.Map{}
 	initMethods()
 }
 
+// faultContext is a rule of fault, the rules of a method are matched in order
+// and the first one matching the path is injected
 type faultContext struct {
 	errno  error
 	random bool
 	pct    uint32
-	path   string
-	delay  time.Duration
+	// path is compiled when the rule is set, and it matches all the files if it's nil
+	path         *regexp.Regexp
+	delay        time.Duration
+	jitter       time.Duration
+	distribution string
+	// mistake corrupts the data of read and write if it's not nil
+	mistake *mistake
+}
+
+func newFaultContext(rule *pb.Rule) (*faultContext, error) {
+	// TODO: use Errno(0), and handle Errno(0) in Hook interfaces
+	var errno error = nil
+	if rule.Errno != 0 {
+		errno = syscall.Errno(rule.Errno)
+	}
+	f := &faultContext{
+		errno:        errno,
+		random:       rule.Random,
+		pct:          rule.Pct,
+		delay:        time.Duration(rule.Delay) * time.Microsecond,
+		jitter:       time.Duration(rule.Jitter) * time.Microsecond,
+		distribution: rule.Distribution,
+	}
+
+	switch f.distribution {
+	case "":
+		f.distribution = uniformDistribution
+	case uniformDistribution, normalDistribution:
+	default:
+		return nil, fmt.Errorf("unsupported distribution %s", rule.Distribution)
+	}
+
+	if len(rule.Path) > 0 {
+		re, err := regexp.Compile(rule.Path)
+		if err != nil {
+			return nil, err
+		}
+		f.path = re
+	}
+
+	if rule.Mistake != nil {
+		m, err := newMistake(rule.Mistake)
+		if err != nil {
+			return nil, err
+		}
+		f.mistake = m
+	}
+
+	return f, nil
+}
+
+// newFaultContexts builds the ordered rules of the request, the fields of
+// request are taken as the only rule if there isn't any rule in it
+func newFaultContexts(in *pb.Request) ([]*faultContext, error) {
+	rules := in.Rules
+	if len(rules) == 0 {
+		rules = []*pb.Rule{
+			{
+				Path:         in.Path,
+				Errno:        in.Errno,
+				Random:       in.Random,
+				Pct:          in.Pct,
+				Delay:        in.Delay,
+				Jitter:       in.Jitter,
+				Distribution: in.Distribution,
+			},
+		}
+	}
+
+	fs := make([]*faultContext, 0, len(rules))
+	for i, rule := range rules {
+		f, err := newFaultContext(rule)
+		if err != nil {
+			log.Error(err, "failed to parse rule", "index", i, "rule", rule)
+			return nil, err
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+// match returns the first rule matching the path
+func match(fs []*faultContext, path string) *faultContext {
+	for _, f := range fs {
+		if f.path == nil || f.path.MatchString(path) {
+			return f
+		}
+	}
+	return nil
+}
+
+// pathString returns the regex of path, which is empty if it matches all the files
+func (f *faultContext) pathString() string {
+	if f.path == nil {
+		return ""
+	}
+	return f.path.String()
+}
+
+// getDelay returns the delay with a random jitter in the distribution
+func (f *faultContext) getDelay() time.Duration {
+	delay := f.delay
+	if f.jitter > 0 {
+		switch f.distribution {
+		case normalDistribution:
+			delay += time.Duration(rand.NormFloat64() * float64(f.jitter))
+		default:
+			delay += time.Duration(rand.Int63n(2*int64(f.jitter)+1)) - f.jitter
+		}
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	return delay
 }
 
 func initMethods() {
@@ -102,25 +226,20 @@ func probab(percentage uint32) bool {
 }
 
 func faultInject(path, method string) error {
+	_, err := inject(path, method)
+	return err
+}
+
+// inject injects the fault of the rule matching the path, and returns the rule if it's hit
+func inject(path, method string) (*faultContext, error) {
 	val, ok := faultMap.Load(method)
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
-	fc := val.(*faultContext)
-	if !probab(fc.pct) {
-		return nil
-	}
-
-	if len(fc.path) > 0 {
-		re, err := regexp.Compile(fc.path)
-		if err != nil {
-			log.Error(err, "failed to parse path", "path: ", fc.path)
-			return nil
-		}
-		if !re.MatchString(path) {
-			return nil
-		}
+	fc := match(val.([]*faultContext), path)
+	if fc == nil || !probab(fc.pct) {
+		return nil, nil
 	}
 
 	log.V(6).Info("Inject fault", "method", method, "path", path)
@@ -133,11 +252,11 @@ func faultInject(path, method string) error {
 		errno = randomErrno()
 	}
 
-	if fc.delay > 0 {
-		time.Sleep(fc.delay)
+	if delay := fc.getDelay(); delay > 0 {
+		time.Sleep(delay)
 	}
 
-	return errno
+	return fc, errno
 }
 
 type server struct {
@@ -181,49 +300,78 @@ func (s *server) RecoverMethod(_ context.Context, in *pb.Request) (*empty.Empty,
 	return &empty.Empty{}, nil
 }
 
-func (s *server) setFault(ms []string, f *faultContext) {
+// setFault merges the rules into the rules of the methods
+func (s *server) setFault(ms []string, fs []*faultContext) {
+	faultLock.Lock()
+	defer faultLock.Unlock()
+
 	for _, v := range ms {
-		faultMap.Store(v, f)
+		var existing []*faultContext
+		if val, ok := faultMap.Load(v); ok {
+			existing = val.([]*faultContext)
+		}
+		faultMap.Store(v, mergeFaultContexts(existing, fs))
 	}
 }
 
+// mergeFaultContexts returns a new list of the existing rules merged with the rules in order. A rule replaces
+// the rule with the same path in place, including the one merged before it in the same request. The other rules
+// are appended, while the rules of a path are kept before the rules matching all the files, otherwise they
+// would never be matched, as the first rule matching the path is the only one injected.
+func mergeFaultContexts(existing []*faultContext, fs []*faultContext) []*faultContext {
+	merged := make([]*faultContext, len(existing), len(existing)+len(fs))
+	copy(merged, existing)
+
+	for _, f := range fs {
+		replaced := false
+		for i := range merged {
+			if merged[i].pathString() == f.pathString() {
+				merged[i] = f
+				replaced = true
+				break
+			}
+		}
+		if replaced {
+			continue
+		}
+
+		index := len(merged)
+		if f.path != nil {
+			for i := range merged {
+				if merged[i].path == nil {
+					index = i
+					break
+				}
+			}
+		}
+		merged = append(merged, nil)
+		copy(merged[index+1:], merged[index:])
+		merged[index] = f
+	}
+	return merged
+}
+
 func (s *server) SetFault(_ context.Context, in *pb.Request) (*empty.Empty, error) {
-	// TODO: use Errno(0), and handle Errno(0) in Hook interfaces
 	log.Info("Set fault", "request", in)
 
-	var errno error = nil
-	if in.Errno != 0 {
-		errno = syscall.Errno(in.Errno)
-	}
-	f := &faultContext{
-		errno:  errno,
-		random: in.Random,
-		pct:    in.Pct,
-		path:   in.Path,
-		delay:  time.Duration(in.Delay) * time.Microsecond,
+	fs, err := newFaultContexts(in)
+	if err != nil {
+		return nil, err
 	}
 
-	s.setFault(in.Methods, f)
+	s.setFault(in.Methods, fs)
 	return &empty.Empty{}, nil
 }
 
 func (s *server) SetFaultAll(ctx context.Context, in *pb.Request) (*empty.Empty, error) {
-	// TODO: use Errno(0), and handle Errno(0) in Hook interfaces
 	log.Info("Set fault all methods", "request", in)
 
-	var errno error = nil
-	if in.Errno != 0 {
-		errno = syscall.Errno(in.Errno)
-	}
-	f := &faultContext{
-		errno:  errno,
-		random: in.Random,
-		pct:    in.Pct,
-		path:   in.Path,
-		delay:  time.Duration(in.Delay) * time.Microsecond,
+	fs, err := newFaultContexts(in)
+	if err != nil {
+		return nil, err
 	}
 
-	s.setFault(s.methods(), f)
+	s.setFault(s.methods(), fs)
 	return &empty.Empty{}, nil
 }
 
@@ -16,6 +16,9 @@ package chaosfs
 import (
 	"context"
 	"errors"
+	"regexp"
+	"syscall"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	. "github.com/onsi/ginkgo"
@@ -53,10 +56,10 @@ var _ = Describe("server", func() {
 
 	Context("faultInject", func() {
 		It("should work", func() {
-			faultMap.Store(faultInjectMethod, &faultContext{
+			faultMap.Store(faultInjectMethod, []*faultContext{{
 				pct:    100,
 				random: true,
-			})
+			}})
 			err := faultInject(faultInjectPath, faultInjectMethod)
 			Expect(err).ToNot(BeNil())
 		})
@@ -67,36 +70,244 @@ var _ = Describe("server", func() {
 		})
 
 		It("should skip on wrong regex", func() {
-			faultMap.Store(faultInjectMethod, &faultContext{
-				pct:    100,
-				random: true,
-				path:   `^\/(?!\/)(.*?)`,
+			s := &server{}
+			faultMap.Delete(faultInjectMethod)
+			_, err := s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Random:  true,
+				Pct:     100,
+				Path:    `^\/(?!\/)(.*?)`,
 			})
-			err := faultInject(faultInjectPath, faultInjectMethod)
+			Expect(err).ToNot(BeNil())
+			err = faultInject(faultInjectPath, faultInjectMethod)
 			Expect(err).To(BeNil())
 		})
 
 		It("should skip on mismatch path", func() {
-			faultMap.Store(faultInjectMethod, &faultContext{
+			faultMap.Store(faultInjectMethod, []*faultContext{{
 				pct:    100,
 				random: true,
-				path:   `mismatch-path`,
-			})
+				path:   regexp.MustCompile(`mismatch-path`),
+			}})
 			err := faultInject(faultInjectPath, faultInjectMethod)
 			Expect(err).To(BeNil())
 		})
 
 		It("should return specified errno", func() {
 			e := errors.New("mock err")
-			faultMap.Store(faultInjectMethod, &faultContext{
+			faultMap.Store(faultInjectMethod, []*faultContext{{
 				pct:   100,
 				errno: e,
-			})
+			}})
 			err := faultInject(faultInjectPath, faultInjectMethod)
 			Expect(err).To(Equal(e))
 		})
 	})
 
+	Context("rules", func() {
+		It("should inject the first rule matching the path", func() {
+			e1 := errors.New("mock err 1")
+			e2 := errors.New("mock err 2")
+			faultMap.Store(faultInjectMethod, []*faultContext{
+				{
+					pct:   100,
+					errno: e1,
+					path:  regexp.MustCompile(`mismatch-path`),
+				},
+				{
+					pct:   100,
+					errno: e2,
+					path:  regexp.MustCompile(`^fault-`),
+				},
+				{
+					pct:    100,
+					random: true,
+				},
+			})
+			err := faultInject(faultInjectPath, faultInjectMethod)
+			Expect(err).To(Equal(e2))
+			err = faultInject("mismatch-path", faultInjectMethod)
+			Expect(err).To(Equal(e1))
+		})
+
+		It("should not fall through when the first matched rule isn't hit", func() {
+			faultMap.Store(faultInjectMethod, []*faultContext{
+				{
+					pct:    0,
+					random: true,
+				},
+				{
+					pct:    100,
+					random: true,
+				},
+			})
+			err := faultInject(faultInjectPath, faultInjectMethod)
+			Expect(err).To(BeNil())
+		})
+
+		It("should set rules in order", func() {
+			s := &server{}
+			faultMap.Delete(faultInjectMethod)
+			_, err := s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				// the fields are ignored if there are rules
+				Errno: 1,
+				Rules: []*pb.Rule{
+					{
+						Path:  `^data/`,
+						Errno: 5,
+						Pct:   100,
+					},
+					{
+						Errno: 28,
+						Pct:   100,
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(faultInject("data/wal", faultInjectMethod)).To(Equal(syscall.Errno(5)))
+			Expect(faultInject(faultInjectPath, faultInjectMethod)).To(Equal(syscall.Errno(28)))
+		})
+
+		It("should merge the rules of several requests", func() {
+			s := &server{}
+			faultMap.Delete(faultInjectMethod)
+			_, err := s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Rules: []*pb.Rule{
+					{
+						Path:  `^data/`,
+						Errno: 5,
+						Pct:   100,
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+
+			// the rule of another path is appended
+			_, err = s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Errno:   28,
+				Pct:     100,
+			})
+			Expect(err).To(BeNil())
+			Expect(faultInject("data/wal", faultInjectMethod)).To(Equal(syscall.Errno(5)))
+			Expect(faultInject(faultInjectPath, faultInjectMethod)).To(Equal(syscall.Errno(28)))
+
+			// the rule of the same path is replaced in place
+			_, err = s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Path:    `^data/`,
+				Errno:   13,
+				Pct:     100,
+			})
+			Expect(err).To(BeNil())
+			Expect(faultInject("data/wal", faultInjectMethod)).To(Equal(syscall.Errno(13)))
+			Expect(faultInject(faultInjectPath, faultInjectMethod)).To(Equal(syscall.Errno(28)))
+
+			val, ok := faultMap.Load(faultInjectMethod)
+			Expect(ok).To(BeTrue())
+			Expect(val.([]*faultContext)).To(HaveLen(2))
+		})
+
+		It("should keep the rules of a path before the rule of all the files", func() {
+			s := &server{}
+			faultMap.Delete(faultInjectMethod)
+			_, err := s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Errno:   28,
+				Pct:     100,
+			})
+			Expect(err).To(BeNil())
+
+			// the rule of the path set later isn't hidden by the rule of all the files
+			_, err = s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Rules: []*pb.Rule{
+					{
+						Path:  `^data/`,
+						Errno: 5,
+						Pct:   100,
+					},
+					{
+						Path:  `^log/`,
+						Errno: 13,
+						Pct:   100,
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(faultInject("data/wal", faultInjectMethod)).To(Equal(syscall.Errno(5)))
+			Expect(faultInject("log/1", faultInjectMethod)).To(Equal(syscall.Errno(13)))
+			Expect(faultInject(faultInjectPath, faultInjectMethod)).To(Equal(syscall.Errno(28)))
+
+			val, ok := faultMap.Load(faultInjectMethod)
+			Expect(ok).To(BeTrue())
+			paths := []string{}
+			for _, f := range val.([]*faultContext) {
+				paths = append(paths, f.pathString())
+			}
+			Expect(paths).To(Equal([]string{`^data/`, `^log/`, ""}))
+		})
+
+		It("should merge the rules of the same path in a request", func() {
+			s := &server{}
+			faultMap.Delete(faultInjectMethod)
+			_, err := s.SetFault(context.TODO(), &pb.Request{
+				Methods: []string{faultInjectMethod},
+				Rules: []*pb.Rule{
+					{
+						Path:  `^data/`,
+						Errno: 5,
+						Pct:   100,
+					},
+					{
+						Path:  `^data/`,
+						Errno: 13,
+						Pct:   100,
+					},
+				},
+			})
+			Expect(err).To(BeNil())
+			Expect(faultInject("data/wal", faultInjectMethod)).To(Equal(syscall.Errno(13)))
+
+			val, ok := faultMap.Load(faultInjectMethod)
+			Expect(ok).To(BeTrue())
+			Expect(val.([]*faultContext)).To(HaveLen(1))
+		})
+
+		It("should fail on unsupported distribution", func() {
+			_, err := newFaultContext(&pb.Rule{Delay: 1000, Jitter: 100, Distribution: "pareto"})
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	Context("getDelay", func() {
+		It("should return fixed delay without jitter", func() {
+			f, err := newFaultContext(&pb.Rule{Delay: 1000})
+			Expect(err).To(BeNil())
+			Expect(f.getDelay()).To(Equal(time.Millisecond))
+		})
+
+		It("should add uniform jitter", func() {
+			f, err := newFaultContext(&pb.Rule{Delay: 1000, Jitter: 500})
+			Expect(err).To(BeNil())
+			for i := 0; i < 100; i++ {
+				delay := f.getDelay()
+				Expect(delay).To(BeNumerically(">=", 500*time.Microsecond))
+				Expect(delay).To(BeNumerically("<=", 1500*time.Microsecond))
+			}
+		})
+
+		It("should never be negative", func() {
+			f, err := newFaultContext(&pb.Rule{Delay: 10, Jitter: 1000, Distribution: "normal"})
+			Expect(err).To(BeNil())
+			for i := 0; i < 100; i++ {
+				Expect(f.getDelay()).To(BeNumerically(">=", 0))
+			}
+		})
+	})
+
 	Context("RecoverAll", func() {
 		It("should work", func() {
 			s := &server{}
@@ -107,7 +318,7 @@ var _ = Describe("server", func() {
 				return true
 			})
 			Expect(count).To(Equal(0))
-			faultMap.Store(faultInjectMethod, &faultContext{})
+			faultMap.Store(faultInjectMethod, []*faultContext{{}})
 			faultMap.Range(func(k, v interface{}) bool {
 				count++
 				return true
@@ -126,7 +337,7 @@ var _ = Describe("server", func() {
 	Context("setFault and RecoverMethod", func() {
 		It("should work", func() {
 			s := &server{}
-			s.setFault([]string{faultInjectMethod}, &faultContext{})
+			s.setFault([]string{faultInjectMethod}, []*faultContext{{}})
 			_, ok := faultMap.Load(faultInjectMethod)
 			Expect(ok).To(Equal(true))
 			s.RecoverMethod(context.TODO(), &pb.Request{
@@ -113,7 +113,8 @@ func (r *ChaosCollector) recordEvent(req ctrl.Request, obj v1alpha1.InnerObject)
 	switch status.Experiment.Phase {
 	case v1alpha1.ExperimentPhaseRunning:
 		return r.createEvent(req, kind, status, string(UID))
-	case v1alpha1.ExperimentPhaseFinished, v1alpha1.ExperimentPhasePaused, v1alpha1.ExperimentPhaseWaiting:
+	case v1alpha1.ExperimentPhaseFinished, v1alpha1.ExperimentPhasePaused, v1alpha1.ExperimentPhaseWaiting,
+		v1alpha1.ExperimentPhaseAborted:
 		return r.updateOrCreateEvent(req, kind, status, string(UID))
 	}
 
@@ -27,6 +27,10 @@ type ChaosControllerConfig struct {
 	ChaosDaemonPort int `envconfig:"CHAOS_DAEMON_PORT" default:"31767"`
 	// BPFKIPort is the port which BFFKI grpc server listens on
 	BPFKIPort int `envconfig:"BPFKI_PORT" default:"50051"`
+	// DNSServerGRPCPort is the port which the grpc server of chaos dns server listens on
+	DNSServerGRPCPort int `envconfig:"CHAOS_DNS_SERVER_GRPC_PORT" default:"9288"`
+	// Namespace is the namespace which chaos mesh is deployed in
+	Namespace string `envconfig:"NAMESPACE" default:""`
 	// MetricsAddr is the address the metric endpoint binds to
 	MetricsAddr string `envconfig:"METRICS_ADDR" default:":10080"`
 	// PprofAddr is the address the pprof endpoint binds to.
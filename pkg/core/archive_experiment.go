@@ -174,22 +174,25 @@ type PodChaosInfo struct {
 
 // NetworkChaosInfo defines the basic information of network chaos for creating a new NetworkChaos.
 type NetworkChaosInfo struct {
-	Action      string                  `json:"action" binding:"oneof='' 'netem' 'delay' 'loss' 'duplicate' 'corrupt' 'partition' 'bandwidth'"`
+	Action      string                  `json:"action" binding:"oneof='' 'netem' 'delay' 'loss' 'duplicate' 'corrupt' 'partition' 'bandwidth' 'reset' 'reject'"`
 	Delay       *v1alpha1.DelaySpec     `json:"delay" binding:"RequiredFieldEqual=Action:delay"`
 	Loss        *v1alpha1.LossSpec      `json:"loss" binding:"RequiredFieldEqual=Action:loss"`
 	Duplicate   *v1alpha1.DuplicateSpec `json:"duplicate" binding:"RequiredFieldEqual=Action:duplicate"`
 	Corrupt     *v1alpha1.CorruptSpec   `json:"corrupt" binding:"RequiredFieldEqual=Action:corrupt"`
 	Bandwidth   *v1alpha1.BandwidthSpec `json:"bandwidth" binding:"RequiredFieldEqual=Action:bandwidth"`
+	Reject      *v1alpha1.RejectSpec    `json:"reject"`
 	Direction   string                  `json:"direction" binding:"oneof='' 'to' 'from' 'both'"`
 	TargetScope *ScopeInfo              `json:"target_scope"`
 }
 
 // IOChaosInfo defines the basic information of io chaos for creating a new IOChaos.
 type IOChaosInfo struct {
-	Action     string                     `json:"action" binding:"oneof='' 'latency' 'fault' 'attrOverride'"`
+	Action     string                     `json:"action" binding:"oneof='' 'latency' 'fault' 'attrOverride' 'mistake' 'throttle'"`
 	Delay      string                     `json:"delay"`
 	Errno      uint32                     `json:"errno"`
 	Attr       *v1alpha1.AttrOverrideSpec `json:"attr"`
+	Mistake    *v1alpha1.MistakeSpec      `json:"mistake"`
+	Throttle   *v1alpha1.IoThrottleSpec   `json:"throttle"`
 	Path       string                     `json:"path"`
 	Percent    int                        `json:"percent"`
 	Methods    []v1alpha1.IoMethod        `json:"methods"`
@@ -290,6 +293,7 @@ func (e *ArchiveExperiment) ParseNetworkChaos() (ExperimentInfo, error) {
 				Duplicate: chaos.Spec.Duplicate,
 				Corrupt:   chaos.Spec.Corrupt,
 				Bandwidth: chaos.Spec.Bandwidth,
+				Reject:    chaos.Spec.Reject,
 				Direction: string(chaos.Spec.Direction),
 			},
 		},
@@ -351,6 +355,8 @@ func (e *ArchiveExperiment) ParseIOChaos() (ExperimentInfo, error) {
 				Delay:      chaos.Spec.Delay,
 				Errno:      chaos.Spec.Errno,
 				Attr:       chaos.Spec.Attr,
+				Mistake:    chaos.Spec.Mistake,
+				Throttle:   chaos.Spec.Throttle,
 				Path:       chaos.Spec.Path,
 				Percent:    chaos.Spec.Percent,
 				Methods:    chaos.Spec.Methods,
@@ -0,0 +1,115 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"errors"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+	"github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
+)
+
+// Endpoint plans the changes of the chaos in dry-run mode instead of applying them,
+// it's a no-op wrapper of the endpoint for the other chaos
+type Endpoint struct {
+	endpoint.Endpoint
+	ctx.Context
+}
+
+// New wraps the endpoint to plan the chaos in dry-run mode
+func New(e endpoint.Endpoint, ctx ctx.Context) *Endpoint {
+	return &Endpoint{
+		Endpoint: e,
+		Context:  ctx,
+	}
+}
+
+// Apply records the changes planned by the endpoint into the status of chaos in dry-run mode.
+// The endpoint which doesn't implement Planner only records the selected pods.
+func (e *Endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if !chaos.IsDryRun() {
+		return e.Endpoint.Apply(ctx, req, chaos)
+	}
+
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return err
+	}
+	// the finalizers added while planning are dropped, nothing needs to be recovered
+	finalizers := accessor.GetFinalizers()
+	defer accessor.SetFinalizers(finalizers)
+
+	var plans []v1alpha1.PodPlan
+	if planner, ok := e.Endpoint.(endpoint.Planner); ok {
+		plans, err = planner.Plan(ctx, req, chaos)
+	} else {
+		plans, err = e.planPods(ctx, chaos)
+	}
+	if err != nil {
+		e.Log.Error(err, "failed to plan the chaos")
+		return err
+	}
+
+	chaos.GetStatus().DryRun = &v1alpha1.DryRunStatus{
+		Pods: plans,
+	}
+	e.Event(chaos, v1.EventTypeNormal, utils.EventChaosPlanned, "")
+	return nil
+}
+
+// Recover recovers the chaos only if it's injected before being switched to dry-run mode
+func (e *Endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if !chaos.IsDryRun() {
+		return e.Endpoint.Recover(ctx, req, chaos)
+	}
+
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return err
+	}
+	if len(accessor.GetFinalizers()) > 0 {
+		return e.Endpoint.Recover(ctx, req, chaos)
+	}
+
+	return nil
+}
+
+// planPods plans the selected pods without any change
+func (e *Endpoint) planPods(ctx context.Context, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	selectable, ok := chaos.(v1alpha1.SelectableObject)
+	if !ok {
+		return nil, errors.New("chaos doesn't select pods")
+	}
+
+	pods, err := utils.SelectAndFilterPods(ctx, e.Client, e.Reader, selectable.GetSelectSpec())
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]v1alpha1.PodPlan, 0, len(pods))
+	for _, pod := range pods {
+		plans = append(plans, v1alpha1.PodPlan{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		})
+	}
+	return plans, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+)
+
+type fakeEndpoint struct {
+	applied   int
+	recovered int
+}
+
+func (e *fakeEndpoint) Object() v1alpha1.InnerObject {
+	return &v1alpha1.TimeChaos{}
+}
+
+func (e *fakeEndpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	e.applied++
+	return nil
+}
+
+func (e *fakeEndpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	e.recovered++
+	return nil
+}
+
+type fakePlanner struct {
+	fakeEndpoint
+}
+
+func (e *fakePlanner) Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error) {
+	chaos.(*v1alpha1.TimeChaos).Finalizers = append(chaos.(*v1alpha1.TimeChaos).Finalizers, "default/p0")
+	return []v1alpha1.PodPlan{{Namespace: "default", Name: "p0", Kind: "TimeRequest", Plan: "{}"}}, nil
+}
+
+func newPod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"app": "web"},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+		},
+	}
+}
+
+func newChaos(dryRun bool) *v1alpha1.TimeChaos {
+	chaos := &v1alpha1.TimeChaos{
+		Spec: v1alpha1.TimeChaosSpec{
+			Mode: v1alpha1.AllPodMode,
+			Selector: v1alpha1.SelectorSpec{
+				LabelSelectors: map[string]string{"app": "web"},
+			},
+		},
+	}
+	if dryRun {
+		chaos.Annotations = map[string]string{v1alpha1.DryRunAnnotationKey: "true"}
+	}
+	return chaos
+}
+
+func newContext() ctx.Context {
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, newPod("p0"), newPod("p1"))
+	return ctx.Context{Client: c, Reader: c, Log: ctrl.Log, EventRecorder: record.NewFakeRecorder(10)}
+}
+
+func TestApply(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	inner := &fakeEndpoint{}
+	e := New(inner, newContext())
+
+	chaos := newChaos(false)
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(inner.applied).To(Equal(1))
+	g.Expect(chaos.Status.DryRun).To(BeNil())
+
+	chaos = newChaos(true)
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(inner.applied).To(Equal(1))
+	g.Expect(chaos.Status.DryRun).ToNot(BeNil())
+	g.Expect(chaos.Status.DryRun.Pods).To(ConsistOf(
+		v1alpha1.PodPlan{Namespace: metav1.NamespaceDefault, Name: "p0"},
+		v1alpha1.PodPlan{Namespace: metav1.NamespaceDefault, Name: "p1"},
+	))
+
+	g.Expect(e.Recover(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(inner.recovered).To(Equal(0))
+}
+
+func TestPlan(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	inner := &fakePlanner{}
+	e := New(inner, newContext())
+
+	chaos := newChaos(true)
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(inner.applied).To(Equal(0))
+	g.Expect(chaos.Status.DryRun.Pods).To(HaveLen(1))
+	g.Expect(chaos.Status.DryRun.Pods[0].Kind).To(Equal("TimeRequest"))
+	g.Expect(chaos.Finalizers).To(BeEmpty())
+
+	// the chaos injected before switching to dry-run mode is still recovered
+	chaos.Finalizers = []string{"default/p0"}
+	g.Expect(e.Recover(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(inner.recovered).To(Equal(1))
+}
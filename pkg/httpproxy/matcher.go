@@ -0,0 +1,131 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpproxy
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// headerMatcher is the compiled v1alpha1.Matcher
+type headerMatcher struct {
+	name    string
+	exact   *string
+	regexps []*regexp.Regexp
+	rangeOf *[2]int64
+	present *bool
+	prefix  *string
+	suffix  *string
+	invert  bool
+}
+
+func newHeaderMatcher(matcher v1alpha1.Matcher) (*headerMatcher, error) {
+	m := &headerMatcher{
+		name:   http.CanonicalHeaderKey(matcher.Name),
+		exact:  matcher.ExactMatch,
+		prefix: matcher.PrefixMatch,
+		suffix: matcher.SuffixMatch,
+	}
+
+	for _, expr := range []*string{matcher.RegexMatch, matcher.SafeRegexMatch} {
+		if expr == nil {
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + *expr + ")$")
+		if err != nil {
+			return nil, err
+		}
+		m.regexps = append(m.regexps, re)
+	}
+
+	if matcher.RangeMatch != nil {
+		bounds := strings.Split(*matcher.RangeMatch, ",")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("range %s should be in the form of start,end", *matcher.RangeMatch)
+		}
+		var rangeOf [2]int64
+		for i, bound := range bounds {
+			value, err := strconv.ParseInt(strings.TrimSpace(bound), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			rangeOf[i] = value
+		}
+		m.rangeOf = &rangeOf
+	}
+
+	if matcher.PresentMatch != nil {
+		present, err := strconv.ParseBool(*matcher.PresentMatch)
+		if err != nil {
+			return nil, err
+		}
+		m.present = &present
+	}
+
+	if matcher.InvertMatch != nil {
+		invert, err := strconv.ParseBool(*matcher.InvertMatch)
+		if err != nil {
+			return nil, err
+		}
+		m.invert = invert
+	}
+
+	return m, nil
+}
+
+func (m *headerMatcher) match(header http.Header) bool {
+	return m.matchValue(header) != m.invert
+}
+
+func (m *headerMatcher) matchValue(header http.Header) bool {
+	values, present := header[m.name]
+	if m.present != nil && *m.present != present {
+		return false
+	}
+
+	var value string
+	if present {
+		value = values[0]
+	} else if m.exact != nil || len(m.regexps) > 0 || m.rangeOf != nil || m.prefix != nil || m.suffix != nil {
+		// the header is required by the other conditions
+		return false
+	}
+
+	if m.exact != nil && value != *m.exact {
+		return false
+	}
+	for _, re := range m.regexps {
+		if !re.MatchString(value) {
+			return false
+		}
+	}
+	if m.rangeOf != nil {
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || number < m.rangeOf[0] || number >= m.rangeOf[1] {
+			return false
+		}
+	}
+	if m.prefix != nil && !strings.HasPrefix(value, *m.prefix) {
+		return false
+	}
+	if m.suffix != nil && !strings.HasSuffix(value, *m.suffix) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpproxy
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+var log = ctrl.Log.WithName("http-proxy")
+
+// Rule describes the fault injected into the requests which match all the headers
+type Rule struct {
+	Action    v1alpha1.HTTPChaosAction `json:"action"`
+	Delay     time.Duration            `json:"delay,omitempty"`
+	AbortCode int                      `json:"abortCode,omitempty"`
+	Percent   int                      `json:"percent"`
+	Headers   []v1alpha1.Matcher       `json:"headers,omitempty"`
+}
+
+// Proxy is a HTTP/1.1 reverse proxy which injects faults into the requests
+type Proxy struct {
+	rule     Rule
+	matchers []*headerMatcher
+	upstream *httputil.ReverseProxy
+
+	// random returns a number in [0, 100)
+	random func() int
+}
+
+// New creates a proxy which forwards the requests to the target address, e.g. 127.0.0.1:80
+func New(rule Rule, target string) (*Proxy, error) {
+	switch rule.Action {
+	case v1alpha1.HTTPDelayAction, v1alpha1.HTTPAbortAction, v1alpha1.HTTPMixedAction:
+	default:
+		return nil, fmt.Errorf("unknown http chaos action %s", rule.Action)
+	}
+
+	proxy := &Proxy{
+		rule: rule,
+		upstream: httputil.NewSingleHostReverseProxy(&url.URL{
+			Scheme: "http",
+			Host:   target,
+		}),
+		random: func() int {
+			return rand.Intn(100)
+		},
+	}
+
+	for _, header := range rule.Headers {
+		matcher, err := newHeaderMatcher(header)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher of header %s: %v", header.Name, err)
+		}
+		proxy.matchers = append(proxy.matchers, matcher)
+	}
+
+	return proxy, nil
+}
+
+// ServeHTTP injects the fault and forwards the request to the target
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.inject(r) {
+		if p.rule.Action == v1alpha1.HTTPDelayAction || p.rule.Action == v1alpha1.HTTPMixedAction {
+			select {
+			case <-time.After(p.rule.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if p.rule.Action == v1alpha1.HTTPAbortAction || p.rule.Action == v1alpha1.HTTPMixedAction {
+			code := p.rule.AbortCode
+			if code == 0 {
+				code = http.StatusInternalServerError
+			}
+			log.V(1).Info("abort request", "method", r.Method, "url", r.URL.String(), "code", code)
+			http.Error(w, http.StatusText(code), code)
+			return
+		}
+	}
+
+	p.upstream.ServeHTTP(w, r)
+}
+
+func (p *Proxy) inject(r *http.Request) bool {
+	for _, matcher := range p.matchers {
+		if !matcher.match(r.Header) {
+			return false
+		}
+	}
+	return p.random() < p.rule.Percent
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpproxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func newTestProxy(g *GomegaWithT, rule Rule) (*httptest.Server, func()) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	target, err := url.Parse(upstream.URL)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	proxy, err := New(rule, target.Host)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	server := httptest.NewServer(proxy)
+
+	return server, func() {
+		server.Close()
+		upstream.Close()
+	}
+}
+
+func get(g *GomegaWithT, server *httptest.Server, header http.Header) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	req.Header = header
+
+	resp, err := http.DefaultClient.Do(req)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	return resp.StatusCode, string(body)
+}
+
+func TestAbort(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server, cleanup := newTestProxy(g, Rule{
+		Action:    v1alpha1.HTTPAbortAction,
+		AbortCode: http.StatusServiceUnavailable,
+		Percent:   100,
+	})
+	defer cleanup()
+
+	code, _ := get(g, server, http.Header{})
+	g.Expect(code).To(Equal(http.StatusServiceUnavailable))
+}
+
+func TestDelay(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server, cleanup := newTestProxy(g, Rule{
+		Action:  v1alpha1.HTTPDelayAction,
+		Delay:   100 * time.Millisecond,
+		Percent: 100,
+	})
+	defer cleanup()
+
+	start := time.Now()
+	code, body := get(g, server, http.Header{})
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	g.Expect(code).To(Equal(http.StatusOK))
+	g.Expect(body).To(Equal("ok"))
+}
+
+func TestMixed(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server, cleanup := newTestProxy(g, Rule{
+		Action:  v1alpha1.HTTPMixedAction,
+		Delay:   100 * time.Millisecond,
+		Percent: 100,
+	})
+	defer cleanup()
+
+	start := time.Now()
+	code, _ := get(g, server, http.Header{})
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	g.Expect(code).To(Equal(http.StatusInternalServerError))
+}
+
+func TestPercent(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server, cleanup := newTestProxy(g, Rule{
+		Action:  v1alpha1.HTTPAbortAction,
+		Percent: 0,
+	})
+	defer cleanup()
+
+	code, body := get(g, server, http.Header{})
+	g.Expect(code).To(Equal(http.StatusOK))
+	g.Expect(body).To(Equal("ok"))
+}
+
+func TestHeaderMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	user := "chaos-.*"
+	version := "1,3"
+	invert := "true"
+	server, cleanup := newTestProxy(g, Rule{
+		Action:  v1alpha1.HTTPAbortAction,
+		Percent: 100,
+		Headers: []v1alpha1.Matcher{
+			{Name: "x-user", RegexMatch: &user},
+			{Name: "x-version", RangeMatch: &version},
+			{Name: "x-skip", PresentMatch: &invert, InvertMatch: &invert},
+		},
+	})
+	defer cleanup()
+
+	type TestCase struct {
+		name   string
+		header http.Header
+		expect int
+	}
+	tcs := []TestCase{
+		{
+			name:   "all matched",
+			header: http.Header{"X-User": {"chaos-mesh"}, "X-Version": {"2"}},
+			expect: http.StatusInternalServerError,
+		},
+		{
+			name:   "regex not matched",
+			header: http.Header{"X-User": {"mesh"}, "X-Version": {"2"}},
+			expect: http.StatusOK,
+		},
+		{
+			name:   "out of range",
+			header: http.Header{"X-User": {"chaos-mesh"}, "X-Version": {"3"}},
+			expect: http.StatusOK,
+		},
+		{
+			name:   "inverted",
+			header: http.Header{"X-User": {"chaos-mesh"}, "X-Version": {"2"}, "X-Skip": {""}},
+			expect: http.StatusOK,
+		},
+		{
+			name:   "missing header",
+			header: http.Header{"X-Version": {"2"}},
+			expect: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tcs {
+		code, _ := get(g, server, tc.header)
+		g.Expect(code).To(Equal(tc.expect), tc.name)
+	}
+}
+
+func TestInvalidRule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := New(Rule{Action: "unknown"}, "127.0.0.1:80")
+	g.Expect(err).Should(HaveOccurred())
+
+	invalid := "("
+	_, err = New(Rule{
+		Action:  v1alpha1.HTTPAbortAction,
+		Headers: []v1alpha1.Matcher{{Name: "x-user", RegexMatch: &invalid}},
+	}, "127.0.0.1:80")
+	g.Expect(err).Should(HaveOccurred())
+}
@@ -0,0 +1,404 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	prometheusapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// EventChaosAborted is the reason of the event recorded when the chaos is
+// recovered because a steady-state probe failed
+const EventChaosAborted = "ChaosAborted"
+
+// pollInterval is the interval to check whether the probes running in background have finished
+const pollInterval = time.Second
+
+// RestConfig is the config used by exec probes to run commands in containers.
+// It's set by the controller manager at startup.
+var RestConfig *rest.Config
+
+// result is the result of a probe running in background
+type result struct {
+	finished bool
+	err      error
+	cancel   context.CancelFunc
+}
+
+// runner runs the probes in background, so that the reconciliation isn't blocked by them
+type runner struct {
+	sync.Mutex
+	wg      sync.WaitGroup
+	results map[string]*result
+}
+
+var probes = &runner{results: make(map[string]*result)}
+
+// start runs the probe in background, and the result is stored with the key
+func (r *runner) start(key string, namespace string, spec v1alpha1.ProbeSpec) {
+	r.Lock()
+	defer r.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res := &result{cancel: cancel}
+	r.results[key] = res
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+		err := Probe(ctx, namespace, &spec)
+
+		r.Lock()
+		defer r.Unlock()
+		res.finished = true
+		res.err = err
+	}()
+}
+
+// poll returns the result of the probe with the key, the result is removed once it has finished
+func (r *runner) poll(key string) (res result, ok bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	stored, ok := r.results[key]
+	if !ok {
+		return result{}, false
+	}
+	if stored.finished {
+		delete(r.results, key)
+	}
+	return *stored, true
+}
+
+// forget cancels the probes with the prefix and removes their results
+func (r *runner) forget(prefix string) {
+	r.Lock()
+	defer r.Unlock()
+
+	for key, res := range r.results {
+		if strings.HasPrefix(key, prefix) {
+			res.cancel()
+			delete(r.results, key)
+		}
+	}
+}
+
+// wait waits for all the probes running in background to finish
+func (r *runner) wait() {
+	r.wg.Wait()
+}
+
+// Probe runs the probe once and returns an error if the steady state doesn't hold
+func Probe(ctx context.Context, namespace string, spec *v1alpha1.ProbeSpec) error {
+	ctx, cancel := context.WithTimeout(ctx, spec.GetTimeout())
+	defer cancel()
+
+	switch spec.Type {
+	case v1alpha1.HTTPProbeType:
+		if spec.HTTP == nil {
+			return fmt.Errorf("http probe %s is not configured", spec.Name)
+		}
+		return probeHTTP(ctx, spec.HTTP)
+	case v1alpha1.TCPProbeType:
+		if spec.TCP == nil {
+			return fmt.Errorf("tcp probe %s is not configured", spec.Name)
+		}
+		return probeTCP(ctx, spec.TCP)
+	case v1alpha1.PrometheusProbeType:
+		if spec.Prometheus == nil {
+			return fmt.Errorf("prometheus probe %s is not configured", spec.Name)
+		}
+		return probePrometheus(ctx, spec.Prometheus)
+	case v1alpha1.ExecProbeType:
+		if spec.Exec == nil {
+			return fmt.Errorf("exec probe %s is not configured", spec.Name)
+		}
+		return probeExec(ctx, namespace, spec.Exec)
+	}
+
+	return fmt.Errorf("unknown probe type %s", spec.Type)
+}
+
+// Check starts the probes which are due at now in background, and records the results of the
+// finished probes in the status of chaos. It returns the status of the first probe which breaches
+// its failure threshold (nil if the steady state holds) and the duration until the chaos should be
+// checked again, which is when the next probe is due or a running probe may have finished.
+func Check(ctx context.Context, chaos v1alpha1.InnerObject, now time.Time) (*v1alpha1.ProbeStatus, time.Duration) {
+	probed, ok := chaos.(v1alpha1.ProbedObject)
+	if !ok {
+		return nil, 0
+	}
+
+	status := chaos.GetStatus()
+	var namespace string
+	if meta, ok := chaos.(metav1.Object); ok {
+		namespace = meta.GetNamespace()
+	}
+	prefix := keyPrefix(chaos)
+	var requeueAfter time.Duration
+	var breached *v1alpha1.ProbeStatus
+
+	for _, spec := range probed.GetProbes() {
+		spec := spec
+
+		probeStatus := status.GetProbeStatus(spec.Name)
+		if probeStatus == nil {
+			status.Probes = append(status.Probes, v1alpha1.ProbeStatus{Name: spec.Name})
+			probeStatus = &status.Probes[len(status.Probes)-1]
+		}
+
+		key := prefix + spec.Name
+		interval := spec.GetInterval()
+		res, ok := probes.poll(key)
+		if ok && res.finished {
+			if res.err != nil {
+				probeStatus.ConsecutiveFailures++
+				probeStatus.Message = res.err.Error()
+			} else {
+				probeStatus.ConsecutiveFailures = 0
+				probeStatus.Message = ""
+			}
+		}
+
+		running := ok && !res.finished
+		if !running && (probeStatus.LastProbeTime == nil || !probeStatus.LastProbeTime.Add(interval).After(now)) {
+			// the time when the probe starts is recorded, so that the probes keep the interval
+			probeStatus.LastProbeTime = &metav1.Time{Time: now}
+			probes.start(key, namespace, spec)
+			running = true
+		}
+
+		if breached == nil && probeStatus.ConsecutiveFailures >= spec.GetFailureThreshold() {
+			breached = probeStatus.DeepCopy()
+		}
+
+		next := probeStatus.LastProbeTime.Add(interval).Sub(now)
+		if running && (next <= 0 || pollInterval < next) {
+			next = pollInterval
+		}
+		if requeueAfter == 0 || next < requeueAfter {
+			requeueAfter = next
+		}
+	}
+
+	return breached, requeueAfter
+}
+
+// Forget cancels the probes of chaos running in background and removes their results,
+// it's called when the chaos is recovered so that the results aren't leaked
+func Forget(chaos v1alpha1.InnerObject) {
+	if _, ok := chaos.(v1alpha1.ProbedObject); !ok {
+		return
+	}
+	probes.forget(keyPrefix(chaos))
+}
+
+// keyPrefix returns the prefix of the keys of the probes of chaos
+func keyPrefix(chaos v1alpha1.InnerObject) string {
+	if meta, ok := chaos.(metav1.Object); ok {
+		return fmt.Sprintf("%s/%s/%s/", meta.GetNamespace(), meta.GetName(), meta.GetUID())
+	}
+	return "/"
+}
+
+func probeHTTP(ctx context.Context, spec *v1alpha1.HTTPProbe) error {
+	req, err := http.NewRequest(http.MethodGet, spec.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	expected := spec.ExpectedStatus
+	if expected == 0 {
+		expected = http.StatusOK
+	}
+	if resp.StatusCode != expected {
+		return fmt.Errorf("unexpected status code %d of %s, expected %d", resp.StatusCode, spec.URL, expected)
+	}
+	return nil
+}
+
+func probeTCP(ctx context.Context, spec *v1alpha1.TCPProbe) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", spec.Address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func probePrometheus(ctx context.Context, spec *v1alpha1.PrometheusProbe) error {
+	threshold, err := strconv.ParseFloat(spec.Threshold, 64)
+	if err != nil {
+		return err
+	}
+
+	client, err := prometheusapi.NewClient(prometheusapi.Config{Address: spec.URL})
+	if err != nil {
+		return err
+	}
+
+	value, _, err := prometheusv1.NewAPI(client).Query(ctx, spec.Query, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var samples []float64
+	switch v := value.(type) {
+	case *model.Scalar:
+		samples = append(samples, float64(v.Value))
+	case model.Vector:
+		for _, sample := range v {
+			samples = append(samples, float64(sample.Value))
+		}
+	default:
+		return fmt.Errorf("unsupported result type %s of query %s", value.Type(), spec.Query)
+	}
+
+	if len(samples) == 0 {
+		return fmt.Errorf("empty result of query %s", spec.Query)
+	}
+
+	for _, sample := range samples {
+		ok, err := compare(sample, spec.Operator, threshold)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("result %v of query %s is not %s %v", sample, spec.Query, spec.Operator, threshold)
+		}
+	}
+	return nil
+}
+
+func compare(value float64, operator string, threshold float64) (bool, error) {
+	switch operator {
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case "==":
+		return value == threshold, nil
+	case "!=":
+		return value != threshold, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", operator)
+}
+
+func probeExec(ctx context.Context, namespace string, spec *v1alpha1.ExecProbe) error {
+	if RestConfig == nil {
+		return fmt.Errorf("rest config is not set for exec probe")
+	}
+
+	clientset, err := kubernetes.NewForConfig(RestConfig)
+	if err != nil {
+		return err
+	}
+
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(spec.Pod).
+		Namespace(namespace).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: spec.Container,
+			Command:   spec.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	transport, upgrader, err := spdy.RoundTripperFor(RestConfig)
+	if err != nil {
+		return err
+	}
+	// the connection is closed once ctx is done, which stops the stream and the remote command
+	executor, err := remotecommand.NewSPDYExecutorForTransports(transport, &cancelableUpgrader{
+		Upgrader: upgrader,
+		ctx:      ctx,
+	}, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+
+	var stdout, stderr bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- executor.Stream(remotecommand.StreamOptions{
+			Stdout: &stdout,
+			Stderr: &stderr,
+		})
+	}()
+
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		return fmt.Errorf("exec %v in %s/%s timeout", spec.Command, namespace, spec.Pod)
+	}
+	if err != nil {
+		return fmt.Errorf("exec %v in %s/%s failed: %v, stderr: %s", spec.Command, namespace, spec.Pod, err, stderr.String())
+	}
+	return nil
+}
+
+// cancelableUpgrader closes the connections it creates once ctx is done
+type cancelableUpgrader struct {
+	spdy.Upgrader
+	ctx context.Context
+}
+
+func (u *cancelableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.Upgrader.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.ctx.Done():
+			conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+	return conn, nil
+}
@@ -0,0 +1,301 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestHTTPProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	spec := &v1alpha1.ProbeSpec{
+		Name: "http",
+		Type: v1alpha1.HTTPProbeType,
+		HTTP: &v1alpha1.HTTPProbe{URL: server.URL + "/healthz"},
+	}
+	g.Expect(Probe(context.TODO(), "", spec)).To(Succeed())
+
+	spec.HTTP.URL = server.URL + "/unavailable"
+	err := Probe(context.TODO(), "", spec)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("unexpected status code 503"))
+
+	spec.HTTP.ExpectedStatus = http.StatusServiceUnavailable
+	g.Expect(Probe(context.TODO(), "", spec)).To(Succeed())
+}
+
+func TestTCPProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	address := listener.Addr().String()
+
+	spec := &v1alpha1.ProbeSpec{
+		Name: "tcp",
+		Type: v1alpha1.TCPProbeType,
+		TCP:  &v1alpha1.TCPProbe{Address: address},
+	}
+	g.Expect(Probe(context.TODO(), "", spec)).To(Succeed())
+
+	g.Expect(listener.Close()).To(Succeed())
+	g.Expect(Probe(context.TODO(), "", spec)).ToNot(Succeed())
+}
+
+func TestPrometheusProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+			`{"metric":{"pod":"a"},"value":[1590000000,"0.5"]},`+
+			`{"metric":{"pod":"b"},"value":[1590000000,"1.5"]}]}}`)
+	}))
+	defer server.Close()
+
+	spec := &v1alpha1.ProbeSpec{
+		Name: "prometheus",
+		Type: v1alpha1.PrometheusProbeType,
+		Prometheus: &v1alpha1.PrometheusProbe{
+			URL:       server.URL,
+			Query:     "error_rate",
+			Operator:  "<",
+			Threshold: "2",
+		},
+	}
+	g.Expect(Probe(context.TODO(), "", spec)).To(Succeed())
+
+	spec.Prometheus.Threshold = "1"
+	err := Probe(context.TODO(), "", spec)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("result 1.5 of query error_rate is not < 1"))
+
+	spec.Prometheus.Operator = ">="
+	spec.Prometheus.Threshold = "0.5"
+	g.Expect(Probe(context.TODO(), "", spec)).To(Succeed())
+}
+
+func TestExecProbeWithoutConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	spec := &v1alpha1.ProbeSpec{
+		Name: "exec",
+		Type: v1alpha1.ExecProbeType,
+		Exec: &v1alpha1.ExecProbe{Pod: "pod", Command: []string{"true"}},
+	}
+	err := Probe(context.TODO(), "default", spec)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("rest config is not set"))
+}
+
+func TestCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if healthy {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	interval := "10s"
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "chaos"},
+		Spec: v1alpha1.PodChaosSpec{
+			Probes: []v1alpha1.ProbeSpec{{
+				Name:             "http",
+				Type:             v1alpha1.HTTPProbeType,
+				Interval:         &interval,
+				FailureThreshold: 2,
+				HTTP:             &v1alpha1.HTTPProbe{URL: server.URL},
+			}},
+		},
+	}
+
+	now := time.Now()
+	breached, requeueAfter := Check(context.TODO(), chaos, now)
+	g.Expect(breached).To(BeNil())
+	// the probe is running in background, so the chaos should be checked soon
+	g.Expect(requeueAfter).To(Equal(pollInterval))
+	g.Expect(chaos.Status.Probes).To(HaveLen(1))
+	g.Expect(chaos.Status.Probes[0].LastProbeTime.Time).To(Equal(now))
+	probes.wait()
+
+	// the probe isn't due, so it won't run again
+	healthy = false
+	breached, requeueAfter = Check(context.TODO(), chaos, now.Add(4*time.Second))
+	g.Expect(breached).To(BeNil())
+	g.Expect(requeueAfter).To(Equal(6 * time.Second))
+	g.Expect(chaos.Status.Probes[0].ConsecutiveFailures).To(Equal(0))
+
+	breached, _ = Check(context.TODO(), chaos, now.Add(10*time.Second))
+	g.Expect(breached).To(BeNil())
+	probes.wait()
+	breached, _ = Check(context.TODO(), chaos, now.Add(11*time.Second))
+	g.Expect(breached).To(BeNil())
+	g.Expect(chaos.Status.Probes[0].ConsecutiveFailures).To(Equal(1))
+
+	breached, _ = Check(context.TODO(), chaos, now.Add(20*time.Second))
+	g.Expect(breached).To(BeNil())
+	probes.wait()
+	breached, _ = Check(context.TODO(), chaos, now.Add(21*time.Second))
+	g.Expect(breached).NotTo(BeNil())
+	g.Expect(breached.Name).To(Equal("http"))
+	g.Expect(breached.ConsecutiveFailures).To(Equal(2))
+	g.Expect(breached.Message).To(ContainSubstring("unexpected status code 500"))
+}
+
+func TestCheckSlowProbe(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "slow"},
+		Spec: v1alpha1.PodChaosSpec{
+			Probes: []v1alpha1.ProbeSpec{{
+				Name: "http",
+				Type: v1alpha1.HTTPProbeType,
+				HTTP: &v1alpha1.HTTPProbe{URL: server.URL},
+			}},
+		},
+	}
+
+	// Check returns without waiting for the probe
+	now := time.Now()
+	breached, requeueAfter := Check(context.TODO(), chaos, now)
+	g.Expect(breached).To(BeNil())
+	g.Expect(requeueAfter).To(Equal(pollInterval))
+
+	// the probe is still running even if it's due again, so it isn't started twice
+	breached, requeueAfter = Check(context.TODO(), chaos, now.Add(time.Hour))
+	g.Expect(breached).To(BeNil())
+	g.Expect(requeueAfter).To(Equal(pollInterval))
+	g.Expect(chaos.Status.Probes[0].LastProbeTime.Time).To(Equal(now))
+
+	close(release)
+	probes.wait()
+	_, _ = Check(context.TODO(), chaos, now)
+	g.Expect(chaos.Status.Probes[0].ConsecutiveFailures).To(Equal(0))
+}
+
+func TestForget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	chaos := &v1alpha1.PodChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "forget", UID: "uid"},
+		Spec: v1alpha1.PodChaosSpec{
+			Probes: []v1alpha1.ProbeSpec{{
+				Name: "http",
+				Type: v1alpha1.HTTPProbeType,
+				HTTP: &v1alpha1.HTTPProbe{URL: server.URL},
+			}},
+		},
+	}
+
+	_, _ = Check(context.TODO(), chaos, time.Now())
+	_, ok := probes.poll(keyPrefix(chaos) + "http")
+	g.Expect(ok).To(BeTrue())
+
+	// the running probe is canceled and its result is removed
+	Forget(chaos)
+	_, ok = probes.poll(keyPrefix(chaos) + "http")
+	g.Expect(ok).To(BeFalse())
+	probes.wait()
+}
+
+type fakeConnection struct {
+	closed chan bool
+}
+
+func (c *fakeConnection) CreateStream(http.Header) (httpstream.Stream, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (c *fakeConnection) Close() error {
+	close(c.closed)
+	return nil
+}
+
+func (c *fakeConnection) CloseChan() <-chan bool {
+	return c.closed
+}
+
+func (c *fakeConnection) SetIdleTimeout(time.Duration) {}
+
+type fakeUpgrader struct {
+	conn *fakeConnection
+}
+
+func (u *fakeUpgrader) NewConnection(*http.Response) (httpstream.Connection, error) {
+	return u.conn, nil
+}
+
+func TestCancelableUpgrader(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	conn := &fakeConnection{closed: make(chan bool)}
+	ctx, cancel := context.WithCancel(context.TODO())
+	upgrader := &cancelableUpgrader{Upgrader: &fakeUpgrader{conn: conn}, ctx: ctx}
+
+	_, err := upgrader.NewConnection(&http.Response{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Consistently(conn.closed, 100*time.Millisecond).ShouldNot(BeClosed())
+
+	// the connection is closed once the probe times out
+	cancel()
+	g.Eventually(conn.closed).Should(BeClosed())
+}
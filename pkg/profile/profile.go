@@ -0,0 +1,90 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+)
+
+// Start sets the profile of the chaos to its first stage, it should be called before
+// the chaos is applied, so that the chaos is applied with the value of the first stage
+func Start(chaos v1alpha1.InnerObject) error {
+	status := chaos.GetStatus()
+	status.Profile = nil
+
+	profiled, ok := chaos.(v1alpha1.ProfiledObject)
+	if !ok || profiled.GetProfile() == nil {
+		return nil
+	}
+
+	// the pods are selected again in a new run, and the following stages are applied
+	// on the pods recorded in the first stage
+	status.Experiment.PodRecords = nil
+
+	stage, _, err := profiled.GetProfile().StageAt(0)
+	if err != nil {
+		return err
+	}
+	status.Profile = &stage
+	return nil
+}
+
+// Step moves the profile of the running chaos to the stage at now. The chaos is applied
+// again with the value of the new stage on the recorded pods, or recovered if the fault
+// is off in the stage.
+// It returns whether the stage is changed and the duration until the next stage, which
+// is 0 if the stage won't change any more.
+func Step(ctx context.Context, e endpoint.Endpoint, req ctrl.Request, chaos v1alpha1.InnerObject, now time.Time) (bool, time.Duration, error) {
+	profiled, ok := chaos.(v1alpha1.ProfiledObject)
+	if !ok || profiled.GetProfile() == nil {
+		return false, 0, nil
+	}
+
+	status := chaos.GetStatus()
+	var elapsed time.Duration
+	if status.Experiment.StartTime != nil {
+		elapsed = now.Sub(status.Experiment.StartTime.Time)
+	}
+
+	stage, requeueAfter, err := profiled.GetProfile().StageAt(elapsed)
+	if err != nil {
+		return false, 0, err
+	}
+
+	previous := status.Profile
+	if previous != nil && previous.Stage == stage.Stage {
+		return false, requeueAfter, nil
+	}
+
+	// the previous stage is restored if the new one fails to apply,
+	// so that it's retried in the next reconciliation
+	status.Profile = &stage
+	if stage.Active {
+		err = e.Apply(ctx, req, chaos)
+	} else {
+		err = e.Recover(ctx, req, chaos)
+	}
+	if err != nil {
+		status.Profile = previous
+		return false, 0, err
+	}
+
+	return true, requeueAfter, nil
+}
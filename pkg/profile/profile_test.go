@@ -0,0 +1,178 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+type fakeEndpoint struct {
+	applied   []string
+	recovered int
+	err       error
+}
+
+func (e *fakeEndpoint) Object() v1alpha1.InnerObject {
+	return &v1alpha1.NetworkChaos{}
+}
+
+func (e *fakeEndpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.applied = append(e.applied, chaos.(*v1alpha1.NetworkChaos).ProfiledSpec().Loss.Loss)
+	return nil
+}
+
+func (e *fakeEndpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.recovered++
+	return nil
+}
+
+func newChaos(profile *v1alpha1.ProfileSpec, start time.Time) *v1alpha1.NetworkChaos {
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.LossAction,
+			TcParameter: v1alpha1.TcParameter{
+				Loss: &v1alpha1.LossSpec{Loss: "10", Correlation: "0"},
+			},
+			Profile: profile,
+		},
+	}
+	chaos.Status.Experiment.StartTime = &metav1.Time{Time: start}
+	return chaos
+}
+
+func TestRamp(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := time.Now()
+	chaos := newChaos(&v1alpha1.ProfileSpec{
+		Type:     v1alpha1.RampProfileType,
+		Interval: "1m",
+		From:     "0",
+		To:       "30",
+		Steps:    3,
+	}, start)
+	e := &fakeEndpoint{}
+
+	g.Expect(Start(chaos)).To(Succeed())
+	g.Expect(chaos.Status.Profile).To(Equal(&v1alpha1.ProfileStatus{Stage: 0, Active: true, Value: "0"}))
+
+	changed, requeueAfter, err := Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(30*time.Second))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	g.Expect(requeueAfter).To(Equal(30 * time.Second))
+
+	changed, requeueAfter, err = Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(time.Minute))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(requeueAfter).To(Equal(time.Minute))
+	g.Expect(e.applied).To(Equal([]string{"10"}))
+
+	changed, requeueAfter, err = Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(10*time.Minute))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(requeueAfter).To(BeZero())
+	g.Expect(e.applied).To(Equal([]string{"10", "30"}))
+	g.Expect(chaos.Status.Profile.Stage).To(Equal(3))
+}
+
+func TestStep(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := time.Now()
+	chaos := newChaos(&v1alpha1.ProfileSpec{
+		Type:     v1alpha1.StepProfileType,
+		Interval: "1m",
+		Values:   []string{"5", "20", "50"},
+	}, start)
+	e := &fakeEndpoint{}
+
+	g.Expect(Start(chaos)).To(Succeed())
+	g.Expect(chaos.Status.Profile.Value).To(Equal("5"))
+
+	_, _, err := Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(90*time.Second))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(e.applied).To(Equal([]string{"20"}))
+
+	// the stage is not changed if it fails to apply
+	e.err = errors.New("failed to apply")
+	_, _, err = Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(150*time.Second))
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(chaos.Status.Profile.Value).To(Equal("20"))
+
+	e.err = nil
+	changed, _, err := Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(150*time.Second))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(e.applied).To(Equal([]string{"20", "50"}))
+}
+
+func TestFlap(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	start := time.Now()
+	chaos := newChaos(&v1alpha1.ProfileSpec{
+		Type:        v1alpha1.FlapProfileType,
+		Interval:    "30s",
+		OffInterval: "10s",
+	}, start)
+	e := &fakeEndpoint{}
+
+	g.Expect(Start(chaos)).To(Succeed())
+	g.Expect(chaos.Status.Profile.Active).To(BeTrue())
+
+	changed, requeueAfter, err := Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(35*time.Second))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(requeueAfter).To(Equal(5 * time.Second))
+	g.Expect(chaos.Status.Profile.Active).To(BeFalse())
+	g.Expect(e.recovered).To(Equal(1))
+
+	changed, requeueAfter, err = Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(40*time.Second))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeTrue())
+	g.Expect(requeueAfter).To(Equal(30 * time.Second))
+	g.Expect(chaos.Status.Profile).To(Equal(&v1alpha1.ProfileStatus{Stage: 2, Active: true}))
+	g.Expect(e.applied).To(Equal([]string{"10"}))
+}
+
+func TestWithoutProfile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := newChaos(nil, time.Now())
+	chaos.Status.Profile = &v1alpha1.ProfileStatus{Stage: 1}
+
+	g.Expect(Start(chaos)).To(Succeed())
+	g.Expect(chaos.Status.Profile).To(BeNil())
+
+	changed, requeueAfter, err := Step(context.TODO(), &fakeEndpoint{}, ctrl.Request{}, chaos, time.Now())
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(changed).To(BeFalse())
+	g.Expect(requeueAfter).To(BeZero())
+}
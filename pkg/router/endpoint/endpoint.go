@@ -15,6 +15,7 @@ package endpoint
 
 import (
 	"context"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -36,3 +37,15 @@ type Endpoint interface {
 
 // NewEndpoint represents a function who creates a new reconciler
 type NewEndpoint func(ctx ctx.Context) Endpoint
+
+// Syncer is the Endpoint which syncs the running chaos with the cluster periodically
+type Syncer interface {
+	// Sync returns whether the chaos is injected again and the duration until the next sync
+	Sync(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) (bool, time.Duration, error)
+}
+
+// Planner is the Endpoint which can plan the changes on each pod without applying them
+type Planner interface {
+	// Plan returns the changes which Apply would make on the selected pods
+	Plan(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) ([]v1alpha1.PodPlan, error)
+}
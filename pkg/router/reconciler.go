@@ -16,19 +16,27 @@ package router
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/twophase"
+	"github.com/chaos-mesh/chaos-mesh/pkg/dryrun"
 	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
 	end "github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/tracker"
 	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
 )
 
@@ -82,6 +90,11 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (result ctrl.Result, err error)
 		return ctrl.Result{}, err
 	}
 
+	// the chaos in dry-run mode only plans the changes
+	controller = dryrun.New(controller, ctx)
+	// the continuous chaos is injected into the pods created or restarted later
+	controller = tracker.New(controller, ctx)
+
 	var reconciler reconcile.Reconciler
 	if scheduler == nil && duration == nil {
 		reconciler = common.NewReconciler(controller, ctx)
@@ -124,7 +137,63 @@ func NewReconciler(name string, object runtime.Object, mgr ctrl.Manager, endpoin
 
 // SetupWithManager registers controller to manager
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(r.Object.DeepCopyObject()).
-		Complete(r)
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(r.Object.DeepCopyObject())
+
+	installed, err := isInstalled(mgr, &v1alpha1.ChaosSchedulePolicy{})
+	if err != nil {
+		return err
+	}
+	if installed {
+		// the chaos is deferred or recovered as soon as the schedule policies are changed
+		builder = builder.Watches(&source.Kind{Type: &v1alpha1.ChaosSchedulePolicy{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.requestsForAllChaos),
+		})
+	} else {
+		r.Log.Info("the CRD of ChaosSchedulePolicy isn't installed, schedule policies are not watched")
+	}
+
+	return builder.Complete(r)
+}
+
+// isInstalled returns whether the CRD of the object is installed in the cluster
+func isInstalled(mgr ctrl.Manager, obj runtime.Object) (bool, error) {
+	gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+	if err != nil {
+		return false, err
+	}
+
+	_, err = mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// requestsForAllChaos returns the requests for all the chaos of the kind of reconciler
+func (r *Reconciler) requestsForAllChaos(_ handler.MapObject) []reconcile.Request {
+	for _, kind := range v1alpha1.AllKinds() {
+		if reflect.TypeOf(kind.Chaos) != reflect.TypeOf(r.Object) {
+			continue
+		}
+
+		list := kind.ChaosList.DeepCopyObject().(v1alpha1.ChaosList)
+		if err := r.Client.List(context.Background(), list); err != nil {
+			r.Log.Error(err, "unable to list chaos", "kind", r.Name)
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, chaos := range list.ListChaos() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: chaos.Namespace, Name: chaos.Name},
+			})
+		}
+		return requests
+	}
+	return nil
 }
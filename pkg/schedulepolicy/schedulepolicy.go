@@ -0,0 +1,252 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulepolicy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cronv3 "github.com/robfig/cron/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// EventChaosBlocked is the reason of the event recorded when the running chaos is
+// recovered because it's blocked by a schedule policy
+const EventChaosBlocked = "ChaosBlocked"
+
+const (
+	// ReasonInBlackout means that the chaos is in a blackout of the policy
+	ReasonInBlackout = "InBlackout"
+	// ReasonOutOfWindow means that the chaos is out of all the windows of the policy
+	ReasonOutOfWindow = "OutOfWindow"
+)
+
+// maxOverlappedPeriods is the maximum number of the overlapped periods of a cron window
+// which are merged to find the end of the window
+const maxOverlappedPeriods = 100
+
+// Block describes why the chaos isn't allowed to run
+type Block struct {
+	// Policy is the name of the policy which blocks the chaos
+	Policy string
+	// Reason is InBlackout or OutOfWindow
+	Reason string
+	// Message is the human readable description of the block
+	Message string
+}
+
+// Check evaluates the schedule policies applied to the chaos at now. It returns the block
+// (nil if the chaos is allowed to run) and the duration until the result may change, which
+// is zero if it never changes.
+func Check(ctx context.Context, c client.Client, chaos v1alpha1.InnerObject, now time.Time) (*Block, time.Duration, error) {
+	var policies v1alpha1.ChaosSchedulePolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The CRD of policy isn't installed, so there is no policy
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var applied []v1alpha1.ChaosSchedulePolicy
+	for _, policy := range policies.Items {
+		if matches(&policy.Spec.Selector, accessor.GetNamespace(), accessor.GetLabels()) {
+			applied = append(applied, policy)
+		}
+	}
+
+	return Evaluate(applied, now)
+}
+
+// Evaluate evaluates the policies at now, see Check for the results
+func Evaluate(policies []v1alpha1.ChaosSchedulePolicy, now time.Time) (*Block, time.Duration, error) {
+	var block *Block
+	var change time.Time
+
+	for _, policy := range policies {
+		policyBlock, policyChange, err := evaluate(&policy, now)
+		if err != nil {
+			return nil, 0, err
+		}
+		if block == nil {
+			block = policyBlock
+		}
+		change = earlier(change, policyChange)
+	}
+
+	if change.IsZero() {
+		return block, 0, nil
+	}
+	return block, change.Sub(now), nil
+}
+
+func evaluate(policy *v1alpha1.ChaosSchedulePolicy, now time.Time) (*Block, time.Time, error) {
+	location, err := policy.Spec.GetLocation()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("policy %s: fail to load time zone %s, %v", policy.Name, policy.Spec.TimeZone, err)
+	}
+	now = now.In(location)
+
+	var block *Block
+	var change time.Time
+
+	for _, blackout := range policy.Spec.Blackouts {
+		active, windowChange, err := at(&blackout, now)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("policy %s: %v", policy.Name, err)
+		}
+		if active && block == nil {
+			block = &Block{
+				Policy:  policy.Name,
+				Reason:  ReasonInBlackout,
+				Message: fmt.Sprintf("blocked by the blackout %s of schedule policy %s", blackout.Name, policy.Name),
+			}
+		}
+		change = earlier(change, windowChange)
+	}
+
+	inWindow := len(policy.Spec.Windows) == 0
+	for _, window := range policy.Spec.Windows {
+		active, windowChange, err := at(&window, now)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("policy %s: %v", policy.Name, err)
+		}
+		inWindow = inWindow || active
+		change = earlier(change, windowChange)
+	}
+	if !inWindow && block == nil {
+		block = &Block{
+			Policy:  policy.Name,
+			Reason:  ReasonOutOfWindow,
+			Message: fmt.Sprintf("blocked by schedule policy %s: out of all the windows", policy.Name),
+		}
+	}
+
+	return block, change, nil
+}
+
+// at returns whether the window is active at now, and the next time when it changes,
+// which is zero if it never changes
+func at(window *v1alpha1.TimeWindow, now time.Time) (bool, time.Time, error) {
+	if window.Cron == "" {
+		if window.Start == nil || window.End == nil {
+			return false, time.Time{}, fmt.Errorf("window %s should define cron or both start and end", window.Name)
+		}
+		if now.Before(window.Start.Time) {
+			return false, window.Start.Time, nil
+		}
+		if now.Before(window.End.Time) {
+			return true, window.End.Time, nil
+		}
+		return false, time.Time{}, nil
+	}
+
+	schedule, err := cronv3.ParseStandard(window.Cron)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("window %s: fail to parse cron %s, %v", window.Name, window.Cron, err)
+	}
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("window %s: fail to parse duration %s, %v", window.Name, window.Duration, err)
+	}
+
+	// The first period which isn't finished before now
+	start := schedule.Next(now.Add(-duration))
+	if start.IsZero() {
+		return false, time.Time{}, nil
+	}
+	if now.Before(start) {
+		return false, start, nil
+	}
+
+	// The window lasts until the end of the last period overlapped with it
+	end := start.Add(duration)
+	for i := 0; i < maxOverlappedPeriods; i++ {
+		next := schedule.Next(start)
+		if next.IsZero() || next.After(end) {
+			break
+		}
+		start = next
+		end = start.Add(duration)
+	}
+	return true, end, nil
+}
+
+func matches(selector *v1alpha1.PolicySelectorSpec, namespace string, labels map[string]string) bool {
+	if len(selector.Namespaces) > 0 {
+		found := false
+		for _, ns := range selector.Namespaces {
+			if ns == namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for key, value := range selector.LabelSelectors {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func earlier(a time.Time, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// SetBlocked records the block in the condition of chaos, or clears it if block is nil.
+// It returns whether the condition is changed.
+func SetBlocked(status *v1alpha1.ChaosStatus, block *Block) bool {
+	existing := status.GetCondition(v1alpha1.ConditionBlocked)
+	if block == nil {
+		if existing == nil || existing.Status == corev1.ConditionFalse {
+			return false
+		}
+		status.SetCondition(v1alpha1.ChaosCondition{
+			Type:   v1alpha1.ConditionBlocked,
+			Status: corev1.ConditionFalse,
+			Reason: "NotBlocked",
+		})
+		return true
+	}
+
+	if existing != nil && existing.Status == corev1.ConditionTrue && existing.Message == block.Message {
+		return false
+	}
+	status.SetCondition(v1alpha1.ChaosCondition{
+		Type:    v1alpha1.ConditionBlocked,
+		Status:  corev1.ConditionTrue,
+		Reason:  block.Reason,
+		Message: block.Message,
+	})
+	return true
+}
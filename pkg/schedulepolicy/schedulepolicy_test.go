@@ -0,0 +1,178 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schedulepolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func newPolicy(name string, spec v1alpha1.ChaosSchedulePolicySpec) v1alpha1.ChaosSchedulePolicy {
+	return v1alpha1.ChaosSchedulePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func TestWindow(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// 2020-08-03 is a Monday
+	now := time.Date(2020, 8, 3, 10, 0, 0, 0, time.UTC)
+	policies := []v1alpha1.ChaosSchedulePolicy{newPolicy("working-hours", v1alpha1.ChaosSchedulePolicySpec{
+		TimeZone: "UTC",
+		Windows:  []v1alpha1.TimeWindow{{Name: "weekdays", Cron: "0 9 * * 1-5", Duration: "8h"}},
+	})}
+
+	block, requeueAfter, err := Evaluate(policies, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).Should(BeNil())
+	g.Expect(requeueAfter).Should(Equal(7 * time.Hour))
+
+	block, requeueAfter, err = Evaluate(policies, now.Add(8*time.Hour))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).ShouldNot(BeNil())
+	g.Expect(block.Reason).Should(Equal(ReasonOutOfWindow))
+	g.Expect(block.Policy).Should(Equal("working-hours"))
+	g.Expect(requeueAfter).Should(Equal(15 * time.Hour))
+}
+
+func TestTimeZone(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	// 10:00 in UTC is 18:00 in Shanghai
+	now := time.Date(2020, 8, 3, 10, 0, 0, 0, time.UTC)
+	policies := []v1alpha1.ChaosSchedulePolicy{newPolicy("working-hours", v1alpha1.ChaosSchedulePolicySpec{
+		TimeZone: "Asia/Shanghai",
+		Windows:  []v1alpha1.TimeWindow{{Name: "weekdays", Cron: "0 9 * * 1-5", Duration: "8h"}},
+	})}
+
+	block, requeueAfter, err := Evaluate(policies, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).ShouldNot(BeNil())
+	g.Expect(requeueAfter).Should(Equal(15 * time.Hour))
+}
+
+func TestBlackout(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2020, 8, 3, 10, 0, 0, 0, time.UTC)
+	start := metav1.NewTime(now.Add(-time.Hour))
+	end := metav1.NewTime(now.Add(24 * time.Hour))
+	policies := []v1alpha1.ChaosSchedulePolicy{
+		newPolicy("working-hours", v1alpha1.ChaosSchedulePolicySpec{
+			TimeZone: "UTC",
+			Windows:  []v1alpha1.TimeWindow{{Name: "weekdays", Cron: "0 9 * * 1-5", Duration: "8h"}},
+		}),
+		newPolicy("freeze", v1alpha1.ChaosSchedulePolicySpec{
+			Blackouts: []v1alpha1.TimeWindow{{Name: "release", Start: &start, End: &end}},
+		}),
+	}
+
+	block, requeueAfter, err := Evaluate(policies, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).ShouldNot(BeNil())
+	g.Expect(block.Reason).Should(Equal(ReasonInBlackout))
+	g.Expect(block.Policy).Should(Equal("freeze"))
+	g.Expect(block.Message).Should(ContainSubstring("release"))
+	g.Expect(requeueAfter).Should(Equal(7 * time.Hour))
+
+	// the blackout is over
+	block, requeueAfter, err = Evaluate(policies[1:], now.Add(48*time.Hour))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).Should(BeNil())
+	g.Expect(requeueAfter).Should(BeZero())
+
+	// the blackout is coming
+	block, requeueAfter, err = Evaluate(policies[1:], now.Add(-2*time.Hour))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(block).Should(BeNil())
+	g.Expect(requeueAfter).Should(Equal(time.Hour))
+}
+
+func TestOverlappedPeriods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	window := &v1alpha1.TimeWindow{Cron: "0 * * * *", Duration: "90m"}
+	now := time.Date(2020, 8, 3, 10, 30, 0, 0, time.UTC)
+
+	active, change, err := at(window, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(active).Should(BeTrue())
+	// the periods keep overlapping, so the window lasts until the last one merged
+	g.Expect(change.After(now.Add(90 * time.Minute))).Should(BeTrue())
+}
+
+func TestCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(v1alpha1.AddToScheme(scheme.Scheme)).Should(Succeed())
+
+	now := time.Date(2020, 8, 3, 10, 0, 0, 0, time.UTC)
+	start := metav1.NewTime(now.Add(-time.Hour))
+	end := metav1.NewTime(now.Add(time.Hour))
+	policy := newPolicy("freeze", v1alpha1.ChaosSchedulePolicySpec{
+		Selector: v1alpha1.PolicySelectorSpec{
+			Namespaces:     []string{"production"},
+			LabelSelectors: map[string]string{"team": "storage"},
+		},
+		Blackouts: []v1alpha1.TimeWindow{{Name: "release", Start: &start, End: &end}},
+	})
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, &policy)
+
+	type TestCase struct {
+		name      string
+		namespace string
+		labels    map[string]string
+		blocked   bool
+	}
+	tcs := []TestCase{
+		{name: "selected", namespace: "production", labels: map[string]string{"team": "storage"}, blocked: true},
+		{name: "other namespace", namespace: "staging", labels: map[string]string{"team": "storage"}, blocked: false},
+		{name: "other labels", namespace: "production", labels: map[string]string{"team": "compute"}, blocked: false},
+	}
+
+	for _, tc := range tcs {
+		chaos := &v1alpha1.PodChaos{ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Name: "chaos", Labels: tc.labels}}
+		block, _, err := Check(context.TODO(), c, chaos, now)
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(block != nil).Should(Equal(tc.blocked), tc.name)
+	}
+}
+
+func TestSetBlocked(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	status := &v1alpha1.ChaosStatus{}
+	g.Expect(SetBlocked(status, nil)).Should(BeFalse())
+	g.Expect(status.Conditions).Should(BeEmpty())
+
+	block := &Block{Policy: "freeze", Reason: ReasonInBlackout, Message: "blocked"}
+	g.Expect(SetBlocked(status, block)).Should(BeTrue())
+	g.Expect(SetBlocked(status, block)).Should(BeFalse())
+	g.Expect(status.GetCondition(v1alpha1.ConditionBlocked).Status).Should(Equal(corev1.ConditionTrue))
+	g.Expect(status.GetCondition(v1alpha1.ConditionBlocked).Reason).Should(Equal(ReasonInBlackout))
+
+	g.Expect(SetBlocked(status, nil)).Should(BeTrue())
+	g.Expect(status.GetCondition(v1alpha1.ConditionBlocked).Status).Should(Equal(corev1.ConditionFalse))
+}
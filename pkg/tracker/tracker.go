@@ -0,0 +1,259 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+	"github.com/chaos-mesh/chaos-mesh/pkg/router/endpoint"
+	"github.com/chaos-mesh/chaos-mesh/pkg/utils"
+)
+
+// DefaultSyncPeriod is the period to check the pods of the running continuous chaos
+const DefaultSyncPeriod = 10 * time.Second
+
+// Endpoint keeps the continuous chaos injected into the pods matching its selector,
+// and refreshes the conditions of every chaos after it's applied or recovered
+type Endpoint struct {
+	endpoint.Endpoint
+	ctx.Context
+}
+
+var _ endpoint.Syncer = &Endpoint{}
+
+// New wraps the endpoint to track the pods of continuous chaos
+func New(e endpoint.Endpoint, ctx ctx.Context) *Endpoint {
+	return &Endpoint{
+		Endpoint: e,
+		Context:  ctx,
+	}
+}
+
+// Apply applies the chaos and records the uid and containers of the injected pods
+func (e *Endpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	defer updateConditions(chaos)
+
+	if !isContinuous(chaos) {
+		return e.Endpoint.Apply(ctx, req, chaos)
+	}
+
+	// the pods are selected again in a new run
+	status := chaos.GetStatus()
+	if status.Experiment.Phase != v1alpha1.ExperimentPhaseRunning {
+		status.Experiment.PodRecords = nil
+	}
+
+	if err := e.Endpoint.Apply(ctx, req, chaos); err != nil {
+		return err
+	}
+
+	return record(ctx, e.Client, chaos)
+}
+
+// Recover recovers the chaos and refreshes its conditions
+func (e *Endpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	defer updateConditions(chaos)
+
+	return e.Endpoint.Recover(ctx, req, chaos)
+}
+
+// Sync injects the continuous chaos again if any pod injected is recreated, restarted or deleted,
+// or there are pods created later within the budget of mode. It returns whether the chaos is
+// injected again and the duration until the next sync.
+func (e *Endpoint) Sync(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) (bool, time.Duration, error) {
+	if !isContinuous(chaos) {
+		return false, 0, nil
+	}
+
+	stale, err := isStale(ctx, e.Client, e.Reader, chaos.(v1alpha1.ContinuousObject))
+	if err != nil {
+		return false, 0, err
+	}
+	if !stale {
+		return false, DefaultSyncPeriod, nil
+	}
+
+	e.Log.Info("Injecting the continuous chaos into the changed pods")
+	if err := e.Apply(ctx, req, chaos); err != nil {
+		return false, 0, err
+	}
+
+	if err := pruneFinalizers(ctx, e.Client, chaos); err != nil {
+		return false, 0, err
+	}
+
+	return true, DefaultSyncPeriod, nil
+}
+
+// SelectPods selects the pods to inject the chaos. The continuous chaos and the chaos with
+// profile keep the pods injected before, and select the other pods within the budget of mode.
+func SelectPods(ctx context.Context, c client.Client, r client.Reader, chaos v1alpha1.ContinuousObject) ([]v1.Pod, error) {
+	if !chaos.IsContinuous() && !isProfiled(chaos) {
+		return utils.SelectAndFilterPods(ctx, c, r, chaos.GetSelectSpec())
+	}
+
+	return utils.SelectAndKeepPods(ctx, c, r, chaos.GetSelectSpec(), recordedPods(chaos))
+}
+
+func updateConditions(chaos v1alpha1.InnerObject) {
+	chaos.GetStatus().UpdateConditions(chaos.IsPaused())
+}
+
+// isContinuous returns whether the chaos is continuous, the chaos in dry-run mode isn't
+// tracked because it's never injected
+func isContinuous(chaos v1alpha1.InnerObject) bool {
+	continuous, ok := chaos.(v1alpha1.ContinuousObject)
+	return ok && continuous.IsContinuous() && !chaos.IsDryRun()
+}
+
+// isProfiled returns whether the chaos has a profile, whose stages are applied on the same pods
+func isProfiled(chaos v1alpha1.InnerObject) bool {
+	profiled, ok := chaos.(v1alpha1.ProfiledObject)
+	return ok && profiled.GetProfile() != nil
+}
+
+func recordedPods(chaos v1alpha1.InnerObject) []types.NamespacedName {
+	records := chaos.GetStatus().Experiment.PodRecords
+
+	keys := make([]types.NamespacedName, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, types.NamespacedName{
+			Namespace: record.Namespace,
+			Name:      record.Name,
+		})
+	}
+	return keys
+}
+
+// record records the uid of the pods in PodRecords, and the containers if the endpoint
+// doesn't record the affected ones
+func record(ctx context.Context, c client.Client, chaos v1alpha1.InnerObject) error {
+	records := chaos.GetStatus().Experiment.PodRecords
+	for i := range records {
+		var pod v1.Pod
+		err := c.Get(ctx, types.NamespacedName{
+			Namespace: records[i].Namespace,
+			Name:      records[i].Name,
+		}, &pod)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		records[i].UID = pod.UID
+		if len(records[i].ContainerIDs) == 0 {
+			records[i].ContainerIDs = utils.ContainerIDs(&pod)
+		}
+	}
+
+	return nil
+}
+
+// isStale returns whether the pods injected are changed, or there are new pods to inject
+func isStale(ctx context.Context, c client.Client, r client.Reader, chaos v1alpha1.ContinuousObject) (bool, error) {
+	recorded := make(map[types.NamespacedName]bool)
+	for _, record := range chaos.GetStatus().Experiment.PodRecords {
+		key := types.NamespacedName{
+			Namespace: record.Namespace,
+			Name:      record.Name,
+		}
+		recorded[key] = true
+
+		var pod v1.Pod
+		err := c.Get(ctx, key, &pod)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+
+		if record.UID != "" && (pod.UID != record.UID || isRestarted(&pod, record.ContainerIDs)) {
+			return true, nil
+		}
+	}
+
+	pods, err := SelectPods(ctx, c, r, chaos)
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		if !recorded[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// isRestarted returns whether any of the containers is restarted, the id of a container is
+// changed after it's restarted
+func isRestarted(pod *v1.Pod, ids []string) bool {
+	current := make(map[string]bool)
+	for _, id := range utils.ContainerIDs(pod) {
+		current[id] = true
+	}
+
+	for _, id := range ids {
+		if !current[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneFinalizers removes the finalizers of the pods which have been deleted
+func pruneFinalizers(ctx context.Context, c client.Client, chaos v1alpha1.InnerObject) error {
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return err
+	}
+
+	finalizers := accessor.GetFinalizers()
+	for _, key := range accessor.GetFinalizers() {
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil || ns == "" {
+			continue
+		}
+
+		var pod v1.Pod
+		err = c.Get(ctx, types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		}, &pod)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				finalizers = utils.RemoveFromFinalizer(finalizers, key)
+				continue
+			}
+			return err
+		}
+	}
+	accessor.SetFinalizers(finalizers)
+
+	return nil
+}
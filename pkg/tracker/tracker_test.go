@@ -0,0 +1,301 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/profile"
+	ctx "github.com/chaos-mesh/chaos-mesh/pkg/router/context"
+)
+
+type fakeEndpoint struct {
+	ctx.Context
+	applied int
+	// failed is the name of pod which fails to be injected
+	failed string
+}
+
+func (e *fakeEndpoint) Object() v1alpha1.InnerObject {
+	return &v1alpha1.NetworkChaos{}
+}
+
+func (e *fakeEndpoint) Apply(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	networkchaos := chaos.(*v1alpha1.NetworkChaos)
+	pods, err := SelectPods(ctx, e.Client, e.Reader, networkchaos)
+	if err != nil {
+		return err
+	}
+
+	e.applied++
+	networkchaos.Status.Experiment.PodRecords = nil
+	var result error
+	for _, pod := range pods {
+		var err error
+		if pod.Name == e.failed {
+			err = fmt.Errorf("failed to inject %s", pod.Name)
+			result = err
+		}
+
+		networkchaos.Finalizers = append(networkchaos.Finalizers, pod.Namespace+"/"+pod.Name)
+		ps := v1alpha1.PodStatus{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}
+		ps.SetInjected(err)
+		networkchaos.Status.Experiment.PodRecords = append(networkchaos.Status.Experiment.PodRecords, ps)
+	}
+	return result
+}
+
+func (e *fakeEndpoint) Recover(ctx context.Context, req ctrl.Request, chaos v1alpha1.InnerObject) error {
+	status := chaos.GetStatus()
+	for _, record := range status.Experiment.PodRecords {
+		status.Experiment.SetPodRecovered(record.Namespace, record.Name, nil)
+	}
+	return nil
+}
+
+func newPod(name string, containerID string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"app": "web"},
+			UID:       types.UID(name + "-uid"),
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", ContainerID: containerID},
+			},
+		},
+	}
+}
+
+func recordedNames(chaos *v1alpha1.NetworkChaos) []string {
+	names := []string{}
+	for _, record := range chaos.Status.Experiment.PodRecords {
+		names = append(names, record.Name)
+	}
+	return names
+}
+
+func TestSync(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, newPod("p0", "docker://0"), newPod("p1", "docker://1"))
+	inner := &fakeEndpoint{Context: ctx.Context{Client: c, Reader: c, Log: ctrl.Log}}
+	e := New(inner, inner.Context)
+
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.PartitionAction,
+			Mode:   v1alpha1.AllPodMode,
+			Selector: v1alpha1.SelectorSpec{
+				LabelSelectors: map[string]string{"app": "web"},
+			},
+			Continuous: true,
+		},
+	}
+
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	chaos.Status.Experiment.Phase = v1alpha1.ExperimentPhaseRunning
+	g.Expect(recordedNames(chaos)).To(ConsistOf("p0", "p1"))
+	g.Expect(chaos.Status.Experiment.PodRecords[0].UID).ToNot(BeEmpty())
+	g.Expect(chaos.Status.Experiment.PodRecords[0].ContainerIDs).To(HaveLen(1))
+
+	sync := func() bool {
+		changed, requeueAfter, err := e.Sync(context.TODO(), ctrl.Request{}, chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(requeueAfter).To(Equal(DefaultSyncPeriod))
+		return changed
+	}
+
+	t.Run("nothing changed", func(t *testing.T) {
+		g.Expect(sync()).To(BeFalse())
+		g.Expect(inner.applied).To(Equal(1))
+	})
+
+	t.Run("new pod", func(t *testing.T) {
+		g.Expect(c.Create(context.TODO(), newPod("p2", "docker://2"))).To(Succeed())
+		g.Expect(sync()).To(BeTrue())
+		g.Expect(recordedNames(chaos)).To(ConsistOf("p0", "p1", "p2"))
+		g.Expect(sync()).To(BeFalse())
+	})
+
+	t.Run("restarted container", func(t *testing.T) {
+		g.Expect(c.Update(context.TODO(), newPod("p0", "docker://restarted"))).To(Succeed())
+		applied := inner.applied
+		g.Expect(sync()).To(BeTrue())
+		g.Expect(inner.applied).To(Equal(applied + 1))
+		g.Expect(sync()).To(BeFalse())
+	})
+
+	t.Run("deleted pod", func(t *testing.T) {
+		g.Expect(c.Delete(context.TODO(), newPod("p1", ""))).To(Succeed())
+		g.Expect(sync()).To(BeTrue())
+		g.Expect(recordedNames(chaos)).To(ConsistOf("p0", "p2"))
+		g.Expect(chaos.Finalizers).ToNot(ContainElement(fmt.Sprintf("%s/p1", metav1.NamespaceDefault)))
+	})
+}
+
+func TestSelectPodsWithBudget(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects := []runtime.Object{}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, newPod(fmt.Sprintf("p%d", i), ""))
+	}
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Mode:  v1alpha1.FixedPodMode,
+			Value: "2",
+			Selector: v1alpha1.SelectorSpec{
+				LabelSelectors: map[string]string{"app": "web"},
+			},
+			Continuous: true,
+		},
+	}
+	chaos.Status.Experiment.PodRecords = []v1alpha1.PodStatus{
+		{Namespace: metav1.NamespaceDefault, Name: "p3"},
+		{Namespace: metav1.NamespaceDefault, Name: "p4"},
+	}
+
+	var r client.Reader = c
+	for i := 0; i < 10; i++ {
+		pods, err := SelectPods(context.TODO(), c, r, chaos)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(pods).To(HaveLen(2))
+		g.Expect([]string{pods[0].Name, pods[1].Name}).To(ConsistOf("p3", "p4"))
+	}
+}
+
+func TestProfileKeepsPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects := []runtime.Object{}
+	for i := 0; i < 5; i++ {
+		objects = append(objects, newPod(fmt.Sprintf("p%d", i), ""))
+	}
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+	inner := &fakeEndpoint{Context: ctx.Context{Client: c, Reader: c, Log: ctrl.Log}}
+	e := New(inner, inner.Context)
+
+	start := time.Now()
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.LossAction,
+			Mode:   v1alpha1.OnePodMode,
+			Selector: v1alpha1.SelectorSpec{
+				LabelSelectors: map[string]string{"app": "web"},
+			},
+			TcParameter: v1alpha1.TcParameter{
+				Loss: &v1alpha1.LossSpec{Loss: "10", Correlation: "0"},
+			},
+			Profile: &v1alpha1.ProfileSpec{
+				Type:     v1alpha1.StepProfileType,
+				Interval: "1m",
+				Values:   []string{"5", "20", "50", "80"},
+			},
+		},
+	}
+	chaos.Status.Experiment.StartTime = &metav1.Time{Time: start}
+
+	g.Expect(profile.Start(chaos)).To(Succeed())
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	chaos.Status.Experiment.Phase = v1alpha1.ExperimentPhaseRunning
+	g.Expect(chaos.Status.Experiment.PodRecords).To(HaveLen(1))
+	selected := recordedNames(chaos)
+
+	for i := 1; i <= 3; i++ {
+		changed, _, err := profile.Step(context.TODO(), e, ctrl.Request{}, chaos, start.Add(time.Duration(i)*time.Minute))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeTrue())
+		g.Expect(recordedNames(chaos)).To(Equal(selected))
+	}
+	g.Expect(inner.applied).To(Equal(4))
+
+	// the pods are selected again in a new run
+	g.Expect(profile.Start(chaos)).To(Succeed())
+	g.Expect(chaos.Status.Experiment.PodRecords).To(BeEmpty())
+}
+
+func TestConditions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, newPod("p0", "docker://0"), newPod("p1", "docker://1"))
+	inner := &fakeEndpoint{Context: ctx.Context{Client: c, Reader: c, Log: ctrl.Log}, failed: "p1"}
+	e := New(inner, inner.Context)
+
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action: v1alpha1.PartitionAction,
+			Mode:   v1alpha1.AllPodMode,
+			Selector: v1alpha1.SelectorSpec{
+				LabelSelectors: map[string]string{"app": "web"},
+			},
+		},
+	}
+	conditionStatus := func(conditionType v1alpha1.ChaosConditionType) v1.ConditionStatus {
+		condition := chaos.Status.GetCondition(conditionType)
+		g.Expect(condition).ToNot(BeNil())
+		return condition.Status
+	}
+
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).ToNot(Succeed())
+	g.Expect(conditionStatus(v1alpha1.ConditionSelected)).To(Equal(v1.ConditionTrue))
+	g.Expect(conditionStatus(v1alpha1.ConditionAllInjected)).To(Equal(v1.ConditionFalse))
+	g.Expect(chaos.Status.GetCondition(v1alpha1.ConditionAllInjected).Message).To(ContainSubstring("failed to inject p1"))
+	g.Expect(conditionStatus(v1alpha1.ConditionPaused)).To(Equal(v1.ConditionFalse))
+
+	for _, record := range chaos.Status.Experiment.PodRecords {
+		g.Expect(record.InjectedTime).ToNot(BeNil())
+		if record.Name == "p1" {
+			g.Expect(record.Phase).To(Equal(v1alpha1.PodInjectionPhaseFailed))
+			g.Expect(record.LastError).ToNot(BeEmpty())
+		} else {
+			g.Expect(record.Phase).To(Equal(v1alpha1.PodInjectionPhaseInjected))
+		}
+	}
+
+	inner.failed = ""
+	g.Expect(e.Apply(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(conditionStatus(v1alpha1.ConditionAllInjected)).To(Equal(v1.ConditionTrue))
+	g.Expect(conditionStatus(v1alpha1.ConditionAllRecovered)).To(Equal(v1.ConditionFalse))
+
+	chaos.Annotations = map[string]string{v1alpha1.PauseAnnotationKey: "true"}
+	g.Expect(e.Recover(context.TODO(), ctrl.Request{}, chaos)).To(Succeed())
+	g.Expect(conditionStatus(v1alpha1.ConditionAllInjected)).To(Equal(v1.ConditionFalse))
+	g.Expect(conditionStatus(v1alpha1.ConditionAllRecovered)).To(Equal(v1.ConditionTrue))
+	g.Expect(conditionStatus(v1alpha1.ConditionPaused)).To(Equal(v1.ConditionTrue))
+	g.Expect(chaos.Status.Experiment.PodRecords[0].RecoveredTime).ToNot(BeNil())
+}
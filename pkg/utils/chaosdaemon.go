@@ -62,7 +62,8 @@ func NewChaosDaemonClient(ctx context.Context, c client.Client, pod *v1.Pod, por
 
 // MergeNetem merges two Netem protos into a new one.
 // REMEMBER to assign the return value, i.e. merged = utils.MergeNetm(merged, em)
-// For each field it takes the bigger value of the two.
+// For each field it takes the bigger value of the two, except the distribution and the overheads
+// of rate, which can be negative, the non-empty ones of a are preferred.
 // Its main use case is merging netem of different types, e.g. delay and loss.
 // It returns nil if both inputs are nil.
 // Otherwise it returns a new Netem with merged values.
@@ -79,22 +80,53 @@ func MergeNetem(a, b *chaosdaemon.Netem) *chaosdaemon.Netem {
 		b = &chaosdaemon.Netem{}
 	}
 	return &chaosdaemon.Netem{
-		Time:          maxu32(a.GetTime(), b.GetTime()),
-		Jitter:        maxu32(a.GetJitter(), b.GetJitter()),
-		DelayCorr:     maxf32(a.GetDelayCorr(), b.GetDelayCorr()),
-		Limit:         maxu32(a.GetLimit(), b.GetLimit()),
-		Loss:          maxf32(a.GetLoss(), b.GetLoss()),
-		LossCorr:      maxf32(a.GetLossCorr(), b.GetLossCorr()),
-		Gap:           maxu32(a.GetGap(), b.GetGap()),
-		Duplicate:     maxf32(a.GetDuplicate(), b.GetDuplicate()),
-		DuplicateCorr: maxf32(a.GetDuplicateCorr(), b.GetDuplicateCorr()),
-		Reorder:       maxf32(a.GetReorder(), b.GetReorder()),
-		ReorderCorr:   maxf32(a.GetReorderCorr(), b.GetReorderCorr()),
-		Corrupt:       maxf32(a.GetCorrupt(), b.GetCorrupt()),
-		CorruptCorr:   maxf32(a.GetCorruptCorr(), b.GetCorruptCorr()),
+		Time:           maxu32(a.GetTime(), b.GetTime()),
+		Jitter:         maxu32(a.GetJitter(), b.GetJitter()),
+		DelayCorr:      maxf32(a.GetDelayCorr(), b.GetDelayCorr()),
+		Limit:          maxu32(a.GetLimit(), b.GetLimit()),
+		Loss:           maxf32(a.GetLoss(), b.GetLoss()),
+		LossCorr:       maxf32(a.GetLossCorr(), b.GetLossCorr()),
+		Gap:            maxu32(a.GetGap(), b.GetGap()),
+		Duplicate:      maxf32(a.GetDuplicate(), b.GetDuplicate()),
+		DuplicateCorr:  maxf32(a.GetDuplicateCorr(), b.GetDuplicateCorr()),
+		Reorder:        maxf32(a.GetReorder(), b.GetReorder()),
+		ReorderCorr:    maxf32(a.GetReorderCorr(), b.GetReorderCorr()),
+		Corrupt:        maxf32(a.GetCorrupt(), b.GetCorrupt()),
+		CorruptCorr:    maxf32(a.GetCorruptCorr(), b.GetCorruptCorr()),
+		Distribution:   nonEmptyString(a.GetDistribution(), b.GetDistribution()),
+		Rate:           minNonZerou64(a.GetRate(), b.GetRate()),
+		PacketOverhead: nonZeroi32(a.GetPacketOverhead(), b.GetPacketOverhead()),
+		CellSize:       maxu32(a.GetCellSize(), b.GetCellSize()),
+		CellOverhead:   nonZeroi32(a.GetCellOverhead(), b.GetCellOverhead()),
+		SlotMin:        maxu32(a.GetSlotMin(), b.GetSlotMin()),
+		SlotMax:        maxu32(a.GetSlotMax(), b.GetSlotMax()),
+		SlotPackets:    maxu32(a.GetSlotPackets(), b.GetSlotPackets()),
+		SlotBytes:      maxu32(a.GetSlotBytes(), b.GetSlotBytes()),
 	}
 }
 
+func nonEmptyString(a, b string) string {
+	if len(a) > 0 {
+		return a
+	}
+	return b
+}
+
+// minNonZerou64 returns the smaller one of the non-zero values, as the zero rate means unlimited
+func minNonZerou64(a, b uint64) uint64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+func nonZeroi32(a, b int32) int32 {
+	if a != 0 {
+		return a
+	}
+	return b
+}
+
 func maxu32(a, b uint32) uint32 {
 	if a > b {
 		return a
@@ -42,6 +42,18 @@ func TestMergeNetem(t *testing.T) {
 			&chaosdaemonpb.Netem{DelayCorr: 90},
 			&chaosdaemonpb.Netem{Loss: 25, DelayCorr: 100.2},
 		},
+		{
+			// the distribution of the first one is preferred
+			&chaosdaemonpb.Netem{Time: 1000, Jitter: 100, Distribution: "normal", Rate: 1000, PacketOverhead: -4},
+			&chaosdaemonpb.Netem{Distribution: "pareto", SlotMin: 800, SlotMax: 1600},
+			&chaosdaemonpb.Netem{Time: 1000, Jitter: 100, Distribution: "normal", Rate: 1000, PacketOverhead: -4, SlotMin: 800, SlotMax: 1600},
+		},
+		{
+			// pick the smaller rate
+			&chaosdaemonpb.Netem{Rate: 1000},
+			&chaosdaemonpb.Netem{Rate: 500},
+			&chaosdaemonpb.Netem{Rate: 500},
+		},
 	}
 
 	for _, tc := range cases {
@@ -29,4 +29,7 @@ const (
 
 	// The chaos just completed
 	EventChaosRecovered string = "ChaosRecovered"
+
+	// The chaos just planned the changes in dry-run mode
+	EventChaosPlanned string = "ChaosPlanned"
 )
@@ -13,6 +13,24 @@
 
 package utils
 
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sync/errgroup"
+	v1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+)
+
 func RemoveFromFinalizer(finalizers []string, key string) []string {
 	slice := make([]string, 0, len(finalizers))
 	for _, f := range finalizers {
@@ -38,3 +56,81 @@ func InsertFinalizer(finalizers []string, finalizer string) []string {
 	}
 	return append(finalizers, finalizer)
 }
+
+// ApplyAllPods inserts the finalizers of the pods into the chaos, and applies the chaos
+// on the pods concurrently, the error of each pod is set in errs
+func ApplyAllPods(chaos metav1.Object, pods []v1.Pod, errs []error, apply func(pod *v1.Pod) error) error {
+	g := errgroup.Group{}
+
+	for index := range pods {
+		index := index
+		pod := &pods[index]
+
+		key, err := cache.MetaNamespaceKeyFunc(pod)
+		if err != nil {
+			return err
+		}
+		chaos.SetFinalizers(InsertFinalizer(chaos.GetFinalizers(), key))
+
+		g.Go(func() error {
+			errs[index] = apply(pod)
+			return errs[index]
+		})
+	}
+	return g.Wait()
+}
+
+// CleanFinalizersAndRecover recovers the pods in the finalizers of the chaos, the finalizers
+// of the pods which are recovered or not found are removed
+func CleanFinalizersAndRecover(ctx context.Context, c client.Client, log logr.Logger, chaos v1alpha1.InnerObject,
+	recover func(pod *v1.Pod) error) error {
+	accessor, err := meta.Accessor(chaos)
+	if err != nil {
+		return err
+	}
+	status := chaos.GetStatus()
+
+	var result error
+	for _, key := range accessor.GetFinalizers() {
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		var pod v1.Pod
+		err = c.Get(ctx, types.NamespacedName{
+			Namespace: ns,
+			Name:      name,
+		}, &pod)
+
+		if err != nil {
+			if !k8serror.IsNotFound(err) {
+				result = multierror.Append(result, err)
+				continue
+			}
+
+			log.Info("Pod not found", "namespace", ns, "name", name)
+			status.Experiment.SetPodRecovered(ns, name, nil)
+			accessor.SetFinalizers(RemoveFromFinalizer(accessor.GetFinalizers(), key))
+			continue
+		}
+
+		err = recover(&pod)
+		status.Experiment.SetPodRecovered(ns, name, err)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		accessor.SetFinalizers(RemoveFromFinalizer(accessor.GetFinalizers(), key))
+	}
+
+	if accessor.GetAnnotations()[common.AnnotationCleanFinalizer] == common.AnnotationCleanFinalizerForced {
+		log.Info("Force cleanup all finalizers", "chaos", chaos)
+		accessor.SetFinalizers(accessor.GetFinalizers()[:0])
+		return nil
+	}
+
+	return result
+}
@@ -44,7 +44,12 @@ func CreateGrpcConnection(ctx context.Context, c client.Client, pod *v1.Pod, por
 		return nil, err
 	}
 
-	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", node.Status.Addresses[0].Address, port),
+	return CreateGrpcConnectionWithAddress(fmt.Sprintf("%s:%d", node.Status.Addresses[0].Address, port))
+}
+
+// CreateGrpcConnectionWithAddress create a grpc connection with given address
+func CreateGrpcConnectionWithAddress(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(address,
 		grpc.WithInsecure(),
 		grpc.WithUnaryInterceptor(TimeoutClientInterceptor))
 	if err != nil {
@@ -0,0 +1,56 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ContainerIDs returns the ids of the containers with the names in the pod,
+// or the ids of all the containers if no name is given
+func ContainerIDs(pod *v1.Pod, names ...string) []string {
+	expected := make(map[string]bool, len(names))
+	for _, name := range names {
+		expected[name] = true
+	}
+
+	ids := make([]string, 0, len(pod.Status.ContainerStatuses))
+	for _, container := range pod.Status.ContainerStatuses {
+		if len(expected) == 0 || expected[container.Name] {
+			ids = append(ids, container.ContainerID)
+		}
+	}
+	return ids
+}
+
+// TargetContainer returns the id of the container with the name in the pod, it's the
+// first container of pod if the name isn't specified
+func TargetContainer(pod *v1.Pod, name *string) (string, error) {
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return "", fmt.Errorf("%s %s can't get the state of container", pod.Namespace, pod.Name)
+	}
+	if name == nil || len(strings.TrimSpace(*name)) == 0 {
+		return pod.Status.ContainerStatuses[0].ContainerID, nil
+	}
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name == *name {
+			return container.ContainerID, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find container with name %s", *name)
+}
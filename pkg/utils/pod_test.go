@@ -0,0 +1,66 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestContainerIDs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", ContainerID: "docker://web"},
+				{Name: "sidecar", ContainerID: "docker://sidecar"},
+			},
+		},
+	}
+
+	g.Expect(ContainerIDs(pod)).To(Equal([]string{"docker://web", "docker://sidecar"}))
+	g.Expect(ContainerIDs(pod, "sidecar")).To(Equal([]string{"docker://sidecar"}))
+	g.Expect(ContainerIDs(pod, "unknown")).To(BeEmpty())
+}
+
+func TestTargetContainer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pod := &v1.Pod{
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", ContainerID: "docker://web"},
+				{Name: "sidecar", ContainerID: "docker://sidecar"},
+			},
+		},
+	}
+
+	empty, sidecar, unknown := " ", "sidecar", "unknown"
+	target, err := TargetContainer(pod, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(target).To(Equal("docker://web"))
+	target, err = TargetContainer(pod, &empty)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(target).To(Equal("docker://web"))
+	target, err = TargetContainer(pod, &sidecar)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(target).To(Equal("docker://sidecar"))
+	_, err = TargetContainer(pod, &unknown)
+	g.Expect(err).To(HaveOccurred())
+	_, err = TargetContainer(&v1.Pod{}, nil)
+	g.Expect(err).To(HaveOccurred())
+}
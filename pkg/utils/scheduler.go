@@ -22,12 +22,28 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 )
 
+// NextTime returns the next time of the cron rule after now, which is evaluated in the time zone of scheduler
 func NextTime(spec v1alpha1.SchedulerSpec, now time.Time) (*time.Time, error) {
 	scheduler, err := cron.ParseStandard(spec.Cron)
 	if err != nil {
 		return nil, fmt.Errorf("fail to parse runner rule %s, %v", spec.Cron, err)
 	}
 
-	next := scheduler.Next(now)
+	location, err := spec.GetLocation()
+	if err != nil {
+		return nil, fmt.Errorf("fail to load time zone %s, %v", spec.TimeZone, err)
+	}
+
+	next := scheduler.Next(now.In(location))
 	return &next, nil
 }
+
+// NextTimeNotBefore returns the first time of the cron rule after now which isn't before notBefore
+func NextTimeNotBefore(spec v1alpha1.SchedulerSpec, now time.Time, notBefore time.Time) (*time.Time, error) {
+	next, err := NextTime(spec, now)
+	// the zero time is returned if the rule is never satisfied
+	for err == nil && !next.IsZero() && next.Before(notBefore) {
+		next, err = NextTime(spec, *next)
+	}
+	return next, err
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestNextTime(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2020, 8, 1, 10, 30, 0, 0, time.UTC)
+
+	next, err := NextTime(v1alpha1.SchedulerSpec{Cron: "0 12 * * *", TimeZone: "UTC"}, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(next.Equal(time.Date(2020, 8, 1, 12, 0, 0, 0, time.UTC))).To(BeTrue())
+
+	// 12:00 in Shanghai is 04:00 in UTC, which has passed
+	next, err = NextTime(v1alpha1.SchedulerSpec{Cron: "0 12 * * *", TimeZone: "Asia/Shanghai"}, now)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(next.Equal(time.Date(2020, 8, 2, 4, 0, 0, 0, time.UTC))).To(BeTrue())
+
+	_, err = NextTime(v1alpha1.SchedulerSpec{Cron: "0 12 * * *", TimeZone: "Mars/Olympus"}, now)
+	g.Expect(err).Should(HaveOccurred())
+
+	_, err = NextTime(v1alpha1.SchedulerSpec{Cron: "xx"}, now)
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestNextTimeNotBefore(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	now := time.Date(2020, 8, 1, 10, 30, 0, 0, time.UTC)
+	spec := v1alpha1.SchedulerSpec{Cron: "0 * * * *", TimeZone: "UTC"}
+
+	next, err := NextTimeNotBefore(spec, now, time.Time{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(next.Equal(time.Date(2020, 8, 1, 11, 0, 0, 0, time.UTC))).To(BeTrue())
+
+	next, err = NextTimeNotBefore(spec, now, now.Add(150*time.Minute))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(next.Equal(time.Date(2020, 8, 1, 13, 0, 0, 0, time.UTC))).To(BeTrue())
+}
@@ -38,11 +38,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
-type SelectSpec interface {
-	GetSelector() v1alpha1.SelectorSpec
-	GetMode() v1alpha1.PodMode
-	GetValue() string
-}
+// SelectSpec defines the selector and mode to select the pods, which is shared with SelectableObject
+type SelectSpec = v1alpha1.SelectSpec
 
 // SelectAndFilterPods returns the list of pods that filtered by selector and PodMode
 func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader, spec SelectSpec) ([]v1.Pod, error) {
@@ -75,6 +72,104 @@ func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader,
 	return filteredPod, nil
 }
 
+// SelectAndKeepPods returns the list of pods like SelectAndFilterPods, but the running pods in
+// kept are always selected, and the other running pods are selected within the budget of PodMode
+func SelectAndKeepPods(ctx context.Context, c client.Client, r client.Reader, spec SelectSpec, kept []types.NamespacedName) ([]v1.Pod, error) {
+	if len(kept) == 0 {
+		return SelectAndFilterPods(ctx, c, r, spec)
+	}
+
+	pods, err := SelectPods(ctx, c, r, spec.GetSelector())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pods) == 0 {
+		err = errors.New("no pod is selected")
+		return nil, err
+	}
+
+	// the kept pods may be selected by other selectors, e.g. the target of network partition
+	keys := make(map[types.NamespacedName]bool, len(kept))
+	for _, key := range kept {
+		keys[key] = true
+	}
+	selected := 0
+	for _, pod := range pods {
+		if keys[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] {
+			selected++
+		}
+	}
+
+	budget, err := budgetOfMode(len(pods), selected, spec.GetMode(), spec.GetValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return keepPods(pods, kept, budget), nil
+}
+
+// budgetOfMode returns the number of pods to select among the total pods, the number of
+// pods selected before is kept in random-max-percent mode
+func budgetOfMode(total int, selected int, mode v1alpha1.PodMode, value string) (int, error) {
+	switch mode {
+	case v1alpha1.OnePodMode:
+		return 1, nil
+	case v1alpha1.AllPodMode:
+		return total, nil
+	case v1alpha1.FixedPodMode:
+		num, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, err
+		}
+		if total < num {
+			num = total
+		}
+		return num, nil
+	case v1alpha1.FixedPercentPodMode, v1alpha1.RandomMaxPercentPodMode:
+		percentage, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, err
+		}
+		num := int(math.Floor(float64(total) * float64(percentage) / 100))
+		if mode == v1alpha1.RandomMaxPercentPodMode && selected < num {
+			num = selected
+		}
+		return num, nil
+	default:
+		return 0, fmt.Errorf("mode %s not supported", mode)
+	}
+}
+
+// keepPods selects the running pods in kept first, and then selects the other running pods randomly
+func keepPods(pods []v1.Pod, kept []types.NamespacedName, budget int) []v1.Pod {
+	keys := make(map[types.NamespacedName]bool, len(kept))
+	for _, key := range kept {
+		keys[key] = true
+	}
+
+	var selected, rest []v1.Pod
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		if keys[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] {
+			selected = append(selected, pod)
+		} else {
+			rest = append(rest, pod)
+		}
+	}
+
+	if len(selected) > budget {
+		selected = selected[:budget]
+	}
+	if num := budget - len(selected); num > 0 && len(rest) > 0 {
+		selected = append(selected, getFixedSubListFromPodList(rest, num)...)
+	}
+
+	return selected
+}
+
 // SelectPods returns the list of pods that are available for pod chaos action.
 // It returns all pods that match the configured label, annotation and namespace selectors.
 // If pods are specifically specified by `selector.Pods`, it just returns the selector.Pods.
@@ -296,6 +391,53 @@ func filterPodByNode(pods []v1.Pod, nodes []v1.Node) []v1.Pod {
 	return filteredList
 }
 
+// GroupPodsByNodeLabel groups the pods by the value of label on their nodes,
+// the pods whose nodes don't have the label are grouped into the empty value
+func GroupPodsByNodeLabel(ctx context.Context, c client.Client, pods []v1.Pod, key string) (map[string][]v1.Pod, error) {
+	var nodes []v1.Node
+	visited := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || visited[pod.Spec.NodeName] {
+			continue
+		}
+		visited[pod.Spec.NodeName] = true
+
+		var node v1.Node
+		err := c.Get(ctx, types.NamespacedName{
+			Name: pod.Spec.NodeName,
+		}, &node)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	return groupPodsByNodeLabel(pods, nodes, key), nil
+}
+
+func groupPodsByNodeLabel(pods []v1.Pod, nodes []v1.Node, key string) map[string][]v1.Pod {
+	groups := make(map[string][]v1.Pod)
+	grouped := make(map[string]bool)
+	for _, node := range nodes {
+		value := node.Labels[key]
+		for _, pod := range filterPodByNode(pods, []v1.Node{node}) {
+			groups[value] = append(groups[value], pod)
+			grouped[pod.Namespace+"/"+pod.Name] = true
+		}
+	}
+
+	for _, pod := range pods {
+		if !grouped[pod.Namespace+"/"+pod.Name] {
+			groups[""] = append(groups[""], pod)
+		}
+	}
+
+	return groups
+}
+
 // filterPodsByMode filters pods by mode from pod list
 func filterPodsByMode(pods []v1.Pod, mode v1alpha1.PodMode, value string) ([]v1.Pod, error) {
 	if len(pods) == 0 {
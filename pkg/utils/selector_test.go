@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -617,6 +618,85 @@ func TestFilterPodByNode(t *testing.T) {
 
 }
 
+func TestGroupPodsByNodeLabel(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := []v1.Pod{
+		newPod("p1", v1.PodRunning, "n1", nil, nil, "node1"),
+		newPod("p2", v1.PodRunning, "n2", nil, nil, "node2"),
+		newPod("p3", v1.PodRunning, "n2", nil, nil, "node3"),
+		newPod("p4", v1.PodRunning, "n4", nil, nil, "node4"),
+		newPod("p5", v1.PodPending, "n4", nil, nil, ""),
+	}
+
+	objects := []runtime.Object{}
+	for _, node := range []v1.Node{
+		newNode("node1", map[string]string{"zone": "az1"}),
+		newNode("node2", map[string]string{"zone": "az2"}),
+		newNode("node3", map[string]string{"zone": "az1"}),
+		newNode("node4", map[string]string{"disktype": "ssd"}),
+	} {
+		node := node
+		objects = append(objects, &node)
+	}
+	c := fake.NewFakeClient(objects...)
+
+	groups, err := GroupPodsByNodeLabel(context.TODO(), c, pods, "zone")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(groups).To(HaveLen(3))
+	g.Expect(groups["az1"]).To(Equal([]v1.Pod{pods[0], pods[2]}))
+	g.Expect(groups["az2"]).To(Equal([]v1.Pod{pods[1]}))
+	g.Expect(groups[""]).To(Equal([]v1.Pod{pods[3], pods[4]}))
+}
+
+func TestKeepPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := []v1.Pod{
+		newPod("p1", v1.PodRunning, "n1", nil, nil, "node1"),
+		newPod("p2", v1.PodRunning, "n1", nil, nil, "node1"),
+		newPod("p3", v1.PodRunning, "n1", nil, nil, "node2"),
+		newPod("p4", v1.PodPending, "n1", nil, nil, "node2"),
+	}
+	kept := []types.NamespacedName{{Namespace: "n1", Name: "p2"}, {Namespace: "n1", Name: "p4"}}
+
+	g.Expect(keepPods(pods, kept, 1)).To(Equal([]v1.Pod{pods[1]}))
+	g.Expect(keepPods(pods, kept, 3)).To(ConsistOf(pods[0], pods[1], pods[2]))
+	g.Expect(keepPods(pods, kept, 2)).To(ContainElement(pods[1]))
+	g.Expect(keepPods(pods, nil, 0)).To(BeEmpty())
+}
+
+func TestBudgetOfMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type TestCase struct {
+		name     string
+		mode     v1alpha1.PodMode
+		value    string
+		selected int
+		expected int
+	}
+
+	tcs := []TestCase{
+		{name: "one", mode: v1alpha1.OnePodMode, expected: 1},
+		{name: "all", mode: v1alpha1.AllPodMode, expected: 10},
+		{name: "fixed", mode: v1alpha1.FixedPodMode, value: "3", expected: 3},
+		{name: "fixed more than total", mode: v1alpha1.FixedPodMode, value: "30", expected: 10},
+		{name: "fixed percent", mode: v1alpha1.FixedPercentPodMode, value: "35", expected: 3},
+		{name: "random max percent", mode: v1alpha1.RandomMaxPercentPodMode, value: "50", selected: 2, expected: 2},
+		{name: "random max percent over max", mode: v1alpha1.RandomMaxPercentPodMode, value: "50", selected: 8, expected: 5},
+	}
+
+	for _, tc := range tcs {
+		budget, err := budgetOfMode(10, tc.selected, tc.mode, tc.value)
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(budget).To(Equal(tc.expected), tc.name)
+	}
+
+	_, err := budgetOfMode(10, 0, v1alpha1.FixedPodMode, "x")
+	g.Expect(err).Should(HaveOccurred())
+}
+
 func newPod(
 	name string,
 	status v1.PodPhase,
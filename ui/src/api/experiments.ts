@@ -8,6 +8,8 @@ export const state: () => Promise<AxiosResponse<StateOfExperiments>> = () => htt
 
 export const newExperiment = (data: Experiment) => http.post('/experiments/new', data)
 
+export const dryRunExperiment = (data: Experiment) => http.post('/experiments/dry-run', data)
+
 export const experiments: (
   namespace?: string,
   name?: string,